@@ -0,0 +1,56 @@
+// Package geo holds the location types shared across SOS App services.
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Location represents a geographic location
+type Location struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Accuracy  *float64 `json:"accuracy,omitempty"`   // meters
+	Altitude  *float64 `json:"altitude,omitempty"`   // meters
+	Address   *string  `json:"address,omitempty"`    // reverse geocoded address
+	Provider  string   `json:"provider,omitempty"`   // GPS, WIFI, CELLULAR, HYBRID
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Value implements driver.Valuer for Location (PostgreSQL JSONB)
+func (l Location) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner for Location (PostgreSQL JSONB)
+func (l *Location) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan Location: invalid type")
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+// ProviderPriority returns the priority of a location provider (higher is
+// better); unknown providers rank lowest
+func ProviderPriority(provider string) int {
+	switch provider {
+	case "HYBRID":
+		return 4
+	case "GPS":
+		return 3
+	case "WIFI":
+		return 2
+	case "CELLULAR":
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/sos-app/pkg/common
+
+go 1.24.0
+
+require github.com/rs/zerolog v1.34.0
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.36.0 // indirect
+)
@@ -0,0 +1,22 @@
+// Package httpapi provides the JSON response helpers shared by SOS App HTTP
+// services, so every service writes the same error envelope.
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// RespondJSON writes data as a JSON response with the given status code. The
+// returned error reports encoding failures after the header has been sent,
+// for callers that want to log them.
+func RespondJSON(w http.ResponseWriter, statusCode int, data interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(data)
+}
+
+// RespondError writes the standard {"error": message} envelope.
+func RespondError(w http.ResponseWriter, statusCode int, message string) error {
+	return RespondJSON(w, statusCode, map[string]string{"error": message})
+}
@@ -0,0 +1,160 @@
+// Package i18n provides the shared translation catalog and Accept-Language
+// negotiation used by SOS App services to localize user-facing response
+// strings without client-side mapping tables.
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale used when negotiation finds no supported match.
+const DefaultLocale = "en"
+
+// supported lists the locales the catalog carries translations for. English
+// is the source language: untranslated strings fall back to it.
+var supported = map[string]bool{
+	"en": true,
+	"es": true,
+	"fr": true,
+}
+
+// catalog maps a source string (or label key) to its translations per
+// locale. English entries are only needed for label keys, where the key
+// itself is not presentable.
+var catalog = map[string]map[string]string{
+	"Invalid request body": {
+		"es": "Cuerpo de la solicitud no válido",
+		"fr": "Corps de requête invalide",
+	},
+	"Internal server error": {
+		"es": "Error interno del servidor",
+		"fr": "Erreur interne du serveur",
+	},
+	"Invalid emergency ID": {
+		"es": "ID de emergencia no válido",
+		"fr": "ID d'urgence invalide",
+	},
+	"Emergency not found": {
+		"es": "Emergencia no encontrada",
+		"fr": "Urgence introuvable",
+	},
+	"Invalid user ID": {
+		"es": "ID de usuario no válido",
+		"fr": "ID d'utilisateur invalide",
+	},
+	"user_id is required": {
+		"es": "Se requiere user_id",
+		"fr": "user_id est requis",
+	},
+	"Emergency is not active": {
+		"es": "La emergencia no está activa",
+		"fr": "L'urgence n'est pas active",
+	},
+	"User already has an active emergency": {
+		"es": "El usuario ya tiene una emergencia activa",
+		"fr": "L'utilisateur a déjà une urgence active",
+	},
+	"Only active emergencies can be claimed": {
+		"es": "Solo se pueden reclamar emergencias activas",
+		"fr": "Seules les urgences actives peuvent être prises en charge",
+	},
+	"Invalid contact ID": {
+		"es": "ID de contacto no válido",
+		"fr": "ID de contact invalide",
+	},
+	"No preferences found for user": {
+		"es": "No se encontraron preferencias para el usuario",
+		"fr": "Aucune préférence trouvée pour l'utilisateur",
+	},
+	"No erasure request for user": {
+		"es": "No hay solicitud de borrado para el usuario",
+		"fr": "Aucune demande d'effacement pour l'utilisateur",
+	},
+	"status.PENDING": {
+		"en": "Pending",
+		"es": "Pendiente",
+		"fr": "En attente",
+	},
+	"status.ACTIVE": {
+		"en": "Active",
+		"es": "Activa",
+		"fr": "Active",
+	},
+	"status.CANCELLED": {
+		"en": "Cancelled",
+		"es": "Cancelada",
+		"fr": "Annulée",
+	},
+	"status.RESOLVED": {
+		"en": "Resolved",
+		"es": "Resuelta",
+		"fr": "Résolue",
+	},
+}
+
+// MatchLocale negotiates an Accept-Language header value against the
+// supported locales, honouring q-weights. Region subtags match their base
+// language (es-MX matches es); no match returns DefaultLocale.
+func MatchLocale(header string) string {
+	best := DefaultLocale
+	bestQ := 0.0
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.ToLower(strings.TrimSpace(fields[0]))
+		if lang == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, field := range fields[1:] {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "q=") {
+				if parsed, err := strconv.ParseFloat(field[2:], 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		if lang == "*" {
+			best = DefaultLocale
+			bestQ = q
+			continue
+		}
+		if base, _, found := strings.Cut(lang, "-"); found {
+			lang = base
+		}
+		if supported[lang] {
+			best = lang
+			bestQ = q
+		}
+	}
+
+	return best
+}
+
+// T returns the translation of an English source string for the locale,
+// falling back to the source string itself.
+func T(locale, message string) string {
+	if translated, ok := catalog[message][locale]; ok {
+		return translated
+	}
+	return message
+}
+
+// StatusLabel returns a presentable, localized label for an emergency
+// status code like ACTIVE, falling back to the code itself.
+func StatusLabel(locale, status string) string {
+	key := "status." + status
+	if translated, ok := catalog[key][locale]; ok {
+		return translated
+	}
+	if english, ok := catalog[key]["en"]; ok {
+		return english
+	}
+	return status
+}
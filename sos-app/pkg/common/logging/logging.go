@@ -0,0 +1,24 @@
+// Package logging provides the zerolog setup shared by SOS App services, so
+// every service emits the same fields and each main.go stops hand-rolling
+// writer configuration.
+package logging
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// New returns a JSON logger writing to stdout with a timestamp and the
+// service name on every entry.
+func New(service string) zerolog.Logger {
+	return zerolog.New(os.Stdout).With().Timestamp().Str("service", service).Logger()
+}
+
+// NewConsole returns a human-readable logger writing to stderr, for services
+// that log through the global zerolog logger during development.
+func NewConsole(service string) zerolog.Logger {
+	writer := zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	return zerolog.New(writer).With().Timestamp().Str("service", service).Logger()
+}
@@ -0,0 +1,295 @@
+// Package config provides layered configuration for SOS App services: an
+// optional YAML or JSON file as the base layer, environment variables
+// overriding it, typed accessors with defaults, and SIGHUP-triggered hot
+// reload so tunables can change without a restart.
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Logf is the logging function used by the loader. It defaults to the
+// standard library logger via fmt when unset (no logging).
+type Logf func(format string, v ...interface{})
+
+// Validator inspects a candidate configuration before it is applied. When a
+// validator returns an error the previous configuration stays active.
+type Validator func(*Loader) error
+
+// Loader resolves configuration keys from three layers: environment
+// variables, then the configuration file, then the caller-supplied default.
+// Keys use UPPER_SNAKE form matching the environment variable name; nested
+// file keys are flattened with underscores (server.port -> SERVER_PORT).
+type Loader struct {
+	mu          sync.RWMutex
+	filePath    string
+	values      map[string]string
+	validators  []Validator
+	reloadHooks []func(*Loader)
+	logf        Logf
+	sigCh       chan os.Signal
+	done        chan struct{}
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithFile sets the configuration file path. An empty path means
+// environment-only configuration; a missing file is not an error so services
+// run unchanged without one.
+func WithFile(path string) Option {
+	return func(l *Loader) { l.filePath = path }
+}
+
+// WithLogf sets the logging function for reload events.
+func WithLogf(logf Logf) Option {
+	return func(l *Loader) { l.logf = logf }
+}
+
+// WithValidator registers a validator run on load and on every reload.
+func WithValidator(v Validator) Option {
+	return func(l *Loader) { l.validators = append(l.validators, v) }
+}
+
+// New creates a Loader and performs the initial load.
+func New(opts ...Option) (*Loader, error) {
+	l := &Loader{
+		values: make(map[string]string),
+		logf:   func(string, ...interface{}) {},
+		done:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	values, err := l.readFile()
+	if err != nil {
+		return nil, err
+	}
+	l.values = values
+
+	for _, v := range l.validators {
+		if err := v(l); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	return l, nil
+}
+
+// OnReload registers a hook invoked after every successful reload, e.g. to
+// re-derive tunables held elsewhere.
+func (l *Loader) OnReload(fn func(*Loader)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reloadHooks = append(l.reloadHooks, fn)
+}
+
+// Reload re-reads the configuration file and applies it if every validator
+// passes; on failure the previous configuration stays active.
+func (l *Loader) Reload() error {
+	values, err := l.readFile()
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	previous := l.values
+	l.values = values
+	validators := l.validators
+	l.mu.Unlock()
+
+	for _, v := range validators {
+		if err := v(l); err != nil {
+			l.mu.Lock()
+			l.values = previous
+			l.mu.Unlock()
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	l.mu.RLock()
+	hooks := append([]func(*Loader){}, l.reloadHooks...)
+	l.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(l)
+	}
+
+	return nil
+}
+
+// WatchSIGHUP reloads the configuration whenever the process receives
+// SIGHUP. Reload failures are logged and the previous configuration stays
+// active.
+func (l *Loader) WatchSIGHUP() {
+	l.sigCh = make(chan os.Signal, 1)
+	signal.Notify(l.sigCh, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-l.done:
+				return
+			case <-l.sigCh:
+				if err := l.Reload(); err != nil {
+					l.logf("Config reload failed, keeping previous configuration: %v", err)
+					continue
+				}
+				l.logf("Configuration reloaded")
+			}
+		}
+	}()
+}
+
+// Close stops the SIGHUP watcher.
+func (l *Loader) Close() {
+	if l.sigCh != nil {
+		signal.Stop(l.sigCh)
+	}
+	close(l.done)
+}
+
+// String returns the value for key, preferring the environment, then the
+// file, then the default.
+func (l *Loader) String(key, defaultValue string) string {
+	if value, ok := l.lookup(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// Int returns the integer value for key or the default when unset or
+// unparsable.
+func (l *Loader) Int(key string, defaultValue int) int {
+	if value, ok := l.lookup(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+		l.logf("Config key %s has non-integer value %q, using default", key, value)
+	}
+	return defaultValue
+}
+
+// Float64 returns the float value for key or the default when unset or
+// unparsable.
+func (l *Loader) Float64(key string, defaultValue float64) float64 {
+	if value, ok := l.lookup(key); ok {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+		l.logf("Config key %s has non-numeric value %q, using default", key, value)
+	}
+	return defaultValue
+}
+
+// Bool returns the boolean value for key or the default when unset or
+// unparsable.
+func (l *Loader) Bool(key string, defaultValue bool) bool {
+	if value, ok := l.lookup(key); ok {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+		l.logf("Config key %s has non-boolean value %q, using default", key, value)
+	}
+	return defaultValue
+}
+
+// Duration returns the duration value for key or the default when unset or
+// unparsable.
+func (l *Loader) Duration(key string, defaultValue time.Duration) time.Duration {
+	if value, ok := l.lookup(key); ok {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		l.logf("Config key %s has non-duration value %q, using default", key, value)
+	}
+	return defaultValue
+}
+
+// StringSlice returns the comma-separated values for key or the default
+// when unset. File lists flatten to comma-separated form, so YAML lists and
+// env overrides behave identically.
+func (l *Loader) StringSlice(key string, defaultValue []string) []string {
+	if value, ok := l.lookup(key); ok {
+		parts := strings.Split(value, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	}
+	return defaultValue
+}
+
+// lookup resolves a key through the environment then the file layer.
+func (l *Loader) lookup(key string) (string, bool) {
+	if value := os.Getenv(key); value != "" {
+		return value, true
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	value, ok := l.values[key]
+	return value, ok
+}
+
+// readFile parses the configuration file into a flattened key map. A
+// missing or unset file yields an empty layer.
+func (l *Loader) readFile() (map[string]string, error) {
+	values := make(map[string]string)
+	if l.filePath == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(l.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", l.filePath, err)
+	}
+
+	// YAML is a superset of JSON, so both file formats parse here
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", l.filePath, err)
+	}
+
+	flatten("", raw, values)
+	return values, nil
+}
+
+// flatten converts nested maps into UPPER_SNAKE keys so file keys line up
+// with the environment variables that override them. Lists of scalars
+// flatten to comma-separated values.
+func flatten(prefix string, raw map[string]interface{}, out map[string]string) {
+	for key, value := range raw {
+		flat := strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		if prefix != "" {
+			flat = prefix + "_" + flat
+		}
+
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			flatten(flat, typed, out)
+		case []interface{}:
+			parts := make([]string, 0, len(typed))
+			for _, item := range typed {
+				parts = append(parts, fmt.Sprintf("%v", item))
+			}
+			out[flat] = strings.Join(parts, ",")
+		case nil:
+			// Ignore explicit nulls
+		default:
+			out[flat] = fmt.Sprintf("%v", typed)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoader_FileLayering(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "9090"
+kafka:
+  brokers:
+    - broker1:9092
+    - broker2:9092
+countdown_seconds: 15
+ws:
+  compression: false
+`)
+
+	loader, err := New(WithFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	if got := loader.String("SERVER_PORT", "8080"); got != "9090" {
+		t.Errorf("SERVER_PORT = %q, want 9090", got)
+	}
+	if got := loader.Int("COUNTDOWN_SECONDS", 10); got != 15 {
+		t.Errorf("COUNTDOWN_SECONDS = %d, want 15", got)
+	}
+	if got := loader.Bool("WS_COMPRESSION", true); got {
+		t.Error("WS_COMPRESSION should be false")
+	}
+	brokers := loader.StringSlice("KAFKA_BROKERS", nil)
+	if len(brokers) != 2 || brokers[0] != "broker1:9092" {
+		t.Errorf("KAFKA_BROKERS = %v", brokers)
+	}
+}
+
+func TestLoader_EnvOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, "server:\n  port: \"9090\"\n")
+
+	t.Setenv("SERVER_PORT", "7070")
+
+	loader, err := New(WithFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	if got := loader.String("SERVER_PORT", "8080"); got != "7070" {
+		t.Errorf("SERVER_PORT = %q, want env override 7070", got)
+	}
+}
+
+func TestLoader_DefaultsWithoutFile(t *testing.T) {
+	loader, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	if got := loader.Duration("READ_TIMEOUT", 15*time.Second); got != 15*time.Second {
+		t.Errorf("READ_TIMEOUT = %v, want default", got)
+	}
+	if got := loader.String("MISSING", "fallback"); got != "fallback" {
+		t.Errorf("MISSING = %q, want fallback", got)
+	}
+}
+
+func TestLoader_ReloadAppliesNewValues(t *testing.T) {
+	path := writeConfigFile(t, "countdown_seconds: 15\n")
+
+	loader, err := New(WithFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	reloaded := false
+	loader.OnReload(func(*Loader) { reloaded = true })
+
+	if err := os.WriteFile(path, []byte("countdown_seconds: 20\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loader.Int("COUNTDOWN_SECONDS", 10); got != 20 {
+		t.Errorf("COUNTDOWN_SECONDS = %d after reload, want 20", got)
+	}
+	if !reloaded {
+		t.Error("reload hook was not invoked")
+	}
+}
+
+func TestLoader_ReloadKeepsPreviousOnValidationFailure(t *testing.T) {
+	path := writeConfigFile(t, "countdown_seconds: 15\n")
+
+	loader, err := New(WithFile(path), WithValidator(func(l *Loader) error {
+		if l.Int("COUNTDOWN_SECONDS", 10) <= 0 {
+			return errors.New("countdown must be positive")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	if err := os.WriteFile(path, []byte("countdown_seconds: -1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.Reload(); err == nil {
+		t.Fatal("expected reload to fail validation")
+	}
+
+	if got := loader.Int("COUNTDOWN_SECONDS", 10); got != 15 {
+		t.Errorf("COUNTDOWN_SECONDS = %d, want previous value 15", got)
+	}
+}
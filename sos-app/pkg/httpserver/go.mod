@@ -0,0 +1,3 @@
+module github.com/sos-app/pkg/httpserver
+
+go 1.21
@@ -0,0 +1,60 @@
+package httpserver
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// CORS returns middleware that adds permissive CORS headers and answers
+// preflight requests. An empty allowOrigin defaults to "*".
+func CORS(allowOrigin string) func(http.Handler) http.Handler {
+	if allowOrigin == "" {
+		allowOrigin = "*"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequestLogging returns middleware that logs method, path, remote address,
+// status code and duration for every request using logf.
+func RequestLogging(logf Logf) func(http.Handler) http.Handler {
+	if logf == nil {
+		logf = log.Printf
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			logf("%s %s %s %d %s", r.Method, r.URL.Path, r.RemoteAddr, sw.status, time.Since(start))
+		})
+	}
+}
+
+// statusWriter captures the response status code for logging.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
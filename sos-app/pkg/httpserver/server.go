@@ -0,0 +1,160 @@
+// Package httpserver provides a shared HTTP server bootstrap for SOS App
+// services: consistent timeouts, middleware chaining, TLS and graceful
+// shutdown, so each main.go stops re-implementing the same plumbing with
+// subtle differences.
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Logf is the logging function used by the server and bundled middleware.
+// It defaults to the standard library logger.
+type Logf func(format string, v ...interface{})
+
+// Server wraps http.Server with standardized lifecycle management.
+type Server struct {
+	httpServer      *http.Server
+	middlewares     []func(http.Handler) http.Handler
+	shutdownTimeout time.Duration
+	shutdownHooks   []func(context.Context)
+	tlsCertFile     string
+	tlsKeyFile      string
+	logf            Logf
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithTimeouts sets read, write and idle timeouts on the underlying server.
+func WithTimeouts(read, write, idle time.Duration) Option {
+	return func(s *Server) {
+		s.httpServer.ReadTimeout = read
+		s.httpServer.WriteTimeout = write
+		s.httpServer.IdleTimeout = idle
+	}
+}
+
+// WithShutdownTimeout sets how long Run waits for in-flight requests and
+// shutdown hooks after receiving a termination signal.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(s *Server) { s.shutdownTimeout = d }
+}
+
+// WithMiddleware appends middleware applied to every request, outermost first.
+func WithMiddleware(mw ...func(http.Handler) http.Handler) Option {
+	return func(s *Server) { s.middlewares = append(s.middlewares, mw...) }
+}
+
+// WithShutdownHook registers a function called after the HTTP server has
+// drained, sharing the shutdown deadline. Hooks run in registration order.
+func WithShutdownHook(hook func(context.Context)) Option {
+	return func(s *Server) { s.shutdownHooks = append(s.shutdownHooks, hook) }
+}
+
+// WithTLS serves TLS using the given certificate and key files.
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.tlsCertFile = certFile
+		s.tlsKeyFile = keyFile
+	}
+}
+
+// WithLogf sets the logging function used by the server.
+func WithLogf(logf Logf) Option {
+	return func(s *Server) { s.logf = logf }
+}
+
+// New creates a Server listening on addr and serving handler.
+func New(addr string, handler http.Handler, opts ...Option) *Server {
+	s := &Server{
+		httpServer: &http.Server{
+			Addr:         addr,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		shutdownTimeout: 30 * time.Second,
+		logf:            log.Printf,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Apply middleware so the first registered wraps the rest
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		handler = s.middlewares[i](handler)
+	}
+	s.httpServer.Handler = handler
+
+	return s
+}
+
+// Run starts the server and blocks until SIGINT/SIGTERM, then performs a
+// graceful shutdown followed by any registered shutdown hooks. It returns
+// an error if the server fails to start or shut down cleanly.
+func (s *Server) Run() error {
+	return RunUntilSignal(s.start, s.stop, s.shutdownTimeout, s.logf)
+}
+
+func (s *Server) start() error {
+	s.logf("HTTP server listening on %s", s.httpServer.Addr)
+	if s.tlsCertFile != "" && s.tlsKeyFile != "" {
+		return s.httpServer.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
+	}
+	return s.httpServer.ListenAndServe()
+}
+
+func (s *Server) stop(ctx context.Context) error {
+	err := s.httpServer.Shutdown(ctx)
+	for _, hook := range s.shutdownHooks {
+		hook(ctx)
+	}
+	return err
+}
+
+// RunUntilSignal runs start in a goroutine, blocks until SIGINT/SIGTERM (or
+// start failing), then calls stop with a context bounded by shutdownTimeout.
+// Services not built on net/http (e.g. Fiber) can use this directly to get
+// the same signal handling and shutdown behavior as Server.Run.
+func RunUntilSignal(start func() error, stop func(context.Context) error, shutdownTimeout time.Duration, logf Logf) error {
+	if logf == nil {
+		logf = log.Printf
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-errCh:
+		return err
+	case sig := <-quit:
+		logf("Received signal %s, shutting down...", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := stop(ctx); err != nil {
+		logf("Graceful shutdown failed: %v", err)
+		return err
+	}
+
+	logf("Shutdown complete")
+	return nil
+}
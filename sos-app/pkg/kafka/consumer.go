@@ -0,0 +1,181 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultMaxRetries        = 3
+	defaultRetryBackoff      = 1 * time.Second
+	defaultRetryBackoffLimit = 30 * time.Second
+)
+
+// Message is a consumed Kafka message handed to a Handler.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Time      time.Time
+}
+
+// Handler processes one consumed message. Returning an error triggers the
+// consumer's retry and dead-letter handling.
+type Handler func(ctx context.Context, message Message) error
+
+// ConsumerConfig holds configuration for a shared consumer group.
+type ConsumerConfig struct {
+	Brokers      []string
+	GroupID      string
+	Topics       []string
+	MaxRetries   int           // handler attempts per message (default 3)
+	RetryBackoff time.Duration // first retry delay, doubled per attempt (default 1s)
+	DeadLetters  *Producer     // when set, exhausted messages go to "<topic>.dlq"
+	Logf         Logf          // defaults to log.Printf
+}
+
+// Consumer consumes topics as part of a consumer group, retrying the handler
+// with backoff and optionally dead-lettering messages it cannot process.
+type Consumer struct {
+	reader       *kafka.Reader
+	handler      Handler
+	maxRetries   int
+	retryBackoff time.Duration
+	deadLetters  *Producer
+	logf         Logf
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewConsumer creates a consumer group over the configured topics.
+func NewConsumer(config ConsumerConfig, handler Handler) *Consumer {
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+	if config.Logf == nil {
+		config.Logf = log.Printf
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     config.Brokers,
+		GroupID:     config.GroupID,
+		GroupTopics: config.Topics,
+		MinBytes:    1,
+		MaxBytes:    1e6,
+		MaxWait:     1 * time.Second,
+	})
+
+	return &Consumer{
+		reader:       reader,
+		handler:      handler,
+		maxRetries:   config.MaxRetries,
+		retryBackoff: config.RetryBackoff,
+		deadLetters:  config.DeadLetters,
+		logf:         config.Logf,
+	}
+}
+
+// Start begins consuming in the background.
+func (c *Consumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			message, err := c.reader.ReadMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logf("kafka: error reading message: %v", err)
+				continue
+			}
+
+			c.process(ctx, message)
+		}
+	}()
+}
+
+// Close stops consuming and releases the Kafka reader.
+func (c *Consumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return c.reader.Close()
+}
+
+// process runs the handler for one message with retries, dead-lettering it
+// when every attempt fails.
+func (c *Consumer) process(ctx context.Context, message kafka.Message) {
+	headers := make(map[string]string, len(message.Headers))
+	for _, h := range message.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	msg := Message{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       message.Key,
+		Value:     message.Value,
+		Headers:   headers,
+		Time:      message.Time,
+	}
+
+	start := time.Now()
+	var lastErr error
+	for attempt := 1; attempt <= c.maxRetries; attempt++ {
+		lastErr = c.handler(ctx, msg)
+		if lastErr == nil {
+			consumedTotal.WithLabelValues(msg.Topic, statusOK).Inc()
+			handlerDuration.WithLabelValues(msg.Topic).Observe(time.Since(start).Seconds())
+			return
+		}
+
+		if ctx.Err() != nil || attempt == c.maxRetries {
+			break
+		}
+
+		c.logf("kafka: handler for %s failed (attempt %d/%d), retrying: %v", msg.Topic, attempt, c.maxRetries, lastErr)
+		if !sleep(ctx, backoff(attempt, c.retryBackoff, defaultRetryBackoffLimit)) {
+			return
+		}
+	}
+
+	consumedTotal.WithLabelValues(msg.Topic, statusError).Inc()
+	c.logf("kafka: handler for %s gave up after %d attempts: %v", msg.Topic, c.maxRetries, lastErr)
+	c.deadLetter(ctx, message, lastErr)
+}
+
+// deadLetter publishes the failed message to its dead-letter topic.
+func (c *Consumer) deadLetter(ctx context.Context, message kafka.Message, handlerErr error) {
+	if c.deadLetters == nil {
+		return
+	}
+
+	topic := message.Topic + DeadLetterSuffix
+	extra := []Header{
+		{HeaderError, handlerErr.Error()},
+		{HeaderOriginalTopic, message.Topic},
+	}
+	if err := c.deadLetters.PublishRaw(ctx, topic, message.Key, message.Value, extra...); err != nil {
+		c.logf("kafka: failed to dead-letter message from %s: %v", message.Topic, err)
+		return
+	}
+
+	deadLetteredTotal.WithLabelValues(message.Topic).Inc()
+}
@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+)
+
+type sampleEvent struct{}
+
+func TestEventTypeName(t *testing.T) {
+	if got := eventTypeName(sampleEvent{}, "topic"); got != "sampleEvent" {
+		t.Errorf("eventTypeName(sampleEvent{}) = %q, want %q", got, "sampleEvent")
+	}
+	if got := eventTypeName(&sampleEvent{}, "topic"); got != "sampleEvent" {
+		t.Errorf("eventTypeName(&sampleEvent{}) = %q, want %q", got, "sampleEvent")
+	}
+	if got := eventTypeName(map[string]interface{}{}, "topic"); got != "topic" {
+		t.Errorf("eventTypeName(map) = %q, want fallback %q", got, "topic")
+	}
+	if got := eventTypeName(nil, "topic"); got != "topic" {
+		t.Errorf("eventTypeName(nil) = %q, want fallback %q", got, "topic")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	min := 100 * time.Millisecond
+	max := 2 * time.Second
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, 1600 * time.Millisecond},
+		{6, max},  // capped
+		{40, max}, // shift overflow still capped
+	}
+	for _, c := range cases {
+		if got := backoff(c.attempt, min, max); got != c.want {
+			t.Errorf("backoff(%d) = %v, want %v", c.attempt, got, c.want)
+		}
+	}
+}
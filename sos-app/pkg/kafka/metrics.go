@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+var (
+	publishedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sos_kafka_messages_published_total",
+		Help: "Messages published to Kafka, by topic and final status.",
+	}, []string{"topic", "status"})
+
+	publishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sos_kafka_publish_duration_seconds",
+		Help:    "Time to publish a message, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	consumedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sos_kafka_messages_consumed_total",
+		Help: "Messages consumed from Kafka, by topic and handler outcome.",
+	}, []string{"topic", "status"})
+
+	handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sos_kafka_handler_duration_seconds",
+		Help:    "Time spent handling a consumed message, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	deadLetteredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sos_kafka_messages_dead_lettered_total",
+		Help: "Messages published to a dead-letter topic, by original topic.",
+	}, []string{"topic"})
+)
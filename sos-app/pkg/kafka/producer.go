@@ -0,0 +1,196 @@
+// Package kafka provides shared Kafka plumbing for SOS App services: JSON
+// publishing with standardized headers, retry with exponential backoff,
+// consumer groups with dead-letter publishing and Prometheus metrics, so each
+// service stops re-implementing the same plumbing with subtle differences.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Standard header keys set on published messages.
+const (
+	HeaderEventType     = "event_type"
+	HeaderOrigin        = "origin"
+	HeaderTimestamp     = "timestamp"
+	HeaderError         = "error"
+	HeaderOriginalTopic = "original_topic"
+)
+
+// DeadLetterSuffix is appended to a topic name to form its dead-letter topic.
+const DeadLetterSuffix = ".dlq"
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoffMin  = 100 * time.Millisecond
+	defaultBackoffMax  = 2 * time.Second
+)
+
+// Logf is the logging function used by this package. It is compatible with
+// log.Printf, which is the default.
+type Logf func(format string, args ...interface{})
+
+// Header is an extra message header passed to PublishRaw.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// ProducerConfig holds configuration for a shared Kafka producer.
+type ProducerConfig struct {
+	Brokers     []string
+	ClientID    string        // origin service name, set on every message
+	MaxAttempts int           // write attempts per message (default 3)
+	BackoffMin  time.Duration // first retry delay (default 100ms)
+	BackoffMax  time.Duration // retry delay cap (default 2s)
+	Logf        Logf          // defaults to log.Printf
+}
+
+// Producer publishes JSON events to any topic with standard headers, retry
+// with exponential backoff and Prometheus metrics.
+type Producer struct {
+	writer      *kafka.Writer
+	clientID    string
+	maxAttempts int
+	backoffMin  time.Duration
+	backoffMax  time.Duration
+	logf        Logf
+}
+
+// NewProducer creates a producer for the given brokers.
+func NewProducer(config ProducerConfig) *Producer {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.BackoffMin <= 0 {
+		config.BackoffMin = defaultBackoffMin
+	}
+	if config.BackoffMax <= 0 {
+		config.BackoffMax = defaultBackoffMax
+	}
+	if config.Logf == nil {
+		config.Logf = log.Printf
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(config.Brokers...),
+		Balancer:     &kafka.Hash{},
+		MaxAttempts:  1, // retries are handled here so they can be observed
+		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  10 * time.Second,
+		RequiredAcks: kafka.RequireAll,
+		Compression:  kafka.Snappy,
+	}
+
+	return &Producer{
+		writer:      writer,
+		clientID:    config.ClientID,
+		maxAttempts: config.MaxAttempts,
+		backoffMin:  config.BackoffMin,
+		backoffMax:  config.BackoffMax,
+		logf:        config.Logf,
+	}
+}
+
+// Publish marshals event to JSON and writes it to topic, keyed by key. The
+// event_type header carries the event's type name, or the topic name when the
+// event is an unnamed type such as a map.
+func (p *Producer) Publish(ctx context.Context, topic, key string, event interface{}) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return p.PublishRaw(ctx, topic, []byte(key), value, Header{HeaderEventType, eventTypeName(event, topic)})
+}
+
+// PublishRaw writes a pre-serialized message to topic with the standard
+// origin and timestamp headers plus any extra headers.
+func (p *Producer) PublishRaw(ctx context.Context, topic string, key, value []byte, extra ...Header) error {
+	headers := make([]kafka.Header, 0, len(extra)+2)
+	headers = append(headers,
+		kafka.Header{Key: HeaderOrigin, Value: []byte(p.clientID)},
+		kafka.Header{Key: HeaderTimestamp, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+	for _, h := range extra {
+		headers = append(headers, kafka.Header{Key: h.Key, Value: []byte(h.Value)})
+	}
+
+	message := kafka.Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
+		Time:    time.Now(),
+	}
+
+	start := time.Now()
+	var lastErr error
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		lastErr = p.writer.WriteMessages(ctx, message)
+		if lastErr == nil {
+			publishedTotal.WithLabelValues(topic, statusOK).Inc()
+			publishDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+			return nil
+		}
+
+		if ctx.Err() != nil || attempt == p.maxAttempts {
+			break
+		}
+
+		p.logf("kafka: publish to %s failed (attempt %d/%d), retrying: %v", topic, attempt, p.maxAttempts, lastErr)
+		if !sleep(ctx, backoff(attempt, p.backoffMin, p.backoffMax)) {
+			lastErr = ctx.Err()
+			break
+		}
+	}
+
+	publishedTotal.WithLabelValues(topic, statusError).Inc()
+	return fmt.Errorf("failed to publish to %s: %w", topic, lastErr)
+}
+
+// Close flushes buffered messages and releases the Kafka writer.
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
+
+// eventTypeName returns the event's type name for the event_type header,
+// dereferencing pointers, or fallback for unnamed types.
+func eventTypeName(event interface{}, fallback string) string {
+	t := reflect.TypeOf(event)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return fallback
+	}
+	return t.Name()
+}
+
+// backoff returns the delay before the next attempt: min doubled per attempt
+// and capped at max.
+func backoff(attempt int, min, max time.Duration) time.Duration {
+	delay := min << (attempt - 1)
+	if delay > max || delay <= 0 {
+		return max
+	}
+	return delay
+}
+
+// sleep waits for the given duration, returning false if the context is
+// cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
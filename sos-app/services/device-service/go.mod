@@ -20,14 +20,29 @@ require (
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
-	github.com/kr/text v0.2.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.19 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/rogpeppe/go-internal v1.14.1 // indirect
+	github.com/rs/xid v1.6.0 // indirect
 	golang.org/x/crypto v0.42.0 // indirect
 	golang.org/x/net v0.44.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 )
+
+require (
+	github.com/mochi-mqtt/server/v2 v2.7.9
+	github.com/segmentio/kafka-go v0.4.48
+	github.com/sos-app/pkg/common v0.0.0
+	github.com/sos-app/pkg/config v0.0.0
+	github.com/sos-app/pkg/httpserver v0.0.0
+)
+
+replace github.com/sos-app/pkg/httpserver => ../../pkg/httpserver
+
+replace github.com/sos-app/pkg/config => ../../pkg/config
+
+replace github.com/sos-app/pkg/common => ../../pkg/common
@@ -0,0 +1,21 @@
+-- Create pending_emergency_triggers table
+-- Durable local retry queue so an SOS trigger is never lost when the
+-- emergency-service is momentarily unreachable
+CREATE TABLE IF NOT EXISTS pending_emergency_triggers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP
+);
+
+-- Create index for the retry worker picking up due triggers
+CREATE INDEX idx_pending_triggers_due ON pending_emergency_triggers(next_attempt_at) WHERE delivered_at IS NULL;
+
+-- Create index on device_id for inspection queries
+CREATE INDEX idx_pending_triggers_device ON pending_emergency_triggers(device_id);
@@ -0,0 +1,21 @@
+-- Create device_shares table
+-- Allows a device to be visible to multiple linked users (family members,
+-- caregivers) with role levels governing what shared users may do
+CREATE TABLE IF NOT EXISTS device_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    owner_user_id UUID NOT NULL,
+    shared_with_user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'VIEWER' CHECK (role IN ('VIEWER', 'MANAGER')),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'REVOKED')),
+    invited_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CONSTRAINT unique_device_share UNIQUE (device_id, shared_with_user_id)
+);
+
+-- Create index for looking up shares granted to a user
+CREATE INDEX idx_device_shares_shared_with ON device_shares(shared_with_user_id);
+
+-- Create index for listing shares of a device
+CREATE INDEX idx_device_shares_device ON device_shares(device_id);
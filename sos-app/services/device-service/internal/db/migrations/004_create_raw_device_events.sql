@@ -0,0 +1,16 @@
+-- Create raw_device_events table
+-- Retains every inbound SOS/fall event payload verbatim, before any parsing,
+-- so disputes about whether a device sent an event can be settled
+CREATE TABLE IF NOT EXISTS raw_device_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    topic VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    payload_hash CHAR(64) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create index for retrieval by topic and time
+CREATE INDEX idx_raw_device_events_topic_time ON raw_device_events(topic, received_at DESC);
+
+-- Create index for the retention purge
+CREATE INDEX idx_raw_device_events_received ON raw_device_events(received_at);
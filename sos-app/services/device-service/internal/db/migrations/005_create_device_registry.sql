@@ -0,0 +1,15 @@
+-- Create device_registry table
+-- Pre-registered hardware shipped to customers; PairDevice only accepts
+-- MAC addresses present here, optionally requiring matching serial proof
+CREATE TABLE IF NOT EXISTS device_registry (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mac_address VARCHAR(17) NOT NULL,
+    serial_number VARCHAR(100),
+    require_serial_proof BOOLEAN NOT NULL DEFAULT FALSE,
+    notes TEXT,
+    registered_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_registry_mac UNIQUE (mac_address)
+);
+
+-- Pairing looks devices up by MAC regardless of separator casing
+CREATE INDEX idx_device_registry_mac ON device_registry(UPPER(mac_address));
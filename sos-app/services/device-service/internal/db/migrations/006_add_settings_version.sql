@@ -0,0 +1,4 @@
+-- Add settings_version to devices
+-- Monotonic counter bumped on every settings change so devices can detect
+-- missed delta patches and request a full sync
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS settings_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,17 @@
+-- Create device_commands table
+-- Remote commands pushed to devices over MQTT (e.g. locate siren/strobe).
+-- Devices report execution on devices/{id}/command-acks and the outcome is
+-- recorded here so callers can poll whether the command actually ran
+CREATE TABLE IF NOT EXISTS device_commands (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL,
+    command_type VARCHAR(50) NOT NULL,
+    issued_by UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    error TEXT,
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    acked_at TIMESTAMP
+);
+
+-- Callers poll command status per device
+CREATE INDEX idx_device_commands_device ON device_commands(device_id, issued_at DESC);
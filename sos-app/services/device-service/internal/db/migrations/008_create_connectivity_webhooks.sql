@@ -0,0 +1,29 @@
+-- Create connectivity webhook tables
+-- Organizations register webhooks that fire when a device transitions to
+-- DISCONNECTED or back, so NOC tooling ingests fleet health without polling.
+-- Every delivery attempt outcome is logged per webhook
+CREATE TABLE IF NOT EXISTS connectivity_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_connectivity_webhooks_org ON connectivity_webhooks(org_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id UUID NOT NULL REFERENCES connectivity_webhooks(id) ON DELETE CASCADE,
+    device_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status_code INTEGER,
+    succeeded BOOLEAN NOT NULL DEFAULT FALSE,
+    last_error TEXT,
+    delivered_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Delivery log is browsed per webhook, newest first
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, delivered_at DESC);
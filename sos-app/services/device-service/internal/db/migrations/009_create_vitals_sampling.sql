@@ -0,0 +1,26 @@
+-- Create vitals_readings and telemetry_sampling_policies tables
+-- Vitals readings are persisted for history; per-device-type sampling
+-- policies keep every Nth reading outside an emergency while an active
+-- emergency stores everything
+CREATE TABLE IF NOT EXISTS vitals_readings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    heart_rate INTEGER,
+    spo2 INTEGER,
+    temperature DOUBLE PRECISION,
+    systolic INTEGER,
+    diastolic INTEGER,
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- History queries read one device's readings newest-first
+CREATE INDEX idx_vitals_readings_device_time ON vitals_readings(device_id, recorded_at DESC);
+
+CREATE TABLE IF NOT EXISTS telemetry_sampling_policies (
+    device_type VARCHAR(50) PRIMARY KEY,
+    sample_every INTEGER NOT NULL CHECK (sample_every >= 1),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN telemetry_sampling_policies.sample_every IS 'Store every Nth vitals reading outside active emergencies; 1 stores all';
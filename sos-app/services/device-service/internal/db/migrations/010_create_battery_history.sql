@@ -0,0 +1,13 @@
+-- Create battery_history table
+-- Decimated battery level samples from telemetry (stored on change or at
+-- most once per interval) powering battery health charts and detection of
+-- batteries that no longer hold charge
+CREATE TABLE IF NOT EXISTS battery_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL,
+    battery_level INTEGER NOT NULL CHECK (battery_level >= 0 AND battery_level <= 100),
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Chart queries read one device's samples over a time range
+CREATE INDEX idx_battery_history_device_time ON battery_history(device_id, recorded_at DESC);
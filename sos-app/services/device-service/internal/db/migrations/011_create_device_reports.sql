@@ -0,0 +1,14 @@
+-- Create device_reports table
+-- Weekly per-user summaries of device uptime, battery trend, alert counts
+-- and firmware status, generated by the scheduled report job
+CREATE TABLE IF NOT EXISTS device_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    report JSONB NOT NULL,
+    generated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create index for fetching a user's latest report
+CREATE INDEX idx_device_reports_user_generated ON device_reports(user_id, generated_at DESC);
@@ -0,0 +1,24 @@
+-- Create provisioning_sessions table
+-- Short-lived sessions for the in-app BLE provisioning flow: the app opens a
+-- session, hands the one-time secret to the device over BLE, relays the
+-- device's confirmation and finalizes pairing atomically
+CREATE TABLE IF NOT EXISTS provisioning_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    device_type VARCHAR(50) NOT NULL,
+    manufacturer VARCHAR(100) NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    secret_hash VARCHAR(64) NOT NULL,
+    mac_address VARCHAR(17),
+    serial_number VARCHAR(255),
+    device_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+-- Expired sessions are filtered by predicate, so index on user + state
+CREATE INDEX IF NOT EXISTS idx_provisioning_sessions_user
+    ON provisioning_sessions(user_id, status);
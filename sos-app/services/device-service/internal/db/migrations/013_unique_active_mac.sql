@@ -0,0 +1,8 @@
+-- Enforce MAC uniqueness for non-deleted devices at the database level
+-- Two simultaneous pairing requests for the same MAC can both pass the
+-- handler's existence check and insert duplicates. The blanket UNIQUE
+-- constraint from 001 also blocked re-pairing hardware whose previous row
+-- was soft-deleted, so it is replaced by a partial unique index scoped to
+-- live rows
+ALTER TABLE devices DROP CONSTRAINT IF EXISTS unique_mac_address;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_mac_address ON devices(mac_address) WHERE status != 'DELETED';
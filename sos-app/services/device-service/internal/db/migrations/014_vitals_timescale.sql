@@ -0,0 +1,25 @@
+-- Convert vitals_readings into a TimescaleDB hypertable
+-- Vitals arrive continuously from the whole fleet; a hypertable keeps the
+-- write path fast and lets old chunks be compressed instead of pruned.
+-- Requires the timescaledb extension to be installed on the server
+CREATE EXTENSION IF NOT EXISTS timescaledb;
+
+-- Hypertables require the time column in every unique constraint, so the
+-- surrogate UUID primary key from 009 is dropped; id keeps its default and
+-- rows are addressed by (device_id, recorded_at) in practice
+ALTER TABLE vitals_readings DROP CONSTRAINT IF EXISTS vitals_readings_pkey;
+
+SELECT create_hypertable('vitals_readings', 'recorded_at',
+    chunk_time_interval => INTERVAL '1 day',
+    migrate_data => TRUE,
+    if_not_exists => TRUE);
+
+-- Compress chunks older than a week; clinician charts read recent data hot
+-- and older ranges come back downsampled anyway
+ALTER TABLE vitals_readings SET (
+    timescaledb.compress,
+    timescaledb.compress_segmentby = 'device_id',
+    timescaledb.compress_orderby = 'recorded_at DESC'
+);
+
+SELECT add_compression_policy('vitals_readings', INTERVAL '7 days', if_not_exists => TRUE);
@@ -0,0 +1,19 @@
+-- Create alert routing rules table
+-- Organizations route device alerts to the team that handles them: battery
+-- alerts to facilities, vitals alerts to nursing staff, disconnects to IT.
+-- Rules are evaluated per organization in rank order; the first match wins
+CREATE TABLE IF NOT EXISTS alert_routing_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    alert_category VARCHAR(20) NOT NULL CHECK (alert_category IN ('battery', 'vitals', 'connectivity')),
+    device_type VARCHAR(50),
+    min_priority VARCHAR(10),
+    team VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Rules are loaded per organization in evaluation order
+CREATE INDEX idx_alert_routing_rules_org ON alert_routing_rules(org_id, rank);
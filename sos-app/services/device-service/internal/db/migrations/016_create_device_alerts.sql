@@ -0,0 +1,16 @@
+-- Create device_alerts table: every alert the battery/vitals/connectivity
+-- monitors emit is persisted so users can review why their device buzzed
+
+CREATE TABLE IF NOT EXISTS device_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('battery', 'vitals', 'connectivity')),
+    alert_type VARCHAR(50) NOT NULL,
+    priority VARCHAR(10) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_alerts_user_time ON device_alerts(user_id, created_at DESC);
+CREATE INDEX idx_device_alerts_device_time ON device_alerts(device_id, created_at DESC);
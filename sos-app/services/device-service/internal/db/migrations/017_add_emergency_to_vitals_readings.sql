@@ -0,0 +1,9 @@
+-- Tag vitals readings captured during an active emergency with the
+-- emergency they belong to, so snapshots can be attached to incident
+-- reports
+
+ALTER TABLE vitals_readings ADD COLUMN IF NOT EXISTS emergency_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_vitals_readings_emergency
+    ON vitals_readings (emergency_id, recorded_at DESC)
+    WHERE emergency_id IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Create device_encryption_keys table
+-- The public half of each device's payload encryption keypair, registered
+-- at pairing and replaced when the device acknowledges a ROTATE_KEY command.
+-- SERVER mode payloads are decrypted on ingest so the full telemetry
+-- pipeline works; E2E mode payloads stay opaque to the service and pass
+-- through to authorized clients holding the keys
+CREATE TABLE IF NOT EXISTS device_encryption_keys (
+    device_id UUID PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    public_key TEXT NOT NULL,
+    mode VARCHAR(10) NOT NULL DEFAULT 'SERVER' CHECK (mode IN ('SERVER', 'E2E')),
+    rotated_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE device_encryption_keys IS 'Public half of per-device payload encryption keypairs (X25519, base64)';
+COMMENT ON COLUMN device_encryption_keys.mode IS 'SERVER: service decrypts payloads on ingest; E2E: payloads stay opaque and pass through to clients';
+COMMENT ON COLUMN device_encryption_keys.rotated_at IS 'When the key was last replaced via a ROTATE_KEY command';
@@ -0,0 +1,14 @@
+-- Create device_beacon_tokens table
+-- Bearer tokens for NB-IoT/LTE-M trackers that cannot hold an MQTT
+-- connection and instead report via periodic HTTPS beacon POSTs. Only the
+-- SHA-256 hash is stored; the token itself is returned exactly once when
+-- issued
+CREATE TABLE IF NOT EXISTS device_beacon_tokens (
+    device_id UUID PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE device_beacon_tokens IS 'Hashed bearer tokens authenticating HTTPS beacon reports from constrained cellular trackers';
+COMMENT ON COLUMN device_beacon_tokens.token_hash IS 'SHA-256 hex of the beacon token; re-issuing replaces it';
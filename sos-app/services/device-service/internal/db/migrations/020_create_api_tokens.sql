@@ -0,0 +1,23 @@
+-- Create api_tokens table
+-- Scoped bearer tokens for third-party integrations (fleet dashboards,
+-- monitoring platforms). Only the SHA-256 hash is stored; the token itself
+-- is returned exactly once when issued. Scopes limit what an integration
+-- may do, so a read-only dashboard cannot push commands that sound sirens
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- Every request with a token does a hash lookup
+CREATE INDEX idx_api_tokens_hash ON api_tokens(token_hash) WHERE revoked_at IS NULL;
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id);
+
+COMMENT ON TABLE api_tokens IS 'Scoped bearer tokens for third-party integrations';
+COMMENT ON COLUMN api_tokens.token_hash IS 'SHA-256 hex of the full token including its prefix';
+COMMENT ON COLUMN api_tokens.scopes IS 'Granted scopes, e.g. device:read, device:command, telemetry:write';
@@ -0,0 +1,29 @@
+-- Create firmware compatibility matrix
+-- Support maintains, per (model, firmware version), which capabilities the
+-- firmware actually delivers and the issues known to affect it. Pairing and
+-- firmware-version telemetry consult the matrix and surface known issues to
+-- the device owner through the alerts API
+
+CREATE TABLE IF NOT EXISTS firmware_compatibility (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_model VARCHAR(255) NOT NULL,
+    firmware_version VARCHAR(64) NOT NULL,
+    supported_capabilities TEXT[] NOT NULL DEFAULT '{}',
+    known_issues TEXT[] NOT NULL DEFAULT '{}',
+    update_recommended BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT unique_model_firmware UNIQUE (device_model, firmware_version)
+);
+
+COMMENT ON COLUMN firmware_compatibility.known_issues IS 'User-facing warning texts, recorded verbatim as alerts';
+
+-- Firmware warnings are their own alert category so organizations can route
+-- them separately from battery/vitals/connectivity alerts
+ALTER TABLE device_alerts DROP CONSTRAINT device_alerts_category_check;
+ALTER TABLE device_alerts ADD CONSTRAINT device_alerts_category_check
+    CHECK (category IN ('battery', 'vitals', 'connectivity', 'firmware'));
+
+ALTER TABLE alert_routing_rules DROP CONSTRAINT alert_routing_rules_alert_category_check;
+ALTER TABLE alert_routing_rules ADD CONSTRAINT alert_routing_rules_alert_category_check
+    CHECK (alert_category IN ('battery', 'vitals', 'connectivity', 'firmware'));
@@ -0,0 +1,20 @@
+-- Create device_transfers table
+-- A transfer hands a device from its current owner to a new user: the owner
+-- initiates, the new owner accepts. Acceptance reassigns the device, wipes
+-- user-specific settings and revokes the previous owner's shares in one
+-- transaction, so refurbished hardware never carries the old owner's data
+CREATE TABLE IF NOT EXISTS device_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'CANCELLED')),
+    initiated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMP
+);
+
+-- Only one transfer may be in flight per device
+CREATE UNIQUE INDEX idx_device_transfers_pending ON device_transfers(device_id) WHERE status = 'PENDING';
+
+-- Look up transfers addressed to a user
+CREATE INDEX idx_device_transfers_to_user ON device_transfers(to_user_id);
@@ -0,0 +1,147 @@
+// Package emergencyapi is a typed client for the emergency-service HTTP API.
+// The request types mirror the AutoTriggerRequest schema in the
+// emergency-service OpenAPI spec (served at /openapi.json); keep the two in
+// lockstep so the cross-service contract cannot drift silently
+package emergencyapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrConflict is returned when the emergency-service answers 409: the user
+// already has an active emergency, so the trigger was not lost
+var ErrConflict = errors.New("user already has an active emergency")
+
+// ErrNoPendingEmergency is returned when a device cancel finds nothing to
+// cancel: either there is no active emergency at all (404) or its countdown
+// has already finished (409)
+var ErrNoPendingEmergency = errors.New("no pending emergency to cancel")
+
+// ErrNotTriggeringDevice is returned when a device cancel is refused because
+// the emergency was triggered by a different device
+var ErrNotTriggeringDevice = errors.New("emergency was not triggered by this device")
+
+// DeviceInfo describes the device that raised the trigger
+type DeviceInfo struct {
+	Type         string `json:"type"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	MacAddress   string `json:"mac_address"`
+	Battery      int    `json:"battery"`
+}
+
+// AutoTriggerRequest is the payload for POST /api/v1/emergency/auto-trigger
+type AutoTriggerRequest struct {
+	UserID     string                 `json:"user_id"`
+	DeviceID   string                 `json:"device_id"`
+	EventType  string                 `json:"event_type"`
+	Reason     string                 `json:"reason,omitempty"`
+	Priority   string                 `json:"priority,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Confidence *float64               `json:"confidence,omitempty"`
+	DeviceInfo *DeviceInfo            `json:"device_info,omitempty"`
+	Location   map[string]interface{} `json:"location,omitempty"`
+	EventData  map[string]interface{} `json:"event_data,omitempty"`
+}
+
+// Client calls the emergency-service auto-trigger endpoint
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the emergency-service at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// AutoTrigger posts a typed trigger request, identifying the originating
+// device via the X-Device-ID and X-User-ID headers
+func (c *Client) AutoTrigger(ctx context.Context, req *AutoTriggerRequest) error {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal emergency payload: %w", err)
+	}
+	return c.deliver(ctx, payload, req.DeviceID, req.UserID)
+}
+
+// Deliver posts a previously marshaled trigger payload. The durable retry
+// queue stores the exact bytes of the original request, so redelivery must
+// not re-marshal
+func (c *Client) Deliver(ctx context.Context, payload []byte) error {
+	return c.deliver(ctx, payload, "", "")
+}
+
+// CancelByDevice asks the emergency-service to cancel the user's pending
+// emergency on behalf of the device, e.g. after a button double-press. The
+// service resolves the emergency from the identity headers and only honours
+// the cancel if this device is the one that triggered it
+func (c *Client) CancelByDevice(ctx context.Context, deviceID, userID string) error {
+	url := c.baseURL + "/api/v1/emergency/device-cancel"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create cancel request: %w", err)
+	}
+
+	req.Header.Set("X-Device-ID", deviceID)
+	req.Header.Set("X-User-ID", userID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call emergency service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusConflict:
+		return ErrNoPendingEmergency
+	case http.StatusForbidden:
+		return ErrNotTriggeringDevice
+	default:
+		return fmt.Errorf("emergency service returned status %d", resp.StatusCode)
+	}
+}
+
+func (c *Client) deliver(ctx context.Context, payload []byte, deviceID, userID string) error {
+	url := c.baseURL + "/api/v1/emergency/auto-trigger"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create emergency request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if deviceID != "" {
+		req.Header.Set("X-Device-ID", deviceID)
+	}
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call emergency service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+		return nil
+	case resp.StatusCode == http.StatusConflict:
+		return ErrConflict
+	default:
+		return fmt.Errorf("emergency service returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// adminSearchDefaultLimit bounds result sets for the admin device search
+const adminSearchDefaultLimit = 50
+
+// adminSearchMaxLimit is the hard ceiling on admin search result sets
+const adminSearchMaxLimit = 500
+
+// AdminSearchHandler lets support staff find devices across all users.
+// User identifiers are masked in responses unless the caller also presents
+// the PII token, so routine hardware lookups don't expose account identities
+type AdminSearchHandler struct {
+	deviceRepo *repository.DeviceRepository
+	adminToken string
+	piiToken   string
+	logger     zerolog.Logger
+}
+
+// NewAdminSearchHandler creates a new admin search handler. An empty piiToken
+// means user identifiers are always masked
+func NewAdminSearchHandler(
+	deviceRepo *repository.DeviceRepository,
+	adminToken string,
+	piiToken string,
+	logger zerolog.Logger,
+) *AdminSearchHandler {
+	return &AdminSearchHandler{
+		deviceRepo: deviceRepo,
+		adminToken: adminToken,
+		piiToken:   piiToken,
+		logger:     logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *AdminSearchHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// hasPIIScope reports whether the caller presented the PII token and may see
+// unmasked user identifiers
+func (h *AdminSearchHandler) hasPIIScope(r *http.Request) bool {
+	if h.piiToken == "" {
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-PII-Token")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.piiToken)) == 1
+}
+
+// SearchDevices handles GET /api/v1/admin/devices/search
+// Query params: mac, serial, model (partial matches, at least one required),
+// limit (default 50, max 500)
+func (h *AdminSearchHandler) SearchDevices(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	macAddress := r.URL.Query().Get("mac")
+	serialNumber := r.URL.Query().Get("serial")
+	model := r.URL.Query().Get("model")
+
+	if macAddress == "" && serialNumber == "" && model == "" {
+		h.respondError(w, http.StatusBadRequest, "At least one of mac, serial or model is required")
+		return
+	}
+
+	limit := adminSearchDefaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > adminSearchMaxLimit {
+			h.respondError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		limit = parsed
+	}
+
+	devices, err := h.deviceRepo.Search(r.Context(), macAddress, serialNumber, model, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to search devices")
+		h.respondError(w, http.StatusInternalServerError, "Failed to search devices")
+		return
+	}
+	if devices == nil {
+		devices = []*models.Device{}
+	}
+
+	// Settings never leave this endpoint; user IDs only with PII scope
+	piiScope := h.hasPIIScope(r)
+	for _, device := range devices {
+		device.Settings = nil
+		if !piiScope {
+			device.UserID = maskUserID(device.UserID)
+		}
+	}
+
+	h.logger.Info().
+		Int("count", len(devices)).
+		Bool("pii_scope", piiScope).
+		Msg("Admin device search")
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"devices": devices,
+		"count":   len(devices),
+		"masked":  !piiScope,
+	})
+}
+
+// maskUserID keeps enough of a user ID to correlate results without
+// exposing the full identifier
+func maskUserID(userID string) string {
+	if len(userID) <= 8 {
+		return "********"
+	}
+	return userID[:8] + "****"
+}
+
+// respondJSON sends a JSON response
+func (h *AdminSearchHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *AdminSearchHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskUserID(t *testing.T) {
+	// Long identifiers keep a correlatable prefix
+	assert.Equal(t, "3f2c81a2****", maskUserID("3f2c81a2-9d41-4a7e-b1c0-8e5f6a7b8c9d"))
+
+	// Short identifiers are masked entirely
+	assert.Equal(t, "********", maskUserID("user1"))
+	assert.Equal(t, "********", maskUserID(""))
+}
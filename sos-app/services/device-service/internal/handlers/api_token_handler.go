@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// APITokenHandler manages scoped API tokens for third-party integrations
+type APITokenHandler struct {
+	tokenRepo *repository.APITokenRepository
+	logger    zerolog.Logger
+}
+
+// NewAPITokenHandler creates a new API token handler
+func NewAPITokenHandler(tokenRepo *repository.APITokenRepository, logger zerolog.Logger) *APITokenHandler {
+	return &APITokenHandler{
+		tokenRepo: tokenRepo,
+		logger:    logger,
+	}
+}
+
+// issueTokenRequest is the body for issuing a new token
+type issueTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// IssueToken handles POST /api/v1/api-tokens
+// Issues a new scoped token; the token value is returned exactly once
+func (h *APITokenHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	// A token must not mint further tokens, or revoking it wouldn't revoke
+	// the access it granted
+	if APITokenFrom(r.Context()) != nil {
+		h.respondError(w, http.StatusForbidden, "API tokens cannot manage API tokens")
+		return
+	}
+
+	var req issueTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		h.respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if err := models.ValidateScopes(req.Scopes); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	plaintext, err := generateAPIToken()
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to generate api token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue token")
+		return
+	}
+
+	token := &models.APIToken{
+		UserID: userID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+	if err := h.tokenRepo.Create(r.Context(), token, hashAPIToken(plaintext)); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to store api token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue token")
+		return
+	}
+
+	h.logger.Info().
+		Str("token_id", token.ID).
+		Str("user_id", userID).
+		Strs("scopes", req.Scopes).
+		Msg("API token issued")
+
+	h.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"token":     plaintext,
+		"api_token": token,
+	})
+}
+
+// ListTokens handles GET /api/v1/api-tokens
+func (h *APITokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	tokens, err := h.tokenRepo.ListByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list api tokens")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list tokens")
+		return
+	}
+	if tokens == nil {
+		tokens = []models.APIToken{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"api_tokens": tokens,
+		"count":      len(tokens),
+	})
+}
+
+// RevokeToken handles DELETE /api/v1/api-tokens/{id}
+func (h *APITokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if APITokenFrom(r.Context()) != nil {
+		h.respondError(w, http.StatusForbidden, "API tokens cannot manage API tokens")
+		return
+	}
+
+	tokenID := mux.Vars(r)["id"]
+
+	if err := h.tokenRepo.Revoke(r.Context(), tokenID, userID); err != nil {
+		if err == repository.ErrAPITokenNotFound {
+			h.respondError(w, http.StatusNotFound, "Token not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("token_id", tokenID).Msg("Failed to revoke api token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to revoke token")
+		return
+	}
+
+	h.logger.Info().
+		Str("token_id", tokenID).
+		Str("user_id", userID).
+		Msg("API token revoked")
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Token revoked",
+	})
+}
+
+// generateAPIToken returns a prefixed random 128-bit bearer token
+func generateAPIToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return models.APITokenPrefix + hex.EncodeToString(token), nil
+}
+
+// respondJSON sends a JSON response
+func (h *APITokenHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *APITokenHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
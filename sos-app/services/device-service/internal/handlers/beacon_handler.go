@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// maxBeaconBytes bounds a beacon body; the compact payload fits well under it
+const maxBeaconBytes = 1024
+
+// BeaconHandler accepts HTTPS beacon reports from NB-IoT/LTE-M trackers
+// that cannot hold an MQTT connection. Beacons are authenticated with a
+// per-device bearer token and mapped into the same telemetry and event
+// pipelines the MQTT topics feed
+type BeaconHandler struct {
+	deviceRepo        *repository.DeviceRepository
+	tokenRepo         *repository.DeviceBeaconTokenRepository
+	telemetryPipeline mqtt.MessageHandler
+	eventPipeline     mqtt.MessageHandler
+	logger            zerolog.Logger
+}
+
+// NewBeaconHandler creates a new beacon handler. telemetryPipeline and
+// eventPipeline are the same wrapped handler chains the MQTT subscriptions
+// use, so beacons get the guard, dedup and archival treatment MQTT
+// payloads do
+func NewBeaconHandler(
+	deviceRepo *repository.DeviceRepository,
+	tokenRepo *repository.DeviceBeaconTokenRepository,
+	telemetryPipeline mqtt.MessageHandler,
+	eventPipeline mqtt.MessageHandler,
+	logger zerolog.Logger,
+) *BeaconHandler {
+	return &BeaconHandler{
+		deviceRepo:        deviceRepo,
+		tokenRepo:         tokenRepo,
+		telemetryPipeline: telemetryPipeline,
+		eventPipeline:     eventPipeline,
+		logger:            logger,
+	}
+}
+
+// IssueToken handles POST /api/v1/devices/{id}/beacon-token
+// Issues (or replaces) the device's beacon bearer token; the token is
+// returned exactly once
+func (h *BeaconHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deviceID := mux.Vars(r)["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get device")
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+	if device.UserID != userID {
+		h.respondError(w, http.StatusForbidden, "Device does not belong to user")
+		return
+	}
+
+	token, err := generateBeaconToken()
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to generate beacon token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue beacon token")
+		return
+	}
+
+	if err := h.tokenRepo.Upsert(r.Context(), deviceID, hashBeaconToken(token)); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to store beacon token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue beacon token")
+		return
+	}
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Str("user_id", userID).
+		Msg("Beacon token issued")
+
+	h.respondJSON(w, http.StatusCreated, map[string]string{
+		"device_id": deviceID,
+		"token":     token,
+	})
+}
+
+// HandleBeacon handles POST /api/v1/devices/{id}/beacon
+// The compact report is authenticated against the device's beacon token
+// and fed into the telemetry pipeline; flags become device events
+func (h *BeaconHandler) HandleBeacon(w http.ResponseWriter, r *http.Request) {
+	deviceID := mux.Vars(r)["id"]
+
+	if !h.authenticate(w, r, deviceID) {
+		return
+	}
+
+	var payload models.BeaconPayload
+	r.Body = http.MaxBytesReader(w, r.Body, maxBeaconBytes)
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid beacon payload")
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+
+	telemetry := models.TelemetryData{
+		DeviceID:     deviceID,
+		Timestamp:    now,
+		BatteryLevel: payload.Battery,
+		Metadata:     map[string]interface{}{"transport": "beacon"},
+	}
+	if payload.Latitude != nil {
+		telemetry.Location = &models.Location{
+			Latitude:  *payload.Latitude,
+			Longitude: *payload.Longitude,
+		}
+	}
+
+	telemetryBytes, err := json.Marshal(telemetry)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to marshal beacon telemetry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to process beacon")
+		return
+	}
+
+	if err := h.telemetryPipeline(fmt.Sprintf("devices/%s/telemetry", deviceID), telemetryBytes); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Beacon telemetry processing failed")
+		h.respondError(w, http.StatusInternalServerError, "Failed to process beacon")
+		return
+	}
+
+	// Flags ride the event pipeline so an "sos" beacon triggers the same
+	// emergency flow as an MQTT SOS button event
+	events := 0
+	for _, flag := range payload.Flags {
+		eventType, ok := models.EventTypeForBeaconFlag(flag)
+		if !ok {
+			continue // Validate already rejected unknown flags
+		}
+
+		event := models.DeviceEvent{
+			DeviceID:  deviceID,
+			EventType: eventType,
+			Timestamp: now,
+			Data:      map[string]interface{}{"transport": "beacon"},
+		}
+		eventBytes, err := json.Marshal(event)
+		if err != nil {
+			h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to marshal beacon event")
+			continue
+		}
+
+		if err := h.eventPipeline(fmt.Sprintf("devices/%s/events", deviceID), eventBytes); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("device_id", deviceID).
+				Str("event_type", eventType).
+				Msg("Beacon event processing failed")
+			h.respondError(w, http.StatusInternalServerError, "Failed to process beacon")
+			return
+		}
+		events++
+	}
+
+	h.respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"device_id": deviceID,
+		"events":    events,
+		"timestamp": now,
+	})
+}
+
+// authenticate verifies the beacon bearer token, writing the error
+// response itself when the check fails
+func (h *BeaconHandler) authenticate(w http.ResponseWriter, r *http.Request, deviceID string) bool {
+	// Integrations may push beacons with a scoped API token instead of the
+	// per-device beacon token; the scope middleware has already validated
+	// it, so only device ownership is checked here
+	if apiToken := APITokenFrom(r.Context()); apiToken != nil {
+		device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+		if err != nil {
+			h.respondError(w, http.StatusNotFound, "Device not found")
+			return false
+		}
+		if device.UserID != apiToken.UserID {
+			h.respondError(w, http.StatusForbidden, "Device does not belong to token owner")
+			return false
+		}
+		return true
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		h.respondError(w, http.StatusUnauthorized, "Missing beacon token")
+		return false
+	}
+
+	storedHash, err := h.tokenRepo.GetHash(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get beacon token")
+		h.respondError(w, http.StatusInternalServerError, "Failed to process beacon")
+		return false
+	}
+	if storedHash == "" {
+		h.respondError(w, http.StatusUnauthorized, "Device has no beacon token issued")
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashBeaconToken(token)), []byte(storedHash)) != 1 {
+		h.logger.Warn().Str("device_id", deviceID).Msg("Beacon with wrong token")
+		h.respondError(w, http.StatusUnauthorized, "Invalid beacon token")
+		return false
+	}
+
+	return true
+}
+
+// generateBeaconToken returns a random 128-bit bearer token as hex
+func generateBeaconToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// hashBeaconToken returns the stored form of a beacon token
+func hashBeaconToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// respondJSON sends a JSON response
+func (h *BeaconHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *BeaconHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
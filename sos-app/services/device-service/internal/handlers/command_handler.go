@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+const (
+	// defaultLocateDurationSeconds is used when the caller doesn't specify one
+	defaultLocateDurationSeconds = 30
+	// maxLocateDurationSeconds caps how long a siren can be triggered remotely
+	maxLocateDurationSeconds = 300
+)
+
+// CommandHandler handles remote device command HTTP requests
+type CommandHandler struct {
+	deviceRepo        *repository.DeviceRepository
+	commandRepo       *repository.DeviceCommandRepository
+	encryptionKeyRepo *repository.DeviceEncryptionKeyRepository
+	mqttClient        *mqtt.Client
+	logger            zerolog.Logger
+}
+
+// NewCommandHandler creates a new command handler
+func NewCommandHandler(
+	deviceRepo *repository.DeviceRepository,
+	commandRepo *repository.DeviceCommandRepository,
+	encryptionKeyRepo *repository.DeviceEncryptionKeyRepository,
+	mqttClient *mqtt.Client,
+	logger zerolog.Logger,
+) *CommandHandler {
+	return &CommandHandler{
+		deviceRepo:        deviceRepo,
+		commandRepo:       commandRepo,
+		encryptionKeyRepo: encryptionKeyRepo,
+		mqttClient:        mqttClient,
+		logger:            logger,
+	}
+}
+
+// LocateDevice handles POST /api/v1/devices/{id}/locate
+// Publishes a "sound siren / flash LED" command to the device and records it
+// so the caller can poll whether the device acknowledged execution
+func (h *CommandHandler) LocateDevice(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deviceID := mux.Vars(r)["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get device")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get device")
+		return
+	}
+	if device == nil || device.Status == models.DeviceStatusDeleted {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	// Only the owner may trigger the siren remotely
+	if device.UserID != userID {
+		h.logger.Warn().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("Locate rejected: user does not own this device")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	var req models.LocateDeviceRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	hasSiren := hasCapability(device, models.CapabilitySiren)
+	hasStrobe := hasCapability(device, models.CapabilityStrobe)
+
+	// Default to every locate capability the device has
+	if !req.Siren && !req.Strobe {
+		req.Siren = hasSiren
+		req.Strobe = hasStrobe
+	}
+
+	if !req.Siren && !req.Strobe {
+		h.respondError(w, http.StatusConflict, "Device has no siren or strobe capability")
+		return
+	}
+	if req.Siren && !hasSiren {
+		h.respondError(w, http.StatusConflict, "Device has no siren capability")
+		return
+	}
+	if req.Strobe && !hasStrobe {
+		h.respondError(w, http.StatusConflict, "Device has no strobe capability")
+		return
+	}
+
+	if req.DurationSeconds <= 0 {
+		req.DurationSeconds = defaultLocateDurationSeconds
+	}
+	if req.DurationSeconds > maxLocateDurationSeconds {
+		h.respondError(w, http.StatusBadRequest, "duration_seconds must be at most 300")
+		return
+	}
+
+	command := &models.DeviceCommand{
+		DeviceID:    deviceID,
+		CommandType: models.CommandTypeLocate,
+		IssuedBy:    userID,
+		Status:      models.CommandStatusPending,
+	}
+	if err := h.commandRepo.Create(r.Context(), command); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to record locate command")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue locate command")
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":             "locate",
+		"command_id":       command.ID,
+		"siren":            req.Siren,
+		"strobe":           req.Strobe,
+		"duration_seconds": req.DurationSeconds,
+	})
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue locate command")
+		return
+	}
+
+	if err := h.mqttClient.PublishCommand(deviceID, payload); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to publish locate command")
+		errMsg := "failed to publish command to device"
+		if ackErr := h.commandRepo.MarkAcked(r.Context(), deviceID, command.ID, models.CommandStatusFailed, &errMsg); ackErr != nil {
+			h.logger.Error().Err(ackErr).Str("command_id", command.ID).Msg("Failed to mark command as failed")
+		}
+		h.respondError(w, http.StatusBadGateway, "Failed to deliver locate command")
+		return
+	}
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Str("user_id", userID).
+		Str("command_id", command.ID).
+		Bool("siren", req.Siren).
+		Bool("strobe", req.Strobe).
+		Msg("Locate command issued")
+
+	h.respondJSON(w, http.StatusAccepted, command)
+}
+
+// RotateKey handles POST /api/v1/devices/{id}/rotate-key
+// Asks the device to generate a fresh payload encryption keypair; the new
+// public key arrives in the command ack and replaces the registered one
+func (h *CommandHandler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deviceID := mux.Vars(r)["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get device")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get device")
+		return
+	}
+	if device == nil || device.Status == models.DeviceStatusDeleted {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	// Only the owner may rotate the device's encryption key
+	if device.UserID != userID {
+		h.logger.Warn().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("Key rotation rejected: user does not own this device")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	// Rotation only makes sense for devices that registered a key
+	key, err := h.encryptionKeyRepo.GetByDeviceID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get device encryption key")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue key rotation command")
+		return
+	}
+	if key == nil {
+		h.respondError(w, http.StatusConflict, "Device has no registered encryption key")
+		return
+	}
+
+	command := &models.DeviceCommand{
+		DeviceID:    deviceID,
+		CommandType: models.CommandTypeRotateKey,
+		IssuedBy:    userID,
+		Status:      models.CommandStatusPending,
+	}
+	if err := h.commandRepo.Create(r.Context(), command); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to record rotate-key command")
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue key rotation command")
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":       "rotate_key",
+		"command_id": command.ID,
+	})
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to issue key rotation command")
+		return
+	}
+
+	if err := h.mqttClient.PublishCommand(deviceID, payload); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to publish rotate-key command")
+		errMsg := "failed to publish command to device"
+		if ackErr := h.commandRepo.MarkAcked(r.Context(), deviceID, command.ID, models.CommandStatusFailed, &errMsg); ackErr != nil {
+			h.logger.Error().Err(ackErr).Str("command_id", command.ID).Msg("Failed to mark command as failed")
+		}
+		h.respondError(w, http.StatusBadGateway, "Failed to deliver key rotation command")
+		return
+	}
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Str("user_id", userID).
+		Str("command_id", command.ID).
+		Msg("Key rotation command issued")
+
+	h.respondJSON(w, http.StatusAccepted, command)
+}
+
+// GetCommand handles GET /api/v1/devices/{id}/commands/{commandId}
+// Lets the caller poll whether the device acknowledged a command
+func (h *CommandHandler) GetCommand(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+	commandID := vars["commandId"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get device")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get device")
+		return
+	}
+	if device == nil {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	if device.UserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	command, err := h.commandRepo.GetByID(r.Context(), deviceID, commandID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCommandNotFound) {
+			h.respondError(w, http.StatusNotFound, "Command not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("command_id", commandID).Msg("Failed to get command")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get command")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, command)
+}
+
+// hasCapability reports whether the device lists the capability, ignoring case
+func hasCapability(device *models.Device, capability string) bool {
+	for _, c := range device.Capabilities {
+		if strings.EqualFold(c, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// respondJSON sends a JSON response
+func (h *CommandHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *CommandHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
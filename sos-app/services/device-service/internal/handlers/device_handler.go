@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,28 +16,85 @@ import (
 	"github.com/sos-app/device-service/internal/models"
 	"github.com/sos-app/device-service/internal/mqtt"
 	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
 )
 
 // DeviceHandler handles device-related HTTP requests
 type DeviceHandler struct {
-	deviceRepo *repository.DeviceRepository
-	mqttClient *mqtt.Client
-	logger     zerolog.Logger
+	deviceRepo         *repository.DeviceRepository
+	shareRepo          *repository.DeviceShareRepository
+	registryRepo       *repository.DeviceRegistryRepository
+	batteryHistoryRepo *repository.BatteryHistoryRepository
+	vitalsReadingRepo  *repository.VitalsReadingRepository
+	alertRepo          *repository.DeviceAlertRepository
+	encryptionKeyRepo  *repository.DeviceEncryptionKeyRepository
+	settingsCipher     *services.SettingsCipher
+	firmwareCompat     *services.FirmwareCompatibilityChecker
+	mqttClient         *mqtt.Client
+	logger             zerolog.Logger
 }
 
-// NewDeviceHandler creates a new device handler
+// NewDeviceHandler creates a new device handler. registryRepo may be nil to
+// disable registry enforcement (e.g. local development); settingsCipher may
+// be nil to store all settings in plaintext; firmwareCompat may be nil to
+// skip firmware compatibility warnings
 func NewDeviceHandler(
 	deviceRepo *repository.DeviceRepository,
+	shareRepo *repository.DeviceShareRepository,
+	registryRepo *repository.DeviceRegistryRepository,
+	batteryHistoryRepo *repository.BatteryHistoryRepository,
+	vitalsReadingRepo *repository.VitalsReadingRepository,
+	alertRepo *repository.DeviceAlertRepository,
+	encryptionKeyRepo *repository.DeviceEncryptionKeyRepository,
+	settingsCipher *services.SettingsCipher,
+	firmwareCompat *services.FirmwareCompatibilityChecker,
 	mqttClient *mqtt.Client,
 	logger zerolog.Logger,
 ) *DeviceHandler {
 	return &DeviceHandler{
-		deviceRepo: deviceRepo,
-		mqttClient: mqttClient,
-		logger:     logger,
+		deviceRepo:         deviceRepo,
+		shareRepo:          shareRepo,
+		registryRepo:       registryRepo,
+		batteryHistoryRepo: batteryHistoryRepo,
+		vitalsReadingRepo:  vitalsReadingRepo,
+		alertRepo:          alertRepo,
+		encryptionKeyRepo:  encryptionKeyRepo,
+		settingsCipher:     settingsCipher,
+		firmwareCompat:     firmwareCompat,
+		mqttClient:         mqttClient,
+		logger:             logger,
 	}
 }
 
+// canAccessDevice reports whether userID may access the device, either as
+// its owner or through an accepted share. needManage restricts access to
+// owners and MANAGER-level shares.
+func (h *DeviceHandler) canAccessDevice(r *http.Request, device *models.Device, userID string, needManage bool) bool {
+	if device.UserID == userID {
+		return true
+	}
+
+	if h.shareRepo == nil {
+		return false
+	}
+
+	share, err := h.shareRepo.GetAcceptedShare(r.Context(), device.ID, userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", device.ID).Msg("Failed to check device share")
+		return false
+	}
+	if share == nil {
+		return false
+	}
+
+	if needManage {
+		return share.Role.AllowsManage()
+	}
+
+	return true
+}
+
 // PairDevice handles POST /api/v1/devices/pair
 func (h *DeviceHandler) PairDevice(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
@@ -59,6 +120,49 @@ func (h *DeviceHandler) PairDevice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Devices that encrypt their payloads register the public half of
+	// their keypair up front
+	if req.EncryptionMode != "" && !models.ValidEncryptionMode(req.EncryptionMode) {
+		h.respondError(w, http.StatusBadRequest, "encryption_mode must be SERVER or E2E")
+		return
+	}
+	if req.EncryptionMode != "" && req.EncryptionPublicKey == "" {
+		h.respondError(w, http.StatusBadRequest, "encryption_public_key is required when encryption_mode is set")
+		return
+	}
+	if req.EncryptionPublicKey != "" {
+		if err := models.ValidateEncryptionPublicKey(req.EncryptionPublicKey); err != nil {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Only hardware pre-registered in the device registry may be paired
+	if h.registryRepo != nil {
+		entry, err := h.registryRepo.GetByMacAddress(r.Context(), req.MacAddress)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("Failed to check device registry")
+			h.respondError(w, http.StatusInternalServerError, "Failed to pair device")
+			return
+		}
+		if entry == nil {
+			h.logger.Warn().
+				Str("mac_address", req.MacAddress).
+				Str("user_id", userID).
+				Msg("Pairing rejected: device not in registry")
+			h.respondError(w, http.StatusForbidden, "Device is not registered")
+			return
+		}
+		if entry.RequireSerialProof && !strings.EqualFold(req.SerialNumber, entry.SerialNumber) {
+			h.logger.Warn().
+				Str("mac_address", req.MacAddress).
+				Str("user_id", userID).
+				Msg("Pairing rejected: serial proof mismatch")
+			h.respondError(w, http.StatusForbidden, "Serial number does not match the registered device")
+			return
+		}
+	}
+
 	// Check if device with this MAC address already exists
 	existingDevice, err := h.deviceRepo.GetByMacAddress(r.Context(), req.MacAddress)
 	if err == nil && existingDevice != nil {
@@ -84,13 +188,48 @@ func (h *DeviceHandler) PairDevice(w http.ResponseWriter, r *http.Request) {
 		Capabilities: req.Capabilities,
 		Settings:     make(map[string]interface{}),
 	}
+	if req.FirmwareVersion != "" {
+		device.Settings["firmware_version"] = req.FirmwareVersion
+	}
 
 	if err := h.deviceRepo.Create(r.Context(), device); err != nil {
+		if errors.Is(err, repository.ErrMacAlreadyPaired) {
+			// Lost the race against a concurrent pairing of the same MAC
+			h.logger.Warn().
+				Str("mac_address", req.MacAddress).
+				Str("user_id", userID).
+				Msg("Device with this MAC address already paired")
+			h.respondError(w, http.StatusConflict, "Device already paired")
+			return
+		}
 		h.logger.Error().Err(err).Msg("Failed to create device")
 		h.respondError(w, http.StatusInternalServerError, "Failed to pair device")
 		return
 	}
 
+	// Register the device's payload encryption key so its encrypted
+	// vitals can be opened (SERVER) or passed through opaque (E2E)
+	if req.EncryptionPublicKey != "" && h.encryptionKeyRepo != nil {
+		mode := req.EncryptionMode
+		if mode == "" {
+			mode = models.EncryptionModeServer
+		}
+		key := &models.DeviceEncryptionKey{
+			DeviceID:  device.ID,
+			PublicKey: req.EncryptionPublicKey,
+			Mode:      mode,
+		}
+		if err := h.encryptionKeyRepo.Upsert(r.Context(), key); err != nil {
+			h.logger.Error().Err(err).Str("device_id", device.ID).Msg("Failed to register device encryption key")
+			h.respondError(w, http.StatusInternalServerError, "Failed to register device encryption key")
+			return
+		}
+		h.logger.Info().
+			Str("device_id", device.ID).
+			Str("mode", string(mode)).
+			Msg("Device encryption key registered")
+	}
+
 	// Subscribe to device's MQTT topics
 	if h.mqttClient.IsConnected() {
 		if err := h.mqttClient.SubscribeToSpecificDevice(device.ID, func(topic string, payload []byte) error {
@@ -107,6 +246,20 @@ func (h *DeviceHandler) PairDevice(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Check the reported firmware against the compatibility matrix; known
+	// issues surface to the owner through the alerts API
+	if warnings := h.firmwareCompat.CheckAndWarn(r.Context(), device, req.FirmwareVersion); len(warnings) > 0 {
+		h.logger.Warn().
+			Str("device_id", device.ID).
+			Str("firmware_version", req.FirmwareVersion).
+			Strs("warnings", warnings).
+			Msg("Paired device firmware has known issues")
+	}
+
+	// Tell the device which topics to publish, derived from its declared
+	// capabilities and the owner's subscription tier
+	h.publishTopicConfig(device, r.Header.Get("X-Subscription-Tier"))
+
 	h.logger.Info().
 		Str("device_id", device.ID).
 		Str("user_id", userID).
@@ -116,6 +269,42 @@ func (h *DeviceHandler) PairDevice(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusCreated, device)
 }
 
+// publishTopicConfig publishes the retained topic configuration message for
+// a device so it knows exactly which topics to publish; failures are logged
+// and the device falls back to its last retained configuration
+func (h *DeviceHandler) publishTopicConfig(device *models.Device, rawTier string) {
+	if !h.mqttClient.IsConnected() {
+		return
+	}
+
+	tier := models.SubscriptionTierFree
+	if strings.EqualFold(rawTier, models.SubscriptionTierPremium) {
+		tier = models.SubscriptionTierPremium
+	}
+
+	config := models.BuildTopicConfig(device, tier)
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", device.ID).Msg("Failed to marshal topic configuration")
+		return
+	}
+
+	if err := h.mqttClient.PublishDeviceConfig(device.ID, configJSON); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Msg("Failed to publish topic configuration")
+		return
+	}
+
+	h.logger.Info().
+		Str("device_id", device.ID).
+		Str("tier", tier).
+		Bool("vitals_enabled", config.VitalsEnabled).
+		Int("event_types", len(config.EventTypes)).
+		Msg("Published retained topic configuration")
+}
+
 // UnpairDevice handles DELETE /api/v1/devices/:id
 func (h *DeviceHandler) UnpairDevice(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context
@@ -148,7 +337,7 @@ func (h *DeviceHandler) UnpairDevice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Unsubscribe from MQTT topics
+	// Unsubscribe from MQTT topics and drop the retained topic configuration
 	if h.mqttClient.IsConnected() {
 		if err := h.mqttClient.UnsubscribeFromDevice(deviceID); err != nil {
 			h.logger.Error().
@@ -156,6 +345,12 @@ func (h *DeviceHandler) UnpairDevice(w http.ResponseWriter, r *http.Request) {
 				Str("device_id", deviceID).
 				Msg("Failed to unsubscribe from device MQTT topics")
 		}
+		if err := h.mqttClient.ClearDeviceConfig(deviceID); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("device_id", deviceID).
+				Msg("Failed to clear retained topic configuration")
+		}
 	}
 
 	// Soft delete device
@@ -192,14 +387,29 @@ func (h *DeviceHandler) GetUserDevices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Include devices shared with this user (household/caregiver access)
+	var shared []models.Device
+	if h.shareRepo != nil {
+		shared, err = h.shareRepo.GetSharedDevices(r.Context(), userID)
+		if err != nil {
+			h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to get shared devices")
+			shared = nil
+		}
+	}
+	if shared == nil {
+		shared = []models.Device{}
+	}
+
 	h.logger.Info().
 		Str("user_id", userID).
 		Int("count", len(devices)).
+		Int("shared_count", len(shared)).
 		Msg("Retrieved user devices")
 
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{
-		"devices": devices,
-		"count":   len(devices),
+		"devices":        devices,
+		"count":          len(devices),
+		"shared_devices": shared,
 	})
 }
 
@@ -224,12 +434,12 @@ func (h *DeviceHandler) UpdateDeviceSettings(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Verify user owns this device
-	if device.UserID != userID {
+	// Verify user owns this device or has MANAGER-level shared access
+	if !h.canAccessDevice(r, device, userID, true) {
 		h.logger.Error().
 			Str("device_id", deviceID).
 			Str("user_id", userID).
-			Msg("User does not own this device")
+			Msg("User may not manage this device")
 		h.respondError(w, http.StatusForbidden, "Access denied")
 		return
 	}
@@ -242,28 +452,53 @@ func (h *DeviceHandler) UpdateDeviceSettings(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Seal sensitive setting values before they reach the database
+	storedSettings, err := h.settingsCipher.EncryptSettings(req.Settings)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to encrypt settings")
+		h.respondError(w, http.StatusInternalServerError, "Failed to update device settings")
+		return
+	}
+
 	// Update settings in database
-	if err := h.deviceRepo.UpdateSettings(r.Context(), deviceID, req.Settings); err != nil {
+	version, err := h.deviceRepo.UpdateSettings(r.Context(), deviceID, storedSettings)
+	if err != nil {
 		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to update settings")
 		h.respondError(w, http.StatusInternalServerError, "Failed to update device settings")
 		return
 	}
 
-	// Publish settings to device via MQTT
+	// Publish only the changed keys to the device (JSON merge patch);
+	// constrained devices request a full sync if they miss a version
 	if h.mqttClient.IsConnected() {
-		settingsJSON, err := json.Marshal(req.Settings)
+		// The device needs plaintext values, so the delta is computed over
+		// decrypted settings rather than the stored envelopes
+		oldSettings, err := h.settingsCipher.DecryptSettings(device.Settings)
+		if err != nil {
+			h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to decrypt previous settings for delta")
+			oldSettings = device.Settings
+		}
+		patch := settingsMergePatch(oldSettings, req.Settings)
+		delta := map[string]interface{}{
+			"type":             "settings:delta",
+			"settings_version": version,
+			"patch":            patch,
+		}
+		deltaJSON, err := json.Marshal(delta)
 		if err != nil {
-			h.logger.Error().Err(err).Msg("Failed to marshal settings")
+			h.logger.Error().Err(err).Msg("Failed to marshal settings delta")
 		} else {
-			if err := h.mqttClient.PublishCommand(deviceID, settingsJSON); err != nil {
+			if err := h.mqttClient.PublishCommand(deviceID, deltaJSON); err != nil {
 				h.logger.Error().
 					Err(err).
 					Str("device_id", deviceID).
-					Msg("Failed to publish settings to device")
+					Msg("Failed to publish settings delta to device")
 			} else {
 				h.logger.Info().
 					Str("device_id", deviceID).
-					Msg("Published settings to device via MQTT")
+					Int("settings_version", version).
+					Int("changed_keys", len(patch)).
+					Msg("Published settings delta to device via MQTT")
 			}
 		}
 	}
@@ -281,6 +516,17 @@ func (h *DeviceHandler) UpdateDeviceSettings(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Settings changes can affect which topics the device should publish,
+	// so refresh the retained topic configuration alongside the delta
+	h.publishTopicConfig(updatedDevice, r.Header.Get("X-Subscription-Tier"))
+
+	// The caller already proved manage access, so return plaintext settings
+	if decrypted, err := h.settingsCipher.DecryptSettings(updatedDevice.Settings); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to decrypt settings for response")
+	} else {
+		updatedDevice.Settings = decrypted
+	}
+
 	h.respondJSON(w, http.StatusOK, updatedDevice)
 }
 
@@ -305,24 +551,320 @@ func (h *DeviceHandler) GetDevice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify user owns this device
-	if device.UserID != userID {
+	// Verify user owns this device or has shared access
+	if !h.canAccessDevice(r, device, userID, false) {
 		h.logger.Error().
 			Str("device_id", deviceID).
 			Str("user_id", userID).
-			Msg("User does not own this device")
+			Msg("User has no access to this device")
 		h.respondError(w, http.StatusForbidden, "Access denied")
 		return
 	}
 
+	// Owners and managers see sensitive settings decrypted; viewer-level
+	// shares only get the stored envelopes
+	if h.settingsCipher.Enabled() && h.canAccessDevice(r, device, userID, true) {
+		decrypted, err := h.settingsCipher.DecryptSettings(device.Settings)
+		if err != nil {
+			h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to decrypt settings for response")
+		} else {
+			device.Settings = decrypted
+		}
+	}
+
 	h.respondJSON(w, http.StatusOK, device)
 }
 
+// GetBatteryHistory handles GET /api/v1/devices/{id}/battery-history
+// Returns time-bucketed battery levels over the requested range (e.g.
+// ?range=7d, default 7d, max 90d) for battery health charts
+func (h *DeviceHandler) GetBatteryHistory(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Device not found")
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	// Verify user owns this device or has shared access
+	if !h.canAccessDevice(r, device, userID, false) {
+		h.logger.Error().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("User has no access to this device")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	historyRange, err := parseHistoryRange(r.URL.Query().Get("range"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	buckets, err := h.batteryHistoryRepo.History(r.Context(), deviceID, time.Now().Add(-historyRange), historyBucketSeconds(historyRange))
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get battery history")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get battery history")
+		return
+	}
+	if buckets == nil {
+		buckets = []models.BatteryHistoryBucket{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"device_id": deviceID,
+		"range":     historyRange.String(),
+		"buckets":   buckets,
+	})
+}
+
+// GetVitalsSeries handles GET /api/v1/devices/{id}/vitals/series
+// Returns downsampled avg/min/max vitals buckets over the requested range
+// (e.g. ?range=24h, default 24h, max 90d) for clinician-facing charts
+func (h *DeviceHandler) GetVitalsSeries(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Device not found")
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	// Verify user owns this device or has shared access
+	if !h.canAccessDevice(r, device, userID, false) {
+		h.logger.Error().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("User has no access to this device")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	raw := r.URL.Query().Get("range")
+	if raw == "" {
+		raw = "24h"
+	}
+	seriesRange, err := parseHistoryRange(raw)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	buckets, err := h.vitalsReadingRepo.GetSeries(r.Context(), deviceID, time.Now().Add(-seriesRange), vitalsBucketSeconds(seriesRange))
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to get vitals series")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get vitals series")
+		return
+	}
+	if buckets == nil {
+		buckets = []models.VitalsSeriesBucket{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"device_id": deviceID,
+		"range":     seriesRange.String(),
+		"buckets":   buckets,
+	})
+}
+
+// GetDeviceAlerts handles GET /api/v1/devices/{id}/alerts
+// Returns the device's persisted monitor alerts newest first, optionally
+// filtered by ?category=, paginated with ?limit= and ?offset=
+func (h *DeviceHandler) GetDeviceAlerts(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Device not found")
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	// Verify user owns this device or has shared access
+	if !h.canAccessDevice(r, device, userID, false) {
+		h.logger.Error().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("User has no access to this device")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	category, limit, offset, err := parseAlertListQuery(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	alerts, total, err := h.alertRepo.ListByDevice(r.Context(), deviceID, category, limit, offset)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to list device alerts")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list device alerts")
+		return
+	}
+	if alerts == nil {
+		alerts = []models.DeviceAlert{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"device_id": deviceID,
+		"alerts":    alerts,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+// GetUserAlerts handles GET /api/v1/alerts
+// Returns persisted monitor alerts across all the caller's devices newest
+// first, with the same category filter and pagination as the per-device list
+func (h *DeviceHandler) GetUserAlerts(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	category, limit, offset, err := parseAlertListQuery(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	alerts, total, err := h.alertRepo.ListByUser(r.Context(), userID, category, limit, offset)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to list user alerts")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list alerts")
+		return
+	}
+	if alerts == nil {
+		alerts = []models.DeviceAlert{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"alerts": alerts,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// parseAlertListQuery parses the shared category/limit/offset query params
+// for the alert list endpoints (limit default 50, max 200)
+func parseAlertListQuery(r *http.Request) (category string, limit, offset int, err error) {
+	category = r.URL.Query().Get("category")
+	if category != "" && !models.IsValidAlertCategory(category) {
+		return "", 0, 0, errors.New("category must be one of battery, vitals or connectivity")
+	}
+
+	limit = 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, parseErr := strconv.Atoi(limitStr)
+		if parseErr != nil || parsed < 1 || parsed > 200 {
+			return "", 0, 0, errors.New("limit must be between 1 and 200")
+		}
+		limit = parsed
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsed, parseErr := strconv.Atoi(offsetStr)
+		if parseErr != nil || parsed < 0 {
+			return "", 0, 0, errors.New("offset must be zero or greater")
+		}
+		offset = parsed
+	}
+
+	return category, limit, offset, nil
+}
+
+// parseHistoryRange parses a chart range like "24h" or "7d"
+func parseHistoryRange(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 7 * 24 * time.Hour, nil
+	}
+
+	var historyRange time.Duration
+	if strings.HasSuffix(raw, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil || days <= 0 {
+			return 0, errors.New("range must be a positive duration like 24h or 7d")
+		}
+		historyRange = time.Duration(days) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return 0, errors.New("range must be a positive duration like 24h or 7d")
+		}
+		historyRange = parsed
+	}
+
+	if historyRange > 90*24*time.Hour {
+		return 0, errors.New("range must not exceed 90d")
+	}
+
+	return historyRange, nil
+}
+
+// historyBucketSeconds picks a chart resolution that keeps the point count
+// reasonable for the requested range
+func historyBucketSeconds(historyRange time.Duration) int {
+	switch {
+	case historyRange <= 48*time.Hour:
+		return int(time.Hour / time.Second)
+	case historyRange <= 14*24*time.Hour:
+		return int(6 * time.Hour / time.Second)
+	default:
+		return int(24 * time.Hour / time.Second)
+	}
+}
+
+// vitalsBucketSeconds picks a chart resolution for vitals, which arrive far
+// more often than battery samples so short ranges get finer buckets
+func vitalsBucketSeconds(seriesRange time.Duration) int {
+	switch {
+	case seriesRange <= 6*time.Hour:
+		return int(5 * time.Minute / time.Second)
+	case seriesRange <= 48*time.Hour:
+		return int(15 * time.Minute / time.Second)
+	case seriesRange <= 14*24*time.Hour:
+		return int(time.Hour / time.Second)
+	default:
+		return int(6 * time.Hour / time.Second)
+	}
+}
+
 // respondJSON sends a JSON response
 func (h *DeviceHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
 		h.logger.Error().Err(err).Msg("Failed to encode response")
 	}
 }
@@ -334,6 +876,27 @@ func (h *DeviceHandler) respondError(w http.ResponseWriter, statusCode int, mess
 	})
 }
 
+// settingsMergePatch computes a JSON merge patch from old to new settings:
+// added and changed keys map to their new value, removed keys map to nil
+func settingsMergePatch(oldSettings, newSettings map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+
+	for key, newValue := range newSettings {
+		oldValue, exists := oldSettings[key]
+		if !exists || !reflect.DeepEqual(oldValue, newValue) {
+			patch[key] = newValue
+		}
+	}
+
+	for key := range oldSettings {
+		if _, exists := newSettings[key]; !exists {
+			patch[key] = nil
+		}
+	}
+
+	return patch
+}
+
 // isValidMacAddress validates MAC address format
 func isValidMacAddress(mac string) bool {
 	// Match common MAC address formats:
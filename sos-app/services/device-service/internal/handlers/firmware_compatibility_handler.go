@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// FirmwareCompatibilityHandler exposes the firmware compatibility matrix to
+// administrators: support maintains, per (model, firmware version), which
+// capabilities work and which issues are known
+type FirmwareCompatibilityHandler struct {
+	compatRepo *repository.FirmwareCompatibilityRepository
+	adminToken string
+	logger     zerolog.Logger
+}
+
+// NewFirmwareCompatibilityHandler creates a new firmware compatibility handler
+func NewFirmwareCompatibilityHandler(
+	compatRepo *repository.FirmwareCompatibilityRepository,
+	adminToken string,
+	logger zerolog.Logger,
+) *FirmwareCompatibilityHandler {
+	return &FirmwareCompatibilityHandler{
+		compatRepo: compatRepo,
+		adminToken: adminToken,
+		logger:     logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *FirmwareCompatibilityHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// UpsertEntry handles PUT /api/v1/admin/firmware-compatibility
+// Creates or replaces the matrix entry for one (model, firmware version)
+// combination
+func (h *FirmwareCompatibilityHandler) UpsertEntry(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var req models.UpsertFirmwareCompatibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.DeviceModel == "" {
+		h.respondError(w, http.StatusBadRequest, "device_model is required")
+		return
+	}
+	if req.FirmwareVersion == "" {
+		h.respondError(w, http.StatusBadRequest, "firmware_version is required")
+		return
+	}
+
+	entry := &models.FirmwareCompatibility{
+		DeviceModel:           req.DeviceModel,
+		FirmwareVersion:       req.FirmwareVersion,
+		SupportedCapabilities: req.SupportedCapabilities,
+		KnownIssues:           req.KnownIssues,
+		UpdateRecommended:     req.UpdateRecommended,
+	}
+	if entry.SupportedCapabilities == nil {
+		entry.SupportedCapabilities = []string{}
+	}
+	if entry.KnownIssues == nil {
+		entry.KnownIssues = []string{}
+	}
+
+	if err := h.compatRepo.Upsert(r.Context(), entry); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to upsert firmware compatibility entry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to save firmware compatibility entry")
+		return
+	}
+
+	h.logger.Info().
+		Str("entry_id", entry.ID).
+		Str("device_model", entry.DeviceModel).
+		Str("firmware_version", entry.FirmwareVersion).
+		Int("known_issues", len(entry.KnownIssues)).
+		Msg("Firmware compatibility entry saved")
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entry": entry,
+	})
+}
+
+// ListMatrix handles GET /api/v1/admin/firmware-compatibility
+func (h *FirmwareCompatibilityHandler) ListMatrix(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	entries, err := h.compatRepo.List(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list firmware compatibility matrix")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list firmware compatibility matrix")
+		return
+	}
+	if entries == nil {
+		entries = []models.FirmwareCompatibility{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+// DeleteEntry handles DELETE /api/v1/admin/firmware-compatibility/{id}
+func (h *FirmwareCompatibilityHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := h.compatRepo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrFirmwareEntryNotFound) {
+			h.respondError(w, http.StatusNotFound, "Firmware compatibility entry not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("entry_id", id).Msg("Failed to delete firmware compatibility entry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete firmware compatibility entry")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Firmware compatibility entry deleted",
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *FirmwareCompatibilityHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *FirmwareCompatibilityHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the hand-maintained OpenAPI 3 contract for this service;
+// keep it in lockstep with the routes and request models
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPIHandler serves the service's OpenAPI 3 spec at /openapi.json so
+// gateways and client generators can discover the contract
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPISpec)
+}
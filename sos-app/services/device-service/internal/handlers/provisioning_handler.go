@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// provisioningSessionTTL is how long a session's one-time secret stays
+// usable; BLE provisioning completes in seconds when it works at all
+const provisioningSessionTTL = 5 * time.Minute
+
+// ProvisioningHandler drives the in-app BLE provisioning flow for devices
+// with no keyboard or display: the app opens a session, writes the one-time
+// secret to the device over BLE, relays the device's confirmation and then
+// finalizes pairing atomically
+type ProvisioningHandler struct {
+	sessionRepo  *repository.ProvisioningSessionRepository
+	deviceRepo   *repository.DeviceRepository
+	registryRepo *repository.DeviceRegistryRepository
+	mqttClient   *mqtt.Client
+	logger       zerolog.Logger
+}
+
+// NewProvisioningHandler creates a new provisioning handler. registryRepo may
+// be nil to disable registry enforcement (e.g. local development)
+func NewProvisioningHandler(
+	sessionRepo *repository.ProvisioningSessionRepository,
+	deviceRepo *repository.DeviceRepository,
+	registryRepo *repository.DeviceRegistryRepository,
+	mqttClient *mqtt.Client,
+	logger zerolog.Logger,
+) *ProvisioningHandler {
+	return &ProvisioningHandler{
+		sessionRepo:  sessionRepo,
+		deviceRepo:   deviceRepo,
+		registryRepo: registryRepo,
+		mqttClient:   mqttClient,
+		logger:       logger,
+	}
+}
+
+// StartSession handles POST /api/v1/devices/provisioning
+// Opens a session and returns the one-time pairing secret exactly once
+func (h *ProvisioningHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.StartProvisioningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.DeviceType == "" {
+		h.respondError(w, http.StatusBadRequest, "device_type is required")
+		return
+	}
+
+	secret, err := generatePairingSecret()
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to generate pairing secret")
+		h.respondError(w, http.StatusInternalServerError, "Failed to start provisioning")
+		return
+	}
+
+	session := &models.ProvisioningSession{
+		UserID:       userID,
+		Status:       models.ProvisioningStatusPending,
+		DeviceType:   req.DeviceType,
+		Manufacturer: req.Manufacturer,
+		Model:        req.Model,
+		SecretHash:   hashPairingSecret(secret),
+		ExpiresAt:    time.Now().Add(provisioningSessionTTL),
+	}
+
+	if err := h.sessionRepo.Create(r.Context(), session); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create provisioning session")
+		h.respondError(w, http.StatusInternalServerError, "Failed to start provisioning")
+		return
+	}
+
+	h.logger.Info().
+		Str("session_id", session.ID).
+		Str("user_id", userID).
+		Str("device_type", string(req.DeviceType)).
+		Msg("Provisioning session started")
+
+	h.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"session": session,
+		"secret":  secret,
+	})
+}
+
+// ConfirmSession handles POST /api/v1/devices/provisioning/{id}/confirm
+// The phone relays the device's proof of the secret together with its MAC
+func (h *ProvisioningHandler) ConfirmSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	session, ok := h.loadOwnSession(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req models.ConfirmProvisioningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if session.Status != models.ProvisioningStatusPending {
+		h.respondError(w, http.StatusConflict, "Session is not awaiting confirmation")
+		return
+	}
+	if session.Expired() {
+		h.respondError(w, http.StatusGone, "Provisioning session has expired")
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashPairingSecret(req.Secret)), []byte(session.SecretHash)) != 1 {
+		h.logger.Warn().
+			Str("session_id", session.ID).
+			Msg("Provisioning confirmation with wrong secret")
+		h.respondError(w, http.StatusForbidden, "Invalid pairing secret")
+		return
+	}
+
+	if !isValidMacAddress(req.MacAddress) {
+		h.respondError(w, http.StatusBadRequest, "Invalid MAC address format")
+		return
+	}
+
+	// The same registry rules as manual pairing apply
+	if h.registryRepo != nil {
+		entry, err := h.registryRepo.GetByMacAddress(r.Context(), req.MacAddress)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("Failed to check device registry")
+			h.respondError(w, http.StatusInternalServerError, "Failed to confirm provisioning")
+			return
+		}
+		if entry == nil {
+			h.respondError(w, http.StatusForbidden, "Device is not registered")
+			return
+		}
+		if entry.RequireSerialProof &&
+			(req.SerialNumber == nil || !strings.EqualFold(*req.SerialNumber, entry.SerialNumber)) {
+			h.respondError(w, http.StatusForbidden, "Serial number does not match the registered device")
+			return
+		}
+	}
+
+	// Reject MACs that are already paired before burning the session
+	existingDevice, err := h.deviceRepo.GetByMacAddress(r.Context(), req.MacAddress)
+	if err == nil && existingDevice != nil {
+		h.respondError(w, http.StatusConflict, "Device already paired")
+		return
+	}
+
+	if err := h.sessionRepo.Confirm(r.Context(), session.ID, req.MacAddress, req.SerialNumber); err != nil {
+		if errors.Is(err, repository.ErrProvisioningWrongState) {
+			h.respondError(w, http.StatusConflict, "Session is not awaiting confirmation")
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to confirm provisioning session")
+		h.respondError(w, http.StatusInternalServerError, "Failed to confirm provisioning")
+		return
+	}
+
+	h.logger.Info().
+		Str("session_id", session.ID).
+		Str("mac_address", req.MacAddress).
+		Msg("Provisioning session confirmed")
+
+	session.Status = models.ProvisioningStatusConfirmed
+	session.MacAddress = &req.MacAddress
+	session.SerialNumber = req.SerialNumber
+
+	h.respondJSON(w, http.StatusOK, session)
+}
+
+// CompleteSession handles POST /api/v1/devices/provisioning/{id}/complete
+// Finalizes pairing: the device row and the session transition commit
+// atomically
+func (h *ProvisioningHandler) CompleteSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	session, ok := h.loadOwnSession(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req models.CompleteProvisioningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if session.Status != models.ProvisioningStatusConfirmed || session.MacAddress == nil {
+		h.respondError(w, http.StatusConflict, "Session has not been confirmed by the device")
+		return
+	}
+	if session.Expired() {
+		h.respondError(w, http.StatusGone, "Provisioning session has expired")
+		return
+	}
+
+	device := &models.Device{
+		ID:           uuid.New().String(),
+		UserID:       session.UserID,
+		DeviceType:   session.DeviceType,
+		Manufacturer: session.Manufacturer,
+		Model:        session.Model,
+		MacAddress:   *session.MacAddress,
+		PairedAt:     time.Now(),
+		BatteryLevel: 100,
+		Status:       models.DeviceStatusActive,
+		Capabilities: req.Capabilities,
+		Settings:     make(map[string]interface{}),
+	}
+
+	if err := h.sessionRepo.Complete(r.Context(), session.ID, device); err != nil {
+		if errors.Is(err, repository.ErrProvisioningWrongState) {
+			h.respondError(w, http.StatusConflict, "Session has not been confirmed by the device")
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to complete provisioning")
+		h.respondError(w, http.StatusInternalServerError, "Failed to complete provisioning")
+		return
+	}
+
+	// Subscribe to the new device's MQTT topics, same as manual pairing
+	if h.mqttClient.IsConnected() {
+		if err := h.mqttClient.SubscribeToSpecificDevice(device.ID, func(topic string, payload []byte) error {
+			h.logger.Info().
+				Str("device_id", device.ID).
+				Str("topic", topic).
+				Msg("Received message from newly provisioned device")
+			return nil
+		}); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("device_id", device.ID).
+				Msg("Failed to subscribe to device MQTT topics")
+		}
+	}
+
+	h.logger.Info().
+		Str("session_id", session.ID).
+		Str("device_id", device.ID).
+		Str("user_id", userID).
+		Msg("Provisioning completed, device paired")
+
+	h.respondJSON(w, http.StatusCreated, device)
+}
+
+// loadOwnSession loads the session from the URL and verifies ownership
+func (h *ProvisioningHandler) loadOwnSession(w http.ResponseWriter, r *http.Request, userID string) (*models.ProvisioningSession, bool) {
+	session, err := h.sessionRepo.GetByID(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if errors.Is(err, repository.ErrProvisioningSessionNotFound) {
+			h.respondError(w, http.StatusNotFound, "Provisioning session not found")
+			return nil, false
+		}
+		h.logger.Error().Err(err).Msg("Failed to get provisioning session")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get provisioning session")
+		return nil, false
+	}
+
+	if session.UserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+
+	return session, true
+}
+
+// generatePairingSecret returns a 128-bit one-time secret as hex, short
+// enough to fit a single BLE characteristic write
+func generatePairingSecret() (string, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// hashPairingSecret returns the stored form of a pairing secret
+func hashPairingSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// respondJSON sends a JSON response
+func (h *ProvisioningHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *ProvisioningHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// RawEventHandler exposes the raw event audit trail to administrators
+type RawEventHandler struct {
+	rawEventRepo *repository.RawEventRepository
+	adminToken   string
+	logger       zerolog.Logger
+}
+
+// NewRawEventHandler creates a new raw event handler
+func NewRawEventHandler(
+	rawEventRepo *repository.RawEventRepository,
+	adminToken string,
+	logger zerolog.Logger,
+) *RawEventHandler {
+	return &RawEventHandler{
+		rawEventRepo: rawEventRepo,
+		adminToken:   adminToken,
+		logger:       logger,
+	}
+}
+
+// ListRawEvents handles GET /api/v1/admin/raw-events
+// Requires the X-Admin-Token header; supports topic, since and limit filters
+func (h *RawEventHandler) ListRawEvents(w http.ResponseWriter, r *http.Request) {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	topicPrefix := r.URL.Query().Get("topic")
+
+	since := time.Now().Add(-24 * time.Hour)
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid since, expected RFC3339")
+			return
+		}
+		since = parsed
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if n, err := strconv.Atoi(limitStr); err == nil && n > 0 && n <= 1000 {
+			limit = n
+		}
+	}
+
+	events, err := h.rawEventRepo.List(r.Context(), topicPrefix, since, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list raw device events")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list raw events")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"events": events,
+		"count":  len(events),
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *RawEventHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *RawEventHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
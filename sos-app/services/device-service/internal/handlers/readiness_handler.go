@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// ReadinessHandler serves the per-user fleet readiness boolean: whether at
+// least one device is currently able to raise an emergency for the user
+type ReadinessHandler struct {
+	readinessTracker *services.FleetReadinessTracker
+	logger           zerolog.Logger
+}
+
+// NewReadinessHandler creates a new readiness handler
+func NewReadinessHandler(readinessTracker *services.FleetReadinessTracker, logger zerolog.Logger) *ReadinessHandler {
+	return &ReadinessHandler{
+		readinessTracker: readinessTracker,
+		logger:           logger,
+	}
+}
+
+// GetReadiness handles GET /api/v1/devices/readiness
+// Computes readiness live so the app can trust the answer right after a
+// pairing or battery change
+func (h *ReadinessHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	ready, err := h.readinessTracker.IsReady(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to compute fleet readiness")
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute readiness")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    userID,
+		"ready":      ready,
+		"checked_at": time.Now().UTC(),
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *ReadinessHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *ReadinessHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// RegistryHandler exposes the pre-pairing device registry to administrators
+type RegistryHandler struct {
+	registryRepo *repository.DeviceRegistryRepository
+	adminToken   string
+	logger       zerolog.Logger
+}
+
+// NewRegistryHandler creates a new registry handler
+func NewRegistryHandler(
+	registryRepo *repository.DeviceRegistryRepository,
+	adminToken string,
+	logger zerolog.Logger,
+) *RegistryHandler {
+	return &RegistryHandler{
+		registryRepo: registryRepo,
+		adminToken:   adminToken,
+		logger:       logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *RegistryHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// RegisterDevice handles POST /api/v1/admin/device-registry
+func (h *RegistryHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var req models.RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !isValidMacAddress(req.MacAddress) {
+		h.respondError(w, http.StatusBadRequest, "Invalid MAC address format")
+		return
+	}
+
+	if req.RequireSerialProof && req.SerialNumber == "" {
+		h.respondError(w, http.StatusBadRequest, "serial_number is required when require_serial_proof is set")
+		return
+	}
+
+	existing, err := h.registryRepo.GetByMacAddress(r.Context(), req.MacAddress)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to check device registry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+	if existing != nil {
+		h.respondError(w, http.StatusConflict, "Device already registered")
+		return
+	}
+
+	entry := &models.DeviceRegistryEntry{
+		MacAddress:         req.MacAddress,
+		SerialNumber:       req.SerialNumber,
+		RequireSerialProof: req.RequireSerialProof,
+		Notes:              req.Notes,
+	}
+
+	if err := h.registryRepo.Create(r.Context(), entry); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create registry entry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+
+	h.logger.Info().
+		Str("registry_id", entry.ID).
+		Str("mac_address", entry.MacAddress).
+		Msg("Device pre-registered")
+
+	h.respondJSON(w, http.StatusCreated, entry)
+}
+
+// ListRegistry handles GET /api/v1/admin/device-registry
+func (h *RegistryHandler) ListRegistry(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	entries, err := h.registryRepo.List(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list registry entries")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list registry")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+// UnregisterDevice handles DELETE /api/v1/admin/device-registry/{id}
+func (h *RegistryHandler) UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	if err := h.registryRepo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrRegistryEntryNotFound) {
+			h.respondError(w, http.StatusNotFound, "Registry entry not found")
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to delete registry entry")
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete registry entry")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Registry entry deleted",
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *RegistryHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *RegistryHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
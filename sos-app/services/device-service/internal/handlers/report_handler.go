@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// ReportHandler serves generated device reports over HTTP
+type ReportHandler struct {
+	reportRepo *repository.DeviceReportRepository
+	logger     zerolog.Logger
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportRepo *repository.DeviceReportRepository, logger zerolog.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportRepo: reportRepo,
+		logger:     logger,
+	}
+}
+
+// GetLatestReport handles GET /api/v1/devices/reports/latest
+// Returns the caller's most recently generated device report
+func (h *ReportHandler) GetLatestReport(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.logger.Error().Msg("Missing user ID in request")
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	report, err := h.reportRepo.GetLatestByUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDeviceReportNotFound) {
+			h.respondError(w, http.StatusNotFound, "No report generated yet")
+			return
+		}
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to get latest device report")
+		h.respondError(w, http.StatusInternalServerError, "Failed to get report")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// respondJSON sends a JSON response
+func (h *ReportHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *ReportHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
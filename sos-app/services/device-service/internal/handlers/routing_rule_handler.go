@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// RoutingRuleHandler exposes alert routing rule management to administrators,
+// plus a simulation endpoint for testing which rule a hypothetical alert
+// would hit before it fires for real
+type RoutingRuleHandler struct {
+	ruleRepo    *repository.AlertRoutingRuleRepository
+	alertRouter *services.AlertRouter
+	adminToken  string
+	logger      zerolog.Logger
+}
+
+// NewRoutingRuleHandler creates a new routing rule handler
+func NewRoutingRuleHandler(
+	ruleRepo *repository.AlertRoutingRuleRepository,
+	alertRouter *services.AlertRouter,
+	adminToken string,
+	logger zerolog.Logger,
+) *RoutingRuleHandler {
+	return &RoutingRuleHandler{
+		ruleRepo:    ruleRepo,
+		alertRouter: alertRouter,
+		adminToken:  adminToken,
+		logger:      logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *RoutingRuleHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// CreateRule handles POST /api/v1/admin/alert-routing/rules
+func (h *RoutingRuleHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var req models.CreateRoutingRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.OrgID == "" {
+		h.respondError(w, http.StatusBadRequest, "org_id is required")
+		return
+	}
+	if req.Name == "" {
+		h.respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if !models.IsValidAlertCategory(req.AlertCategory) {
+		h.respondError(w, http.StatusBadRequest, "alert_category must be one of: battery, vitals, connectivity, firmware")
+		return
+	}
+	if req.Team == "" {
+		h.respondError(w, http.StatusBadRequest, "team is required")
+		return
+	}
+	if req.MinPriority != nil && !models.IsValidAlertPriority(*req.MinPriority) {
+		h.respondError(w, http.StatusBadRequest, "min_priority must be one of: LOW, MEDIUM, HIGH")
+		return
+	}
+	if req.MinPriority != nil {
+		upper := strings.ToUpper(*req.MinPriority)
+		req.MinPriority = &upper
+	}
+
+	rule := &models.AlertRoutingRule{
+		OrgID:         req.OrgID,
+		Name:          req.Name,
+		AlertCategory: req.AlertCategory,
+		DeviceType:    req.DeviceType,
+		MinPriority:   req.MinPriority,
+		Team:          req.Team,
+		Rank:          req.Rank,
+		Enabled:       true,
+	}
+	if err := h.ruleRepo.Create(r.Context(), rule); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create routing rule")
+		h.respondError(w, http.StatusInternalServerError, "Failed to create routing rule")
+		return
+	}
+
+	h.logger.Info().
+		Str("rule_id", rule.ID).
+		Str("org_id", rule.OrgID).
+		Str("alert_category", rule.AlertCategory).
+		Str("team", rule.Team).
+		Msg("Alert routing rule created")
+
+	h.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"rule": rule,
+	})
+}
+
+// ListRules handles GET /api/v1/admin/alert-routing/rules?org_id=
+func (h *RoutingRuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	orgID := r.URL.Query().Get("org_id")
+	if orgID == "" {
+		h.respondError(w, http.StatusBadRequest, "org_id query parameter is required")
+		return
+	}
+
+	rules, err := h.ruleRepo.ListByOrg(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list routing rules")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list routing rules")
+		return
+	}
+	if rules == nil {
+		rules = []models.AlertRoutingRule{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// DeleteRule handles DELETE /api/v1/admin/alert-routing/rules/{id}
+func (h *RoutingRuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := h.ruleRepo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrRoutingRuleNotFound) {
+			h.respondError(w, http.StatusNotFound, "Routing rule not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("rule_id", id).Msg("Failed to delete routing rule")
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete routing rule")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Routing rule deleted",
+	})
+}
+
+// simulateRouteRequest is the hypothetical alert posed to the simulator
+type simulateRouteRequest struct {
+	OrgID string `json:"org_id"`
+	models.AlertContext
+}
+
+// SimulateRoute handles POST /api/v1/admin/alert-routing/simulate
+// Evaluates the organization's rules against a hypothetical alert and
+// reports which rule would handle it, without emitting anything
+func (h *RoutingRuleHandler) SimulateRoute(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var req simulateRouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.OrgID == "" {
+		h.respondError(w, http.StatusBadRequest, "org_id is required")
+		return
+	}
+	if !models.IsValidAlertCategory(req.Category) {
+		h.respondError(w, http.StatusBadRequest, "category must be one of: battery, vitals, connectivity, firmware")
+		return
+	}
+
+	rule, err := h.alertRouter.Route(r.Context(), req.OrgID, req.AlertContext)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to simulate alert routing")
+		h.respondError(w, http.StatusInternalServerError, "Failed to simulate alert routing")
+		return
+	}
+
+	response := map[string]interface{}{
+		"alert":   req.AlertContext,
+		"matched": rule != nil,
+	}
+	if rule != nil {
+		response["rule"] = rule
+	}
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// respondJSON sends a JSON response
+func (h *RoutingRuleHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *RoutingRuleHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// SamplingHandler exposes telemetry sampling policy management to
+// administrators
+type SamplingHandler struct {
+	policyRepo *repository.SamplingPolicyRepository
+	sampler    *services.TelemetrySampler
+	adminToken string
+	logger     zerolog.Logger
+}
+
+// NewSamplingHandler creates a new sampling policy handler
+func NewSamplingHandler(
+	policyRepo *repository.SamplingPolicyRepository,
+	sampler *services.TelemetrySampler,
+	adminToken string,
+	logger zerolog.Logger,
+) *SamplingHandler {
+	return &SamplingHandler{
+		policyRepo: policyRepo,
+		sampler:    sampler,
+		adminToken: adminToken,
+		logger:     logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *SamplingHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// ListPolicies handles GET /api/v1/admin/telemetry-sampling
+func (h *SamplingHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	policies, err := h.policyRepo.List(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list sampling policies")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list sampling policies")
+		return
+	}
+	if policies == nil {
+		policies = []models.SamplingPolicy{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"policies": policies,
+		"count":    len(policies),
+	})
+}
+
+// SetPolicy handles PUT /api/v1/admin/telemetry-sampling/{deviceType}
+func (h *SamplingHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	deviceType := models.DeviceType(mux.Vars(r)["deviceType"])
+
+	var req models.SetSamplingPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.SampleEvery < 1 {
+		h.respondError(w, http.StatusBadRequest, "sample_every must be at least 1")
+		return
+	}
+
+	policy := &models.SamplingPolicy{
+		DeviceType:  deviceType,
+		SampleEvery: req.SampleEvery,
+	}
+	if err := h.policyRepo.Upsert(r.Context(), policy); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to store sampling policy")
+		h.respondError(w, http.StatusInternalServerError, "Failed to store sampling policy")
+		return
+	}
+
+	h.sampler.SetPolicy(deviceType, req.SampleEvery)
+
+	h.logger.Info().
+		Str("device_type", string(deviceType)).
+		Int("sample_every", req.SampleEvery).
+		Msg("Telemetry sampling policy updated")
+
+	h.respondJSON(w, http.StatusOK, policy)
+}
+
+// DeletePolicy handles DELETE /api/v1/admin/telemetry-sampling/{deviceType}
+func (h *SamplingHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	deviceType := models.DeviceType(mux.Vars(r)["deviceType"])
+	if err := h.policyRepo.Delete(r.Context(), deviceType); err != nil {
+		if errors.Is(err, repository.ErrSamplingPolicyNotFound) {
+			h.respondError(w, http.StatusNotFound, "Sampling policy not found")
+			return
+		}
+		h.logger.Error().Err(err).Msg("Failed to delete sampling policy")
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete sampling policy")
+		return
+	}
+
+	h.sampler.RemovePolicy(deviceType)
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Sampling policy deleted",
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *SamplingHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *SamplingHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
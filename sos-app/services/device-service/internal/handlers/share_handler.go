@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// ShareHandler handles device sharing (household/caregiver access) requests
+type ShareHandler struct {
+	deviceRepo *repository.DeviceRepository
+	shareRepo  *repository.DeviceShareRepository
+	logger     zerolog.Logger
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(
+	deviceRepo *repository.DeviceRepository,
+	shareRepo *repository.DeviceShareRepository,
+	logger zerolog.Logger,
+) *ShareHandler {
+	return &ShareHandler{
+		deviceRepo: deviceRepo,
+		shareRepo:  shareRepo,
+		logger:     logger,
+	}
+}
+
+// InviteShare handles POST /api/v1/devices/{id}/shares
+// Only the device owner may invite other users
+func (h *ShareHandler) InviteShare(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	if device.UserID != userID {
+		h.logger.Error().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("Only the device owner can invite users")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	var req models.CreateShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.SharedWithUserID == "" {
+		h.respondError(w, http.StatusBadRequest, "shared_with_user_id is required")
+		return
+	}
+
+	if req.SharedWithUserID == userID {
+		h.respondError(w, http.StatusBadRequest, "Cannot share a device with its owner")
+		return
+	}
+
+	if req.Role == "" {
+		req.Role = models.ShareRoleViewer
+	}
+	if !models.ValidShareRole(req.Role) {
+		h.respondError(w, http.StatusBadRequest, "Invalid role: must be VIEWER or MANAGER")
+		return
+	}
+
+	share := &models.DeviceShare{
+		ID:               uuid.New().String(),
+		DeviceID:         deviceID,
+		OwnerUserID:      userID,
+		SharedWithUserID: req.SharedWithUserID,
+		Role:             req.Role,
+		Status:           models.ShareStatusPending,
+	}
+
+	if err := h.shareRepo.CreateInvite(r.Context(), share); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to create share invite")
+		h.respondError(w, http.StatusConflict, "Share already exists for this user")
+		return
+	}
+
+	h.logger.Info().
+		Str("share_id", share.ID).
+		Str("device_id", deviceID).
+		Str("shared_with", req.SharedWithUserID).
+		Str("role", string(req.Role)).
+		Msg("Device share invite created")
+
+	h.respondJSON(w, http.StatusCreated, share)
+}
+
+// AcceptShare handles PUT /api/v1/devices/shares/{shareId}/accept
+// Only the invited user may accept
+func (h *ShareHandler) AcceptShare(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	shareID := vars["shareId"]
+
+	share, err := h.shareRepo.GetByID(r.Context(), shareID)
+	if err != nil || share == nil {
+		h.respondError(w, http.StatusNotFound, "Share not found")
+		return
+	}
+
+	if share.SharedWithUserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if share.Status != models.ShareStatusPending {
+		h.respondError(w, http.StatusBadRequest, "Share is not pending")
+		return
+	}
+
+	if err := h.shareRepo.UpdateStatus(r.Context(), shareID, models.ShareStatusAccepted); err != nil {
+		h.logger.Error().Err(err).Str("share_id", shareID).Msg("Failed to accept share")
+		h.respondError(w, http.StatusInternalServerError, "Failed to accept share")
+		return
+	}
+
+	h.logger.Info().
+		Str("share_id", shareID).
+		Str("user_id", userID).
+		Msg("Device share accepted")
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Share accepted",
+	})
+}
+
+// RevokeShare handles DELETE /api/v1/devices/shares/{shareId}
+// The owner may revoke any share; the invited user may revoke their own access
+func (h *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	shareID := vars["shareId"]
+
+	share, err := h.shareRepo.GetByID(r.Context(), shareID)
+	if err != nil || share == nil {
+		h.respondError(w, http.StatusNotFound, "Share not found")
+		return
+	}
+
+	if share.OwnerUserID != userID && share.SharedWithUserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if share.Status == models.ShareStatusRevoked {
+		h.respondError(w, http.StatusBadRequest, "Share already revoked")
+		return
+	}
+
+	if err := h.shareRepo.UpdateStatus(r.Context(), shareID, models.ShareStatusRevoked); err != nil {
+		h.logger.Error().Err(err).Str("share_id", shareID).Msg("Failed to revoke share")
+		h.respondError(w, http.StatusInternalServerError, "Failed to revoke share")
+		return
+	}
+
+	h.logger.Info().
+		Str("share_id", shareID).
+		Str("user_id", userID).
+		Msg("Device share revoked")
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Share revoked",
+	})
+}
+
+// ListShares handles GET /api/v1/devices/{id}/shares
+// Only the device owner may list shares
+func (h *ShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	if device.UserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	shares, err := h.shareRepo.ListForDevice(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to list shares")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list shares")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"shares": shares,
+		"count":  len(shares),
+	})
+}
+
+// respondJSON sends a JSON response
+func (h *ShareHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *ShareHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// TransferHandler handles device transfer (ownership hand-over) requests.
+// On acceptance the device is reassigned atomically, monitor notification
+// state is reset and the retained MQTT topic configuration is re-provisioned
+// for the new owner
+type TransferHandler struct {
+	deviceRepo          *repository.DeviceRepository
+	transferRepo        *repository.DeviceTransferRepository
+	batteryMonitor      *services.BatteryMonitor
+	connectivityMonitor *services.ConnectivityMonitor
+	mqttClient          *mqtt.Client
+	logger              zerolog.Logger
+}
+
+// NewTransferHandler creates a new transfer handler
+func NewTransferHandler(
+	deviceRepo *repository.DeviceRepository,
+	transferRepo *repository.DeviceTransferRepository,
+	batteryMonitor *services.BatteryMonitor,
+	connectivityMonitor *services.ConnectivityMonitor,
+	mqttClient *mqtt.Client,
+	logger zerolog.Logger,
+) *TransferHandler {
+	return &TransferHandler{
+		deviceRepo:          deviceRepo,
+		transferRepo:        transferRepo,
+		batteryMonitor:      batteryMonitor,
+		connectivityMonitor: connectivityMonitor,
+		mqttClient:          mqttClient,
+		logger:              logger,
+	}
+}
+
+// InitiateTransfer handles POST /api/v1/devices/{id}/transfer
+// Only the current owner may offer their device to a new owner
+func (h *TransferHandler) InitiateTransfer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	deviceID := vars["id"]
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	if device.UserID != userID {
+		h.logger.Error().
+			Str("device_id", deviceID).
+			Str("user_id", userID).
+			Msg("Only the device owner can initiate a transfer")
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	var req models.InitiateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ToUserID == "" {
+		h.respondError(w, http.StatusBadRequest, "to_user_id is required")
+		return
+	}
+
+	if req.ToUserID == userID {
+		h.respondError(w, http.StatusBadRequest, "Cannot transfer a device to its owner")
+		return
+	}
+
+	transfer := &models.DeviceTransfer{
+		ID:         uuid.New().String(),
+		DeviceID:   deviceID,
+		FromUserID: userID,
+		ToUserID:   req.ToUserID,
+		Status:     models.TransferStatusPending,
+	}
+
+	if err := h.transferRepo.Create(r.Context(), transfer); err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to create transfer")
+		h.respondError(w, http.StatusConflict, "A transfer is already in flight for this device")
+		return
+	}
+
+	h.logger.Info().
+		Str("transfer_id", transfer.ID).
+		Str("device_id", deviceID).
+		Str("to_user_id", req.ToUserID).
+		Msg("Device transfer initiated")
+
+	h.respondJSON(w, http.StatusCreated, transfer)
+}
+
+// AcceptTransfer handles PUT /api/v1/devices/transfers/{transferId}/accept
+// Only the prospective new owner may accept. The device is reassigned, its
+// user-specific settings wiped and the previous owner's shares revoked in
+// one transaction; then monitor state and the retained MQTT topic
+// configuration are refreshed for the new owner
+func (h *TransferHandler) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	transferID := vars["transferId"]
+
+	transfer, err := h.transferRepo.GetByID(r.Context(), transferID)
+	if err != nil || transfer == nil {
+		h.respondError(w, http.StatusNotFound, "Transfer not found")
+		return
+	}
+
+	if transfer.ToUserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if err := h.transferRepo.Execute(r.Context(), transfer); err != nil {
+		if err == repository.ErrTransferWrongState {
+			h.respondError(w, http.StatusConflict, "Transfer is no longer pending or the device changed hands")
+			return
+		}
+		h.logger.Error().Err(err).Str("transfer_id", transferID).Msg("Failed to execute transfer")
+		h.respondError(w, http.StatusInternalServerError, "Failed to accept transfer")
+		return
+	}
+
+	// Notification state tracked per device belongs to the old owner's
+	// tenure; reset it so the new owner gets fresh threshold alerts
+	h.batteryMonitor.ResetDevice(transfer.DeviceID)
+	h.connectivityMonitor.MarkDeviceReconnected(transfer.DeviceID)
+
+	// Re-provision the retained MQTT topic configuration for the new
+	// owner's tier so the device reconfigures itself on next connect
+	h.publishTopicConfig(r, transfer.DeviceID)
+
+	h.logger.Info().
+		Str("transfer_id", transferID).
+		Str("device_id", transfer.DeviceID).
+		Str("from_user_id", transfer.FromUserID).
+		Str("to_user_id", transfer.ToUserID).
+		Msg("Device transfer accepted")
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Transfer accepted",
+	})
+}
+
+// CancelTransfer handles DELETE /api/v1/devices/transfers/{transferId}
+// The initiating owner may cancel a pending offer; the prospective new
+// owner may decline it
+func (h *TransferHandler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	transferID := vars["transferId"]
+
+	transfer, err := h.transferRepo.GetByID(r.Context(), transferID)
+	if err != nil || transfer == nil {
+		h.respondError(w, http.StatusNotFound, "Transfer not found")
+		return
+	}
+
+	if transfer.FromUserID != userID && transfer.ToUserID != userID {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	status := models.TransferStatusCancelled
+	if transfer.ToUserID == userID {
+		status = models.TransferStatusDeclined
+	}
+
+	if err := h.transferRepo.UpdateStatus(r.Context(), transferID, status); err != nil {
+		if err == repository.ErrTransferWrongState {
+			h.respondError(w, http.StatusBadRequest, "Transfer is not pending")
+			return
+		}
+		h.logger.Error().Err(err).Str("transfer_id", transferID).Msg("Failed to cancel transfer")
+		h.respondError(w, http.StatusInternalServerError, "Failed to cancel transfer")
+		return
+	}
+
+	h.logger.Info().
+		Str("transfer_id", transferID).
+		Str("user_id", userID).
+		Str("status", string(status)).
+		Msg("Device transfer closed")
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Transfer " + strings.ToLower(string(status)),
+	})
+}
+
+// publishTopicConfig republishes the retained topic configuration after a
+// transfer, using the accepting user's subscription tier; failures are
+// logged and the device keeps its last retained configuration
+func (h *TransferHandler) publishTopicConfig(r *http.Request, deviceID string) {
+	if !h.mqttClient.IsConnected() {
+		return
+	}
+
+	device, err := h.deviceRepo.GetByID(r.Context(), deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", deviceID).Msg("Failed to reload device after transfer")
+		return
+	}
+
+	tier := models.SubscriptionTierFree
+	if strings.EqualFold(r.Header.Get("X-Subscription-Tier"), models.SubscriptionTierPremium) {
+		tier = models.SubscriptionTierPremium
+	}
+
+	config := models.BuildTopicConfig(device, tier)
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		h.logger.Error().Err(err).Str("device_id", device.ID).Msg("Failed to marshal topic configuration")
+		return
+	}
+
+	if err := h.mqttClient.PublishDeviceConfig(device.ID, configJSON); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Msg("Failed to publish topic configuration after transfer")
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *TransferHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *TransferHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
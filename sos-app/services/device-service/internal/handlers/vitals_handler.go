@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// VitalsHandler exposes vital sign threshold configuration over HTTP
+type VitalsHandler struct {
+	vitalsService *services.VitalsService
+	logger        zerolog.Logger
+}
+
+// NewVitalsHandler creates a new vitals handler
+func NewVitalsHandler(vitalsService *services.VitalsService, logger zerolog.Logger) *VitalsHandler {
+	return &VitalsHandler{
+		vitalsService: vitalsService,
+		logger:        logger,
+	}
+}
+
+// GetThresholds handles GET /api/v1/vitals/thresholds
+// Returns the effective thresholds (per-user override or default) for the caller
+func (h *VitalsHandler) GetThresholds(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.logger.Error().Msg("Missing user ID in request")
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, h.vitalsService.ThresholdsForUser(userID))
+}
+
+// UpdateThresholds handles PUT /api/v1/vitals/thresholds
+// Installs a per-user threshold override, including alert cooldown and
+// sustained-breach policies
+func (h *VitalsHandler) UpdateThresholds(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		h.logger.Error().Msg("Missing user ID in request")
+		h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var thresholds services.VitalThresholds
+	if err := json.NewDecoder(r.Body).Decode(&thresholds); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	h.vitalsService.SetUserThresholds(userID, thresholds)
+
+	h.logger.Info().Str("user_id", userID).Msg("Updated vital sign thresholds")
+
+	h.respondJSON(w, http.StatusOK, thresholds)
+}
+
+// respondJSON sends a JSON response
+func (h *VitalsHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *VitalsHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
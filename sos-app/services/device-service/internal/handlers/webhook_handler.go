@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/pkg/common/httpapi"
+)
+
+// WebhookHandler exposes connectivity webhook management to administrators
+type WebhookHandler struct {
+	webhookRepo *repository.ConnectivityWebhookRepository
+	adminToken  string
+	logger      zerolog.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(
+	webhookRepo *repository.ConnectivityWebhookRepository,
+	adminToken string,
+	logger zerolog.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookRepo: webhookRepo,
+		adminToken:  adminToken,
+		logger:      logger,
+	}
+}
+
+// authorize checks the X-Admin-Token header
+func (h *WebhookHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		h.respondError(w, http.StatusServiceUnavailable, "Admin API is not configured")
+		return false
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.respondError(w, http.StatusForbidden, "Access denied")
+		return false
+	}
+
+	return true
+}
+
+// RegisterWebhook handles POST /api/v1/admin/connectivity-webhooks
+// The signing secret is returned once in the response; subsequent reads
+// never include it
+func (h *WebhookHandler) RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var req models.RegisterWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode request body")
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.OrgID == "" {
+		h.respondError(w, http.StatusBadRequest, "org_id is required")
+		return
+	}
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		h.respondError(w, http.StatusBadRequest, "url must be a valid http(s) URL")
+		return
+	}
+
+	secret := req.Secret
+	generated := false
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			h.logger.Error().Err(err).Msg("Failed to generate webhook secret")
+			h.respondError(w, http.StatusInternalServerError, "Failed to register webhook")
+			return
+		}
+		generated = true
+	}
+
+	webhook := &models.ConnectivityWebhook{
+		OrgID:   req.OrgID,
+		URL:     req.URL,
+		Secret:  secret,
+		Enabled: true,
+	}
+	if err := h.webhookRepo.Create(r.Context(), webhook); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to register webhook")
+		h.respondError(w, http.StatusInternalServerError, "Failed to register webhook")
+		return
+	}
+
+	h.logger.Info().
+		Str("webhook_id", webhook.ID).
+		Str("org_id", webhook.OrgID).
+		Msg("Connectivity webhook registered")
+
+	response := map[string]interface{}{
+		"webhook": webhook,
+	}
+	if generated {
+		response["secret"] = secret
+	}
+	h.respondJSON(w, http.StatusCreated, response)
+}
+
+// ListWebhooks handles GET /api/v1/admin/connectivity-webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	webhooks, err := h.webhookRepo.List(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list webhooks")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list webhooks")
+		return
+	}
+	if webhooks == nil {
+		webhooks = []models.ConnectivityWebhook{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"webhooks": webhooks,
+		"count":    len(webhooks),
+	})
+}
+
+// DeleteWebhook handles DELETE /api/v1/admin/connectivity-webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := h.webhookRepo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			h.respondError(w, http.StatusNotFound, "Webhook not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("webhook_id", id).Msg("Failed to delete webhook")
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete webhook")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Webhook deleted",
+	})
+}
+
+// ListDeliveries handles GET /api/v1/admin/connectivity-webhooks/{id}/deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	limit := 100
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 && n <= 1000 {
+			limit = n
+		}
+	}
+
+	id := mux.Vars(r)["id"]
+	deliveries, err := h.webhookRepo.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("webhook_id", id).Msg("Failed to list webhook deliveries")
+		h.respondError(w, http.StatusInternalServerError, "Failed to list webhook deliveries")
+		return
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+// generateWebhookSecret returns a random signing secret
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// respondJSON sends a JSON response
+func (h *WebhookHandler) respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	if err := httpapi.RespondJSON(w, statusCode, data); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// respondError sends an error response
+func (h *WebhookHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
+	h.respondJSON(w, statusCode, map[string]string{
+		"error": message,
+	})
+}
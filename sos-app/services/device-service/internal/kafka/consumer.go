@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErasureHandler is invoked for each data erasure event received from Kafka.
+// requestID identifies the originating erasure request so the purge can be
+// confirmed back to emergency-service; it may be empty on legacy events
+type ErasureHandler func(ctx context.Context, requestID, userID string)
+
+// erasureEvent is the emergency-service data erasure payload
+type erasureEvent struct {
+	RequestID string `json:"request_id"`
+	UserID    string `json:"user_id"`
+}
+
+// ErasureConsumer consumes GDPR/CCPA data erasure events so device records
+// for erased users are purged from this service too
+type ErasureConsumer struct {
+	reader  *kafka.Reader
+	handler ErasureHandler
+	logger  zerolog.Logger
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewErasureConsumer creates a consumer for the data erasure topic
+func NewErasureConsumer(brokers []string, groupID, topic string, handler ErasureHandler, logger zerolog.Logger) *ErasureConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  brokers,
+		GroupID:  groupID,
+		Topic:    topic,
+		MinBytes: 1,
+		MaxBytes: 1e6,
+		MaxWait:  1 * time.Second,
+	})
+
+	return &ErasureConsumer{
+		reader:  reader,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start begins consuming erasure events in the background
+func (c *ErasureConsumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			message, err := c.reader.ReadMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error().Err(err).Msg("Error reading erasure event")
+				continue
+			}
+
+			var event erasureEvent
+			if err := json.Unmarshal(message.Value, &event); err != nil {
+				c.logger.Error().Err(err).Msg("Failed to parse erasure event")
+				continue
+			}
+
+			if event.UserID == "" {
+				c.logger.Error().Msg("Erasure event missing user_id")
+				continue
+			}
+
+			c.handler(ctx, event.RequestID, event.UserID)
+		}
+	}()
+
+	c.logger.Info().Msg("Data erasure consumer started")
+}
+
+// Close stops consuming and releases the Kafka reader
+func (c *ErasureConsumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return c.reader.Close()
+}
@@ -0,0 +1,122 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// lifecycleEvent carries the fields shared by all emergency lifecycle payloads
+type lifecycleEvent struct {
+	EmergencyID string `json:"emergency_id"`
+	UserID      string `json:"user_id"`
+}
+
+// EmergencyLifecycleConsumer follows the emergency created/resolved/cancelled
+// topics to keep an up-to-date view of which users have an active emergency
+type EmergencyLifecycleConsumer struct {
+	readers []*kafka.Reader
+	byTopic map[string]func(event lifecycleEvent)
+	logger  zerolog.Logger
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewEmergencyLifecycleConsumer creates a consumer for the emergency
+// lifecycle topics. onActive fires for created events with the emergency ID
+// so telemetry can be attached to it, onInactive for resolved and cancelled
+// events
+func NewEmergencyLifecycleConsumer(
+	brokers []string,
+	groupID string,
+	createdTopic, resolvedTopic, cancelledTopic string,
+	onActive func(userID, emergencyID string),
+	onInactive func(userID string),
+	logger zerolog.Logger,
+) *EmergencyLifecycleConsumer {
+	c := &EmergencyLifecycleConsumer{
+		byTopic: map[string]func(event lifecycleEvent){
+			createdTopic:   func(event lifecycleEvent) { onActive(event.UserID, event.EmergencyID) },
+			resolvedTopic:  func(event lifecycleEvent) { onInactive(event.UserID) },
+			cancelledTopic: func(event lifecycleEvent) { onInactive(event.UserID) },
+		},
+		logger: logger,
+	}
+
+	for topic := range c.byTopic {
+		c.readers = append(c.readers, kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			GroupID:  groupID,
+			Topic:    topic,
+			MinBytes: 1,
+			MaxBytes: 1e6,
+			MaxWait:  1 * time.Second,
+		}))
+	}
+
+	return c
+}
+
+// Start begins consuming lifecycle events in the background, one goroutine
+// per topic
+func (c *EmergencyLifecycleConsumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	for _, reader := range c.readers {
+		reader := reader
+		topic := reader.Config().Topic
+		handle := c.byTopic[topic]
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+
+			for {
+				message, err := reader.ReadMessage(ctx)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					c.logger.Error().Err(err).Str("topic", topic).Msg("Error reading lifecycle event")
+					continue
+				}
+
+				var event lifecycleEvent
+				if err := json.Unmarshal(message.Value, &event); err != nil {
+					c.logger.Error().Err(err).Str("topic", topic).Msg("Failed to parse lifecycle event")
+					continue
+				}
+
+				if event.UserID == "" {
+					c.logger.Error().Str("topic", topic).Msg("Lifecycle event missing user_id")
+					continue
+				}
+
+				handle(event)
+			}
+		}()
+	}
+
+	c.logger.Info().Msg("Emergency lifecycle consumer started")
+}
+
+// Close stops consuming and releases the Kafka readers
+func (c *EmergencyLifecycleConsumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	var firstErr error
+	for _, reader := range c.readers {
+		if err := reader.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// erasureCompletedEvent confirms this service's leg of the data erasure saga.
+// Field names follow the emergency-service ErasureCompletedEvent contract
+type erasureCompletedEvent struct {
+	RequestID     string    `json:"request_id"`
+	UserID        string    `json:"user_id"`
+	Service       string    `json:"service"`
+	RecordsPurged int64     `json:"records_purged"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// ErasureCompletedPublisher publishes purge confirmations back to
+// emergency-service so erasure requests can track per-service completion
+type ErasureCompletedPublisher struct {
+	writer *kafka.Writer
+	logger zerolog.Logger
+}
+
+// NewErasureCompletedPublisher creates a publisher for the erasure
+// completion topic
+func NewErasureCompletedPublisher(brokers []string, topic string, logger zerolog.Logger) *ErasureCompletedPublisher {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.Hash{},
+	}
+
+	return &ErasureCompletedPublisher{
+		writer: writer,
+		logger: logger,
+	}
+}
+
+// Publish emits one purge confirmation, keyed by user
+func (p *ErasureCompletedPublisher) Publish(ctx context.Context, requestID, userID string, recordsPurged int64) error {
+	payload, err := json.Marshal(erasureCompletedEvent{
+		RequestID:     requestID,
+		UserID:        userID,
+		Service:       "device-service",
+		RecordsPurged: recordsPurged,
+		Timestamp:     time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal erasure completion: %w", err)
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(userID),
+		Value: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to publish erasure completion: %w", err)
+	}
+
+	p.logger.Info().
+		Str("request_id", requestID).
+		Str("user_id", userID).
+		Int64("records_purged", recordsPurged).
+		Msg("Published erasure completion")
+
+	return nil
+}
+
+// Close flushes and closes the underlying writer
+func (p *ErasureCompletedPublisher) Close() error {
+	return p.writer.Close()
+}
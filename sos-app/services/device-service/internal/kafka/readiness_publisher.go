@@ -0,0 +1,71 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// readinessEvent is the payload published when a user's fleet readiness
+// changes, consumed by the emergency app to warn unprotected users
+type readinessEvent struct {
+	UserID    string    `json:"user_id"`
+	Ready     bool      `json:"ready"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// ReadinessPublisher publishes fleet readiness transitions to Kafka
+type ReadinessPublisher struct {
+	writer *kafka.Writer
+	logger zerolog.Logger
+}
+
+// NewReadinessPublisher creates a publisher for the readiness topic
+func NewReadinessPublisher(brokers []string, topic string, logger zerolog.Logger) *ReadinessPublisher {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.Hash{},
+	}
+
+	return &ReadinessPublisher{
+		writer: writer,
+		logger: logger,
+	}
+}
+
+// Publish emits one readiness transition, keyed by user so consumers see
+// each user's changes in order
+func (p *ReadinessPublisher) Publish(ctx context.Context, userID string, ready bool) error {
+	payload, err := json.Marshal(readinessEvent{
+		UserID:    userID,
+		Ready:     ready,
+		ChangedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal readiness event: %w", err)
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(userID),
+		Value: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to publish readiness event: %w", err)
+	}
+
+	p.logger.Info().
+		Str("user_id", userID).
+		Bool("ready", ready).
+		Msg("Published fleet readiness change")
+
+	return nil
+}
+
+// Close flushes and closes the underlying writer
+func (p *ReadinessPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,69 @@
+// Package locationapi is a typed client for the location-service HTTP API.
+// The request type mirrors the LocationUpdate schema in the location-service
+// OpenAPI spec (served at /openapi.json); keep the two in lockstep so the
+// cross-service contract cannot drift silently
+package locationapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LocationUpdate is the payload for POST /api/v1/location/update. Field
+// names follow the location-service camelCase convention
+type LocationUpdate struct {
+	EmergencyID  string   `json:"emergencyId"`
+	UserID       string   `json:"userId"`
+	Latitude     float64  `json:"latitude"`
+	Longitude    float64  `json:"longitude"`
+	Accuracy     *float64 `json:"accuracy,omitempty"`
+	Provider     string   `json:"provider"`
+	BatteryLevel *int     `json:"batteryLevel,omitempty"`
+}
+
+// Client calls the location-service location update endpoint
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the location-service at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// UpdateLocation posts one location point for an active emergency
+func (c *Client) UpdateLocation(ctx context.Context, update *LocationUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal location payload: %w", err)
+	}
+
+	url := c.baseURL + "/api/v1/location/update"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create location request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call location service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("location service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Alert categories organizations can route to teams
+const (
+	AlertCategoryBattery      = "battery"
+	AlertCategoryVitals       = "vitals"
+	AlertCategoryConnectivity = "connectivity"
+	AlertCategoryFirmware     = "firmware"
+)
+
+// Alert priorities in ascending order of urgency
+const (
+	AlertPriorityLow    = "LOW"
+	AlertPriorityMedium = "MEDIUM"
+	AlertPriorityHigh   = "HIGH"
+)
+
+// AlertRoutingRule routes one category of device alert to the organization
+// team that handles it. Rules are evaluated in rank order and the first
+// match wins; the optional matchers narrow a rule to a device type or a
+// minimum priority
+type AlertRoutingRule struct {
+	ID            string    `json:"id" db:"id"`
+	OrgID         string    `json:"org_id" db:"org_id"`
+	Name          string    `json:"name" db:"name"`
+	AlertCategory string    `json:"alert_category" db:"alert_category"`
+	DeviceType    *string   `json:"device_type,omitempty" db:"device_type"`
+	MinPriority   *string   `json:"min_priority,omitempty" db:"min_priority"`
+	Team          string    `json:"team" db:"team"`
+	Rank          int       `json:"rank" db:"rank"`
+	Enabled       bool      `json:"enabled" db:"enabled"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// AlertContext is the alert shape routing rules match against
+type AlertContext struct {
+	Category   string `json:"category"`
+	Priority   string `json:"priority"`
+	DeviceType string `json:"device_type,omitempty"`
+}
+
+// CreateRoutingRuleRequest represents the request to create a routing rule
+type CreateRoutingRuleRequest struct {
+	OrgID         string  `json:"org_id"`
+	Name          string  `json:"name"`
+	AlertCategory string  `json:"alert_category"`
+	DeviceType    *string `json:"device_type,omitempty"`
+	MinPriority   *string `json:"min_priority,omitempty"`
+	Team          string  `json:"team"`
+	Rank          int     `json:"rank"`
+}
+
+// IsValidAlertCategory reports whether the category is routable
+func IsValidAlertCategory(category string) bool {
+	switch category {
+	case AlertCategoryBattery, AlertCategoryVitals, AlertCategoryConnectivity, AlertCategoryFirmware:
+		return true
+	}
+	return false
+}
+
+// alertPriorityLevel orders alert priorities; unknown priorities rank lowest
+func alertPriorityLevel(priority string) int {
+	switch strings.ToUpper(priority) {
+	case AlertPriorityHigh:
+		return 3
+	case AlertPriorityMedium:
+		return 2
+	case AlertPriorityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsValidAlertPriority reports whether the priority is a known level
+func IsValidAlertPriority(priority string) bool {
+	return alertPriorityLevel(priority) > 0
+}
+
+// Matches reports whether this rule applies to the alert
+func (r *AlertRoutingRule) Matches(alert AlertContext) bool {
+	if !r.Enabled || r.AlertCategory != alert.Category {
+		return false
+	}
+	if r.DeviceType != nil && !strings.EqualFold(*r.DeviceType, alert.DeviceType) {
+		return false
+	}
+	if r.MinPriority != nil && alertPriorityLevel(alert.Priority) < alertPriorityLevel(*r.MinPriority) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestAlertRoutingRuleMatchesCategory(t *testing.T) {
+	rule := AlertRoutingRule{
+		AlertCategory: AlertCategoryBattery,
+		Team:          "facilities",
+		Enabled:       true,
+	}
+
+	assert.True(t, rule.Matches(AlertContext{Category: AlertCategoryBattery, Priority: AlertPriorityMedium}))
+	assert.False(t, rule.Matches(AlertContext{Category: AlertCategoryVitals, Priority: AlertPriorityMedium}))
+}
+
+func TestAlertRoutingRuleDisabledNeverMatches(t *testing.T) {
+	rule := AlertRoutingRule{
+		AlertCategory: AlertCategoryConnectivity,
+		Team:          "it",
+		Enabled:       false,
+	}
+
+	assert.False(t, rule.Matches(AlertContext{Category: AlertCategoryConnectivity, Priority: AlertPriorityHigh}))
+}
+
+func TestAlertRoutingRuleDeviceTypeMatcherIsCaseInsensitive(t *testing.T) {
+	rule := AlertRoutingRule{
+		AlertCategory: AlertCategoryVitals,
+		DeviceType:    strPtr("health_monitor"),
+		Team:          "nursing",
+		Enabled:       true,
+	}
+
+	assert.True(t, rule.Matches(AlertContext{Category: AlertCategoryVitals, DeviceType: "HEALTH_MONITOR"}))
+	assert.False(t, rule.Matches(AlertContext{Category: AlertCategoryVitals, DeviceType: "SMART_WATCH"}))
+}
+
+func TestAlertRoutingRuleMinPriorityMatcher(t *testing.T) {
+	rule := AlertRoutingRule{
+		AlertCategory: AlertCategoryBattery,
+		MinPriority:   strPtr(AlertPriorityHigh),
+		Team:          "facilities",
+		Enabled:       true,
+	}
+
+	assert.True(t, rule.Matches(AlertContext{Category: AlertCategoryBattery, Priority: AlertPriorityHigh}))
+	assert.False(t, rule.Matches(AlertContext{Category: AlertCategoryBattery, Priority: AlertPriorityMedium}))
+	// Unknown priorities rank below every configured minimum
+	assert.False(t, rule.Matches(AlertContext{Category: AlertCategoryBattery, Priority: "BOGUS"}))
+}
+
+func TestIsValidAlertCategory(t *testing.T) {
+	assert.True(t, IsValidAlertCategory(AlertCategoryBattery))
+	assert.True(t, IsValidAlertCategory(AlertCategoryVitals))
+	assert.True(t, IsValidAlertCategory(AlertCategoryConnectivity))
+	assert.False(t, IsValidAlertCategory("emergency"))
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// APITokenPrefix marks a bearer credential as a scoped API token, so
+// middleware can tell it apart from other Authorization header users
+const APITokenPrefix = "sosd_"
+
+// API token scopes. Each scope grants one capability; an integration gets
+// exactly the scopes it needs
+const (
+	// ScopeDeviceRead allows listing devices and reading their state
+	ScopeDeviceRead = "device:read"
+	// ScopeDeviceCommand allows sending commands (locate, key rotation)
+	ScopeDeviceCommand = "device:command"
+	// ScopeTelemetryWrite allows pushing beacon reports on behalf of the
+	// token owner's devices
+	ScopeTelemetryWrite = "telemetry:write"
+)
+
+// APIToken is a scoped bearer token issued to a third-party integration.
+// The token value itself is never stored; only its hash is
+type APIToken struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// HasScope reports whether the token grants the given scope
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScopes checks that at least one scope is requested and every
+// scope is known
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		switch scope {
+		case ScopeDeviceRead, ScopeDeviceCommand, ScopeTelemetryWrite:
+		default:
+			return fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPITokenHasScope(t *testing.T) {
+	token := &APIToken{Scopes: []string{ScopeDeviceRead, ScopeTelemetryWrite}}
+
+	assert.True(t, token.HasScope(ScopeDeviceRead))
+	assert.True(t, token.HasScope(ScopeTelemetryWrite))
+	assert.False(t, token.HasScope(ScopeDeviceCommand))
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes([]string{ScopeDeviceRead}))
+	assert.NoError(t, ValidateScopes([]string{ScopeDeviceRead, ScopeDeviceCommand, ScopeTelemetryWrite}))
+
+	assert.Error(t, ValidateScopes(nil), "empty scope list must be rejected")
+	assert.Error(t, ValidateScopes([]string{"device:admin"}), "unknown scope must be rejected")
+}
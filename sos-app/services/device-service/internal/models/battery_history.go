@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// BatteryHistoryBucket is one time bucket of battery samples for charting
+type BatteryHistoryBucket struct {
+	BucketStart time.Time `json:"bucket_start"`
+	AvgLevel    int       `json:"avg_level"`
+	MinLevel    int       `json:"min_level"`
+	MaxLevel    int       `json:"max_level"`
+	Samples     int       `json:"samples"`
+}
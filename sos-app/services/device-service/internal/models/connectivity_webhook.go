@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Connectivity webhook event types
+const (
+	WebhookEventDeviceDisconnected = "device.disconnected"
+	WebhookEventDeviceReconnected  = "device.reconnected"
+)
+
+// ConnectivityWebhook is an organization's endpoint notified whenever a
+// device transitions to DISCONNECTED or back
+type ConnectivityWebhook struct {
+	ID        string    `json:"id" db:"id"`
+	OrgID     string    `json:"org_id" db:"org_id"`
+	URL       string    `json:"url" db:"url"`
+	Secret    string    `json:"-" db:"secret"`
+	Enabled   bool      `json:"enabled" db:"enabled"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RegisterWebhookRequest represents the request to register a connectivity
+// webhook. When Secret is empty a random signing secret is generated and
+// returned once in the response
+type RegisterWebhookRequest struct {
+	OrgID  string `json:"org_id"`
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// WebhookDelivery is one logged delivery attempt outcome
+type WebhookDelivery struct {
+	ID          string    `json:"id" db:"id"`
+	WebhookID   string    `json:"webhook_id" db:"webhook_id"`
+	DeviceID    string    `json:"device_id" db:"device_id"`
+	EventType   string    `json:"event_type" db:"event_type"`
+	Attempts    int       `json:"attempts" db:"attempts"`
+	StatusCode  *int      `json:"status_code,omitempty" db:"status_code"`
+	Succeeded   bool      `json:"succeeded" db:"succeeded"`
+	LastError   *string   `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt time.Time `json:"delivered_at" db:"delivered_at"`
+}
@@ -37,6 +37,7 @@ type Device struct {
 	Status       DeviceStatus `json:"status" db:"status"`
 	Capabilities []string     `json:"capabilities" db:"capabilities"`
 	Settings     map[string]interface{} `json:"settings,omitempty" db:"settings"`
+	SettingsVersion int                 `json:"settings_version" db:"settings_version"`
 	LastSeenAt   *time.Time   `json:"last_seen_at,omitempty" db:"last_seen_at"`
 	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
@@ -48,7 +49,17 @@ type PairDeviceRequest struct {
 	Manufacturer string     `json:"manufacturer"`
 	Model        string     `json:"model"`
 	MacAddress   string     `json:"mac_address"`
+	SerialNumber string     `json:"serial_number,omitempty"`
 	Capabilities []string   `json:"capabilities"`
+	// FirmwareVersion lets the compatibility matrix warn about known
+	// issues at pairing time rather than on the first telemetry message
+	FirmwareVersion string `json:"firmware_version,omitempty"`
+
+	// Devices that encrypt their vitals payloads register the public half
+	// of their X25519 keypair (base64) and who may decrypt: SERVER
+	// (default) or E2E
+	EncryptionPublicKey string         `json:"encryption_public_key,omitempty"`
+	EncryptionMode      EncryptionMode `json:"encryption_mode,omitempty"`
 }
 
 // UpdateDeviceSettingsRequest represents the request to update device settings
@@ -100,4 +111,5 @@ const (
 	EventTypeFallDetected     = "FallDetected"
 	EventTypeSOSButtonPressed = "SOSButtonPressed"
 	EventTypeGeofenceExit     = "GeofenceExit"
+	EventTypeCancelRequested  = "CancelRequested"
 )
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Alert types recorded by the monitors
+const (
+	AlertTypeLowBattery      = "low_battery"
+	AlertTypeCriticalBattery = "critical_battery"
+	AlertTypeDisconnected    = "disconnected"
+	AlertTypeVitalSigns      = "vital_signs"
+	AlertTypeClockSkew       = "clock_skew"
+	AlertTypeFirmwareIssue   = "firmware_issue"
+)
+
+// DeviceAlert is one alert emitted by the battery, vitals or connectivity
+// monitors, persisted so users can review their alert history
+type DeviceAlert struct {
+	ID        string    `json:"id" db:"id"`
+	DeviceID  string    `json:"device_id" db:"device_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Category  string    `json:"category" db:"category"`
+	AlertType string    `json:"alert_type" db:"alert_type"`
+	Priority  string    `json:"priority" db:"priority"`
+	Message   string    `json:"message" db:"message"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "errors"
+
+// Beacon flags map compact single-word markers from constrained trackers
+// onto the full device event types
+const (
+	BeaconFlagSOS    = "sos"
+	BeaconFlagFall   = "fall"
+	BeaconFlagCancel = "cancel"
+)
+
+// BeaconPayload is the compact report body posted by NB-IoT/LTE-M trackers
+// that cannot hold an MQTT connection. Field names are single letters to
+// keep the payload inside one small cellular datagram
+type BeaconPayload struct {
+	Battery   int      `json:"b,omitempty"`
+	Latitude  *float64 `json:"lat,omitempty"`
+	Longitude *float64 `json:"lng,omitempty"`
+	Flags     []string `json:"f,omitempty"`
+}
+
+// Validate checks coordinate bounds and that every flag is known
+func (p *BeaconPayload) Validate() error {
+	if p.Battery < 0 || p.Battery > 100 {
+		return errors.New("battery must be between 0 and 100")
+	}
+
+	if (p.Latitude == nil) != (p.Longitude == nil) {
+		return errors.New("lat and lng must be provided together")
+	}
+	if p.Latitude != nil {
+		if *p.Latitude < -90 || *p.Latitude > 90 {
+			return errors.New("lat must be between -90 and 90")
+		}
+		if *p.Longitude < -180 || *p.Longitude > 180 {
+			return errors.New("lng must be between -180 and 180")
+		}
+	}
+
+	for _, flag := range p.Flags {
+		if _, ok := beaconEventTypes[flag]; !ok {
+			return errors.New("unknown beacon flag: " + flag)
+		}
+	}
+
+	return nil
+}
+
+// beaconEventTypes maps beacon flags to device event types
+var beaconEventTypes = map[string]string{
+	BeaconFlagSOS:    EventTypeSOSButtonPressed,
+	BeaconFlagFall:   EventTypeFallDetected,
+	BeaconFlagCancel: EventTypeCancelRequested,
+}
+
+// EventTypeForBeaconFlag returns the device event type a beacon flag maps
+// to; ok is false for unknown flags
+func EventTypeForBeaconFlag(flag string) (string, bool) {
+	eventType, ok := beaconEventTypes[flag]
+	return eventType, ok
+}
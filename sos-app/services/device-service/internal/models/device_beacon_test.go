@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeaconPayloadValidate(t *testing.T) {
+	lat := 40.7128
+	lng := -74.0060
+
+	valid := &BeaconPayload{
+		Battery:   45,
+		Latitude:  &lat,
+		Longitude: &lng,
+		Flags:     []string{BeaconFlagSOS},
+	}
+	assert.NoError(t, valid.Validate())
+
+	// Flags alone are a valid beacon (no fix available)
+	flagsOnly := &BeaconPayload{Flags: []string{BeaconFlagFall, BeaconFlagCancel}}
+	assert.NoError(t, flagsOnly.Validate())
+
+	// Coordinates must come as a pair
+	latOnly := &BeaconPayload{Latitude: &lat}
+	assert.Error(t, latOnly.Validate())
+
+	badBattery := &BeaconPayload{Battery: 101}
+	assert.Error(t, badBattery.Validate())
+
+	unknownFlag := &BeaconPayload{Flags: []string{"reboot"}}
+	assert.Error(t, unknownFlag.Validate())
+}
+
+func TestBeaconPayloadCompactJSON(t *testing.T) {
+	// The wire format uses single-letter keys to fit small cellular datagrams
+	var payload BeaconPayload
+	err := json.Unmarshal([]byte(`{"b":12,"lat":40.7,"lng":-74.0,"f":["sos"]}`), &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, payload.Battery)
+	assert.Equal(t, 40.7, *payload.Latitude)
+	assert.Equal(t, []string{"sos"}, payload.Flags)
+}
+
+func TestEventTypeForBeaconFlag(t *testing.T) {
+	eventType, ok := EventTypeForBeaconFlag(BeaconFlagSOS)
+	assert.True(t, ok)
+	assert.Equal(t, EventTypeSOSButtonPressed, eventType)
+
+	_, ok = EventTypeForBeaconFlag("reboot")
+	assert.False(t, ok)
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// CommandStatus represents the execution state of a remote device command
+type CommandStatus string
+
+const (
+	CommandStatusPending      CommandStatus = "PENDING"
+	CommandStatusAcknowledged CommandStatus = "ACKNOWLEDGED"
+	CommandStatusFailed       CommandStatus = "FAILED"
+)
+
+// CommandTypeLocate asks the device to sound its siren and/or flash its LED
+// so it can be found (or draw attention during an active emergency)
+const CommandTypeLocate = "LOCATE"
+
+// CommandTypeRotateKey asks the device to generate a fresh payload
+// encryption keypair and report the new public key in its ack
+const CommandTypeRotateKey = "ROTATE_KEY"
+
+// Device capabilities required for the locate command
+const (
+	CapabilitySiren  = "siren"
+	CapabilityStrobe = "strobe"
+)
+
+// DeviceCommand is a remote command pushed to a device over MQTT, tracked
+// until the device acknowledges execution
+type DeviceCommand struct {
+	ID          string        `json:"id" db:"id"`
+	DeviceID    string        `json:"device_id" db:"device_id"`
+	CommandType string        `json:"command_type" db:"command_type"`
+	IssuedBy    string        `json:"issued_by" db:"issued_by"`
+	Status      CommandStatus `json:"status" db:"status"`
+	Error       *string       `json:"error,omitempty" db:"error"`
+	IssuedAt    time.Time     `json:"issued_at" db:"issued_at"`
+	AckedAt     *time.Time    `json:"acked_at,omitempty" db:"acked_at"`
+}
+
+// LocateDeviceRequest represents the request to trigger the device
+// siren/strobe. When neither siren nor strobe is requested, every locate
+// capability the device has is used
+type LocateDeviceRequest struct {
+	Siren           bool `json:"siren"`
+	Strobe          bool `json:"strobe"`
+	DurationSeconds int  `json:"duration_seconds"`
+}
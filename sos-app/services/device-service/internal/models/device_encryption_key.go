@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// EncryptionMode says who is able to open a device's encrypted payloads
+type EncryptionMode string
+
+const (
+	// EncryptionModeServer decrypts payloads on ingest so the full
+	// telemetry pipeline (monitoring, storage, forwarding) works
+	EncryptionModeServer EncryptionMode = "SERVER"
+	// EncryptionModeE2E keeps payloads opaque to the service; they pass
+	// through to authorized clients, which hold the decryption keys
+	EncryptionModeE2E EncryptionMode = "E2E"
+)
+
+// EncryptionKeyBytes is the required X25519 public key length
+const EncryptionKeyBytes = 32
+
+// DeviceEncryptionKey is the public half of a device's payload encryption
+// keypair, registered at pairing and replaced on rotation
+type DeviceEncryptionKey struct {
+	DeviceID  string         `json:"device_id" db:"device_id"`
+	PublicKey string         `json:"public_key" db:"public_key"`
+	Mode      EncryptionMode `json:"mode" db:"mode"`
+	RotatedAt *time.Time     `json:"rotated_at,omitempty" db:"rotated_at"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// ValidateEncryptionPublicKey checks a base64-encoded X25519 public key
+func ValidateEncryptionPublicKey(encoded string) error {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("public key is not valid base64")
+	}
+	if len(key) != EncryptionKeyBytes {
+		return fmt.Errorf("public key must be %d bytes, got %d", EncryptionKeyBytes, len(key))
+	}
+	return nil
+}
+
+// ValidEncryptionMode reports whether mode is a known encryption mode
+func ValidEncryptionMode(mode EncryptionMode) bool {
+	return mode == EncryptionModeServer || mode == EncryptionModeE2E
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// DeviceRegistryEntry is a pre-registered piece of hardware shipped to a
+// customer; only registered MAC addresses may be paired
+type DeviceRegistryEntry struct {
+	ID                 string    `json:"id" db:"id"`
+	MacAddress         string    `json:"mac_address" db:"mac_address"`
+	SerialNumber       string    `json:"serial_number,omitempty" db:"serial_number"`
+	RequireSerialProof bool      `json:"require_serial_proof" db:"require_serial_proof"`
+	Notes              string    `json:"notes,omitempty" db:"notes"`
+	RegisteredAt       time.Time `json:"registered_at" db:"registered_at"`
+}
+
+// RegisterDeviceRequest represents the request to pre-register hardware
+type RegisterDeviceRequest struct {
+	MacAddress         string `json:"mac_address"`
+	SerialNumber       string `json:"serial_number"`
+	RequireSerialProof bool   `json:"require_serial_proof"`
+	Notes              string `json:"notes"`
+}
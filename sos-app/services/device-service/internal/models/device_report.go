@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// DeviceReportEntry summarizes one device over a reporting period
+type DeviceReportEntry struct {
+	DeviceID        string       `json:"device_id"`
+	DeviceType      DeviceType   `json:"device_type"`
+	Manufacturer    string       `json:"manufacturer"`
+	Model           string       `json:"model"`
+	Status          DeviceStatus `json:"status"`
+	FirmwareVersion string       `json:"firmware_version,omitempty"`
+	UptimePercent   float64      `json:"uptime_percent"`
+	BatteryStart    *int         `json:"battery_start,omitempty"`
+	BatteryEnd      *int         `json:"battery_end,omitempty"`
+	BatteryMin      *int         `json:"battery_min,omitempty"`
+	AlertCount      int          `json:"alert_count"`
+}
+
+// DeviceReport is one user's stored device report for a reporting period
+type DeviceReport struct {
+	ID          string              `json:"id" db:"id"`
+	UserID      string              `json:"user_id" db:"user_id"`
+	PeriodStart time.Time           `json:"period_start" db:"period_start"`
+	PeriodEnd   time.Time           `json:"period_end" db:"period_end"`
+	Devices     []DeviceReportEntry `json:"devices" db:"report"`
+	GeneratedAt time.Time           `json:"generated_at" db:"generated_at"`
+}
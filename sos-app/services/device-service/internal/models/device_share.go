@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// ShareRole represents the access level granted to a shared user
+type ShareRole string
+
+const (
+	ShareRoleViewer  ShareRole = "VIEWER"
+	ShareRoleManager ShareRole = "MANAGER"
+)
+
+// ShareStatus represents the lifecycle state of a device share invite
+type ShareStatus string
+
+const (
+	ShareStatusPending  ShareStatus = "PENDING"
+	ShareStatusAccepted ShareStatus = "ACCEPTED"
+	ShareStatusRevoked  ShareStatus = "REVOKED"
+)
+
+// DeviceShare links a device to an additional user (family member or
+// caregiver) with a role level. The device owner always has full access.
+type DeviceShare struct {
+	ID               string      `json:"id" db:"id"`
+	DeviceID         string      `json:"device_id" db:"device_id"`
+	OwnerUserID      string      `json:"owner_user_id" db:"owner_user_id"`
+	SharedWithUserID string      `json:"shared_with_user_id" db:"shared_with_user_id"`
+	Role             ShareRole   `json:"role" db:"role"`
+	Status           ShareStatus `json:"status" db:"status"`
+	InvitedAt        time.Time   `json:"invited_at" db:"invited_at"`
+	RespondedAt      *time.Time  `json:"responded_at,omitempty" db:"responded_at"`
+	RevokedAt        *time.Time  `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// CreateShareRequest is the payload for inviting a user to a device
+type CreateShareRequest struct {
+	SharedWithUserID string    `json:"shared_with_user_id"`
+	Role             ShareRole `json:"role"`
+}
+
+// ValidShareRole checks if the role is a known share role
+func ValidShareRole(role ShareRole) bool {
+	return role == ShareRoleViewer || role == ShareRoleManager
+}
+
+// AllowsManage reports whether the role may change device settings
+func (r ShareRole) AllowsManage() bool {
+	return r == ShareRoleManager
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// TransferStatus represents the lifecycle state of a device transfer
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "PENDING"
+	TransferStatusAccepted  TransferStatus = "ACCEPTED"
+	TransferStatusDeclined  TransferStatus = "DECLINED"
+	TransferStatusCancelled TransferStatus = "CANCELLED"
+)
+
+// DeviceTransfer hands a device from its current owner to a new user. The
+// owner initiates, the new owner accepts; acceptance reassigns the device
+// and wipes everything specific to the previous owner so refurbished
+// hardware can change hands without data bleed.
+type DeviceTransfer struct {
+	ID          string         `json:"id" db:"id"`
+	DeviceID    string         `json:"device_id" db:"device_id"`
+	FromUserID  string         `json:"from_user_id" db:"from_user_id"`
+	ToUserID    string         `json:"to_user_id" db:"to_user_id"`
+	Status      TransferStatus `json:"status" db:"status"`
+	InitiatedAt time.Time      `json:"initiated_at" db:"initiated_at"`
+	RespondedAt *time.Time     `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+// InitiateTransferRequest is the payload for offering a device to a new owner
+type InitiateTransferRequest struct {
+	ToUserID string `json:"to_user_id"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// FirmwareCompatibility is one row of the support-maintained compatibility
+// matrix: which capabilities a (model, firmware version) combination
+// actually delivers and the issues known to affect it
+type FirmwareCompatibility struct {
+	ID                    string    `json:"id" db:"id"`
+	DeviceModel           string    `json:"device_model" db:"device_model"`
+	FirmwareVersion       string    `json:"firmware_version" db:"firmware_version"`
+	SupportedCapabilities []string  `json:"supported_capabilities" db:"supported_capabilities"`
+	KnownIssues           []string  `json:"known_issues" db:"known_issues"`
+	UpdateRecommended     bool      `json:"update_recommended" db:"update_recommended"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpsertFirmwareCompatibilityRequest creates or replaces the matrix entry
+// for one (model, firmware version) combination
+type UpsertFirmwareCompatibilityRequest struct {
+	DeviceModel           string   `json:"device_model"`
+	FirmwareVersion       string   `json:"firmware_version"`
+	SupportedCapabilities []string `json:"supported_capabilities"`
+	KnownIssues           []string `json:"known_issues"`
+	UpdateRecommended     bool     `json:"update_recommended"`
+}
+
+// Warnings returns the user-facing warnings this entry produces for a device
+// with the given paired capabilities: every known issue verbatim, plus one
+// warning per capability the firmware does not support
+func (fc *FirmwareCompatibility) Warnings(capabilities []string) []string {
+	warnings := append([]string(nil), fc.KnownIssues...)
+	for _, capability := range capabilities {
+		if !fc.supports(capability) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s is not supported on firmware %s — update recommended", capability, fc.FirmwareVersion))
+		}
+	}
+	return warnings
+}
+
+// supports reports whether the firmware delivers the capability
+func (fc *FirmwareCompatibility) supports(capability string) bool {
+	for _, supported := range fc.SupportedCapabilities {
+		if supported == capability {
+			return true
+		}
+	}
+	return false
+}
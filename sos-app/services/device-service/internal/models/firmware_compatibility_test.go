@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirmwareCompatibilityWarnings(t *testing.T) {
+	entry := &FirmwareCompatibility{
+		DeviceModel:           "Guardian Watch 2",
+		FirmwareVersion:       "2.1.0",
+		SupportedCapabilities: []string{"sos_button", "heart_rate"},
+		KnownIssues:           []string{"Fall detection unreliable on firmware 2.1.0 — update recommended"},
+	}
+
+	t.Run("known issues are reported verbatim", func(t *testing.T) {
+		warnings := entry.Warnings([]string{"sos_button"})
+		assert.Equal(t, entry.KnownIssues, warnings)
+	})
+
+	t.Run("unsupported capabilities add a warning", func(t *testing.T) {
+		warnings := entry.Warnings([]string{"sos_button", "fall_detection"})
+		assert.Len(t, warnings, 2)
+		assert.Contains(t, warnings[1], "fall_detection is not supported on firmware 2.1.0")
+	})
+
+	t.Run("clean entry warns nothing", func(t *testing.T) {
+		clean := &FirmwareCompatibility{
+			FirmwareVersion:       "2.2.0",
+			SupportedCapabilities: []string{"sos_button", "fall_detection"},
+		}
+		assert.Empty(t, clean.Warnings([]string{"sos_button", "fall_detection"}))
+	})
+}
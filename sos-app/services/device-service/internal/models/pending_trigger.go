@@ -0,0 +1,22 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PendingEmergencyTrigger is an emergency trigger that failed to reach the
+// emergency-service and is queued locally for retry. The payload is the exact
+// request body that would have been sent.
+type PendingEmergencyTrigger struct {
+	ID            string          `json:"id" db:"id"`
+	DeviceID      string          `json:"device_id" db:"device_id"`
+	UserID        string          `json:"user_id" db:"user_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	LastError     *string         `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+}
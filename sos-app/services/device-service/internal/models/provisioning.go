@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// ProvisioningStatus represents the state of a BLE provisioning session
+type ProvisioningStatus string
+
+const (
+	ProvisioningStatusPending   ProvisioningStatus = "PENDING"   // Secret issued, waiting for device confirmation
+	ProvisioningStatusConfirmed ProvisioningStatus = "CONFIRMED" // Device proved the secret over BLE
+	ProvisioningStatusCompleted ProvisioningStatus = "COMPLETED" // Pairing finalized, device created
+)
+
+// ProvisioningSession is one run of the in-app BLE provisioning flow for a
+// device that has no keyboard or display. The one-time secret is returned
+// exactly once at creation and only its hash is stored
+type ProvisioningSession struct {
+	ID           string             `json:"id" db:"id"`
+	UserID       string             `json:"user_id" db:"user_id"`
+	Status       ProvisioningStatus `json:"status" db:"status"`
+	DeviceType   DeviceType         `json:"device_type" db:"device_type"`
+	Manufacturer string             `json:"manufacturer" db:"manufacturer"`
+	Model        string             `json:"model" db:"model"`
+	SecretHash   string             `json:"-" db:"secret_hash"`
+	MacAddress   *string            `json:"mac_address,omitempty" db:"mac_address"`
+	SerialNumber *string            `json:"serial_number,omitempty" db:"serial_number"`
+	DeviceID     *string            `json:"device_id,omitempty" db:"device_id"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+	ExpiresAt    time.Time          `json:"expires_at" db:"expires_at"`
+	ConfirmedAt  *time.Time         `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	CompletedAt  *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Expired reports whether the session's secret is no longer usable
+func (s *ProvisioningSession) Expired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// StartProvisioningRequest opens a provisioning session
+type StartProvisioningRequest struct {
+	DeviceType   DeviceType `json:"device_type"`
+	Manufacturer string     `json:"manufacturer"`
+	Model        string     `json:"model"`
+}
+
+// ConfirmProvisioningRequest relays the device's BLE confirmation
+type ConfirmProvisioningRequest struct {
+	Secret       string  `json:"secret"`
+	MacAddress   string  `json:"mac_address"`
+	SerialNumber *string `json:"serial_number,omitempty"`
+}
+
+// CompleteProvisioningRequest finalizes pairing for a confirmed session
+type CompleteProvisioningRequest struct {
+	Capabilities []string `json:"capabilities"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RawDeviceEvent is a verbatim copy of an inbound MQTT event payload,
+// retained for the liability audit trail
+type RawDeviceEvent struct {
+	ID          string    `json:"id" db:"id"`
+	Topic       string    `json:"topic" db:"topic"`
+	Payload     []byte    `json:"payload" db:"payload"`
+	PayloadHash string    `json:"payload_hash" db:"payload_hash"`
+	ReceivedAt  time.Time `json:"received_at" db:"received_at"`
+}
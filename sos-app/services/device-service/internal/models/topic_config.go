@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Subscription tiers used for topic autoconfiguration. The gateway forwards
+// the user's tier alongside their identity; unknown or missing tiers get the
+// free defaults
+const (
+	SubscriptionTierFree    = "FREE"
+	SubscriptionTierPremium = "PREMIUM"
+)
+
+// Capability names devices declare at pairing, used to decide which topics
+// the device is told to publish. Siren/strobe live in device_command.go
+const (
+	CapabilityHeartRate     = "heart_rate"
+	CapabilitySpO2          = "spo2"
+	CapabilityTemperature   = "temperature"
+	CapabilityBloodPressure = "blood_pressure"
+	CapabilityGPS           = "gps"
+	CapabilityFallDetection = "fall_detection"
+	CapabilitySOSButton     = "sos_button"
+	CapabilityGeofence      = "geofence"
+)
+
+// Location reporting cadence per subscription tier, in seconds
+const (
+	locationIntervalFree    = 60
+	locationIntervalPremium = 15
+)
+
+// HasCapability reports whether the device declared a capability at pairing
+func (d *Device) HasCapability(capability string) bool {
+	for _, c := range d.Capabilities {
+		if strings.EqualFold(c, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// TopicConfig is the retained per-device configuration message that tells a
+// device exactly which topics to publish and at what cadence, derived from
+// its declared capabilities and the owner's subscription tier
+type TopicConfig struct {
+	Type                    string    `json:"type"` // "topic:config"
+	VitalsEnabled           bool      `json:"vitals_enabled"`
+	VitalsTopic             string    `json:"vitals_topic,omitempty"`
+	LocationEnabled         bool      `json:"location_enabled"`
+	LocationTopic           string    `json:"location_topic,omitempty"`
+	LocationIntervalSeconds int       `json:"location_interval_seconds,omitempty"`
+	EventTypes              []string  `json:"event_types"`
+	EventsTopic             string    `json:"events_topic,omitempty"`
+	GeneratedAt             time.Time `json:"generated_at"`
+}
+
+// BuildTopicConfig derives the topic configuration for a device. Vitals are
+// enabled when the device has any vitals capability; location cadence
+// tightens with the owner's subscription tier; event types are the subset
+// the device can actually detect
+func BuildTopicConfig(device *Device, tier string) TopicConfig {
+	config := TopicConfig{
+		Type:        "topic:config",
+		EventTypes:  []string{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	hasVitals := device.HasCapability(CapabilityHeartRate) ||
+		device.HasCapability(CapabilitySpO2) ||
+		device.HasCapability(CapabilityTemperature) ||
+		device.HasCapability(CapabilityBloodPressure)
+	if hasVitals {
+		config.VitalsEnabled = true
+		config.VitalsTopic = "devices/" + device.ID + "/telemetry"
+	}
+
+	if device.HasCapability(CapabilityGPS) {
+		config.LocationEnabled = true
+		config.LocationTopic = "devices/" + device.ID + "/telemetry"
+		if tier == SubscriptionTierPremium {
+			config.LocationIntervalSeconds = locationIntervalPremium
+		} else {
+			config.LocationIntervalSeconds = locationIntervalFree
+		}
+	}
+
+	if device.HasCapability(CapabilityFallDetection) {
+		config.EventTypes = append(config.EventTypes, EventTypeFallDetected)
+	}
+	if device.HasCapability(CapabilitySOSButton) {
+		config.EventTypes = append(config.EventTypes, EventTypeSOSButtonPressed)
+	}
+	if device.HasCapability(CapabilityGeofence) {
+		config.EventTypes = append(config.EventTypes, EventTypeGeofenceExit)
+	}
+	if len(config.EventTypes) > 0 {
+		config.EventsTopic = "devices/" + device.ID + "/events"
+	}
+
+	return config
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTopicConfig_VitalsAndEventsFromCapabilities(t *testing.T) {
+	device := &Device{
+		ID:           "device-123",
+		Capabilities: []string{"heart_rate", "gps", "fall_detection", "sos_button"},
+	}
+
+	config := BuildTopicConfig(device, SubscriptionTierFree)
+
+	assert.Equal(t, "topic:config", config.Type)
+	assert.True(t, config.VitalsEnabled)
+	assert.Equal(t, "devices/device-123/telemetry", config.VitalsTopic)
+	assert.True(t, config.LocationEnabled)
+	assert.Equal(t, []string{EventTypeFallDetected, EventTypeSOSButtonPressed}, config.EventTypes)
+	assert.Equal(t, "devices/device-123/events", config.EventsTopic)
+}
+
+func TestBuildTopicConfig_TierControlsLocationInterval(t *testing.T) {
+	device := &Device{ID: "device-123", Capabilities: []string{"gps"}}
+
+	free := BuildTopicConfig(device, SubscriptionTierFree)
+	premium := BuildTopicConfig(device, SubscriptionTierPremium)
+
+	assert.Greater(t, free.LocationIntervalSeconds, premium.LocationIntervalSeconds)
+}
+
+func TestBuildTopicConfig_PanicButtonPublishesNothingContinuous(t *testing.T) {
+	device := &Device{ID: "device-123", Capabilities: []string{"sos_button"}}
+
+	config := BuildTopicConfig(device, SubscriptionTierPremium)
+
+	assert.False(t, config.VitalsEnabled)
+	assert.Empty(t, config.VitalsTopic)
+	assert.False(t, config.LocationEnabled)
+	assert.Zero(t, config.LocationIntervalSeconds)
+	assert.Equal(t, []string{EventTypeSOSButtonPressed}, config.EventTypes)
+}
+
+func TestHasCapability_CaseInsensitive(t *testing.T) {
+	device := &Device{Capabilities: []string{"Heart_Rate"}}
+
+	assert.True(t, device.HasCapability(CapabilityHeartRate))
+	assert.False(t, device.HasCapability(CapabilityGPS))
+}
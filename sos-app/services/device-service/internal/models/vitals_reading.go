@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// VitalsReading is one persisted vital signs sample from a device
+type VitalsReading struct {
+	ID          string    `json:"id" db:"id"`
+	DeviceID    string    `json:"device_id" db:"device_id"`
+	UserID      string    `json:"user_id" db:"user_id"`
+	HeartRate   *int      `json:"heart_rate,omitempty" db:"heart_rate"`
+	SpO2        *int      `json:"spo2,omitempty" db:"spo2"`
+	Temperature *float64  `json:"temperature,omitempty" db:"temperature"`
+	Systolic    *int      `json:"systolic,omitempty" db:"systolic"`
+	Diastolic   *int      `json:"diastolic,omitempty" db:"diastolic"`
+	EmergencyID *string   `json:"emergency_id,omitempty" db:"emergency_id"` // Set while the user has an active emergency
+	RecordedAt  time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+// VitalsSeriesBucket is one downsampled time bucket of vitals readings for
+// clinician-facing charts; aggregates are nil when no device reported the
+// metric inside the bucket
+type VitalsSeriesBucket struct {
+	BucketStart    time.Time `json:"bucket_start"`
+	HeartRateAvg   *float64  `json:"heart_rate_avg,omitempty"`
+	HeartRateMin   *float64  `json:"heart_rate_min,omitempty"`
+	HeartRateMax   *float64  `json:"heart_rate_max,omitempty"`
+	SpO2Avg        *float64  `json:"spo2_avg,omitempty"`
+	SpO2Min        *float64  `json:"spo2_min,omitempty"`
+	SpO2Max        *float64  `json:"spo2_max,omitempty"`
+	TemperatureAvg *float64  `json:"temperature_avg,omitempty"`
+	TemperatureMin *float64  `json:"temperature_min,omitempty"`
+	TemperatureMax *float64  `json:"temperature_max,omitempty"`
+}
+
+// SamplingPolicy controls vitals storage for one device type: outside an
+// active emergency only every Nth reading is stored; during an emergency
+// every reading is stored regardless
+type SamplingPolicy struct {
+	DeviceType  DeviceType `json:"device_type" db:"device_type"`
+	SampleEvery int        `json:"sample_every" db:"sample_every"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SetSamplingPolicyRequest represents the request to set a sampling policy
+type SetSamplingPolicyRequest struct {
+	SampleEvery int `json:"sample_every"`
+}
@@ -3,16 +3,32 @@ package mqtt
 import (
 	"crypto/tls"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/rs/zerolog"
 )
 
+// subscription remembers a topic's QoS and handler so it can be replayed
+// after a forced reconnect
+type subscription struct {
+	qos     byte
+	handler MessageHandler
+}
+
 // Client represents an MQTT client for IoT device communication
 type Client struct {
 	client mqtt.Client
 	logger zerolog.Logger
+
+	// lastMessage is the unix-nano receive time of the most recent inbound
+	// message, read by the connection watchdog
+	lastMessage int64
+
+	mu   sync.Mutex
+	subs map[string]subscription
 }
 
 // Config holds MQTT client configuration
@@ -78,6 +94,7 @@ func NewClient(config Config, logger zerolog.Logger) (*Client, error) {
 	return &Client{
 		client: client,
 		logger: logger,
+		subs:   make(map[string]subscription),
 	}, nil
 }
 
@@ -91,9 +108,21 @@ func (c *Client) Connect() error {
 	}
 
 	c.logger.Info().Msg("Successfully connected to MQTT broker")
+	c.markActivity()
 	return nil
 }
 
+// markActivity records that traffic arrived from the broker
+func (c *Client) markActivity() {
+	atomic.StoreInt64(&c.lastMessage, time.Now().UnixNano())
+}
+
+// LastMessageAt returns when the most recent inbound message (or the
+// connection itself) was seen
+func (c *Client) LastMessageAt() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.lastMessage))
+}
+
 // Disconnect closes the connection to MQTT broker
 func (c *Client) Disconnect() {
 	c.logger.Info().Msg("Disconnecting from MQTT broker...")
@@ -101,11 +130,30 @@ func (c *Client) Disconnect() {
 	c.logger.Info().Msg("Disconnected from MQTT broker")
 }
 
-// Subscribe subscribes to a topic with a message handler
+// Subscribe subscribes to a topic with a message handler. The subscription
+// is remembered so it can be replayed after a forced reconnect
 func (c *Client) Subscribe(topic string, qos byte, handler MessageHandler) error {
 	c.logger.Info().Str("topic", topic).Msg("Subscribing to MQTT topic")
 
-	callback := func(client mqtt.Client, msg mqtt.Message) {
+	token := c.client.Subscribe(topic, qos, c.callbackFor(handler))
+	if token.Wait() && token.Error() != nil {
+		return fmt.Errorf("failed to subscribe to topic %s: %w", topic, token.Error())
+	}
+
+	c.mu.Lock()
+	c.subs[topic] = subscription{qos: qos, handler: handler}
+	c.mu.Unlock()
+
+	c.logger.Info().Str("topic", topic).Msg("Successfully subscribed to MQTT topic")
+	return nil
+}
+
+// callbackFor adapts a MessageHandler to the paho callback signature,
+// recording inbound activity for the watchdog
+func (c *Client) callbackFor(handler MessageHandler) mqtt.MessageHandler {
+	return func(client mqtt.Client, msg mqtt.Message) {
+		c.markActivity()
+
 		c.logger.Debug().
 			Str("topic", msg.Topic()).
 			Bytes("payload", msg.Payload()).
@@ -118,13 +166,34 @@ func (c *Client) Subscribe(topic string, qos byte, handler MessageHandler) error
 				Msg("Error handling MQTT message")
 		}
 	}
+}
 
-	token := c.client.Subscribe(topic, qos, callback)
-	if token.Wait() && token.Error() != nil {
-		return fmt.Errorf("failed to subscribe to topic %s: %w", topic, token.Error())
+// ForceReconnect tears the broker connection down, reconnects and replays
+// every remembered subscription. Used by the watchdog when the connection
+// looks half-open: reported as connected but delivering nothing
+func (c *Client) ForceReconnect() error {
+	c.logger.Warn().Msg("Forcing MQTT reconnect")
+
+	c.client.Disconnect(250)
+	if err := c.Connect(); err != nil {
+		return err
 	}
 
-	c.logger.Info().Str("topic", topic).Msg("Successfully subscribed to MQTT topic")
+	c.mu.Lock()
+	subs := make(map[string]subscription, len(c.subs))
+	for topic, sub := range c.subs {
+		subs[topic] = sub
+	}
+	c.mu.Unlock()
+
+	for topic, sub := range subs {
+		token := c.client.Subscribe(topic, sub.qos, c.callbackFor(sub.handler))
+		if token.Wait() && token.Error() != nil {
+			return fmt.Errorf("failed to resubscribe to topic %s: %w", topic, token.Error())
+		}
+	}
+
+	c.logger.Info().Int("topics", len(subs)).Msg("MQTT reconnected and resubscribed")
 	return nil
 }
 
@@ -137,6 +206,10 @@ func (c *Client) Unsubscribe(topic string) error {
 		return fmt.Errorf("failed to unsubscribe from topic %s: %w", topic, token.Error())
 	}
 
+	c.mu.Lock()
+	delete(c.subs, topic)
+	c.mu.Unlock()
+
 	c.logger.Info().Str("topic", topic).Msg("Successfully unsubscribed from MQTT topic")
 	return nil
 }
@@ -166,6 +239,16 @@ func (c *Client) SubscribeToDeviceTelemetry(handler MessageHandler) error {
 	return c.Subscribe("devices/+/telemetry", 1, handler)
 }
 
+// SubscribeToSettingsRequests subscribes to settings sync requests from all devices
+func (c *Client) SubscribeToSettingsRequests(handler MessageHandler) error {
+	return c.Subscribe("devices/+/settings-request", 1, handler)
+}
+
+// SubscribeToCommandAcks subscribes to command acknowledgments from all devices
+func (c *Client) SubscribeToCommandAcks(handler MessageHandler) error {
+	return c.Subscribe("devices/+/command-acks", 1, handler)
+}
+
 // SubscribeToDeviceEvents subscribes to events from all devices
 func (c *Client) SubscribeToDeviceEvents(handler MessageHandler) error {
 	return c.Subscribe("devices/+/events", 1, handler)
@@ -206,3 +289,17 @@ func (c *Client) PublishCommand(deviceID string, command []byte) error {
 	topic := fmt.Sprintf("devices/%s/commands", deviceID)
 	return c.Publish(topic, 1, false, command)
 }
+
+// PublishDeviceConfig publishes a device's topic configuration. The message
+// is retained so the device picks it up on every (re)connect
+func (c *Client) PublishDeviceConfig(deviceID string, config []byte) error {
+	topic := fmt.Sprintf("devices/%s/config", deviceID)
+	return c.Publish(topic, 1, true, config)
+}
+
+// ClearDeviceConfig removes the retained topic configuration for a device,
+// e.g. after unpairing
+func (c *Client) ClearDeviceConfig(deviceID string) error {
+	topic := fmt.Sprintf("devices/%s/config", deviceID)
+	return c.Publish(topic, 1, true, nil)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// CommandAckHandler records devices acknowledging execution of remote
+// commands (e.g. the locate siren/strobe or a key rotation)
+type CommandAckHandler struct {
+	commandRepo       *repository.DeviceCommandRepository
+	encryptionKeyRepo *repository.DeviceEncryptionKeyRepository
+	logger            zerolog.Logger
+}
+
+// NewCommandAckHandler creates a new command ack handler. encryptionKeyRepo
+// may be nil to ignore rotated keys reported in acks
+func NewCommandAckHandler(
+	commandRepo *repository.DeviceCommandRepository,
+	encryptionKeyRepo *repository.DeviceEncryptionKeyRepository,
+	logger zerolog.Logger,
+) *CommandAckHandler {
+	return &CommandAckHandler{
+		commandRepo:       commandRepo,
+		encryptionKeyRepo: encryptionKeyRepo,
+		logger:            logger,
+	}
+}
+
+// commandAck is the payload devices publish on devices/{id}/command-acks.
+// A ROTATE_KEY ack carries the freshly generated public key
+type commandAck struct {
+	CommandID string `json:"command_id"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	PublicKey string `json:"public_key"`
+}
+
+// Handle processes command acknowledgments
+func (h *CommandAckHandler) Handle(topic string, payload []byte) error {
+	// Extract device ID from topic (devices/{deviceID}/command-acks)
+	parts := strings.Split(topic, "/")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid topic format: %s", topic)
+	}
+	deviceID := parts[1]
+
+	var ack commandAck
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		return fmt.Errorf("failed to parse command ack: %w", err)
+	}
+
+	if ack.CommandID == "" {
+		return fmt.Errorf("command ack missing command_id")
+	}
+
+	status := models.CommandStatusAcknowledged
+	var errMsg *string
+	if !strings.EqualFold(ack.Status, "ok") {
+		status = models.CommandStatusFailed
+		if ack.Error != "" {
+			errMsg = &ack.Error
+		}
+	}
+
+	if err := h.commandRepo.MarkAcked(context.Background(), deviceID, ack.CommandID, status, errMsg); err != nil {
+		return fmt.Errorf("failed to record command ack: %w", err)
+	}
+
+	// A successful key rotation reports the new public key; store it so
+	// the next encrypted payload opens against the rotated key
+	if h.encryptionKeyRepo != nil && status == models.CommandStatusAcknowledged && ack.PublicKey != "" {
+		if err := models.ValidateEncryptionPublicKey(ack.PublicKey); err != nil {
+			return fmt.Errorf("rotated public key from device %s rejected: %w", deviceID, err)
+		}
+
+		updated, err := h.encryptionKeyRepo.UpdatePublicKey(context.Background(), deviceID, ack.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to store rotated public key: %w", err)
+		}
+		if !updated {
+			return fmt.Errorf("device %s reported a rotated key but has none registered", deviceID)
+		}
+
+		h.logger.Info().
+			Str("device_id", deviceID).
+			Str("command_id", ack.CommandID).
+			Msg("Device payload encryption key rotated")
+	}
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Str("command_id", ack.CommandID).
+		Str("status", string(status)).
+		Msg("Device acknowledged command")
+
+	return nil
+}
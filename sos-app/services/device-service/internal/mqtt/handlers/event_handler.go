@@ -1,40 +1,47 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/emergencyapi"
 	"github.com/sos-app/device-service/internal/models"
 	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
 )
 
 // EventHandler handles device events like fall detection
 type EventHandler struct {
-	deviceRepo         *repository.DeviceRepository
-	emergencyServiceURL string
-	httpClient         *http.Client
-	logger             zerolog.Logger
+	deviceRepo      *repository.DeviceRepository
+	triggerQueue    *repository.TriggerQueueRepository
+	emergencyClient *emergencyapi.Client
+	deduplicator    *services.EventDeduplicator
+	clockSkew       *services.ClockSkewMonitor
+	logger          zerolog.Logger
 }
 
-// NewEventHandler creates a new event handler
+// NewEventHandler creates a new event handler. clockSkew may be nil to
+// disable timestamp correction
 func NewEventHandler(
 	deviceRepo *repository.DeviceRepository,
+	triggerQueue *repository.TriggerQueueRepository,
 	emergencyServiceURL string,
+	deduplicator *services.EventDeduplicator,
+	clockSkew *services.ClockSkewMonitor,
 	logger zerolog.Logger,
 ) *EventHandler {
 	return &EventHandler{
-		deviceRepo:         deviceRepo,
-		emergencyServiceURL: emergencyServiceURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		logger: logger,
+		deviceRepo:      deviceRepo,
+		triggerQueue:    triggerQueue,
+		emergencyClient: emergencyapi.NewClient(emergencyServiceURL),
+		deduplicator:    deduplicator,
+		clockSkew:       clockSkew,
+		logger:          logger,
 	}
 }
 
@@ -80,6 +87,8 @@ func (h *EventHandler) Handle(topic string, payload []byte) error {
 		return h.handleSOSButton(ctx, event)
 	case models.EventTypeGeofenceExit:
 		return h.handleGeofenceExit(ctx, event)
+	case models.EventTypeCancelRequested:
+		return h.handleCancelRequested(ctx, event)
 	default:
 		h.logger.Warn().
 			Str("device_id", deviceID).
@@ -107,8 +116,19 @@ func (h *EventHandler) handleFallDetection(ctx context.Context, event models.Dev
 		return err
 	}
 
+	h.normalizeEventTime(ctx, device, &event)
+
 	// Auto-trigger emergency if confidence > 0.8
 	if event.Confidence > 0.8 {
+		// Suppress repeated fall events from the same device within the window
+		if suppress, repeats := h.shouldSuppress(device, &event); suppress {
+			h.logger.Info().
+				Str("device_id", event.DeviceID).
+				Int("suppressed_repeats", repeats).
+				Msg("Duplicate fall event suppressed within dedupe window")
+			return nil
+		}
+
 		h.logger.Info().
 			Str("device_id", event.DeviceID).
 			Str("user_id", device.UserID).
@@ -152,6 +172,19 @@ func (h *EventHandler) handleSOSButton(ctx context.Context, event models.DeviceE
 		return err
 	}
 
+	h.normalizeEventTime(ctx, device, &event)
+
+	// A panicking user mashing the button should result in a single trigger;
+	// repeated presses within the window are logged as metadata instead
+	if suppress, repeats := h.shouldSuppress(device, &event); suppress {
+		h.logger.Info().
+			Str("device_id", event.DeviceID).
+			Str("user_id", device.UserID).
+			Int("suppressed_repeats", repeats).
+			Msg("Duplicate SOS press suppressed within dedupe window")
+		return nil
+	}
+
 	// Immediately trigger emergency
 	h.logger.Info().
 		Str("device_id", event.DeviceID).
@@ -182,48 +215,150 @@ func (h *EventHandler) handleGeofenceExit(ctx context.Context, event models.Devi
 	return nil
 }
 
+// handleCancelRequested processes a cancel gesture from the wearable (button
+// double-press): the user wants to call off the countdown they just started.
+// The emergency-service only honours the cancel while the emergency is still
+// pending and was triggered by this same device
+func (h *EventHandler) handleCancelRequested(ctx context.Context, event models.DeviceEvent) error {
+	h.logger.Info().
+		Str("device_id", event.DeviceID).
+		Msg("Cancel requested event received")
+
+	// Get device to retrieve user ID
+	device, err := h.deviceRepo.GetByID(ctx, event.DeviceID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", event.DeviceID).
+			Msg("Failed to get device for cancel request")
+		return err
+	}
+
+	// Unlike triggers, a failed cancel is never queued for retry: replaying
+	// a stale cancel minutes later could silence a newer, real emergency
+	err = h.emergencyClient.CancelByDevice(ctx, device.ID, device.UserID)
+	switch {
+	case err == nil:
+		h.logger.Info().
+			Str("device_id", device.ID).
+			Str("user_id", device.UserID).
+			Msg("Pending emergency cancelled from device")
+		return nil
+	case errors.Is(err, emergencyapi.ErrNoPendingEmergency):
+		h.logger.Info().
+			Str("device_id", device.ID).
+			Str("user_id", device.UserID).
+			Msg("Cancel request ignored: no pending emergency")
+		return nil
+	case errors.Is(err, emergencyapi.ErrNotTriggeringDevice):
+		h.logger.Warn().
+			Str("device_id", device.ID).
+			Str("user_id", device.UserID).
+			Msg("Cancel request refused: emergency was triggered by another device")
+		return nil
+	default:
+		h.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Msg("Failed to cancel emergency from device")
+		return err
+	}
+}
+
+// normalizeEventTime clamps a skewed event timestamp to receipt time before
+// the event is forwarded to emergency-service, annotating the event data
+// with the original value and measured drift
+func (h *EventHandler) normalizeEventTime(ctx context.Context, device *models.Device, event *models.DeviceEvent) {
+	check := h.clockSkew.Check(ctx, device, event.Timestamp)
+	event.Data = check.Annotate(event.Data, event.Timestamp)
+	event.Timestamp = check.Timestamp
+}
+
+// shouldSuppress checks the dedupe window for this device and event type,
+// attaching the count of suppressed repeats to the event when it is forwarded
+func (h *EventHandler) shouldSuppress(device *models.Device, event *models.DeviceEvent) (bool, int) {
+	if h.deduplicator == nil {
+		return false, 0
+	}
+
+	suppress, repeats := h.deduplicator.ShouldSuppress(event.DeviceID, event.EventType, device.DeviceType)
+	if !suppress && repeats > 0 {
+		if event.Data == nil {
+			event.Data = make(map[string]interface{})
+		}
+		event.Data["repeat_presses"] = repeats
+	}
+
+	return suppress, repeats
+}
+
 // triggerEmergency calls the Emergency Service to auto-trigger an emergency
 func (h *EventHandler) triggerEmergency(ctx context.Context, device *models.Device, event models.DeviceEvent, reason string) error {
 	// Prepare request payload
-	payload := map[string]interface{}{
-		"user_id":     device.UserID,
-		"device_id":   device.ID,
-		"event_type":  event.EventType,
-		"reason":      reason,
-		"timestamp":   event.Timestamp,
-		"confidence":  event.Confidence,
-		"event_data":  event.Data,
+	confidence := event.Confidence
+	trigger := &emergencyapi.AutoTriggerRequest{
+		UserID:     device.UserID,
+		DeviceID:   device.ID,
+		EventType:  event.EventType,
+		Reason:     reason,
+		Timestamp:  event.Timestamp,
+		Confidence: &confidence,
+		EventData:  event.Data,
 	}
 
-	payloadBytes, err := json.Marshal(payload)
+	// The retry queue stores the marshaled payload so redelivery sends the
+	// exact original request
+	payloadBytes, err := json.Marshal(trigger)
 	if err != nil {
 		return fmt.Errorf("failed to marshal emergency payload: %w", err)
 	}
 
-	// Call Emergency Service auto-trigger endpoint
-	url := fmt.Sprintf("%s/api/v1/emergency/auto-trigger", h.emergencyServiceURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create emergency request: %w", err)
+	if err := h.emergencyClient.Deliver(ctx, payloadBytes); err != nil {
+		return h.queueForRetry(ctx, device, event, payloadBytes, err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	h.logger.Info().
+		Str("device_id", device.ID).
+		Str("user_id", device.UserID).
+		Str("reason", reason).
+		Msg("Emergency triggered successfully")
 
-	resp, err := h.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call emergency service: %w", err)
+	return nil
+}
+
+// queueForRetry durably stores a trigger that could not be delivered so the
+// retry worker can re-attempt it. The SOS is only lost if the queue itself
+// is unavailable, which is logged as loudly as possible.
+func (h *EventHandler) queueForRetry(ctx context.Context, device *models.Device, event models.DeviceEvent, payload []byte, cause error) error {
+	if h.triggerQueue == nil {
+		return cause
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("emergency service returned status %d", resp.StatusCode)
+	errMsg := cause.Error()
+	trigger := &models.PendingEmergencyTrigger{
+		DeviceID:      device.ID,
+		UserID:        device.UserID,
+		EventType:     event.EventType,
+		Payload:       payload,
+		LastError:     &errMsg,
+		NextAttemptAt: time.Now(),
 	}
 
-	h.logger.Info().
+	if err := h.triggerQueue.Enqueue(ctx, trigger); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Str("user_id", device.UserID).
+			Msg("CRITICAL: failed to queue emergency trigger - SOS may be lost")
+		return cause
+	}
+
+	h.logger.Warn().
+		Str("trigger_id", trigger.ID).
 		Str("device_id", device.ID).
 		Str("user_id", device.UserID).
-		Str("reason", reason).
-		Msg("Emergency triggered successfully")
+		Str("cause", errMsg).
+		Msg("ALARM: emergency service unreachable, trigger queued for retry")
 
 	return nil
 }
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
+)
+
+// SettingsRequestHandler answers devices asking for a full settings sync,
+// e.g. after missing delta patches or a factory reset
+type SettingsRequestHandler struct {
+	deviceRepo     *repository.DeviceRepository
+	settingsCipher *services.SettingsCipher
+	mqttClient     *mqtt.Client
+	logger         zerolog.Logger
+}
+
+// NewSettingsRequestHandler creates a new settings request handler.
+// settingsCipher may be nil when settings encryption is disabled
+func NewSettingsRequestHandler(
+	deviceRepo *repository.DeviceRepository,
+	settingsCipher *services.SettingsCipher,
+	mqttClient *mqtt.Client,
+	logger zerolog.Logger,
+) *SettingsRequestHandler {
+	return &SettingsRequestHandler{
+		deviceRepo:     deviceRepo,
+		settingsCipher: settingsCipher,
+		mqttClient:     mqttClient,
+		logger:         logger,
+	}
+}
+
+// Handle processes settings sync requests
+func (h *SettingsRequestHandler) Handle(topic string, payload []byte) error {
+	// Extract device ID from topic (devices/{deviceID}/settings-request)
+	parts := strings.Split(topic, "/")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid topic format: %s", topic)
+	}
+	deviceID := parts[1]
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Msg("Device requested full settings sync")
+
+	device, err := h.deviceRepo.GetByID(context.Background(), deviceID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", deviceID).
+			Msg("Failed to load device for settings sync")
+		return fmt.Errorf("failed to load device: %w", err)
+	}
+
+	// The device applies settings locally, so envelopes are opened here
+	settings, err := h.settingsCipher.DecryptSettings(device.Settings)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("device_id", deviceID).
+			Msg("Failed to decrypt settings for sync")
+		return fmt.Errorf("failed to decrypt settings: %w", err)
+	}
+
+	response := map[string]interface{}{
+		"type":             "settings:full",
+		"settings_version": device.SettingsVersion,
+		"settings":         settings,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings sync: %w", err)
+	}
+
+	if err := h.mqttClient.PublishCommand(deviceID, responseJSON); err != nil {
+		return fmt.Errorf("failed to publish settings sync: %w", err)
+	}
+
+	h.logger.Info().
+		Str("device_id", deviceID).
+		Int("settings_version", device.SettingsVersion).
+		Msg("Published full settings sync to device")
+
+	return nil
+}
@@ -1,39 +1,36 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/emergencyapi"
 	"github.com/sos-app/device-service/internal/models"
 	"github.com/sos-app/device-service/internal/repository"
+	"github.com/sos-app/device-service/internal/services"
 )
 
 // SOSHandler handles SOS button press events specifically
 type SOSHandler struct {
-	deviceRepo         *repository.DeviceRepository
-	emergencyServiceURL string
-	httpClient         *http.Client
-	logger             zerolog.Logger
+	deviceRepo      *repository.DeviceRepository
+	emergencyClient *emergencyapi.Client
+	deduplicator    *services.EventDeduplicator
+	logger          zerolog.Logger
 }
 
 // NewSOSHandler creates a new SOS handler
 func NewSOSHandler(
 	deviceRepo *repository.DeviceRepository,
 	emergencyServiceURL string,
+	deduplicator *services.EventDeduplicator,
 	logger zerolog.Logger,
 ) *SOSHandler {
 	return &SOSHandler{
-		deviceRepo:         deviceRepo,
-		emergencyServiceURL: emergencyServiceURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		logger: logger,
+		deviceRepo:      deviceRepo,
+		emergencyClient: emergencyapi.NewClient(emergencyServiceURL),
+		deduplicator:    deduplicator,
+		logger:          logger,
 	}
 }
 
@@ -54,6 +51,25 @@ func (h *SOSHandler) HandleSOSButtonPress(ctx context.Context, event models.Devi
 		return fmt.Errorf("failed to get device: %w", err)
 	}
 
+	// A panicking user mashing the button should result in a single trigger
+	if h.deduplicator != nil {
+		suppress, repeats := h.deduplicator.ShouldSuppress(event.DeviceID, event.EventType, device.DeviceType)
+		if suppress {
+			h.logger.Info().
+				Str("device_id", event.DeviceID).
+				Str("user_id", device.UserID).
+				Int("suppressed_repeats", repeats).
+				Msg("Duplicate SOS press suppressed within dedupe window")
+			return nil
+		}
+		if repeats > 0 {
+			if event.Data == nil {
+				event.Data = make(map[string]interface{})
+			}
+			event.Data["repeat_presses"] = repeats
+		}
+	}
+
 	// Log critical information
 	h.logger.Warn().
 		Str("device_id", event.DeviceID).
@@ -84,73 +100,40 @@ func (h *SOSHandler) HandleSOSButtonPress(ctx context.Context, event models.Devi
 // triggerEmergencyImmediately calls the Emergency Service to immediately trigger an emergency
 func (h *SOSHandler) triggerEmergencyImmediately(ctx context.Context, device *models.Device, event models.DeviceEvent) error {
 	// Prepare emergency request payload
-	payload := map[string]interface{}{
-		"user_id":     device.UserID,
-		"device_id":   device.ID,
-		"event_type":  models.EventTypeSOSButtonPressed,
-		"reason":      "SOS button pressed - immediate emergency",
-		"priority":    "HIGH",
-		"timestamp":   event.Timestamp,
-		"device_info": map[string]interface{}{
-			"type":         device.DeviceType,
-			"manufacturer": device.Manufacturer,
-			"model":        device.Model,
-			"mac_address":  device.MacAddress,
-			"battery":      device.BatteryLevel,
+	trigger := &emergencyapi.AutoTriggerRequest{
+		UserID:    device.UserID,
+		DeviceID:  device.ID,
+		EventType: models.EventTypeSOSButtonPressed,
+		Reason:    "SOS button pressed - immediate emergency",
+		Priority:  "HIGH",
+		Timestamp: event.Timestamp,
+		DeviceInfo: &emergencyapi.DeviceInfo{
+			Type:         string(device.DeviceType),
+			Manufacturer: device.Manufacturer,
+			Model:        device.Model,
+			MacAddress:   device.MacAddress,
+			Battery:      device.BatteryLevel,
 		},
-		"event_data": event.Data,
+		EventData: event.Data,
 	}
 
 	// Add location if available
 	if location, ok := event.Data["location"].(map[string]interface{}); ok {
-		payload["location"] = location
+		trigger.Location = location
 	}
 
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal emergency payload: %w", err)
-	}
-
-	// Call Emergency Service auto-trigger endpoint
-	url := fmt.Sprintf("%s/api/v1/emergency/auto-trigger", h.emergencyServiceURL)
-
 	h.logger.Info().
-		Str("url", url).
 		Str("device_id", device.ID).
 		Msg("Calling emergency service auto-trigger endpoint")
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create emergency request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Device-ID", device.ID)
-	req.Header.Set("X-User-ID", device.UserID)
-
-	resp, err := h.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call emergency service: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		// Read response body for error details
-		var errorResponse map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err == nil {
-			h.logger.Error().
-				Int("status_code", resp.StatusCode).
-				Interface("error", errorResponse).
-				Msg("Emergency service returned error response")
-		}
-		return fmt.Errorf("emergency service returned status %d", resp.StatusCode)
+	if err := h.emergencyClient.AutoTrigger(ctx, trigger); err != nil {
+		return err
 	}
 
 	// Log successful trigger
 	h.logger.Info().
 		Str("device_id", device.ID).
 		Str("user_id", device.UserID).
-		Int("status_code", resp.StatusCode).
 		Msg("CRITICAL: Emergency triggered successfully via Emergency Service")
 
 	return nil
@@ -17,24 +17,73 @@ type TelemetryHandler struct {
 	deviceRepo      *repository.DeviceRepository
 	vitalsService   *services.VitalsService
 	batteryMonitor  *services.BatteryMonitor
+	batteryRecorder *services.BatteryHistoryRecorder
+	dispatcher      *services.WebhookDispatcher
+	vitalsWriter    *services.VitalsWriter
+	sampler         *services.TelemetrySampler
+	forwarder       *services.EmergencyTelemetryForwarder
+	clockSkew       *services.ClockSkewMonitor
+	deviceCache     *services.DeviceCache
+	firmwareCompat  *services.FirmwareCompatibilityChecker
 	logger          zerolog.Logger
 }
 
-// NewTelemetryHandler creates a new telemetry handler
+// NewTelemetryHandler creates a new telemetry handler. dispatcher may be nil
+// to disable connectivity webhooks; vitalsWriter and sampler may be nil to
+// disable vitals persistence; batteryRecorder may be nil to disable battery
+// history; forwarder may be nil to disable emergency forwarding; clockSkew
+// may be nil to disable timestamp correction; deviceCache may be nil to
+// look devices up in the database on every message; firmwareCompat may be
+// nil to skip firmware compatibility warnings
 func NewTelemetryHandler(
 	deviceRepo *repository.DeviceRepository,
 	vitalsService *services.VitalsService,
 	batteryMonitor *services.BatteryMonitor,
+	batteryRecorder *services.BatteryHistoryRecorder,
+	dispatcher *services.WebhookDispatcher,
+	vitalsWriter *services.VitalsWriter,
+	sampler *services.TelemetrySampler,
+	forwarder *services.EmergencyTelemetryForwarder,
+	clockSkew *services.ClockSkewMonitor,
+	deviceCache *services.DeviceCache,
+	firmwareCompat *services.FirmwareCompatibilityChecker,
 	logger zerolog.Logger,
 ) *TelemetryHandler {
 	return &TelemetryHandler{
-		deviceRepo:     deviceRepo,
-		vitalsService:  vitalsService,
-		batteryMonitor: batteryMonitor,
-		logger:         logger,
+		deviceRepo:      deviceRepo,
+		vitalsService:   vitalsService,
+		batteryMonitor:  batteryMonitor,
+		batteryRecorder: batteryRecorder,
+		dispatcher:      dispatcher,
+		vitalsWriter:    vitalsWriter,
+		sampler:         sampler,
+		forwarder:       forwarder,
+		clockSkew:       clockSkew,
+		deviceCache:     deviceCache,
+		firmwareCompat:  firmwareCompat,
+		logger:          logger,
 	}
 }
 
+// getDevice resolves a device, preferring the warm cache over the database
+func (h *TelemetryHandler) getDevice(ctx context.Context, deviceID string) (*models.Device, error) {
+	if h.deviceCache != nil {
+		if device, ok := h.deviceCache.Get(deviceID); ok {
+			return device, nil
+		}
+	}
+
+	device, err := h.deviceRepo.GetByID(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.deviceCache != nil {
+		h.deviceCache.Put(device)
+	}
+	return device, nil
+}
+
 // Handle processes telemetry messages
 func (h *TelemetryHandler) Handle(topic string, payload []byte) error {
 	// Extract device ID from topic (devices/{deviceID}/telemetry)
@@ -90,7 +139,7 @@ func (h *TelemetryHandler) Handle(topic string, payload []byte) error {
 	}
 
 	// Process connectivity status - mark device as ACTIVE
-	device, err := h.deviceRepo.GetByID(ctx, deviceID)
+	device, err := h.getDevice(ctx, deviceID)
 	if err != nil {
 		h.logger.Error().
 			Err(err).
@@ -99,6 +148,24 @@ func (h *TelemetryHandler) Handle(topic string, payload []byte) error {
 		return err
 	}
 
+	// A device with a wrong clock sends future or ancient timestamps; clamp
+	// them to receipt time and annotate so telemetry ordering stays sane
+	check := h.clockSkew.Check(ctx, device, telemetry.Timestamp)
+	telemetry.Metadata = check.Annotate(telemetry.Metadata, telemetry.Timestamp)
+	telemetry.Timestamp = check.Timestamp
+
+	// Devices report their running firmware in telemetry metadata; check
+	// it against the compatibility matrix so known issues reach the owner
+	if version, ok := telemetry.Metadata["firmware_version"].(string); ok {
+		h.firmwareCompat.CheckAndWarn(ctx, device, version)
+	}
+
+	// During an active emergency the location portion rides along to
+	// location-service so the trail keeps updating from the device itself
+	if telemetry.Location != nil {
+		h.forwarder.ForwardLocation(ctx, device, telemetry.Location, telemetry.BatteryLevel)
+	}
+
 	if device.Status == models.DeviceStatusDisconnected {
 		if err := h.deviceRepo.UpdateStatus(ctx, deviceID, models.DeviceStatusActive); err != nil {
 			h.logger.Error().
@@ -109,6 +176,16 @@ func (h *TelemetryHandler) Handle(topic string, payload []byte) error {
 			h.logger.Info().
 				Str("device_id", deviceID).
 				Msg("Device reconnected, status updated to ACTIVE")
+
+			device.Status = models.DeviceStatusActive
+			if h.deviceCache != nil {
+				h.deviceCache.Put(device)
+			}
+
+			// Notify registered NOC webhooks about the transition
+			if h.dispatcher != nil {
+				h.dispatcher.NotifyConnectivityChange(ctx, device, models.WebhookEventDeviceReconnected)
+			}
 		}
 	}
 
@@ -127,9 +204,14 @@ func (h *TelemetryHandler) processBatteryLevel(ctx context.Context, deviceID str
 		return err
 	}
 
+	// Keep the decimated history for battery health charts
+	if h.batteryRecorder != nil {
+		h.batteryRecorder.Record(ctx, deviceID, batteryLevel)
+	}
+
 	// Check for low battery and send alert
 	if batteryLevel <= 20 {
-		device, err := h.deviceRepo.GetByID(ctx, deviceID)
+		device, err := h.getDevice(ctx, deviceID)
 		if err != nil {
 			return err
 		}
@@ -148,7 +230,7 @@ func (h *TelemetryHandler) processBatteryLevel(ctx context.Context, deviceID str
 
 // processVitalSigns processes vital signs data
 func (h *TelemetryHandler) processVitalSigns(ctx context.Context, deviceID string, vitals *models.VitalSigns) error {
-	device, err := h.deviceRepo.GetByID(ctx, deviceID)
+	device, err := h.getDevice(ctx, deviceID)
 	if err != nil {
 		return err
 	}
@@ -162,5 +244,45 @@ func (h *TelemetryHandler) processVitalSigns(ctx context.Context, deviceID strin
 		return err
 	}
 
+	// Persist the reading subject to the sampling policy for this device
+	// type. Threshold monitoring above always sees every reading; only
+	// storage is sampled, and everything is kept during an active emergency
+	if h.vitalsWriter != nil && (h.sampler == nil || h.sampler.ShouldStore(device)) {
+		reading := readingFromVitals(device, vitals)
+		// Snapshots taken during an active emergency are tagged with it
+		h.forwarder.AttachEmergency(reading)
+		h.vitalsWriter.Enqueue(reading)
+	}
+
 	return nil
 }
+
+// readingFromVitals maps a telemetry vitals payload onto a stored reading,
+// keeping only the fields the device actually reported
+func readingFromVitals(device *models.Device, vitals *models.VitalSigns) *models.VitalsReading {
+	reading := &models.VitalsReading{
+		DeviceID: device.ID,
+		UserID:   device.UserID,
+	}
+
+	if vitals.HeartRate > 0 {
+		hr := vitals.HeartRate
+		reading.HeartRate = &hr
+	}
+	if vitals.SpO2 > 0 {
+		spo2 := vitals.SpO2
+		reading.SpO2 = &spo2
+	}
+	if vitals.Temperature > 0 {
+		temp := vitals.Temperature
+		reading.Temperature = &temp
+	}
+	if vitals.BloodPressure != nil {
+		systolic := vitals.BloodPressure.Systolic
+		diastolic := vitals.BloodPressure.Diastolic
+		reading.Systolic = &systolic
+		reading.Diastolic = &diastolic
+	}
+
+	return reading
+}
@@ -0,0 +1,216 @@
+package mqtt
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestBroker runs an in-process MQTT broker on a random loopback port
+// so the integration tests need no external infrastructure. Returns the
+// broker address; the broker is shut down when the test finishes
+func startTestBroker(t *testing.T) string {
+	t.Helper()
+
+	// Reserve a free loopback port for the broker listener
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := probe.Addr().String()
+	require.NoError(t, probe.Close())
+
+	server := mochi.New(&mochi.Options{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, server.AddHook(new(auth.AllowHook), nil))
+	require.NoError(t, server.AddListener(listeners.NewTCP(listeners.Config{ID: "test", Address: addr})))
+
+	go func() {
+		if err := server.Serve(); err != nil {
+			t.Errorf("broker serve failed: %v", err)
+		}
+	}()
+	t.Cleanup(func() { _ = server.Close() })
+
+	// Wait for the listener to accept connections
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			require.NoError(t, conn.Close())
+			return addr
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("broker did not start listening on %s: %v", addr, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestClient connects a Client to the test broker; it is disconnected
+// when the test finishes
+func newTestClient(t *testing.T, addr, clientID string) *Client {
+	t.Helper()
+
+	client, err := NewClient(Config{
+		BrokerURL:      "tcp://" + addr,
+		ClientID:       clientID,
+		CleanSession:   true,
+		AutoReconnect:  true,
+		ConnectTimeout: 5 * time.Second,
+		KeepAlive:      30 * time.Second,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	require.NoError(t, client.Connect())
+	t.Cleanup(client.Disconnect)
+
+	return client
+}
+
+// receivedMessage is one message captured by a channel-backed handler
+type receivedMessage struct {
+	topic   string
+	payload []byte
+}
+
+// channelHandler returns a MessageHandler that forwards every message to
+// the returned channel
+func channelHandler() (MessageHandler, chan receivedMessage) {
+	ch := make(chan receivedMessage, 16)
+	return func(topic string, payload []byte) error {
+		ch <- receivedMessage{topic: topic, payload: payload}
+		return nil
+	}, ch
+}
+
+// waitForMessage asserts that a message arrives on the channel
+func waitForMessage(t *testing.T, ch chan receivedMessage) receivedMessage {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for MQTT message")
+		return receivedMessage{}
+	}
+}
+
+// assertNoMessage asserts that nothing arrives on the channel for a short
+// settling period
+func assertNoMessage(t *testing.T, ch chan receivedMessage) {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		t.Fatalf("unexpected MQTT message on topic %s", msg.topic)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestIntegration_PublishSubscribeRoundTrip(t *testing.T) {
+	addr := startTestBroker(t)
+	subscriber := newTestClient(t, addr, "test-subscriber")
+	publisher := newTestClient(t, addr, "test-publisher")
+
+	handler, ch := channelHandler()
+	require.NoError(t, subscriber.Subscribe("integration/roundtrip", 1, handler))
+
+	require.NoError(t, publisher.Publish("integration/roundtrip", 1, false, []byte(`{"hello":"world"}`)))
+
+	msg := waitForMessage(t, ch)
+	assert.Equal(t, "integration/roundtrip", msg.topic)
+	assert.JSONEq(t, `{"hello":"world"}`, string(msg.payload))
+}
+
+func TestIntegration_HandlerRouting(t *testing.T) {
+	addr := startTestBroker(t)
+	service := newTestClient(t, addr, "test-service")
+	device := newTestClient(t, addr, "test-device")
+
+	telemetryHandler, telemetryCh := channelHandler()
+	eventsHandler, eventsCh := channelHandler()
+	require.NoError(t, service.SubscribeToDeviceTelemetry(telemetryHandler))
+	require.NoError(t, service.SubscribeToDeviceEvents(eventsHandler))
+
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(`{"battery_level":80}`)))
+	require.NoError(t, device.Publish("devices/device-2/events", 1, false, []byte(`{"event_type":"fall_detected"}`)))
+
+	telemetry := waitForMessage(t, telemetryCh)
+	assert.Equal(t, "devices/device-1/telemetry", telemetry.topic)
+
+	event := waitForMessage(t, eventsCh)
+	assert.Equal(t, "devices/device-2/events", event.topic)
+
+	// The wildcard subscriptions must not cross-deliver between handlers
+	assertNoMessage(t, telemetryCh)
+	assertNoMessage(t, eventsCh)
+}
+
+func TestIntegration_DeviceSubscriptionLifecycle(t *testing.T) {
+	addr := startTestBroker(t)
+	service := newTestClient(t, addr, "test-service")
+	device := newTestClient(t, addr, "test-device")
+
+	handler, ch := channelHandler()
+	require.NoError(t, service.SubscribeToSpecificDevice("device-1", handler))
+
+	// Both per-device topics are live after pairing
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(`{"battery_level":55}`)))
+	assert.Equal(t, "devices/device-1/telemetry", waitForMessage(t, ch).topic)
+
+	require.NoError(t, device.Publish("devices/device-1/events", 1, false, []byte(`{"event_type":"sos"}`)))
+	assert.Equal(t, "devices/device-1/events", waitForMessage(t, ch).topic)
+
+	// After unpairing nothing is delivered anymore
+	require.NoError(t, service.UnsubscribeFromDevice("device-1"))
+
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(`{"battery_level":54}`)))
+	require.NoError(t, device.Publish("devices/device-1/events", 1, false, []byte(`{"event_type":"sos"}`)))
+	assertNoMessage(t, ch)
+}
+
+func TestIntegration_ForceReconnectResubscribes(t *testing.T) {
+	addr := startTestBroker(t)
+	service := newTestClient(t, addr, "test-service")
+	device := newTestClient(t, addr, "test-device")
+
+	handler, ch := channelHandler()
+	require.NoError(t, service.Subscribe("devices/device-1/telemetry", 1, handler))
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(`{"battery_level":70}`)))
+	waitForMessage(t, ch)
+
+	// A forced reconnect must replay the remembered subscription, so
+	// messages published afterwards still arrive
+	require.NoError(t, service.ForceReconnect())
+	assert.True(t, service.IsConnected())
+
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(`{"battery_level":69}`)))
+	msg := waitForMessage(t, ch)
+	assert.JSONEq(t, `{"battery_level":69}`, string(msg.payload))
+}
+
+func TestIntegration_LastMessageActivityTracking(t *testing.T) {
+	addr := startTestBroker(t)
+	service := newTestClient(t, addr, "test-service")
+	device := newTestClient(t, addr, "test-device")
+
+	handler, ch := channelHandler()
+	require.NoError(t, service.Subscribe("devices/device-1/telemetry", 1, handler))
+
+	connectedAt := service.LastMessageAt()
+
+	require.NoError(t, device.Publish("devices/device-1/telemetry", 1, false, []byte(fmt.Sprintf(`{"ts":%d}`, time.Now().UnixNano()))))
+	waitForMessage(t, ch)
+
+	assert.False(t, service.LastMessageAt().Before(connectedAt), "inbound message should advance the activity timestamp")
+}
@@ -0,0 +1,197 @@
+package mqtt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// TopicClass groups MQTT topics that share a payload size budget
+type TopicClass string
+
+const (
+	TopicClassTelemetry TopicClass = "telemetry"
+	TopicClassEvents    TopicClass = "events"
+	TopicClassUnknown   TopicClass = "unknown"
+)
+
+// Default guard limits; telemetry payloads may carry vitals batches and are
+// allowed to be larger than event payloads
+const (
+	DefaultMaxTelemetryBytes = 16 * 1024
+	DefaultMaxEventBytes     = 4 * 1024
+	DefaultMaxJSONDepth      = 10
+	DefaultMaxJSONFields     = 256
+)
+
+var (
+	ErrPayloadTooLarge  = errors.New("payload exceeds maximum size for topic class")
+	ErrPayloadTooDeep   = errors.New("payload JSON nesting exceeds maximum depth")
+	ErrTooManyFields    = errors.New("payload JSON exceeds maximum field count")
+	ErrPayloadMalformed = errors.New("payload is not valid JSON")
+)
+
+// GuardConfig holds payload guard limits
+type GuardConfig struct {
+	MaxTelemetryBytes int
+	MaxEventBytes     int
+	MaxJSONDepth      int
+	MaxJSONFields     int
+}
+
+// GuardStats exposes rejection counters for metrics
+type GuardStats struct {
+	RejectedOversized int64 `json:"rejected_oversized"`
+	RejectedTooDeep   int64 `json:"rejected_too_deep"`
+	RejectedTooWide   int64 `json:"rejected_too_wide"`
+	RejectedMalformed int64 `json:"rejected_malformed"`
+}
+
+// PayloadGuard rejects oversized or abusive MQTT payloads before they reach
+// the handlers, so a malfunctioning (or malicious) device cannot exhaust
+// memory of the shared consumer
+type PayloadGuard struct {
+	config GuardConfig
+	logger zerolog.Logger
+
+	rejectedOversized int64
+	rejectedTooDeep   int64
+	rejectedTooWide   int64
+	rejectedMalformed int64
+}
+
+// NewPayloadGuard creates a payload guard, filling in defaults for any
+// unset limits
+func NewPayloadGuard(config GuardConfig, logger zerolog.Logger) *PayloadGuard {
+	if config.MaxTelemetryBytes <= 0 {
+		config.MaxTelemetryBytes = DefaultMaxTelemetryBytes
+	}
+	if config.MaxEventBytes <= 0 {
+		config.MaxEventBytes = DefaultMaxEventBytes
+	}
+	if config.MaxJSONDepth <= 0 {
+		config.MaxJSONDepth = DefaultMaxJSONDepth
+	}
+	if config.MaxJSONFields <= 0 {
+		config.MaxJSONFields = DefaultMaxJSONFields
+	}
+
+	return &PayloadGuard{
+		config: config,
+		logger: logger,
+	}
+}
+
+// ClassifyTopic maps an MQTT topic to its topic class
+func ClassifyTopic(topic string) TopicClass {
+	switch {
+	case strings.HasSuffix(topic, "/telemetry"):
+		return TopicClassTelemetry
+	case strings.HasSuffix(topic, "/events"):
+		return TopicClassEvents
+	default:
+		return TopicClassUnknown
+	}
+}
+
+// maxBytesFor returns the size budget for a topic class. Unknown topics get
+// the smaller event budget
+func (g *PayloadGuard) maxBytesFor(class TopicClass) int {
+	if class == TopicClassTelemetry {
+		return g.config.MaxTelemetryBytes
+	}
+	return g.config.MaxEventBytes
+}
+
+// Check validates a payload against the size and JSON shape limits
+func (g *PayloadGuard) Check(topic string, payload []byte) error {
+	class := ClassifyTopic(topic)
+
+	if maxBytes := g.maxBytesFor(class); len(payload) > maxBytes {
+		atomic.AddInt64(&g.rejectedOversized, 1)
+		g.logger.Warn().
+			Str("topic", topic).
+			Str("topic_class", string(class)).
+			Int("payload_bytes", len(payload)).
+			Int("max_bytes", maxBytes).
+			Msg("Rejected oversized MQTT payload")
+		return ErrPayloadTooLarge
+	}
+
+	if err := g.checkJSONShape(payload); err != nil {
+		g.logger.Warn().
+			Err(err).
+			Str("topic", topic).
+			Str("topic_class", string(class)).
+			Msg("Rejected malformed MQTT payload")
+		return err
+	}
+
+	return nil
+}
+
+// Wrap returns a handler that applies the guard before delegating
+func (g *PayloadGuard) Wrap(handler MessageHandler) MessageHandler {
+	return func(topic string, payload []byte) error {
+		if err := g.Check(topic, payload); err != nil {
+			return err
+		}
+		return handler(topic, payload)
+	}
+}
+
+// Stats returns a snapshot of rejection counters
+func (g *PayloadGuard) Stats() GuardStats {
+	return GuardStats{
+		RejectedOversized: atomic.LoadInt64(&g.rejectedOversized),
+		RejectedTooDeep:   atomic.LoadInt64(&g.rejectedTooDeep),
+		RejectedTooWide:   atomic.LoadInt64(&g.rejectedTooWide),
+		RejectedMalformed: atomic.LoadInt64(&g.rejectedMalformed),
+	}
+}
+
+// checkJSONShape streams through the payload tokens enforcing nesting depth
+// and total field limits without materializing the document
+func (g *PayloadGuard) checkJSONShape(payload []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+
+	depth := 0
+	fields := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// Truncated documents surface as EOF with unclosed containers
+			if errors.Is(err, io.EOF) && depth == 0 {
+				return nil
+			}
+			atomic.AddInt64(&g.rejectedMalformed, 1)
+			return ErrPayloadMalformed
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+				if depth > g.config.MaxJSONDepth {
+					atomic.AddInt64(&g.rejectedTooDeep, 1)
+					return ErrPayloadTooDeep
+				}
+			case '}', ']':
+				depth--
+			}
+			continue
+		}
+
+		fields++
+		if fields > g.config.MaxJSONFields {
+			atomic.AddInt64(&g.rejectedTooWide, 1)
+			return ErrTooManyFields
+		}
+	}
+}
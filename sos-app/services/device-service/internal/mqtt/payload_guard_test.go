@@ -0,0 +1,95 @@
+package mqtt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGuard(config GuardConfig) *PayloadGuard {
+	return NewPayloadGuard(config, zerolog.Nop())
+}
+
+func TestClassifyTopic(t *testing.T) {
+	assert.Equal(t, TopicClassTelemetry, ClassifyTopic("devices/device-123/telemetry"))
+	assert.Equal(t, TopicClassEvents, ClassifyTopic("devices/device-123/events"))
+	assert.Equal(t, TopicClassUnknown, ClassifyTopic("devices/device-123/commands"))
+}
+
+func TestPayloadGuard_RejectsOversizedPayload(t *testing.T) {
+	guard := newTestGuard(GuardConfig{MaxEventBytes: 64})
+
+	payload := []byte(`{"padding": "` + strings.Repeat("x", 100) + `"}`)
+	err := guard.Check("devices/device-123/events", payload)
+
+	assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	assert.Equal(t, int64(1), guard.Stats().RejectedOversized)
+}
+
+func TestPayloadGuard_TelemetryGetsLargerBudget(t *testing.T) {
+	guard := newTestGuard(GuardConfig{MaxEventBytes: 64, MaxTelemetryBytes: 1024})
+
+	payload := []byte(`{"padding": "` + strings.Repeat("x", 100) + `"}`)
+	err := guard.Check("devices/device-123/telemetry", payload)
+
+	assert.NoError(t, err)
+}
+
+func TestPayloadGuard_RejectsDeeplyNestedJSON(t *testing.T) {
+	guard := newTestGuard(GuardConfig{MaxJSONDepth: 5})
+
+	payload := []byte(strings.Repeat(`{"a":`, 10) + `1` + strings.Repeat(`}`, 10))
+	err := guard.Check("devices/device-123/events", payload)
+
+	assert.ErrorIs(t, err, ErrPayloadTooDeep)
+	assert.Equal(t, int64(1), guard.Stats().RejectedTooDeep)
+}
+
+func TestPayloadGuard_RejectsTooManyFields(t *testing.T) {
+	guard := newTestGuard(GuardConfig{MaxJSONFields: 10})
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"a0":0`)
+	for i := 1; i < 20; i++ {
+		buf.WriteString(`,"a`)
+		buf.WriteByte(byte('0' + i%10))
+		buf.WriteByte(byte('0' + i/10))
+		buf.WriteString(`":0`)
+	}
+	buf.WriteString(`}`)
+
+	err := guard.Check("devices/device-123/events", buf.Bytes())
+
+	assert.ErrorIs(t, err, ErrTooManyFields)
+	assert.Equal(t, int64(1), guard.Stats().RejectedTooWide)
+}
+
+func TestPayloadGuard_RejectsMalformedJSON(t *testing.T) {
+	guard := newTestGuard(GuardConfig{})
+
+	err := guard.Check("devices/device-123/events", []byte(`{"unterminated": `))
+
+	assert.ErrorIs(t, err, ErrPayloadMalformed)
+	assert.Equal(t, int64(1), guard.Stats().RejectedMalformed)
+}
+
+func TestPayloadGuard_WrapBlocksHandler(t *testing.T) {
+	guard := newTestGuard(GuardConfig{MaxEventBytes: 48})
+
+	called := false
+	handler := guard.Wrap(func(topic string, payload []byte) error {
+		called = true
+		return nil
+	})
+
+	err := handler("devices/device-123/events", []byte(strings.Repeat(`1`, 64)))
+	assert.Error(t, err)
+	assert.False(t, called)
+
+	err = handler("devices/device-123/events", []byte(`{"event_type": "SOS_BUTTON"}`))
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,128 @@
+package mqtt
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Default dedup limits; the window covers the reconnect retransmit bursts
+// seen from some firmware, the per-topic depth covers a burst of distinct
+// payloads arriving inside one window
+const (
+	DefaultDedupWindow          = 30 * time.Second
+	DefaultDedupHashesPerDevice = 8
+)
+
+// DedupConfig holds duplicate suppression tunables
+type DedupConfig struct {
+	Window          time.Duration
+	HashesPerDevice int
+}
+
+// DedupStats exposes suppression counters for metrics
+type DedupStats struct {
+	SuppressedDuplicates int64 `json:"suppressed_duplicates"`
+}
+
+// TelemetryDeduplicator skips reprocessing byte-identical telemetry a device
+// retransmits shortly after reconnecting, sparing the database redundant
+// writes and the user duplicate alerts. Payloads are remembered per topic as
+// short-lived hashes; an identical message is processed again once the
+// window has passed, so steady-state heartbeats are never starved
+type TelemetryDeduplicator struct {
+	config DedupConfig
+	logger zerolog.Logger
+
+	mu         sync.Mutex
+	seen       map[string][]hashEntry // keyed by topic, newest last
+	suppressed int64
+}
+
+type hashEntry struct {
+	sum    [sha256.Size]byte
+	seenAt time.Time
+}
+
+// NewTelemetryDeduplicator creates a deduplicator, filling in defaults for
+// any unset limits
+func NewTelemetryDeduplicator(config DedupConfig, logger zerolog.Logger) *TelemetryDeduplicator {
+	if config.Window <= 0 {
+		config.Window = DefaultDedupWindow
+	}
+	if config.HashesPerDevice <= 0 {
+		config.HashesPerDevice = DefaultDedupHashesPerDevice
+	}
+
+	return &TelemetryDeduplicator{
+		config: config,
+		logger: logger,
+		seen:   make(map[string][]hashEntry),
+	}
+}
+
+// IsDuplicate reports whether a byte-identical payload arrived on this topic
+// within the window. First sightings are recorded with their arrival time,
+// which is deliberately not refreshed on repeats: once the window passes the
+// payload is processed again even if the device kept retransmitting it
+func (d *TelemetryDeduplicator) IsDuplicate(topic string, payload []byte) bool {
+	sum := sha256.Sum256(payload)
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Drop expired hashes for this topic, then look for a live match
+	entries := d.seen[topic][:0]
+	duplicate := false
+	for _, entry := range d.seen[topic] {
+		if now.Sub(entry.seenAt) >= d.config.Window {
+			continue
+		}
+		if entry.sum == sum {
+			duplicate = true
+		}
+		entries = append(entries, entry)
+	}
+
+	if !duplicate {
+		entries = append(entries, hashEntry{sum: sum, seenAt: now})
+		// Evict the oldest hashes when a device floods distinct payloads
+		if overflow := len(entries) - d.config.HashesPerDevice; overflow > 0 {
+			entries = entries[overflow:]
+		}
+	}
+
+	if len(entries) == 0 {
+		delete(d.seen, topic)
+	} else {
+		d.seen[topic] = entries
+	}
+
+	return duplicate
+}
+
+// Wrap returns a handler that drops duplicate payloads before delegating
+func (d *TelemetryDeduplicator) Wrap(handler MessageHandler) MessageHandler {
+	return func(topic string, payload []byte) error {
+		if d.IsDuplicate(topic, payload) {
+			atomic.AddInt64(&d.suppressed, 1)
+			d.logger.Debug().
+				Str("topic", topic).
+				Int("payload_bytes", len(payload)).
+				Msg("Suppressed duplicate telemetry payload")
+			return nil
+		}
+		return handler(topic, payload)
+	}
+}
+
+// Stats returns a snapshot of the suppression counter
+func (d *TelemetryDeduplicator) Stats() DedupStats {
+	return DedupStats{
+		SuppressedDuplicates: atomic.LoadInt64(&d.suppressed),
+	}
+}
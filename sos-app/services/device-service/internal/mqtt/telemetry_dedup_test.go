@@ -0,0 +1,76 @@
+package mqtt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDedup(config DedupConfig) *TelemetryDeduplicator {
+	return NewTelemetryDeduplicator(config, zerolog.Nop())
+}
+
+func TestTelemetryDedup_SuppressesIdenticalPayloadWithinWindow(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: time.Minute})
+	payload := []byte(`{"battery_level": 80}`)
+
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+	assert.True(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+	assert.True(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+}
+
+func TestTelemetryDedup_DistinctPayloadsPassThrough(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: time.Minute})
+
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte(`{"battery_level": 80}`)))
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte(`{"battery_level": 79}`)))
+}
+
+func TestTelemetryDedup_TopicsAreIndependent(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: time.Minute})
+	payload := []byte(`{"battery_level": 80}`)
+
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+	assert.False(t, dedup.IsDuplicate("devices/device-456/telemetry", payload))
+}
+
+func TestTelemetryDedup_ReprocessesAfterWindowExpires(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: 10 * time.Millisecond})
+	payload := []byte(`{"battery_level": 80}`)
+
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+	time.Sleep(15 * time.Millisecond)
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", payload))
+}
+
+func TestTelemetryDedup_EvictsOldestHashWhenFull(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: time.Minute, HashesPerDevice: 2})
+
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte("a")))
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte("b")))
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte("c")))
+
+	// "a" was evicted to make room for "c"; the newer hashes still match
+	assert.False(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte("a")))
+	assert.True(t, dedup.IsDuplicate("devices/device-123/telemetry", []byte("c")))
+}
+
+func TestTelemetryDedup_WrapSkipsHandlerAndCountsSuppressions(t *testing.T) {
+	dedup := newTestDedup(DedupConfig{Window: time.Minute})
+	payload := []byte(`{"battery_level": 80}`)
+
+	calls := 0
+	handler := dedup.Wrap(func(topic string, payload []byte) error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, handler("devices/device-123/telemetry", payload))
+	assert.NoError(t, handler("devices/device-123/telemetry", payload))
+	assert.NoError(t, handler("devices/device-123/telemetry", payload))
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, int64(2), dedup.Stats().SuppressedDuplicates)
+}
@@ -0,0 +1,151 @@
+package mqtt
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// watchdogCheckInterval is how often the silence window is evaluated
+	watchdogCheckInterval = 30 * time.Second
+	// watchdogProbeTimeout is how long a loopback self-test message has to
+	// come back before the connection is declared half-open
+	watchdogProbeTimeout = 10 * time.Second
+)
+
+// Watchdog guards against half-open broker connections: paho sometimes
+// reports connected while receiving nothing. When the wildcard topics go
+// silent for too long, the watchdog publishes a self-test message to a
+// loopback topic it subscribes to itself; if the message does not come back,
+// the connection is torn down, reconnected and resubscribed
+type Watchdog struct {
+	client           *Client
+	loopbackTopic    string
+	silenceThreshold time.Duration
+	logger           zerolog.Logger
+
+	// lastLoopback is the unix-nano receive time of the most recent
+	// self-test message
+	lastLoopback int64
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// NewWatchdog creates a watchdog for the given client. The loopback topic is
+// derived from clientID so replicas only answer their own probes
+func NewWatchdog(client *Client, clientID string, silenceThreshold time.Duration, logger zerolog.Logger) *Watchdog {
+	return &Watchdog{
+		client:           client,
+		loopbackTopic:    fmt.Sprintf("device-service/%s/loopback", clientID),
+		silenceThreshold: silenceThreshold,
+		logger:           logger,
+		stopChan:         make(chan struct{}),
+		doneChan:         make(chan struct{}),
+	}
+}
+
+// Start subscribes to the loopback topic and begins the background checks
+func (w *Watchdog) Start() error {
+	if err := w.client.Subscribe(w.loopbackTopic, 1, func(topic string, payload []byte) error {
+		atomic.StoreInt64(&w.lastLoopback, time.Now().UnixNano())
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe to loopback topic: %w", err)
+	}
+
+	go w.run()
+
+	w.logger.Info().
+		Str("loopback_topic", w.loopbackTopic).
+		Dur("silence_threshold", w.silenceThreshold).
+		Msg("MQTT watchdog started")
+
+	return nil
+}
+
+// Stop halts the background checks
+func (w *Watchdog) Stop() {
+	close(w.stopChan)
+	<-w.doneChan
+}
+
+// run evaluates the silence window periodically
+func (w *Watchdog) run() {
+	defer close(w.doneChan)
+
+	ticker := time.NewTicker(watchdogCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// check probes the connection if the wildcard topics have gone silent. A
+// quiet fleet is fine as long as the loopback self-test comes back
+func (w *Watchdog) check() {
+	idle := time.Since(w.client.LastMessageAt())
+	if idle < w.silenceThreshold {
+		return
+	}
+
+	w.logger.Warn().
+		Dur("idle", idle).
+		Msg("No MQTT traffic within silence threshold - probing loopback")
+
+	if !w.probe() {
+		w.reconnect()
+	}
+}
+
+// probe publishes a self-test message and waits for it to be delivered back.
+// Returns whether end-to-end delivery was verified
+func (w *Watchdog) probe() bool {
+	probeSent := time.Now()
+	payload := []byte(fmt.Sprintf(`{"sent_at":%q}`, probeSent.UTC().Format(time.RFC3339Nano)))
+
+	if err := w.client.Publish(w.loopbackTopic, 1, false, payload); err != nil {
+		w.logger.Error().Err(err).Msg("Failed to publish loopback self-test")
+		return false
+	}
+
+	deadline := time.After(watchdogProbeTimeout)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			received := time.Unix(0, atomic.LoadInt64(&w.lastLoopback))
+			if !received.Before(probeSent) {
+				w.logger.Info().
+					Dur("round_trip", time.Since(probeSent)).
+					Msg("Loopback self-test delivered - broker connection healthy")
+				return true
+			}
+		case <-deadline:
+			w.logger.Error().
+				Dur("timeout", watchdogProbeTimeout).
+				Msg("Loopback self-test not delivered - connection is half-open")
+			return false
+		case <-w.stopChan:
+			return true
+		}
+	}
+}
+
+// reconnect tears the client down and brings it back with its subscriptions
+func (w *Watchdog) reconnect() {
+	if err := w.client.ForceReconnect(); err != nil {
+		w.logger.Error().Err(err).Msg("MQTT watchdog reconnect failed - will retry next check")
+	}
+}
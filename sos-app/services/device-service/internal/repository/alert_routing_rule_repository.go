@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrRoutingRuleNotFound is returned when an alert routing rule doesn't exist
+var ErrRoutingRuleNotFound = errors.New("alert routing rule not found")
+
+// AlertRoutingRuleRepository handles organization alert routing rules
+type AlertRoutingRuleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAlertRoutingRuleRepository creates a new alert routing rule repository
+func NewAlertRoutingRuleRepository(db *pgxpool.Pool) *AlertRoutingRuleRepository {
+	return &AlertRoutingRuleRepository{db: db}
+}
+
+// Create stores a routing rule
+func (r *AlertRoutingRuleRepository) Create(ctx context.Context, rule *models.AlertRoutingRule) error {
+	query := `
+		INSERT INTO alert_routing_rules (org_id, name, alert_category, device_type, min_priority, team, rank, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		rule.OrgID,
+		rule.Name,
+		rule.AlertCategory,
+		rule.DeviceType,
+		rule.MinPriority,
+		rule.Team,
+		rule.Rank,
+		rule.Enabled,
+	).Scan(&rule.ID, &rule.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create routing rule: %w", err)
+	}
+
+	return nil
+}
+
+// ListByOrg retrieves all of an organization's rules in evaluation order
+func (r *AlertRoutingRuleRepository) ListByOrg(ctx context.Context, orgID string) ([]models.AlertRoutingRule, error) {
+	query := `
+		SELECT id, org_id, name, alert_category, device_type, min_priority, team, rank, enabled, created_at
+		FROM alert_routing_rules
+		WHERE org_id = $1
+		ORDER BY rank, created_at
+	`
+
+	return r.scanRules(ctx, query, orgID)
+}
+
+// ListEnabledByOrg retrieves an organization's enabled rules in evaluation order
+func (r *AlertRoutingRuleRepository) ListEnabledByOrg(ctx context.Context, orgID string) ([]models.AlertRoutingRule, error) {
+	query := `
+		SELECT id, org_id, name, alert_category, device_type, min_priority, team, rank, enabled, created_at
+		FROM alert_routing_rules
+		WHERE org_id = $1 AND enabled
+		ORDER BY rank, created_at
+	`
+
+	return r.scanRules(ctx, query, orgID)
+}
+
+// scanRules runs a rule query and scans the rows
+func (r *AlertRoutingRuleRepository) scanRules(ctx context.Context, query string, args ...interface{}) ([]models.AlertRoutingRule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routing rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []models.AlertRoutingRule
+	for rows.Next() {
+		var rule models.AlertRoutingRule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.OrgID,
+			&rule.Name,
+			&rule.AlertCategory,
+			&rule.DeviceType,
+			&rule.MinPriority,
+			&rule.Team,
+			&rule.Rank,
+			&rule.Enabled,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan routing rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Delete removes a routing rule
+func (r *AlertRoutingRuleRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM alert_routing_rules WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete routing rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrRoutingRuleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrAPITokenNotFound is returned when no matching API token exists
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// APITokenRepository stores scoped API tokens for third-party integrations
+type APITokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *pgxpool.Pool) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Create stores a new token, filling in its generated ID and creation time
+func (r *APITokenRepository) Create(ctx context.Context, token *models.APIToken, tokenHash string) error {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, scopes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, token.UserID, token.Name, tokenHash, token.Scopes).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash returns the non-revoked token with the given hash, or nil when
+// none exists
+func (r *APITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE token_hash = $1 AND revoked_at IS NULL
+	`
+
+	var token models.APIToken
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.Scopes,
+		&token.CreatedAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// ListByUserID returns all of a user's tokens, newest first, including
+// revoked ones so the audit trail stays visible
+func (r *APITokenRepository) ListByUserID(ctx context.Context, userID string) ([]models.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []models.APIToken
+	for rows.Next() {
+		var token models.APIToken
+		err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.Scopes,
+			&token.CreatedAt,
+			&token.LastUsedAt,
+			&token.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// Revoke marks a user's token as revoked
+func (r *APITokenRepository) Revoke(ctx context.Context, id, userID string) error {
+	query := `
+		UPDATE api_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that the token was just used
+func (r *APITokenRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to touch api token: %w", err)
+	}
+
+	return nil
+}
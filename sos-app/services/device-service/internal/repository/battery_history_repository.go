@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// BatteryHistoryRepository stores decimated battery level samples
+type BatteryHistoryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBatteryHistoryRepository creates a new battery history repository
+func NewBatteryHistoryRepository(db *pgxpool.Pool) *BatteryHistoryRepository {
+	return &BatteryHistoryRepository{db: db}
+}
+
+// Record stores one battery level sample
+func (r *BatteryHistoryRepository) Record(ctx context.Context, deviceID string, batteryLevel int) error {
+	query := `
+		INSERT INTO battery_history (device_id, battery_level)
+		VALUES ($1, $2)
+	`
+
+	if _, err := r.db.Exec(ctx, query, deviceID, batteryLevel); err != nil {
+		return fmt.Errorf("failed to record battery sample: %w", err)
+	}
+
+	return nil
+}
+
+// History retrieves time-bucketed battery levels for one device since the
+// given time. bucketSeconds controls the chart resolution
+func (r *BatteryHistoryRepository) History(ctx context.Context, deviceID string, since time.Time, bucketSeconds int) ([]models.BatteryHistoryBucket, error) {
+	query := `
+		SELECT to_timestamp(floor(extract(epoch FROM recorded_at) / $3) * $3) AS bucket_start,
+		       ROUND(AVG(battery_level))::int AS avg_level,
+		       MIN(battery_level) AS min_level,
+		       MAX(battery_level) AS max_level,
+		       COUNT(*) AS samples
+		FROM battery_history
+		WHERE device_id = $1 AND recorded_at >= $2
+		GROUP BY bucket_start
+		ORDER BY bucket_start
+	`
+
+	rows, err := r.db.Query(ctx, query, deviceID, since, bucketSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get battery history: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []models.BatteryHistoryBucket
+	for rows.Next() {
+		var bucket models.BatteryHistoryBucket
+		err := rows.Scan(
+			&bucket.BucketStart,
+			&bucket.AvgLevel,
+			&bucket.MinLevel,
+			&bucket.MaxLevel,
+			&bucket.Samples,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan battery bucket: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
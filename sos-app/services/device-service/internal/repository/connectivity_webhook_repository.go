@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrWebhookNotFound is returned when a connectivity webhook doesn't exist
+var ErrWebhookNotFound = errors.New("connectivity webhook not found")
+
+// ConnectivityWebhookRepository handles connectivity webhook registrations
+// and their delivery log
+type ConnectivityWebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewConnectivityWebhookRepository creates a new connectivity webhook repository
+func NewConnectivityWebhookRepository(db *pgxpool.Pool) *ConnectivityWebhookRepository {
+	return &ConnectivityWebhookRepository{db: db}
+}
+
+// Create registers a webhook
+func (r *ConnectivityWebhookRepository) Create(ctx context.Context, webhook *models.ConnectivityWebhook) error {
+	query := `
+		INSERT INTO connectivity_webhooks (org_id, url, secret, enabled)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		webhook.OrgID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.Enabled,
+	).Scan(&webhook.ID, &webhook.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// ListEnabled retrieves all enabled webhooks
+func (r *ConnectivityWebhookRepository) ListEnabled(ctx context.Context) ([]models.ConnectivityWebhook, error) {
+	query := `
+		SELECT id, org_id, url, secret, enabled, created_at
+		FROM connectivity_webhooks
+		WHERE enabled
+		ORDER BY created_at
+	`
+
+	return r.scanWebhooks(ctx, query)
+}
+
+// List retrieves all webhooks, newest first
+func (r *ConnectivityWebhookRepository) List(ctx context.Context) ([]models.ConnectivityWebhook, error) {
+	query := `
+		SELECT id, org_id, url, secret, enabled, created_at
+		FROM connectivity_webhooks
+		ORDER BY created_at DESC
+	`
+
+	return r.scanWebhooks(ctx, query)
+}
+
+// scanWebhooks runs a webhook query and scans the rows
+func (r *ConnectivityWebhookRepository) scanWebhooks(ctx context.Context, query string, args ...interface{}) ([]models.ConnectivityWebhook, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.ConnectivityWebhook
+	for rows.Next() {
+		var webhook models.ConnectivityWebhook
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.OrgID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.Enabled,
+			&webhook.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// Delete removes a webhook and its delivery log
+func (r *ConnectivityWebhookRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM connectivity_webhooks WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RecordDelivery appends a delivery attempt outcome to the log
+func (r *ConnectivityWebhookRepository) RecordDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, device_id, event_type, attempts, status_code, succeeded, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, delivered_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		delivery.WebhookID,
+		delivery.DeviceID,
+		delivery.EventType,
+		delivery.Attempts,
+		delivery.StatusCode,
+		delivery.Succeeded,
+		delivery.LastError,
+	).Scan(&delivery.ID, &delivery.DeliveredAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log for one webhook, newest first
+func (r *ConnectivityWebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, device_id, event_type, attempts, status_code, succeeded, last_error, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY delivered_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.DeviceID,
+			&delivery.EventType,
+			&delivery.Attempts,
+			&delivery.StatusCode,
+			&delivery.Succeeded,
+			&delivery.LastError,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// DeviceAlertRepository handles persistence of monitor-emitted alerts
+type DeviceAlertRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceAlertRepository creates a new device alert repository
+func NewDeviceAlertRepository(db *pgxpool.Pool) *DeviceAlertRepository {
+	return &DeviceAlertRepository{db: db}
+}
+
+// Create stores one alert
+func (r *DeviceAlertRepository) Create(ctx context.Context, alert *models.DeviceAlert) error {
+	query := `
+		INSERT INTO device_alerts (device_id, user_id, category, alert_type, priority, message)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		alert.DeviceID,
+		alert.UserID,
+		alert.Category,
+		alert.AlertType,
+		alert.Priority,
+		alert.Message,
+	).Scan(&alert.ID, &alert.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create device alert: %w", err)
+	}
+
+	return nil
+}
+
+// ListByDevice retrieves a device's alerts newest first, optionally filtered
+// by category. Returns the page and the total matching count
+func (r *DeviceAlertRepository) ListByDevice(ctx context.Context, deviceID, category string, limit, offset int) ([]models.DeviceAlert, int, error) {
+	countQuery := `
+		SELECT COUNT(*) FROM device_alerts
+		WHERE device_id = $1 AND ($2 = '' OR category = $2)
+	`
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, deviceID, category).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count device alerts: %w", err)
+	}
+
+	query := `
+		SELECT id, device_id, user_id, category, alert_type, priority, message, created_at
+		FROM device_alerts
+		WHERE device_id = $1 AND ($2 = '' OR category = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, deviceID, category, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list device alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts, err := scanAlerts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+// ListByUser retrieves alerts across all the user's devices newest first,
+// optionally filtered by category. Returns the page and the total matching
+// count
+func (r *DeviceAlertRepository) ListByUser(ctx context.Context, userID, category string, limit, offset int) ([]models.DeviceAlert, int, error) {
+	countQuery := `
+		SELECT COUNT(*) FROM device_alerts
+		WHERE user_id = $1 AND ($2 = '' OR category = $2)
+	`
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, userID, category).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count device alerts: %w", err)
+	}
+
+	query := `
+		SELECT id, device_id, user_id, category, alert_type, priority, message, created_at
+		FROM device_alerts
+		WHERE user_id = $1 AND ($2 = '' OR category = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, category, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list device alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts, err := scanAlerts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+// scanAlerts reads alert rows into models
+func scanAlerts(rows pgx.Rows) ([]models.DeviceAlert, error) {
+	var alerts []models.DeviceAlert
+	for rows.Next() {
+		var alert models.DeviceAlert
+		err := rows.Scan(
+			&alert.ID,
+			&alert.DeviceID,
+			&alert.UserID,
+			&alert.Category,
+			&alert.AlertType,
+			&alert.Priority,
+			&alert.Message,
+			&alert.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DeviceBeaconTokenRepository stores the hashed bearer tokens that
+// authenticate HTTPS beacon reports from constrained cellular trackers
+type DeviceBeaconTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceBeaconTokenRepository creates a new device beacon token repository
+func NewDeviceBeaconTokenRepository(db *pgxpool.Pool) *DeviceBeaconTokenRepository {
+	return &DeviceBeaconTokenRepository{db: db}
+}
+
+// Upsert stores a device's beacon token hash, replacing any previous one
+func (r *DeviceBeaconTokenRepository) Upsert(ctx context.Context, deviceID, tokenHash string) error {
+	query := `
+		INSERT INTO device_beacon_tokens (device_id, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (device_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, deviceID, tokenHash); err != nil {
+		return fmt.Errorf("failed to upsert device beacon token: %w", err)
+	}
+
+	return nil
+}
+
+// GetHash returns the device's beacon token hash, or "" when the device
+// has no beacon token issued
+func (r *DeviceBeaconTokenRepository) GetHash(ctx context.Context, deviceID string) (string, error) {
+	query := `SELECT token_hash FROM device_beacon_tokens WHERE device_id = $1`
+
+	var tokenHash string
+	err := r.db.QueryRow(ctx, query, deviceID).Scan(&tokenHash)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get device beacon token: %w", err)
+	}
+
+	return tokenHash, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrCommandNotFound is returned when a device command doesn't exist
+var ErrCommandNotFound = errors.New("device command not found")
+
+// DeviceCommandRepository tracks remote commands pushed to devices
+type DeviceCommandRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceCommandRepository creates a new device command repository
+func NewDeviceCommandRepository(db *pgxpool.Pool) *DeviceCommandRepository {
+	return &DeviceCommandRepository{db: db}
+}
+
+// Create records a newly issued command
+func (r *DeviceCommandRepository) Create(ctx context.Context, command *models.DeviceCommand) error {
+	query := `
+		INSERT INTO device_commands (device_id, command_type, issued_by, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, issued_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		command.DeviceID,
+		command.CommandType,
+		command.IssuedBy,
+		command.Status,
+	).Scan(&command.ID, &command.IssuedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create device command: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a command scoped to a device
+func (r *DeviceCommandRepository) GetByID(ctx context.Context, deviceID, commandID string) (*models.DeviceCommand, error) {
+	query := `
+		SELECT id, device_id, command_type, issued_by, status, error, issued_at, acked_at
+		FROM device_commands
+		WHERE id = $1 AND device_id = $2
+	`
+
+	command := &models.DeviceCommand{}
+	err := r.db.QueryRow(ctx, query, commandID, deviceID).Scan(
+		&command.ID,
+		&command.DeviceID,
+		&command.CommandType,
+		&command.IssuedBy,
+		&command.Status,
+		&command.Error,
+		&command.IssuedAt,
+		&command.AckedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to get device command: %w", err)
+	}
+
+	return command, nil
+}
+
+// MarkAcked records the device's execution outcome for a pending command.
+// The device ID is matched so a device cannot ack another device's command
+func (r *DeviceCommandRepository) MarkAcked(ctx context.Context, deviceID, commandID string, status models.CommandStatus, errMsg *string) error {
+	query := `
+		UPDATE device_commands
+		SET status = $3, error = $4, acked_at = NOW()
+		WHERE id = $1 AND device_id = $2 AND status = 'PENDING'
+	`
+
+	result, err := r.db.Exec(ctx, query, commandID, deviceID, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to ack device command: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrCommandNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// DeviceEncryptionKeyRepository stores the public half of per-device
+// payload encryption keypairs
+type DeviceEncryptionKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceEncryptionKeyRepository creates a new device encryption key repository
+func NewDeviceEncryptionKeyRepository(db *pgxpool.Pool) *DeviceEncryptionKeyRepository {
+	return &DeviceEncryptionKeyRepository{db: db}
+}
+
+// Upsert registers a device's encryption key, replacing any previous one
+func (r *DeviceEncryptionKeyRepository) Upsert(ctx context.Context, key *models.DeviceEncryptionKey) error {
+	query := `
+		INSERT INTO device_encryption_keys (device_id, public_key, mode)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (device_id) DO UPDATE
+		SET public_key = EXCLUDED.public_key, mode = EXCLUDED.mode, rotated_at = NOW(), updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, key.DeviceID, key.PublicKey, key.Mode).
+		Scan(&key.CreatedAt, &key.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert device encryption key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByDeviceID returns the device's encryption key, or nil when the device
+// sends plaintext payloads
+func (r *DeviceEncryptionKeyRepository) GetByDeviceID(ctx context.Context, deviceID string) (*models.DeviceEncryptionKey, error) {
+	query := `
+		SELECT device_id, public_key, mode, rotated_at, created_at, updated_at
+		FROM device_encryption_keys
+		WHERE device_id = $1
+	`
+
+	key := &models.DeviceEncryptionKey{}
+	err := r.db.QueryRow(ctx, query, deviceID).Scan(
+		&key.DeviceID,
+		&key.PublicKey,
+		&key.Mode,
+		&key.RotatedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
+// UpdatePublicKey replaces a device's public key after a rotation, keeping
+// its mode. Returns false when the device has no key registered
+func (r *DeviceEncryptionKeyRepository) UpdatePublicKey(ctx context.Context, deviceID, publicKey string) (bool, error) {
+	query := `
+		UPDATE device_encryption_keys
+		SET public_key = $2, rotated_at = NOW(), updated_at = NOW()
+		WHERE device_id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, deviceID, publicKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to update device encryption key: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
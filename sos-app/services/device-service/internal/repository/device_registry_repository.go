@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrRegistryEntryNotFound is returned when a registry entry doesn't exist
+var ErrRegistryEntryNotFound = errors.New("device registry entry not found")
+
+// DeviceRegistryRepository handles the pre-pairing device registry
+type DeviceRegistryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceRegistryRepository creates a new device registry repository
+func NewDeviceRegistryRepository(db *pgxpool.Pool) *DeviceRegistryRepository {
+	return &DeviceRegistryRepository{db: db}
+}
+
+// Create pre-registers a device
+func (r *DeviceRegistryRepository) Create(ctx context.Context, entry *models.DeviceRegistryEntry) error {
+	query := `
+		INSERT INTO device_registry (mac_address, serial_number, require_serial_proof, notes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, registered_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.MacAddress,
+		entry.SerialNumber,
+		entry.RequireSerialProof,
+		entry.Notes,
+	).Scan(&entry.ID, &entry.RegisteredAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create registry entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMacAddress retrieves a registry entry by MAC address, ignoring case.
+// Returns nil when the MAC is not registered
+func (r *DeviceRegistryRepository) GetByMacAddress(ctx context.Context, macAddress string) (*models.DeviceRegistryEntry, error) {
+	query := `
+		SELECT id, mac_address, COALESCE(serial_number, ''), require_serial_proof,
+			COALESCE(notes, ''), registered_at
+		FROM device_registry
+		WHERE UPPER(mac_address) = UPPER($1)
+	`
+
+	entry := &models.DeviceRegistryEntry{}
+	err := r.db.QueryRow(ctx, query, macAddress).Scan(
+		&entry.ID,
+		&entry.MacAddress,
+		&entry.SerialNumber,
+		&entry.RequireSerialProof,
+		&entry.Notes,
+		&entry.RegisteredAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get registry entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// List retrieves all registry entries, newest first
+func (r *DeviceRegistryRepository) List(ctx context.Context) ([]models.DeviceRegistryEntry, error) {
+	query := `
+		SELECT id, mac_address, COALESCE(serial_number, ''), require_serial_proof,
+			COALESCE(notes, ''), registered_at
+		FROM device_registry
+		ORDER BY registered_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.DeviceRegistryEntry
+	for rows.Next() {
+		var entry models.DeviceRegistryEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.MacAddress,
+			&entry.SerialNumber,
+			&entry.RequireSerialProof,
+			&entry.Notes,
+			&entry.RegisteredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registry entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Delete removes a registry entry
+func (r *DeviceRegistryRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM device_registry WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete registry entry: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrRegistryEntryNotFound
+	}
+
+	return nil
+}
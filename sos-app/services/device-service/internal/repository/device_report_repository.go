@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrDeviceReportNotFound is returned when no report exists for a user
+var ErrDeviceReportNotFound = errors.New("device report not found")
+
+// DeviceReportRepository stores generated per-user device reports
+type DeviceReportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceReportRepository creates a new device report repository
+func NewDeviceReportRepository(db *pgxpool.Pool) *DeviceReportRepository {
+	return &DeviceReportRepository{db: db}
+}
+
+// Insert stores a generated report
+func (r *DeviceReportRepository) Insert(ctx context.Context, report *models.DeviceReport) error {
+	devices, err := json.Marshal(report.Devices)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	query := `
+		INSERT INTO device_reports (user_id, period_start, period_end, report)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, generated_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		report.UserID,
+		report.PeriodStart,
+		report.PeriodEnd,
+		devices,
+	).Scan(&report.ID, &report.GeneratedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert device report: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestByUser retrieves the most recently generated report for a user
+func (r *DeviceReportRepository) GetLatestByUser(ctx context.Context, userID string) (*models.DeviceReport, error) {
+	query := `
+		SELECT id, user_id, period_start, period_end, report, generated_at
+		FROM device_reports
+		WHERE user_id = $1
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`
+
+	var report models.DeviceReport
+	var devices []byte
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&report.ID,
+		&report.UserID,
+		&report.PeriodStart,
+		&report.PeriodEnd,
+		&devices,
+		&report.GeneratedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeviceReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest device report: %w", err)
+	}
+
+	if err := json.Unmarshal(devices, &report.Devices); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal report: %w", err)
+	}
+
+	return &report, nil
+}
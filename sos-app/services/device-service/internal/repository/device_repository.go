@@ -2,14 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sos-app/device-service/internal/models"
 )
 
+// ErrMacAlreadyPaired is returned when an insert loses the race against a
+// concurrent pairing of the same MAC address
+var ErrMacAlreadyPaired = errors.New("device with this MAC address is already paired")
+
+// isUniqueViolation reports whether err is a Postgres unique-constraint
+// violation, i.e. the insert lost a race against a concurrent writer
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 // DeviceRepository handles device data persistence
 type DeviceRepository struct {
 	db *pgxpool.Pool
@@ -44,6 +57,11 @@ func (r *DeviceRepository) Create(ctx context.Context, device *models.Device) er
 	).Scan(&device.CreatedAt, &device.UpdatedAt)
 
 	if err != nil {
+		// The unique_active_mac_address partial index is the authority on
+		// duplicate MACs; the handler's pre-check only catches the common case
+		if isUniqueViolation(err) {
+			return ErrMacAlreadyPaired
+		}
 		return fmt.Errorf("failed to create device: %w", err)
 	}
 
@@ -54,7 +72,7 @@ func (r *DeviceRepository) Create(ctx context.Context, device *models.Device) er
 func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*models.Device, error) {
 	query := `
 		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
-			battery_level, status, capabilities, settings, last_seen_at, created_at, updated_at
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
 		FROM devices
 		WHERE id = $1 AND status != 'DELETED'
 	`
@@ -72,6 +90,7 @@ func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*models.Devi
 		&device.Status,
 		&device.Capabilities,
 		&device.Settings,
+		&device.SettingsVersion,
 		&device.LastSeenAt,
 		&device.CreatedAt,
 		&device.UpdatedAt,
@@ -91,7 +110,7 @@ func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*models.Devi
 func (r *DeviceRepository) GetByMacAddress(ctx context.Context, macAddress string) (*models.Device, error) {
 	query := `
 		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
-			battery_level, status, capabilities, settings, last_seen_at, created_at, updated_at
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
 		FROM devices
 		WHERE mac_address = $1 AND status != 'DELETED'
 	`
@@ -109,6 +128,7 @@ func (r *DeviceRepository) GetByMacAddress(ctx context.Context, macAddress strin
 		&device.Status,
 		&device.Capabilities,
 		&device.Settings,
+		&device.SettingsVersion,
 		&device.LastSeenAt,
 		&device.CreatedAt,
 		&device.UpdatedAt,
@@ -128,7 +148,7 @@ func (r *DeviceRepository) GetByMacAddress(ctx context.Context, macAddress strin
 func (r *DeviceRepository) GetByUserID(ctx context.Context, userID string) ([]*models.Device, error) {
 	query := `
 		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
-			battery_level, status, capabilities, settings, last_seen_at, created_at, updated_at
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
 		FROM devices
 		WHERE user_id = $1 AND status != 'DELETED'
 		ORDER BY paired_at DESC
@@ -155,6 +175,134 @@ func (r *DeviceRepository) GetByUserID(ctx context.Context, userID string) ([]*m
 			&device.Status,
 			&device.Capabilities,
 			&device.Settings,
+			&device.SettingsVersion,
+			&device.LastSeenAt,
+			&device.CreatedAt,
+			&device.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// ListActive returns every device currently in ACTIVE status, across all
+// users (used to warm the device cache at startup)
+func (r *DeviceRepository) ListActive(ctx context.Context) ([]*models.Device, error) {
+	query := `
+		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
+		FROM devices
+		WHERE status = 'ACTIVE'
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*models.Device
+	for rows.Next() {
+		device := &models.Device{}
+		err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.DeviceType,
+			&device.Manufacturer,
+			&device.Model,
+			&device.MacAddress,
+			&device.PairedAt,
+			&device.BatteryLevel,
+			&device.Status,
+			&device.Capabilities,
+			&device.Settings,
+			&device.SettingsVersion,
+			&device.LastSeenAt,
+			&device.CreatedAt,
+			&device.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// Search finds devices across all users by partial MAC address, serial
+// number or model match. Serial numbers live in the device registry, so that
+// filter matches through the registry entry for the device's MAC address
+func (r *DeviceRepository) Search(ctx context.Context, macAddress, serialNumber, model string, limit int) ([]*models.Device, error) {
+	query := `
+		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
+		FROM devices
+		WHERE status != 'DELETED'
+	`
+
+	var args []interface{}
+	argPos := 1
+
+	if macAddress != "" {
+		query += fmt.Sprintf(" AND mac_address ILIKE $%d", argPos)
+		args = append(args, "%"+macAddress+"%")
+		argPos++
+	}
+
+	if serialNumber != "" {
+		query += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM device_registry
+			WHERE device_registry.mac_address = devices.mac_address
+			  AND device_registry.serial_number ILIKE $%d
+		)`, argPos)
+		args = append(args, "%"+serialNumber+"%")
+		argPos++
+	}
+
+	if model != "" {
+		query += fmt.Sprintf(" AND model ILIKE $%d", argPos)
+		args = append(args, "%"+model+"%")
+		argPos++
+	}
+
+	query += fmt.Sprintf(" ORDER BY paired_at DESC LIMIT $%d", argPos)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*models.Device
+	for rows.Next() {
+		device := &models.Device{}
+		err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.DeviceType,
+			&device.Manufacturer,
+			&device.Model,
+			&device.MacAddress,
+			&device.PairedAt,
+			&device.BatteryLevel,
+			&device.Status,
+			&device.Capabilities,
+			&device.Settings,
+			&device.SettingsVersion,
 			&device.LastSeenAt,
 			&device.CreatedAt,
 			&device.UpdatedAt,
@@ -244,24 +392,26 @@ func (r *DeviceRepository) UpdateStatus(ctx context.Context, deviceID string, st
 	return nil
 }
 
-// UpdateSettings updates device settings
-func (r *DeviceRepository) UpdateSettings(ctx context.Context, deviceID string, settings map[string]interface{}) error {
+// UpdateSettings updates device settings, bumping the settings version.
+// Returns the new version number
+func (r *DeviceRepository) UpdateSettings(ctx context.Context, deviceID string, settings map[string]interface{}) (int, error) {
 	query := `
 		UPDATE devices
-		SET settings = $2
+		SET settings = $2, settings_version = settings_version + 1
 		WHERE id = $1 AND status != 'DELETED'
+		RETURNING settings_version
 	`
 
-	result, err := r.db.Exec(ctx, query, deviceID, settings)
+	var version int
+	err := r.db.QueryRow(ctx, query, deviceID, settings).Scan(&version)
 	if err != nil {
-		return fmt.Errorf("failed to update settings: %w", err)
-	}
-
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("device not found")
+		if err == pgx.ErrNoRows {
+			return 0, fmt.Errorf("device not found")
+		}
+		return 0, fmt.Errorf("failed to update settings: %w", err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // UpdateLastSeen updates the last seen timestamp
@@ -308,7 +458,7 @@ func (r *DeviceRepository) SoftDelete(ctx context.Context, id string) error {
 func (r *DeviceRepository) GetDisconnectedDevices(ctx context.Context, duration time.Duration) ([]*models.Device, error) {
 	query := `
 		SELECT id, user_id, device_type, manufacturer, model, mac_address, paired_at,
-			battery_level, status, capabilities, settings, last_seen_at, created_at, updated_at
+			battery_level, status, capabilities, settings, settings_version, last_seen_at, created_at, updated_at
 		FROM devices
 		WHERE status = 'ACTIVE'
 			AND last_seen_at IS NOT NULL
@@ -337,6 +487,7 @@ func (r *DeviceRepository) GetDisconnectedDevices(ctx context.Context, duration
 			&device.Status,
 			&device.Capabilities,
 			&device.Settings,
+			&device.SettingsVersion,
 			&device.LastSeenAt,
 			&device.CreatedAt,
 			&device.UpdatedAt,
@@ -353,3 +504,75 @@ func (r *DeviceRepository) GetDisconnectedDevices(ctx context.Context, duration
 
 	return devices, nil
 }
+
+// ListUserIDs returns the distinct owners of all non-deleted devices
+func (r *DeviceRepository) ListUserIDs(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT user_id FROM devices WHERE status != 'DELETED'`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device owners: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// GetFleetReadiness returns, per device owner, whether at least one ACTIVE
+// device has battery above minBattery and was seen since heartbeatAfter
+func (r *DeviceRepository) GetFleetReadiness(ctx context.Context, minBattery int, heartbeatAfter time.Time) (map[string]bool, error) {
+	query := `
+		SELECT user_id,
+			BOOL_OR(status = 'ACTIVE'
+				AND battery_level > $1
+				AND last_seen_at IS NOT NULL
+				AND last_seen_at >= $2)
+		FROM devices
+		WHERE status != 'DELETED'
+		GROUP BY user_id
+	`
+
+	rows, err := r.db.Query(ctx, query, minBattery, heartbeatAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fleet readiness: %w", err)
+	}
+	defer rows.Close()
+
+	readiness := make(map[string]bool)
+	for rows.Next() {
+		var userID string
+		var ready bool
+		if err := rows.Scan(&userID, &ready); err != nil {
+			return nil, fmt.Errorf("failed to scan fleet readiness: %w", err)
+		}
+		readiness[userID] = ready
+	}
+
+	return readiness, rows.Err()
+}
+
+// EraseUserData soft deletes a user's devices and clears their settings
+// (GDPR/CCPA data erasure). Returns the number of devices touched
+func (r *DeviceRepository) EraseUserData(ctx context.Context, userID string) (int64, error) {
+	query := `
+		UPDATE devices
+		SET status = 'DELETED', settings = '{}'::jsonb
+		WHERE user_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to erase user devices: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
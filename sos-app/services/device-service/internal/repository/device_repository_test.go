@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	// A pairing insert that loses the race hits the unique_active_mac_address
+	// partial index and surfaces as SQLSTATE 23505
+	unique := &pgconn.PgError{Code: "23505", ConstraintName: "unique_active_mac_address"}
+	assert.True(t, isUniqueViolation(unique))
+
+	// Detection must survive wrapping
+	assert.True(t, isUniqueViolation(fmt.Errorf("insert failed: %w", unique)))
+
+	// Other database errors and plain errors stay on the 500 path
+	assert.False(t, isUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	assert.False(t, isUniqueViolation(errors.New("connection refused")))
+	assert.False(t, isUniqueViolation(nil))
+}
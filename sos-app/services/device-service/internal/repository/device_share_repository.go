@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// DeviceShareRepository handles device share persistence
+type DeviceShareRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceShareRepository creates a new device share repository
+func NewDeviceShareRepository(db *pgxpool.Pool) *DeviceShareRepository {
+	return &DeviceShareRepository{db: db}
+}
+
+// CreateInvite stores a pending share invite
+func (r *DeviceShareRepository) CreateInvite(ctx context.Context, share *models.DeviceShare) error {
+	query := `
+		INSERT INTO device_shares (id, device_id, owner_user_id, shared_with_user_id, role, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING invited_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		share.ID,
+		share.DeviceID,
+		share.OwnerUserID,
+		share.SharedWithUserID,
+		share.Role,
+		share.Status,
+	).Scan(&share.InvitedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create share invite: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a share by ID
+func (r *DeviceShareRepository) GetByID(ctx context.Context, id string) (*models.DeviceShare, error) {
+	query := `
+		SELECT id, device_id, owner_user_id, shared_with_user_id, role, status,
+			invited_at, responded_at, revoked_at
+		FROM device_shares
+		WHERE id = $1
+	`
+
+	share := &models.DeviceShare{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&share.ID,
+		&share.DeviceID,
+		&share.OwnerUserID,
+		&share.SharedWithUserID,
+		&share.Role,
+		&share.Status,
+		&share.InvitedAt,
+		&share.RespondedAt,
+		&share.RevokedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share: %w", err)
+	}
+
+	return share, nil
+}
+
+// GetAcceptedShare returns the accepted share granting userID access to
+// deviceID, or nil if none exists
+func (r *DeviceShareRepository) GetAcceptedShare(ctx context.Context, deviceID, userID string) (*models.DeviceShare, error) {
+	query := `
+		SELECT id, device_id, owner_user_id, shared_with_user_id, role, status,
+			invited_at, responded_at, revoked_at
+		FROM device_shares
+		WHERE device_id = $1 AND shared_with_user_id = $2 AND status = 'ACCEPTED'
+	`
+
+	share := &models.DeviceShare{}
+	err := r.db.QueryRow(ctx, query, deviceID, userID).Scan(
+		&share.ID,
+		&share.DeviceID,
+		&share.OwnerUserID,
+		&share.SharedWithUserID,
+		&share.Role,
+		&share.Status,
+		&share.InvitedAt,
+		&share.RespondedAt,
+		&share.RevokedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accepted share: %w", err)
+	}
+
+	return share, nil
+}
+
+// UpdateStatus transitions a share to the given status
+func (r *DeviceShareRepository) UpdateStatus(ctx context.Context, id string, status models.ShareStatus) error {
+	query := `
+		UPDATE device_shares
+		SET status = $2,
+			responded_at = CASE WHEN $2 = 'ACCEPTED' THEN NOW() ELSE responded_at END,
+			revoked_at = CASE WHEN $2 = 'REVOKED' THEN NOW() ELSE revoked_at END
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update share status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("share not found")
+	}
+
+	return nil
+}
+
+// ListForDevice lists all non-revoked shares of a device
+func (r *DeviceShareRepository) ListForDevice(ctx context.Context, deviceID string) ([]models.DeviceShare, error) {
+	query := `
+		SELECT id, device_id, owner_user_id, shared_with_user_id, role, status,
+			invited_at, responded_at, revoked_at
+		FROM device_shares
+		WHERE device_id = $1 AND status != 'REVOKED'
+		ORDER BY invited_at ASC
+	`
+
+	return r.queryShares(ctx, query, deviceID)
+}
+
+// GetSharedDevices returns devices shared with a user via accepted shares
+func (r *DeviceShareRepository) GetSharedDevices(ctx context.Context, userID string) ([]models.Device, error) {
+	query := `
+		SELECT d.id, d.user_id, d.device_type, d.manufacturer, d.model, d.mac_address, d.paired_at,
+			d.battery_level, d.status, d.capabilities, d.settings, d.last_seen_at, d.created_at, d.updated_at
+		FROM devices d
+		JOIN device_shares s ON s.device_id = d.id
+		WHERE s.shared_with_user_id = $1 AND s.status = 'ACCEPTED' AND d.status != 'DELETED'
+		ORDER BY d.paired_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []models.Device
+	for rows.Next() {
+		device := models.Device{}
+		err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.DeviceType,
+			&device.Manufacturer,
+			&device.Model,
+			&device.MacAddress,
+			&device.PairedAt,
+			&device.BatteryLevel,
+			&device.Status,
+			&device.Capabilities,
+			&device.Settings,
+			&device.LastSeenAt,
+			&device.CreatedAt,
+			&device.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// queryShares runs a share query and scans the result rows
+func (r *DeviceShareRepository) queryShares(ctx context.Context, query string, args ...interface{}) ([]models.DeviceShare, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shares: %w", err)
+	}
+	defer rows.Close()
+
+	var shares []models.DeviceShare
+	for rows.Next() {
+		var share models.DeviceShare
+		err := rows.Scan(
+			&share.ID,
+			&share.DeviceID,
+			&share.OwnerUserID,
+			&share.SharedWithUserID,
+			&share.Role,
+			&share.Status,
+			&share.InvitedAt,
+			&share.RespondedAt,
+			&share.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	return shares, rows.Err()
+}
+
+// DeleteForUser removes every share a user participates in, as owner or
+// recipient (GDPR/CCPA data erasure)
+func (r *DeviceShareRepository) DeleteForUser(ctx context.Context, userID string) (int64, error) {
+	query := `DELETE FROM device_shares WHERE owner_user_id = $1 OR shared_with_user_id = $1`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete device shares for user: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
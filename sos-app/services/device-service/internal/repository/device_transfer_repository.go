@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrTransferWrongState is returned when a transfer cannot proceed because
+// it is no longer pending, or the device changed hands since it was initiated
+var ErrTransferWrongState = errors.New("transfer is not in the required state")
+
+// DeviceTransferRepository handles device transfer persistence
+type DeviceTransferRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceTransferRepository creates a new device transfer repository
+func NewDeviceTransferRepository(db *pgxpool.Pool) *DeviceTransferRepository {
+	return &DeviceTransferRepository{db: db}
+}
+
+// Create stores a pending transfer offer; the partial unique index rejects a
+// second in-flight transfer for the same device
+func (r *DeviceTransferRepository) Create(ctx context.Context, transfer *models.DeviceTransfer) error {
+	query := `
+		INSERT INTO device_transfers (id, device_id, from_user_id, to_user_id, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING initiated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		transfer.ID,
+		transfer.DeviceID,
+		transfer.FromUserID,
+		transfer.ToUserID,
+		transfer.Status,
+	).Scan(&transfer.InitiatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a transfer by ID
+func (r *DeviceTransferRepository) GetByID(ctx context.Context, id string) (*models.DeviceTransfer, error) {
+	query := `
+		SELECT id, device_id, from_user_id, to_user_id, status, initiated_at, responded_at
+		FROM device_transfers
+		WHERE id = $1
+	`
+
+	transfer := &models.DeviceTransfer{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&transfer.ID,
+		&transfer.DeviceID,
+		&transfer.FromUserID,
+		&transfer.ToUserID,
+		&transfer.Status,
+		&transfer.InitiatedAt,
+		&transfer.RespondedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// UpdateStatus transitions a pending transfer to DECLINED or CANCELLED;
+// acceptance goes through Execute instead
+func (r *DeviceTransferRepository) UpdateStatus(ctx context.Context, id string, status models.TransferStatus) error {
+	query := `
+		UPDATE device_transfers
+		SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+	`
+
+	result, err := r.db.Exec(ctx, query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update transfer status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrTransferWrongState
+	}
+
+	return nil
+}
+
+// Execute accepts a transfer: the transfer transition, the device
+// reassignment with a settings wipe, and the revocation of the previous
+// owner's shares commit in one transaction, so a crash can never leave a
+// half-transferred device
+func (r *DeviceTransferRepository) Execute(ctx context.Context, transfer *models.DeviceTransfer) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE device_transfers
+		SET status = $1, responded_at = NOW()
+		WHERE id = $2 AND status = $3
+	`, models.TransferStatusAccepted, transfer.ID, models.TransferStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to accept transfer: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrTransferWrongState
+	}
+
+	// Reassign the device and wipe user-specific settings; guarding on the
+	// initiating owner catches a device that was re-paired or deleted since
+	// the offer was made
+	result, err = tx.Exec(ctx, `
+		UPDATE devices
+		SET user_id = $2, settings = '{}', settings_version = settings_version + 1, updated_at = NOW()
+		WHERE id = $1 AND user_id = $3 AND status != 'DELETED'
+	`, transfer.DeviceID, transfer.ToUserID, transfer.FromUserID)
+	if err != nil {
+		return fmt.Errorf("failed to reassign device: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrTransferWrongState
+	}
+
+	// The previous owner's household shares must not follow the device
+	_, err = tx.Exec(ctx, `
+		UPDATE device_shares
+		SET status = 'REVOKED', revoked_at = NOW()
+		WHERE device_id = $1 AND status != 'REVOKED'
+	`, transfer.DeviceID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke shares: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transfer: %w", err)
+	}
+
+	return nil
+}
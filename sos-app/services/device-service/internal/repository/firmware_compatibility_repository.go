@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrFirmwareEntryNotFound is returned when a compatibility matrix entry
+// does not exist
+var ErrFirmwareEntryNotFound = errors.New("firmware compatibility entry not found")
+
+// FirmwareCompatibilityRepository stores the per-model firmware
+// compatibility matrix
+type FirmwareCompatibilityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewFirmwareCompatibilityRepository creates a new firmware compatibility repository
+func NewFirmwareCompatibilityRepository(db *pgxpool.Pool) *FirmwareCompatibilityRepository {
+	return &FirmwareCompatibilityRepository{db: db}
+}
+
+// Upsert creates or replaces the matrix entry for the entry's (model,
+// firmware version) combination, filling in the generated fields
+func (r *FirmwareCompatibilityRepository) Upsert(ctx context.Context, entry *models.FirmwareCompatibility) error {
+	query := `
+		INSERT INTO firmware_compatibility (device_model, firmware_version, supported_capabilities, known_issues, update_recommended)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (device_model, firmware_version) DO UPDATE SET
+			supported_capabilities = EXCLUDED.supported_capabilities,
+			known_issues = EXCLUDED.known_issues,
+			update_recommended = EXCLUDED.update_recommended,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.DeviceModel,
+		entry.FirmwareVersion,
+		entry.SupportedCapabilities,
+		entry.KnownIssues,
+		entry.UpdateRecommended,
+	).Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert firmware compatibility entry: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the matrix entry for a (model, firmware version) combination,
+// or nil when the combination is not in the matrix
+func (r *FirmwareCompatibilityRepository) Get(ctx context.Context, deviceModel, firmwareVersion string) (*models.FirmwareCompatibility, error) {
+	query := `
+		SELECT id, device_model, firmware_version, supported_capabilities, known_issues, update_recommended, created_at, updated_at
+		FROM firmware_compatibility
+		WHERE device_model = $1 AND firmware_version = $2
+	`
+
+	entry, err := scanFirmwareEntry(r.db.QueryRow(ctx, query, deviceModel, firmwareVersion))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firmware compatibility entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// List returns the whole compatibility matrix, grouped by model then version
+func (r *FirmwareCompatibilityRepository) List(ctx context.Context) ([]models.FirmwareCompatibility, error) {
+	query := `
+		SELECT id, device_model, firmware_version, supported_capabilities, known_issues, update_recommended, created_at, updated_at
+		FROM firmware_compatibility
+		ORDER BY device_model, firmware_version
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firmware compatibility entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.FirmwareCompatibility
+	for rows.Next() {
+		entry, err := scanFirmwareEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan firmware compatibility entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Delete removes a matrix entry by ID
+func (r *FirmwareCompatibilityRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM firmware_compatibility WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete firmware compatibility entry: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrFirmwareEntryNotFound
+	}
+
+	return nil
+}
+
+// scanFirmwareEntry scans one matrix row
+func scanFirmwareEntry(row pgx.Row) (*models.FirmwareCompatibility, error) {
+	var entry models.FirmwareCompatibility
+	err := row.Scan(
+		&entry.ID,
+		&entry.DeviceModel,
+		&entry.FirmwareVersion,
+		&entry.SupportedCapabilities,
+		&entry.KnownIssues,
+		&entry.UpdateRecommended,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
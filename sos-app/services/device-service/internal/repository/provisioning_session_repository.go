@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrProvisioningSessionNotFound is returned when a session does not exist
+var ErrProvisioningSessionNotFound = errors.New("provisioning session not found")
+
+// ErrProvisioningWrongState is returned when a session transition races with
+// another request or targets the wrong state
+var ErrProvisioningWrongState = errors.New("provisioning session is not in the required state")
+
+// ProvisioningSessionRepository handles BLE provisioning session persistence
+type ProvisioningSessionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProvisioningSessionRepository creates a new provisioning session repository
+func NewProvisioningSessionRepository(db *pgxpool.Pool) *ProvisioningSessionRepository {
+	return &ProvisioningSessionRepository{db: db}
+}
+
+// Create stores a new provisioning session
+func (r *ProvisioningSessionRepository) Create(ctx context.Context, session *models.ProvisioningSession) error {
+	query := `
+		INSERT INTO provisioning_sessions (user_id, status, device_type, manufacturer, model, secret_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		session.UserID,
+		session.Status,
+		session.DeviceType,
+		session.Manufacturer,
+		session.Model,
+		session.SecretHash,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create provisioning session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a provisioning session by ID
+func (r *ProvisioningSessionRepository) GetByID(ctx context.Context, id string) (*models.ProvisioningSession, error) {
+	query := `
+		SELECT id, user_id, status, device_type, manufacturer, model, secret_hash,
+			mac_address, serial_number, device_id, created_at, expires_at, confirmed_at, completed_at
+		FROM provisioning_sessions
+		WHERE id = $1
+	`
+
+	session := &models.ProvisioningSession{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.Status,
+		&session.DeviceType,
+		&session.Manufacturer,
+		&session.Model,
+		&session.SecretHash,
+		&session.MacAddress,
+		&session.SerialNumber,
+		&session.DeviceID,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&session.ConfirmedAt,
+		&session.CompletedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProvisioningSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get provisioning session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Confirm records the device's BLE confirmation on a pending session. The
+// status guard in the WHERE clause makes concurrent confirmations race-safe
+func (r *ProvisioningSessionRepository) Confirm(ctx context.Context, id, macAddress string, serialNumber *string) error {
+	query := `
+		UPDATE provisioning_sessions
+		SET status = $1, mac_address = $2, serial_number = $3, confirmed_at = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		models.ProvisioningStatusConfirmed,
+		macAddress,
+		serialNumber,
+		time.Now(),
+		id,
+		models.ProvisioningStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to confirm provisioning session: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrProvisioningWrongState
+	}
+
+	return nil
+}
+
+// Complete finalizes pairing for a confirmed session: the device row and the
+// session transition commit in one transaction, so a crash can never leave a
+// paired device without a completed session or vice versa
+func (r *ProvisioningSessionRepository) Complete(ctx context.Context, sessionID string, device *models.Device) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE provisioning_sessions
+		SET status = $1, device_id = $2, completed_at = $3
+		WHERE id = $4 AND status = $5
+	`,
+		models.ProvisioningStatusCompleted,
+		device.ID,
+		time.Now(),
+		sessionID,
+		models.ProvisioningStatusConfirmed,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete provisioning session: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrProvisioningWrongState
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO devices (id, user_id, device_type, manufacturer, model, mac_address,
+			paired_at, battery_level, status, capabilities, settings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING created_at, updated_at
+	`,
+		device.ID,
+		device.UserID,
+		device.DeviceType,
+		device.Manufacturer,
+		device.Model,
+		device.MacAddress,
+		device.PairedAt,
+		device.BatteryLevel,
+		device.Status,
+		device.Capabilities,
+		device.Settings,
+	).Scan(&device.CreatedAt, &device.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create device: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit provisioning: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// RawEventRepository handles persistence of verbatim MQTT event payloads
+type RawEventRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRawEventRepository creates a new raw event repository
+func NewRawEventRepository(db *pgxpool.Pool) *RawEventRepository {
+	return &RawEventRepository{db: db}
+}
+
+// Insert stores a raw event payload verbatim
+func (r *RawEventRepository) Insert(ctx context.Context, event *models.RawDeviceEvent) error {
+	query := `
+		INSERT INTO raw_device_events (topic, payload, payload_hash, received_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		event.Topic,
+		event.Payload,
+		event.PayloadHash,
+		event.ReceivedAt,
+	).Scan(&event.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert raw device event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves raw events, optionally filtered by topic prefix and time,
+// newest first
+func (r *RawEventRepository) List(ctx context.Context, topicPrefix string, since time.Time, limit int) ([]models.RawDeviceEvent, error) {
+	query := `
+		SELECT id, topic, payload, payload_hash, received_at
+		FROM raw_device_events
+		WHERE received_at >= $1 AND ($2 = '' OR topic LIKE $2 || '%')
+		ORDER BY received_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, since, topicPrefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raw device events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.RawDeviceEvent
+	for rows.Next() {
+		var event models.RawDeviceEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.Topic,
+			&event.Payload,
+			&event.PayloadHash,
+			&event.ReceivedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan raw device event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteForDevice purges all archived payloads for one device (GDPR/CCPA
+// data erasure), returning the number of rows removed. Raw events carry no
+// user column, so ownership is resolved through the MQTT topic prefix
+func (r *RawEventRepository) DeleteForDevice(ctx context.Context, deviceID string) (int64, error) {
+	query := `DELETE FROM raw_device_events WHERE topic LIKE 'devices/' || $1 || '/%'`
+
+	result, err := r.db.Exec(ctx, query, deviceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge raw device events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// DeleteOlderThan purges events past the retention window, returning the
+// number of rows removed
+func (r *RawEventRepository) DeleteOlderThan(ctx context.Context, retention time.Duration) (int64, error) {
+	query := `DELETE FROM raw_device_events WHERE received_at < $1`
+
+	result, err := r.db.Exec(ctx, query, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge raw device events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
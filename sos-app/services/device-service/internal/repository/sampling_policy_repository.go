@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// ErrSamplingPolicyNotFound is returned when no policy exists for a device type
+var ErrSamplingPolicyNotFound = errors.New("sampling policy not found")
+
+// SamplingPolicyRepository stores per-device-type telemetry sampling policies
+type SamplingPolicyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSamplingPolicyRepository creates a new sampling policy repository
+func NewSamplingPolicyRepository(db *pgxpool.Pool) *SamplingPolicyRepository {
+	return &SamplingPolicyRepository{db: db}
+}
+
+// Upsert creates or replaces the policy for a device type
+func (r *SamplingPolicyRepository) Upsert(ctx context.Context, policy *models.SamplingPolicy) error {
+	query := `
+		INSERT INTO telemetry_sampling_policies (device_type, sample_every, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (device_type)
+		DO UPDATE SET sample_every = EXCLUDED.sample_every, updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, policy.DeviceType, policy.SampleEvery).Scan(&policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert sampling policy: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves all sampling policies
+func (r *SamplingPolicyRepository) List(ctx context.Context) ([]models.SamplingPolicy, error) {
+	query := `
+		SELECT device_type, sample_every, updated_at
+		FROM telemetry_sampling_policies
+		ORDER BY device_type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sampling policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []models.SamplingPolicy
+	for rows.Next() {
+		var policy models.SamplingPolicy
+		if err := rows.Scan(&policy.DeviceType, &policy.SampleEvery, &policy.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sampling policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// Delete removes the policy for a device type
+func (r *SamplingPolicyRepository) Delete(ctx context.Context, deviceType models.DeviceType) error {
+	query := `DELETE FROM telemetry_sampling_policies WHERE device_type = $1`
+
+	result, err := r.db.Exec(ctx, query, deviceType)
+	if err != nil {
+		return fmt.Errorf("failed to delete sampling policy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSamplingPolicyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// TriggerQueueRepository persists emergency triggers that could not be
+// delivered to the emergency-service, so they survive restarts and are
+// retried until delivery succeeds
+type TriggerQueueRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTriggerQueueRepository creates a new trigger queue repository
+func NewTriggerQueueRepository(db *pgxpool.Pool) *TriggerQueueRepository {
+	return &TriggerQueueRepository{db: db}
+}
+
+// Enqueue stores a failed trigger for later retry
+func (r *TriggerQueueRepository) Enqueue(ctx context.Context, trigger *models.PendingEmergencyTrigger) error {
+	query := `
+		INSERT INTO pending_emergency_triggers (device_id, user_id, event_type, payload, last_error, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		trigger.DeviceID,
+		trigger.UserID,
+		trigger.EventType,
+		trigger.Payload,
+		trigger.LastError,
+		trigger.NextAttemptAt,
+	).Scan(&trigger.ID, &trigger.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue pending trigger: %w", err)
+	}
+
+	return nil
+}
+
+// FetchDue returns undelivered triggers whose next attempt is due, oldest first
+func (r *TriggerQueueRepository) FetchDue(ctx context.Context, limit int) ([]models.PendingEmergencyTrigger, error) {
+	query := `
+		SELECT id, device_id, user_id, event_type, payload, attempts, last_error, next_attempt_at, created_at
+		FROM pending_emergency_triggers
+		WHERE delivered_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY created_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch due triggers: %w", err)
+	}
+	defer rows.Close()
+
+	var triggers []models.PendingEmergencyTrigger
+	for rows.Next() {
+		var t models.PendingEmergencyTrigger
+		err := rows.Scan(
+			&t.ID,
+			&t.DeviceID,
+			&t.UserID,
+			&t.EventType,
+			&t.Payload,
+			&t.Attempts,
+			&t.LastError,
+			&t.NextAttemptAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending trigger: %w", err)
+		}
+		triggers = append(triggers, t)
+	}
+
+	return triggers, rows.Err()
+}
+
+// MarkDelivered records successful delivery of a trigger
+func (r *TriggerQueueRepository) MarkDelivered(ctx context.Context, id string) error {
+	query := `UPDATE pending_emergency_triggers SET delivered_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark trigger delivered: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFailure bumps the attempt counter and schedules the next retry
+func (r *TriggerQueueRepository) RecordFailure(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE pending_emergency_triggers
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to record trigger failure: %w", err)
+	}
+
+	return nil
+}
+
+// CountUndelivered returns the number of triggers still awaiting delivery
+func (r *TriggerQueueRepository) CountUndelivered(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM pending_emergency_triggers WHERE delivered_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count undelivered triggers: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByDeviceSince returns the number of emergency triggers a device has
+// raised since the given time, delivered or not
+func (r *TriggerQueueRepository) CountByDeviceSince(ctx context.Context, deviceID string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM pending_emergency_triggers WHERE device_id = $1 AND created_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, deviceID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count device triggers: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// VitalsReadingRepository persists sampled vital signs readings
+type VitalsReadingRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewVitalsReadingRepository creates a new vitals reading repository
+func NewVitalsReadingRepository(db *pgxpool.Pool) *VitalsReadingRepository {
+	return &VitalsReadingRepository{db: db}
+}
+
+// Create stores one vitals reading
+func (r *VitalsReadingRepository) Create(ctx context.Context, reading *models.VitalsReading) error {
+	query := `
+		INSERT INTO vitals_readings (device_id, user_id, heart_rate, spo2, temperature, systolic, diastolic, emergency_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, recorded_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		reading.DeviceID,
+		reading.UserID,
+		reading.HeartRate,
+		reading.SpO2,
+		reading.Temperature,
+		reading.Systolic,
+		reading.Diastolic,
+		reading.EmergencyID,
+	).Scan(&reading.ID, &reading.RecordedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create vitals reading: %w", err)
+	}
+
+	return nil
+}
+
+// ListByDevice retrieves recent readings for one device, newest first
+func (r *VitalsReadingRepository) ListByDevice(ctx context.Context, deviceID string, limit int) ([]models.VitalsReading, error) {
+	query := `
+		SELECT id, device_id, user_id, heart_rate, spo2, temperature, systolic, diastolic, emergency_id, recorded_at
+		FROM vitals_readings
+		WHERE device_id = $1
+		ORDER BY recorded_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, deviceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vitals readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []models.VitalsReading
+	for rows.Next() {
+		var reading models.VitalsReading
+		err := rows.Scan(
+			&reading.ID,
+			&reading.DeviceID,
+			&reading.UserID,
+			&reading.HeartRate,
+			&reading.SpO2,
+			&reading.Temperature,
+			&reading.Systolic,
+			&reading.Diastolic,
+			&reading.EmergencyID,
+			&reading.RecordedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vitals reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+
+	return readings, rows.Err()
+}
+
+// InsertBatch stores a batch of readings with the COPY protocol. The batched
+// writer hands over readings with recorded_at already set
+func (r *VitalsReadingRepository) InsertBatch(ctx context.Context, readings []*models.VitalsReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+
+	columns := []string{"device_id", "user_id", "heart_rate", "spo2", "temperature", "systolic", "diastolic", "emergency_id", "recorded_at"}
+	rows := make([][]interface{}, 0, len(readings))
+	for _, reading := range readings {
+		rows = append(rows, []interface{}{
+			reading.DeviceID,
+			reading.UserID,
+			reading.HeartRate,
+			reading.SpO2,
+			reading.Temperature,
+			reading.Systolic,
+			reading.Diastolic,
+			reading.EmergencyID,
+			reading.RecordedAt,
+		})
+	}
+
+	_, err := r.db.CopyFrom(ctx, pgx.Identifier{"vitals_readings"}, columns, pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("failed to batch insert vitals readings: %w", err)
+	}
+
+	return nil
+}
+
+// GetSeries returns downsampled avg/min/max buckets for one device's vitals
+// since the given time, oldest first. Downsampling happens in the database
+// (time_bucket) so charts over long ranges stay cheap
+func (r *VitalsReadingRepository) GetSeries(ctx context.Context, deviceID string, since time.Time, bucketSeconds int) ([]models.VitalsSeriesBucket, error) {
+	query := `
+		SELECT time_bucket(make_interval(secs => $3), recorded_at) AS bucket,
+		       AVG(heart_rate)::DOUBLE PRECISION, MIN(heart_rate)::DOUBLE PRECISION, MAX(heart_rate)::DOUBLE PRECISION,
+		       AVG(spo2)::DOUBLE PRECISION, MIN(spo2)::DOUBLE PRECISION, MAX(spo2)::DOUBLE PRECISION,
+		       AVG(temperature)::DOUBLE PRECISION, MIN(temperature), MAX(temperature)
+		FROM vitals_readings
+		WHERE device_id = $1 AND recorded_at >= $2
+		GROUP BY bucket
+		ORDER BY bucket ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, deviceID, since, bucketSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vitals series: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []models.VitalsSeriesBucket
+	for rows.Next() {
+		var bucket models.VitalsSeriesBucket
+		err := rows.Scan(
+			&bucket.BucketStart,
+			&bucket.HeartRateAvg,
+			&bucket.HeartRateMin,
+			&bucket.HeartRateMax,
+			&bucket.SpO2Avg,
+			&bucket.SpO2Min,
+			&bucket.SpO2Max,
+			&bucket.TemperatureAvg,
+			&bucket.TemperatureMin,
+			&bucket.TemperatureMax,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vitals bucket: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// AlertRecorder persists every alert the monitors emit so users can review
+// their alert history. Recording is best-effort: a storage failure is logged
+// and never interrupts the monitor that raised the alert
+type AlertRecorder struct {
+	alertRepo *repository.DeviceAlertRepository
+	logger    zerolog.Logger
+}
+
+// NewAlertRecorder creates a new alert recorder
+func NewAlertRecorder(alertRepo *repository.DeviceAlertRepository, logger zerolog.Logger) *AlertRecorder {
+	return &AlertRecorder{
+		alertRepo: alertRepo,
+		logger:    logger,
+	}
+}
+
+// Record stores one alert. Safe to call on a nil receiver, so monitors can
+// take an optional recorder
+func (r *AlertRecorder) Record(ctx context.Context, device *models.Device, category, alertType, priority, message string) {
+	if r == nil {
+		return
+	}
+
+	alert := &models.DeviceAlert{
+		DeviceID:  device.ID,
+		UserID:    device.UserID,
+		Category:  category,
+		AlertType: alertType,
+		Priority:  priority,
+		Message:   message,
+	}
+
+	if err := r.alertRepo.Create(ctx, alert); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Str("category", category).
+			Msg("Failed to persist device alert")
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// AlertRouter evaluates organization routing rules when alerts are emitted,
+// answering which team should handle a given alert (battery to facilities,
+// vitals to nursing, disconnects to IT)
+type AlertRouter struct {
+	ruleRepo *repository.AlertRoutingRuleRepository
+	logger   zerolog.Logger
+}
+
+// NewAlertRouter creates a new alert router
+func NewAlertRouter(ruleRepo *repository.AlertRoutingRuleRepository, logger zerolog.Logger) *AlertRouter {
+	return &AlertRouter{
+		ruleRepo: ruleRepo,
+		logger:   logger,
+	}
+}
+
+// Route returns the first enabled rule, in rank order, that matches the
+// alert for the organization, or nil when no rule matches
+func (r *AlertRouter) Route(ctx context.Context, orgID string, alert models.AlertContext) (*models.AlertRoutingRule, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	rules, err := r.ruleRepo.ListEnabledByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		if rules[i].Matches(alert) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
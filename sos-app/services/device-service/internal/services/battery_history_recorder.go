@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// defaultBatterySampleInterval is how often an unchanged battery level is
+// still persisted, so gaps in the chart stay bounded
+const defaultBatterySampleInterval = 15 * time.Minute
+
+// BatteryHistoryRecorder decimates battery telemetry before persisting it:
+// a sample is stored when the level changed or when the last stored sample
+// is older than the sample interval
+type BatteryHistoryRecorder struct {
+	historyRepo *repository.BatteryHistoryRepository
+	interval    time.Duration
+	logger      zerolog.Logger
+
+	mu   sync.Mutex
+	last map[string]batterySample
+}
+
+// batterySample remembers the last stored sample per device
+type batterySample struct {
+	level    int
+	storedAt time.Time
+}
+
+// NewBatteryHistoryRecorder creates a new battery history recorder. A
+// non-positive interval falls back to the default
+func NewBatteryHistoryRecorder(historyRepo *repository.BatteryHistoryRepository, interval time.Duration, logger zerolog.Logger) *BatteryHistoryRecorder {
+	if interval <= 0 {
+		interval = defaultBatterySampleInterval
+	}
+
+	return &BatteryHistoryRecorder{
+		historyRepo: historyRepo,
+		interval:    interval,
+		logger:      logger,
+		last:        make(map[string]batterySample),
+	}
+}
+
+// Record stores the sample if it adds information to the chart
+func (r *BatteryHistoryRecorder) Record(ctx context.Context, deviceID string, batteryLevel int) {
+	r.mu.Lock()
+	previous, seen := r.last[deviceID]
+	if seen && previous.level == batteryLevel && time.Since(previous.storedAt) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.last[deviceID] = batterySample{level: batteryLevel, storedAt: time.Now()}
+	r.mu.Unlock()
+
+	if err := r.historyRepo.Record(ctx, deviceID, batteryLevel); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("device_id", deviceID).
+			Msg("Failed to record battery sample")
+	}
+}
@@ -23,6 +23,7 @@ type BatteryMonitor struct {
 	// to avoid sending duplicate notifications
 	notifiedDevices map[string]map[BatteryThreshold]bool
 	mu              sync.RWMutex
+	alertRecorder   *AlertRecorder
 	logger          zerolog.Logger
 }
 
@@ -34,6 +35,32 @@ func NewBatteryMonitor(logger zerolog.Logger) *BatteryMonitor {
 	}
 }
 
+// SetAlertRecorder enables alert history persistence. Call during startup,
+// before monitoring begins
+func (m *BatteryMonitor) SetAlertRecorder(recorder *AlertRecorder) {
+	m.alertRecorder = recorder
+}
+
+// RegisterDevice pre-creates a device's notification tracking, seeding it
+// from the stored battery level so a restart doesn't re-alert devices that
+// were already below a threshold (used by the startup cache warmer)
+func (m *BatteryMonitor) RegisterDevice(device *models.Device) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.notifiedDevices[device.ID] != nil {
+		return
+	}
+
+	m.notifiedDevices[device.ID] = make(map[BatteryThreshold]bool)
+	if device.BatteryLevel <= int(BatteryThreshold20) {
+		m.notifiedDevices[device.ID][BatteryThreshold20] = true
+	}
+	if device.BatteryLevel <= int(BatteryThreshold10) {
+		m.notifiedDevices[device.ID][BatteryThreshold10] = true
+	}
+}
+
 // CheckAndNotify checks battery level and sends notification if needed
 func (m *BatteryMonitor) CheckAndNotify(ctx context.Context, device *models.Device, batteryLevel int) error {
 	m.mu.Lock()
@@ -85,10 +112,12 @@ func (m *BatteryMonitor) sendLowBatteryNotification(
 	threshold BatteryThreshold,
 ) error {
 	priority := "MEDIUM"
+	alertType := models.AlertTypeLowBattery
 	message := fmt.Sprintf("Device battery is low (%d%%)", batteryLevel)
 
 	if threshold == BatteryThreshold10 {
 		priority = "HIGH"
+		alertType = models.AlertTypeCriticalBattery
 		message = fmt.Sprintf("CRITICAL: Device battery is critically low (%d%%)", batteryLevel)
 		m.logger.Warn().
 			Str("device_id", device.ID).
@@ -115,6 +144,9 @@ func (m *BatteryMonitor) sendLowBatteryNotification(
 		Str("message", message).
 		Msg("Low battery notification should be sent")
 
+	// Persist the alert so the user can review it later
+	m.alertRecorder.Record(ctx, device, models.AlertCategoryBattery, alertType, priority, message)
+
 	// TODO: Integrate with notification service
 	// Example:
 	// notificationPayload := map[string]interface{}{
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// CacheWarmer loads the active device fleet at startup so the first burst
+// of MQTT traffic after a restart doesn't turn into one database lookup
+// per message. Besides filling the device cache it seeds the battery
+// monitor's notification state from the stored battery levels (otherwise a
+// restart re-alerts every device already below a threshold) and
+// pre-creates the vitals alert-policy entries for each device owner
+type CacheWarmer struct {
+	deviceRepo     *repository.DeviceRepository
+	cache          *DeviceCache
+	batteryMonitor *BatteryMonitor
+	vitalsService  *VitalsService
+	logger         zerolog.Logger
+}
+
+// NewCacheWarmer creates a cache warmer
+func NewCacheWarmer(
+	deviceRepo *repository.DeviceRepository,
+	cache *DeviceCache,
+	batteryMonitor *BatteryMonitor,
+	vitalsService *VitalsService,
+	logger zerolog.Logger,
+) *CacheWarmer {
+	return &CacheWarmer{
+		deviceRepo:     deviceRepo,
+		cache:          cache,
+		batteryMonitor: batteryMonitor,
+		vitalsService:  vitalsService,
+		logger:         logger,
+	}
+}
+
+// Warm loads all ACTIVE devices into the cache and pre-registers their
+// per-device monitoring state. The caller bounds it with a context
+// timeout; a failure leaves the service serving cold, it is never fatal
+func (w *CacheWarmer) Warm(ctx context.Context) error {
+	start := time.Now()
+
+	devices, err := w.deviceRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active devices: %w", err)
+	}
+
+	for _, device := range devices {
+		w.cache.Put(device)
+		w.batteryMonitor.RegisterDevice(device)
+		w.vitalsService.RegisterUser(device.UserID)
+	}
+
+	w.logger.Info().
+		Int("devices", len(devices)).
+		Dur("took", time.Since(start)).
+		Msg("Device cache warmed")
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// Default clock-skew limits; the tolerance absorbs normal NTP drift and MQTT
+// delivery delay, the cooldown keeps a chronically skewed device from
+// flooding the user's alert history on every message
+const (
+	DefaultMaxClockSkew           = 2 * time.Minute
+	DefaultClockSkewAlertCooldown = 6 * time.Hour
+)
+
+// ClockSkewConfig holds clock-skew detection tunables
+type ClockSkewConfig struct {
+	MaxSkew       time.Duration
+	AlertCooldown time.Duration
+}
+
+// ClockSkewCheck is the outcome of comparing a device-reported timestamp
+// against the broker receipt time. Timestamp is always the value callers
+// should use; Corrected reports whether it was replaced by the receipt time
+type ClockSkewCheck struct {
+	Timestamp time.Time
+	Skew      time.Duration
+	Corrected bool
+}
+
+// Annotate records the correction on a payload's metadata map so downstream
+// consumers can tell the timestamp was rewritten, returning the map (which
+// is allocated when nil)
+func (c ClockSkewCheck) Annotate(meta map[string]interface{}, original time.Time) map[string]interface{} {
+	if !c.Corrected {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]interface{})
+	}
+	meta["original_timestamp"] = original.Format(time.RFC3339)
+	meta["clock_skew_seconds"] = c.Skew.Seconds()
+	return meta
+}
+
+// ClockSkewMonitor detects devices whose clocks have drifted. Devices with a
+// wrong clock report future or ancient timestamps that corrupt last-seen
+// logic and telemetry ordering, so reported times beyond the tolerance are
+// replaced with the broker receipt time and the device owner is alerted.
+// Missing timestamps are filled with the receipt time without counting as
+// skew, since some firmware never sets one
+type ClockSkewMonitor struct {
+	config        ClockSkewConfig
+	alertRecorder *AlertRecorder
+	logger        zerolog.Logger
+
+	mu          sync.Mutex
+	lastAlerted map[string]time.Time
+}
+
+// NewClockSkewMonitor creates a clock-skew monitor, filling in defaults for
+// any unset limits
+func NewClockSkewMonitor(config ClockSkewConfig, logger zerolog.Logger) *ClockSkewMonitor {
+	if config.MaxSkew <= 0 {
+		config.MaxSkew = DefaultMaxClockSkew
+	}
+	if config.AlertCooldown <= 0 {
+		config.AlertCooldown = DefaultClockSkewAlertCooldown
+	}
+
+	return &ClockSkewMonitor{
+		config:      config,
+		logger:      logger,
+		lastAlerted: make(map[string]time.Time),
+	}
+}
+
+// SetAlertRecorder enables alert history persistence. Call during startup,
+// before messages are processed
+func (m *ClockSkewMonitor) SetAlertRecorder(recorder *AlertRecorder) {
+	m.alertRecorder = recorder
+}
+
+// Check compares a device-reported timestamp against the local receipt time.
+// Within tolerance the reported time is kept; beyond it the receipt time is
+// used instead and a device-clock-skew alert is raised, subject to the
+// per-device cooldown. Safe to call on a nil receiver, so handlers can take
+// an optional monitor
+func (m *ClockSkewMonitor) Check(ctx context.Context, device *models.Device, reported time.Time) ClockSkewCheck {
+	receivedAt := time.Now()
+
+	if m == nil {
+		return ClockSkewCheck{Timestamp: reported}
+	}
+	if reported.IsZero() {
+		return ClockSkewCheck{Timestamp: receivedAt}
+	}
+
+	skew := reported.Sub(receivedAt)
+	drift := skew
+	direction := "ahead"
+	if drift < 0 {
+		drift = -drift
+		direction = "behind"
+	}
+	if drift <= m.config.MaxSkew {
+		return ClockSkewCheck{Timestamp: reported, Skew: skew}
+	}
+
+	m.logger.Warn().
+		Str("device_id", device.ID).
+		Time("reported", reported).
+		Dur("skew", skew).
+		Msg("Device timestamp beyond skew tolerance - using receipt time")
+
+	if m.shouldAlert(device.ID, receivedAt) {
+		message := fmt.Sprintf("Device clock is %s by %s - check its time settings", direction, drift.Round(time.Second))
+		m.alertRecorder.Record(ctx, device, models.AlertCategoryConnectivity, models.AlertTypeClockSkew, models.AlertPriorityLow, message)
+	}
+
+	return ClockSkewCheck{Timestamp: receivedAt, Skew: skew, Corrected: true}
+}
+
+// shouldAlert enforces the per-device alert cooldown
+func (m *ClockSkewMonitor) shouldAlert(deviceID string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if last, ok := m.lastAlerted[deviceID]; ok && now.Sub(last) < m.config.AlertCooldown {
+		return false
+	}
+	m.lastAlerted[deviceID] = now
+	return true
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClockSkewMonitor(config ClockSkewConfig) *ClockSkewMonitor {
+	return NewClockSkewMonitor(config, zerolog.Nop())
+}
+
+func testClockSkewDevice() *models.Device {
+	return &models.Device{ID: "device-123", UserID: "user-456"}
+}
+
+func TestClockSkew_KeepsTimestampWithinTolerance(t *testing.T) {
+	monitor := newTestClockSkewMonitor(ClockSkewConfig{MaxSkew: time.Minute})
+	reported := time.Now().Add(30 * time.Second)
+
+	check := monitor.Check(context.Background(), testClockSkewDevice(), reported)
+
+	assert.False(t, check.Corrected)
+	assert.Equal(t, reported, check.Timestamp)
+}
+
+func TestClockSkew_CorrectsFutureTimestamp(t *testing.T) {
+	monitor := newTestClockSkewMonitor(ClockSkewConfig{MaxSkew: time.Minute})
+	reported := time.Now().Add(time.Hour)
+
+	check := monitor.Check(context.Background(), testClockSkewDevice(), reported)
+
+	assert.True(t, check.Corrected)
+	assert.True(t, check.Timestamp.Before(reported))
+	assert.Greater(t, check.Skew, time.Duration(0))
+}
+
+func TestClockSkew_CorrectsAncientTimestamp(t *testing.T) {
+	monitor := newTestClockSkewMonitor(ClockSkewConfig{MaxSkew: time.Minute})
+	reported := time.Now().Add(-24 * time.Hour)
+
+	check := monitor.Check(context.Background(), testClockSkewDevice(), reported)
+
+	assert.True(t, check.Corrected)
+	assert.True(t, check.Timestamp.After(reported))
+	assert.Less(t, check.Skew, time.Duration(0))
+}
+
+func TestClockSkew_FillsMissingTimestampWithoutCorrection(t *testing.T) {
+	monitor := newTestClockSkewMonitor(ClockSkewConfig{MaxSkew: time.Minute})
+
+	check := monitor.Check(context.Background(), testClockSkewDevice(), time.Time{})
+
+	assert.False(t, check.Corrected)
+	assert.False(t, check.Timestamp.IsZero())
+}
+
+func TestClockSkew_NilMonitorPassesThrough(t *testing.T) {
+	var monitor *ClockSkewMonitor
+	reported := time.Now().Add(time.Hour)
+
+	check := monitor.Check(context.Background(), testClockSkewDevice(), reported)
+
+	assert.False(t, check.Corrected)
+	assert.Equal(t, reported, check.Timestamp)
+}
+
+func TestClockSkew_AlertCooldownPerDevice(t *testing.T) {
+	monitor := newTestClockSkewMonitor(ClockSkewConfig{MaxSkew: time.Minute, AlertCooldown: time.Hour})
+	now := time.Now()
+
+	assert.True(t, monitor.shouldAlert("device-123", now))
+	assert.False(t, monitor.shouldAlert("device-123", now.Add(time.Minute)))
+	assert.True(t, monitor.shouldAlert("device-456", now))
+	assert.True(t, monitor.shouldAlert("device-123", now.Add(2*time.Hour)))
+}
+
+func TestClockSkewCheck_AnnotateRecordsCorrection(t *testing.T) {
+	original := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	check := ClockSkewCheck{Skew: 90 * time.Second, Corrected: true}
+
+	meta := check.Annotate(nil, original)
+
+	assert.Equal(t, "2026-01-01T12:00:00Z", meta["original_timestamp"])
+	assert.Equal(t, 90.0, meta["clock_skew_seconds"])
+}
+
+func TestClockSkewCheck_AnnotateNoOpWhenNotCorrected(t *testing.T) {
+	check := ClockSkewCheck{}
+
+	assert.Nil(t, check.Annotate(nil, time.Now()))
+}
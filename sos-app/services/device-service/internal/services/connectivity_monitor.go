@@ -14,22 +14,27 @@ import (
 // ConnectivityMonitor monitors device connectivity status
 type ConnectivityMonitor struct {
 	deviceRepo          *repository.DeviceRepository
+	dispatcher          *WebhookDispatcher
 	disconnectThreshold time.Duration
 	checkInterval       time.Duration
 	notifiedDevices     map[string]bool
 	mu                  sync.RWMutex
+	alertRecorder       *AlertRecorder
 	logger              zerolog.Logger
 	stopChan            chan struct{}
 	wg                  sync.WaitGroup
 }
 
-// NewConnectivityMonitor creates a new connectivity monitor
+// NewConnectivityMonitor creates a new connectivity monitor. dispatcher may
+// be nil to disable connectivity webhooks
 func NewConnectivityMonitor(
 	deviceRepo *repository.DeviceRepository,
+	dispatcher *WebhookDispatcher,
 	logger zerolog.Logger,
 ) *ConnectivityMonitor {
 	return &ConnectivityMonitor{
 		deviceRepo:          deviceRepo,
+		dispatcher:          dispatcher,
 		disconnectThreshold: 5 * time.Minute, // Mark as disconnected if no telemetry for 5 minutes
 		checkInterval:       1 * time.Minute,  // Check every minute
 		notifiedDevices:     make(map[string]bool),
@@ -38,6 +43,12 @@ func NewConnectivityMonitor(
 	}
 }
 
+// SetAlertRecorder enables alert history persistence. Call during startup,
+// before monitoring begins
+func (m *ConnectivityMonitor) SetAlertRecorder(recorder *AlertRecorder) {
+	m.alertRecorder = recorder
+}
+
 // Start begins monitoring device connectivity
 func (m *ConnectivityMonitor) Start(ctx context.Context) {
 	m.logger.Info().
@@ -135,6 +146,12 @@ func (m *ConnectivityMonitor) handleDisconnectedDevice(ctx context.Context, devi
 			return err
 		}
 
+		// Notify registered NOC webhooks about the transition
+		if m.dispatcher != nil {
+			device.Status = models.DeviceStatusDisconnected
+			m.dispatcher.NotifyConnectivityChange(ctx, device, models.WebhookEventDeviceDisconnected)
+		}
+
 		// Mark as notified
 		m.mu.Lock()
 		m.notifiedDevices[device.ID] = true
@@ -164,6 +181,10 @@ func (m *ConnectivityMonitor) sendDisconnectionNotification(ctx context.Context,
 		Msgf("Device %s (%s) has disconnected. Last seen: %s",
 			device.Model, device.DeviceType, lastSeenStr)
 
+	// Persist the alert so the user can review it later
+	m.alertRecorder.Record(ctx, device, models.AlertCategoryConnectivity, models.AlertTypeDisconnected, "MEDIUM",
+		fmt.Sprintf("Device %s (%s) has disconnected. Last seen: %s", device.Model, device.DeviceType, lastSeenStr))
+
 	// In a real implementation, this would call a notification service
 	// For now, we just log the notification
 
@@ -237,6 +258,11 @@ func (m *ConnectivityMonitor) CheckDeviceConnectivity(ctx context.Context, devic
 				return fmt.Errorf("failed to update device status: %w", err)
 			}
 			m.MarkDeviceReconnected(deviceID)
+
+			if m.dispatcher != nil {
+				device.Status = models.DeviceStatusActive
+				m.dispatcher.NotifyConnectivityChange(ctx, device, models.WebhookEventDeviceReconnected)
+			}
 		}
 	}
 
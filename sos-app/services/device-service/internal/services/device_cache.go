@@ -0,0 +1,78 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// DefaultDeviceCacheTTL bounds how stale a cached device record may get
+const DefaultDeviceCacheTTL = 5 * time.Minute
+
+// DeviceCache is an in-memory, TTL-bounded cache of device records so the
+// MQTT handlers don't hit the database for every message. Entries age out
+// instead of being invalidated on every write path, so readers must
+// tolerate a record up to the TTL stale
+type DeviceCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]deviceCacheEntry
+}
+
+type deviceCacheEntry struct {
+	device    models.Device
+	expiresAt time.Time
+}
+
+// NewDeviceCache creates a device cache. A non-positive TTL falls back to
+// the default
+func NewDeviceCache(ttl time.Duration) *DeviceCache {
+	if ttl <= 0 {
+		ttl = DefaultDeviceCacheTTL
+	}
+	return &DeviceCache{
+		ttl:     ttl,
+		entries: make(map[string]deviceCacheEntry),
+	}
+}
+
+// Get returns a copy of the cached device, if present and not expired.
+// Returning a copy keeps callers that tweak fields (e.g. status after a
+// reconnect) from racing each other through the cache
+func (c *DeviceCache) Get(deviceID string) (*models.Device, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[deviceID]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	device := entry.device
+	return &device, true
+}
+
+// Put stores a device record, resetting its TTL
+func (c *DeviceCache) Put(device *models.Device) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[device.ID] = deviceCacheEntry{
+		device:    *device,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// Invalidate drops a device from the cache
+func (c *DeviceCache) Invalidate(deviceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, deviceID)
+}
+
+// Len returns the number of entries, expired or not
+func (c *DeviceCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceCache_PutAndGet(t *testing.T) {
+	cache := NewDeviceCache(time.Minute)
+
+	device := &models.Device{ID: "device-123", UserID: "user-123"}
+	cache.Put(device)
+
+	got, ok := cache.Get("device-123")
+	assert.True(t, ok)
+	assert.Equal(t, "user-123", got.UserID)
+
+	// Callers get a copy, not the cached record
+	got.UserID = "mutated"
+	again, ok := cache.Get("device-123")
+	assert.True(t, ok)
+	assert.Equal(t, "user-123", again.UserID)
+
+	_, ok = cache.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestDeviceCache_ExpiredEntryMisses(t *testing.T) {
+	cache := NewDeviceCache(10 * time.Millisecond)
+
+	cache.Put(&models.Device{ID: "device-123"})
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok := cache.Get("device-123")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDeviceCache_Invalidate(t *testing.T) {
+	cache := NewDeviceCache(time.Minute)
+
+	cache.Put(&models.Device{ID: "device-123"})
+	cache.Invalidate("device-123")
+
+	_, ok := cache.Get("device-123")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestBatteryMonitor_RegisterDevice_SeedsFromStoredLevel(t *testing.T) {
+	monitor := NewBatteryMonitor(zerolog.Nop())
+
+	// A device already below the warning threshold at warm-up must not be
+	// re-alerted after a restart
+	monitor.RegisterDevice(&models.Device{ID: "device-low", BatteryLevel: 15})
+	status := monitor.GetNotificationStatus("device-low")
+	assert.True(t, status[BatteryThreshold20])
+	assert.False(t, status[BatteryThreshold10])
+
+	// A healthy device starts with a clean slate
+	monitor.RegisterDevice(&models.Device{ID: "device-full", BatteryLevel: 90})
+	status = monitor.GetNotificationStatus("device-full")
+	assert.False(t, status[BatteryThreshold20])
+	assert.False(t, status[BatteryThreshold10])
+}
@@ -0,0 +1,51 @@
+package services
+
+import "sync"
+
+// EmergencyStateCache tracks which users currently have an active emergency
+// and which emergency it is. It is fed from the emergency lifecycle Kafka
+// topics so telemetry handling can check emergency state without calling the
+// emergency service
+type EmergencyStateCache struct {
+	mu     sync.RWMutex
+	active map[string]string
+}
+
+// NewEmergencyStateCache creates an empty emergency state cache
+func NewEmergencyStateCache() *EmergencyStateCache {
+	return &EmergencyStateCache{
+		active: make(map[string]string),
+	}
+}
+
+// SetActive marks a user as having an active emergency. emergencyID may be
+// empty on legacy events; the user still counts as active
+func (c *EmergencyStateCache) SetActive(userID, emergencyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active[userID] = emergencyID
+}
+
+// ClearActive marks a user's emergency as over
+func (c *EmergencyStateCache) ClearActive(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.active, userID)
+}
+
+// IsActive reports whether a user currently has an active emergency
+func (c *EmergencyStateCache) IsActive(userID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.active[userID]
+	return ok
+}
+
+// ActiveEmergency returns the user's active emergency ID. ok is true while
+// the user has an active emergency, even if its ID is unknown
+func (c *EmergencyStateCache) ActiveEmergency(userID string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	emergencyID, ok := c.active[userID]
+	return emergencyID, ok
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/locationapi"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// EmergencyTelemetryForwarder routes telemetry from the triggering device to
+// the right downstream APIs while its user has an active emergency: location
+// fixes go to location-service and vitals snapshots are tagged with the
+// emergency. Forwarding is best-effort: a downstream failure is logged and
+// never interrupts telemetry processing
+type EmergencyTelemetryForwarder struct {
+	stateCache     *EmergencyStateCache
+	locationClient *locationapi.Client
+	logger         zerolog.Logger
+}
+
+// NewEmergencyTelemetryForwarder creates a new emergency telemetry forwarder
+func NewEmergencyTelemetryForwarder(stateCache *EmergencyStateCache, locationClient *locationapi.Client, logger zerolog.Logger) *EmergencyTelemetryForwarder {
+	return &EmergencyTelemetryForwarder{
+		stateCache:     stateCache,
+		locationClient: locationClient,
+		logger:         logger,
+	}
+}
+
+// ForwardLocation posts the location portion of a telemetry payload to
+// location-service when the device's user has an active emergency. Safe to
+// call on a nil receiver, so the telemetry handler can take an optional
+// forwarder
+func (f *EmergencyTelemetryForwarder) ForwardLocation(ctx context.Context, device *models.Device, location *models.Location, batteryLevel int) {
+	if f == nil || location == nil {
+		return
+	}
+
+	emergencyID, ok := f.stateCache.ActiveEmergency(device.UserID)
+	if !ok || emergencyID == "" {
+		return
+	}
+
+	update := &locationapi.LocationUpdate{
+		EmergencyID: emergencyID,
+		UserID:      device.UserID,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		Provider:    "GPS",
+	}
+	if location.Accuracy > 0 {
+		accuracy := location.Accuracy
+		update.Accuracy = &accuracy
+	}
+	if batteryLevel > 0 {
+		battery := batteryLevel
+		update.BatteryLevel = &battery
+	}
+
+	if err := f.locationClient.UpdateLocation(ctx, update); err != nil {
+		f.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Str("emergency_id", emergencyID).
+			Msg("Failed to forward location to location-service")
+	}
+}
+
+// AttachEmergency tags a vitals reading with the user's active emergency so
+// the snapshot can be pulled into the incident record. Safe to call on a nil
+// receiver
+func (f *EmergencyTelemetryForwarder) AttachEmergency(reading *models.VitalsReading) {
+	if f == nil {
+		return
+	}
+
+	emergencyID, ok := f.stateCache.ActiveEmergency(reading.UserID)
+	if !ok || emergencyID == "" {
+		return
+	}
+
+	reading.EmergencyID = &emergencyID
+}
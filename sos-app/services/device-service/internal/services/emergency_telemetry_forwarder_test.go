@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/locationapi"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forwarderDevice() *models.Device {
+	return &models.Device{
+		ID:     "device-123",
+		UserID: "user-1",
+	}
+}
+
+func TestEmergencyTelemetryForwarder_ForwardsLocationWhileActive(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/location/update", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cache := NewEmergencyStateCache()
+	cache.SetActive("user-1", "emergency-1")
+	forwarder := NewEmergencyTelemetryForwarder(cache, locationapi.NewClient(server.URL), zerolog.Nop())
+
+	forwarder.ForwardLocation(context.Background(), forwarderDevice(), &models.Location{
+		Latitude:  37.7749,
+		Longitude: -122.4194,
+		Accuracy:  8.0,
+	}, 72)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "emergency-1", received["emergencyId"])
+	assert.Equal(t, "user-1", received["userId"])
+	assert.Equal(t, 37.7749, received["latitude"])
+	assert.Equal(t, -122.4194, received["longitude"])
+	assert.Equal(t, 8.0, received["accuracy"])
+	assert.Equal(t, float64(72), received["batteryLevel"])
+	assert.Equal(t, "GPS", received["provider"])
+}
+
+func TestEmergencyTelemetryForwarder_SkipsWithoutActiveEmergency(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	forwarder := NewEmergencyTelemetryForwarder(NewEmergencyStateCache(), locationapi.NewClient(server.URL), zerolog.Nop())
+
+	forwarder.ForwardLocation(context.Background(), forwarderDevice(), &models.Location{Latitude: 1, Longitude: 2}, 50)
+
+	assert.False(t, called)
+}
+
+func TestEmergencyTelemetryForwarder_AttachEmergency(t *testing.T) {
+	cache := NewEmergencyStateCache()
+	cache.SetActive("user-1", "emergency-1")
+	forwarder := NewEmergencyTelemetryForwarder(cache, nil, zerolog.Nop())
+
+	tagged := &models.VitalsReading{DeviceID: "device-123", UserID: "user-1"}
+	forwarder.AttachEmergency(tagged)
+	require.NotNil(t, tagged.EmergencyID)
+	assert.Equal(t, "emergency-1", *tagged.EmergencyID)
+
+	untagged := &models.VitalsReading{DeviceID: "device-456", UserID: "user-2"}
+	forwarder.AttachEmergency(untagged)
+	assert.Nil(t, untagged.EmergencyID)
+}
+
+func TestEmergencyTelemetryForwarder_NilReceiverIsSafe(t *testing.T) {
+	var forwarder *EmergencyTelemetryForwarder
+
+	forwarder.ForwardLocation(context.Background(), forwarderDevice(), &models.Location{Latitude: 1, Longitude: 2}, 50)
+	forwarder.AttachEmergency(&models.VitalsReading{UserID: "user-1"})
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// DefaultDedupeWindow is the suppression window used when no per-device-type
+// override is configured.
+const DefaultDedupeWindow = 30 * time.Second
+
+// EventDeduplicator suppresses repeated identical events from the same device
+// within a configurable window, so a user mashing the SOS button produces a
+// single emergency trigger instead of dozens of emergency-service calls.
+// Suppressed presses are still counted and exposed as metadata.
+type EventDeduplicator struct {
+	defaultWindow time.Duration
+	// Per-device-type window overrides (e.g. a panic button may warrant a
+	// longer window than a smartwatch)
+	typeWindows map[models.DeviceType]time.Duration
+
+	// Track the last forwarded event and suppressed repeats per device+event type
+	entries map[dedupeKey]*dedupeEntry
+	mu      sync.Mutex
+	logger  zerolog.Logger
+}
+
+type dedupeKey struct {
+	deviceID  string
+	eventType string
+}
+
+type dedupeEntry struct {
+	lastForwarded   time.Time
+	suppressedCount int
+}
+
+// NewEventDeduplicator creates a new event deduplicator. A zero defaultWindow
+// falls back to DefaultDedupeWindow. typeWindows may be nil.
+func NewEventDeduplicator(defaultWindow time.Duration, typeWindows map[models.DeviceType]time.Duration, logger zerolog.Logger) *EventDeduplicator {
+	if defaultWindow <= 0 {
+		defaultWindow = DefaultDedupeWindow
+	}
+
+	return &EventDeduplicator{
+		defaultWindow: defaultWindow,
+		typeWindows:   typeWindows,
+		entries:       make(map[dedupeKey]*dedupeEntry),
+		logger:        logger,
+	}
+}
+
+// ShouldSuppress reports whether an identical event from this device arrived
+// within the dedupe window. When suppressed, the repeat is counted; when
+// forwarded, it returns how many repeats were suppressed since the last
+// forwarded event so handlers can attach that as trigger metadata.
+func (d *EventDeduplicator) ShouldSuppress(deviceID, eventType string, deviceType models.DeviceType) (suppress bool, suppressedRepeats int) {
+	window := d.windowFor(deviceType)
+	now := time.Now()
+	key := dedupeKey{deviceID: deviceID, eventType: eventType}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, exists := d.entries[key]
+	if exists && now.Sub(entry.lastForwarded) < window {
+		entry.suppressedCount++
+		d.logger.Info().
+			Str("device_id", deviceID).
+			Str("event_type", eventType).
+			Int("suppressed_count", entry.suppressedCount).
+			Dur("window", window).
+			Msg("Suppressing duplicate device event within dedupe window")
+		return true, entry.suppressedCount
+	}
+
+	repeats := 0
+	if exists {
+		repeats = entry.suppressedCount
+	}
+
+	d.entries[key] = &dedupeEntry{lastForwarded: now}
+	d.pruneLocked(now)
+
+	return false, repeats
+}
+
+// windowFor returns the dedupe window for a device type
+func (d *EventDeduplicator) windowFor(deviceType models.DeviceType) time.Duration {
+	if w, ok := d.typeWindows[deviceType]; ok && w > 0 {
+		return w
+	}
+	return d.defaultWindow
+}
+
+// pruneLocked drops entries that are far outside any configured window.
+// Caller must hold d.mu.
+func (d *EventDeduplicator) pruneLocked(now time.Time) {
+	maxWindow := d.defaultWindow
+	for _, w := range d.typeWindows {
+		if w > maxWindow {
+			maxWindow = w
+		}
+	}
+
+	for key, entry := range d.entries {
+		if now.Sub(entry.lastForwarded) > 2*maxWindow {
+			delete(d.entries, key)
+		}
+	}
+}
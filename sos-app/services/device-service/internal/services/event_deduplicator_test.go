@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventDeduplicator_SuppressesRepeatsWithinWindow(t *testing.T) {
+	dedup := NewEventDeduplicator(30*time.Second, nil, zerolog.Nop())
+
+	// First press goes through
+	suppress, repeats := dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+	assert.Equal(t, 0, repeats)
+
+	// Repeated presses within the window are suppressed and counted
+	suppress, repeats = dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.True(t, suppress)
+	assert.Equal(t, 1, repeats)
+
+	suppress, repeats = dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.True(t, suppress)
+	assert.Equal(t, 2, repeats)
+}
+
+func TestEventDeduplicator_DifferentEventTypesNotSuppressed(t *testing.T) {
+	dedup := NewEventDeduplicator(30*time.Second, nil, zerolog.Nop())
+
+	suppress, _ := dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+
+	// A different event type from the same device is not a duplicate
+	suppress, _ = dedup.ShouldSuppress("device-123", models.EventTypeFallDetected, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+
+	// Neither is the same event type from a different device
+	suppress, _ = dedup.ShouldSuppress("device-456", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+}
+
+func TestEventDeduplicator_ForwardsAfterWindowExpires(t *testing.T) {
+	dedup := NewEventDeduplicator(10*time.Millisecond, nil, zerolog.Nop())
+
+	suppress, _ := dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+
+	suppress, _ = dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.True(t, suppress)
+
+	time.Sleep(15 * time.Millisecond)
+
+	// After the window, the event is forwarded again and carries the count
+	// of presses that were suppressed in the meantime
+	suppress, repeats := dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypePanicButton)
+	assert.False(t, suppress)
+	assert.Equal(t, 1, repeats)
+}
+
+func TestEventDeduplicator_PerDeviceTypeWindow(t *testing.T) {
+	dedup := NewEventDeduplicator(30*time.Second, map[models.DeviceType]time.Duration{
+		models.DeviceTypeSmartWatch: 10 * time.Millisecond,
+	}, zerolog.Nop())
+
+	suppress, _ := dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypeSmartWatch)
+	assert.False(t, suppress)
+
+	time.Sleep(15 * time.Millisecond)
+
+	// The smartwatch override has already expired while the default window
+	// would still be suppressing
+	suppress, _ = dedup.ShouldSuppress("device-123", models.EventTypeSOSButtonPressed, models.DeviceTypeSmartWatch)
+	assert.False(t, suppress)
+}
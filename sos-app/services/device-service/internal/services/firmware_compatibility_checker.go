@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// DefaultFirmwareAlertCooldown keeps a device that reports its firmware
+// version in every telemetry message from flooding the owner's alert
+// history with the same warnings
+const DefaultFirmwareAlertCooldown = 24 * time.Hour
+
+// FirmwareCompatibilityChecker checks paired devices and firmware-version
+// telemetry against the support-maintained compatibility matrix and surfaces
+// the known issues of a (model, firmware version) combination through the
+// alerts API
+type FirmwareCompatibilityChecker struct {
+	compatRepo    *repository.FirmwareCompatibilityRepository
+	alertRecorder *AlertRecorder
+	cooldown      time.Duration
+	logger        zerolog.Logger
+
+	mu          sync.Mutex
+	lastAlerted map[string]time.Time
+}
+
+// NewFirmwareCompatibilityChecker creates a new firmware compatibility
+// checker. A non-positive cooldown falls back to the default
+func NewFirmwareCompatibilityChecker(compatRepo *repository.FirmwareCompatibilityRepository, cooldown time.Duration, logger zerolog.Logger) *FirmwareCompatibilityChecker {
+	if cooldown <= 0 {
+		cooldown = DefaultFirmwareAlertCooldown
+	}
+	return &FirmwareCompatibilityChecker{
+		compatRepo:  compatRepo,
+		cooldown:    cooldown,
+		logger:      logger,
+		lastAlerted: make(map[string]time.Time),
+	}
+}
+
+// SetAlertRecorder enables alert history persistence. Call during startup,
+// before checking begins
+func (c *FirmwareCompatibilityChecker) SetAlertRecorder(recorder *AlertRecorder) {
+	c.alertRecorder = recorder
+}
+
+// CheckAndWarn looks the device's (model, firmware version) combination up
+// in the matrix and records one alert covering every warning it produces.
+// Combinations not in the matrix warn nothing. Safe to call on a nil
+// receiver, so handlers can take an optional checker. The warnings are
+// returned so callers like pairing can also log them
+func (c *FirmwareCompatibilityChecker) CheckAndWarn(ctx context.Context, device *models.Device, firmwareVersion string) []string {
+	if c == nil || firmwareVersion == "" {
+		return nil
+	}
+
+	entry, err := c.compatRepo.Get(ctx, device.Model, firmwareVersion)
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Str("device_id", device.ID).
+			Str("firmware_version", firmwareVersion).
+			Msg("Failed to check firmware compatibility matrix")
+		return nil
+	}
+	if entry == nil {
+		return nil
+	}
+
+	warnings := entry.Warnings(device.Capabilities)
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	// Re-alerting the same device about the same firmware is held back for
+	// the cooldown; a version change alerts immediately
+	key := device.ID + "|" + firmwareVersion
+	now := time.Now()
+	c.mu.Lock()
+	last, seen := c.lastAlerted[key]
+	if !seen || now.Sub(last) >= c.cooldown {
+		c.lastAlerted[key] = now
+		seen = false
+	}
+	c.mu.Unlock()
+	if seen {
+		return warnings
+	}
+
+	priority := models.AlertPriorityMedium
+	if entry.UpdateRecommended {
+		priority = models.AlertPriorityHigh
+	}
+	c.alertRecorder.Record(ctx, device, models.AlertCategoryFirmware, models.AlertTypeFirmwareIssue, priority, strings.Join(warnings, "; "))
+
+	c.logger.Warn().
+		Str("device_id", device.ID).
+		Str("model", device.Model).
+		Str("firmware_version", firmwareVersion).
+		Int("warnings", len(warnings)).
+		Msg("Device firmware has known compatibility issues")
+
+	return warnings
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// payloadEnvelope is the wire form of an encrypted device payload, sent
+// under the "__sealed" key: a nonce-prefixed AES-256-GCM ciphertext,
+// base64 encoded
+type payloadEnvelope struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+// PayloadCipher opens encrypted vitals/telemetry payloads from devices whose
+// public key was registered at pairing. Both sides derive the same AES key
+// by X25519 between the device keypair and the service keypair (SHA-256 of
+// the shared secret), so no key material travels with the payload. Devices
+// in E2E mode stay opaque by design: their payloads are never decrypted here
+// and reach authorized clients through the raw event archive instead of the
+// server pipeline
+type PayloadCipher struct {
+	privateKey *ecdh.PrivateKey
+	keyRepo    *repository.DeviceEncryptionKeyRepository
+	logger     zerolog.Logger
+}
+
+// NewPayloadCipher creates a payload cipher from the base64-encoded X25519
+// service private key. An empty key disables payload decryption
+func NewPayloadCipher(
+	privateKey string,
+	keyRepo *repository.DeviceEncryptionKeyRepository,
+	logger zerolog.Logger,
+) (*PayloadCipher, error) {
+	if strings.TrimSpace(privateKey) == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("payload private key is not valid base64: %w", err)
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload private key: %w", err)
+	}
+
+	return &PayloadCipher{
+		privateKey: key,
+		keyRepo:    keyRepo,
+		logger:     logger,
+	}, nil
+}
+
+// Enabled reports whether payload decryption is configured
+func (c *PayloadCipher) Enabled() bool {
+	return c != nil
+}
+
+// PublicKey returns the base64 service public key devices encrypt against
+func (c *PayloadCipher) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(c.privateKey.PublicKey().Bytes())
+}
+
+// Wrap decrypts envelope payloads before they reach the handler. Plaintext
+// payloads pass through untouched; E2E payloads are dropped from the server
+// pipeline after the raw archive has already seen them
+func (c *PayloadCipher) Wrap(handler mqtt.MessageHandler) mqtt.MessageHandler {
+	if !c.Enabled() {
+		return handler
+	}
+
+	return func(topic string, payload []byte) error {
+		var wrapper struct {
+			Sealed *payloadEnvelope `json:"__sealed"`
+		}
+		if err := json.Unmarshal(payload, &wrapper); err != nil || wrapper.Sealed == nil {
+			return handler(topic, payload)
+		}
+
+		// Topics are devices/{deviceID}/{suffix}
+		parts := strings.Split(topic, "/")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid topic format: %s", topic)
+		}
+		deviceID := parts[1]
+
+		key, err := c.keyRepo.GetByDeviceID(context.Background(), deviceID)
+		if err != nil {
+			return fmt.Errorf("failed to get encryption key for device %s: %w", deviceID, err)
+		}
+		if key == nil {
+			return fmt.Errorf("device %s sent an encrypted payload but has no registered key", deviceID)
+		}
+
+		if key.Mode == models.EncryptionModeE2E {
+			c.logger.Debug().
+				Str("device_id", deviceID).
+				Str("topic", topic).
+				Msg("Skipping server-side processing of E2E payload")
+			return nil
+		}
+
+		plaintext, err := c.open(key.PublicKey, wrapper.Sealed)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt payload from device %s: %w", deviceID, err)
+		}
+
+		return handler(topic, plaintext)
+	}
+}
+
+// open derives the key shared with a device and opens one ciphertext
+func (c *PayloadCipher) open(devicePublicKey string, envelope *payloadEnvelope) ([]byte, error) {
+	sharedKey, err := c.sharedKey(devicePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("ciphertext is not valid base64: %w", err)
+	}
+
+	return gcmOpen(sharedKey, ciphertext)
+}
+
+// sharedKey derives the AES key shared with a device from the service
+// private key and the device's registered public key
+func (c *PayloadCipher) sharedKey(devicePublicKey string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(devicePublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("device public key is not valid base64: %w", err)
+	}
+
+	pub, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid device public key: %w", err)
+	}
+
+	secret, err := c.privateKey.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+
+	key := sha256.Sum256(secret)
+	return key[:], nil
+}
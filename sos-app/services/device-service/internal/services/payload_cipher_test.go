@@ -0,0 +1,148 @@
+package services
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeypair generates an X25519 keypair, returning the private key and the
+// base64 public key
+func testKeypair(t *testing.T) (*ecdh.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key, base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())
+}
+
+// sealAsDevice does the device side of the exchange: derive the shared key
+// from the device private key and the service public key, then seal
+func sealAsDevice(t *testing.T, devicePriv *ecdh.PrivateKey, servicePublicKey string, plaintext []byte) []byte {
+	t.Helper()
+
+	raw, err := base64.StdEncoding.DecodeString(servicePublicKey)
+	require.NoError(t, err)
+	servicePub, err := ecdh.X25519().NewPublicKey(raw)
+	require.NoError(t, err)
+
+	secret, err := devicePriv.ECDH(servicePub)
+	require.NoError(t, err)
+	key := sha256.Sum256(secret)
+
+	ciphertext, err := gcmSeal(key[:], plaintext)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"__sealed": map[string]string{
+			"ciphertext": base64.StdEncoding.EncodeToString(ciphertext),
+		},
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestPayloadCipher_OpenRoundTrip(t *testing.T) {
+	servicePriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cipher, err := NewPayloadCipher(
+		base64.StdEncoding.EncodeToString(servicePriv.Bytes()),
+		nil,
+		zerolog.Nop(),
+	)
+	require.NoError(t, err)
+	require.True(t, cipher.Enabled())
+
+	devicePriv, devicePubB64 := testKeypair(t)
+	plaintext := []byte(`{"device_id":"d1","battery_level":80}`)
+
+	payload := sealAsDevice(t, devicePriv, cipher.PublicKey(), plaintext)
+
+	var wrapper struct {
+		Sealed *payloadEnvelope `json:"__sealed"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &wrapper))
+	require.NotNil(t, wrapper.Sealed)
+
+	opened, err := cipher.open(devicePubB64, wrapper.Sealed)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+}
+
+func TestPayloadCipher_WrongDeviceKey(t *testing.T) {
+	servicePriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cipher, err := NewPayloadCipher(
+		base64.StdEncoding.EncodeToString(servicePriv.Bytes()),
+		nil,
+		zerolog.Nop(),
+	)
+	require.NoError(t, err)
+
+	devicePriv, _ := testKeypair(t)
+	_, otherPubB64 := testKeypair(t)
+
+	payload := sealAsDevice(t, devicePriv, cipher.PublicKey(), []byte(`{"ok":true}`))
+
+	var wrapper struct {
+		Sealed *payloadEnvelope `json:"__sealed"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &wrapper))
+
+	// Opening against a different registered key must fail
+	_, err = cipher.open(otherPubB64, wrapper.Sealed)
+	assert.Error(t, err)
+}
+
+func TestPayloadCipher_Disabled(t *testing.T) {
+	cipher, err := NewPayloadCipher("", nil, zerolog.Nop())
+	require.NoError(t, err)
+	assert.False(t, cipher.Enabled())
+
+	// A disabled cipher leaves handlers untouched
+	called := false
+	handler := cipher.Wrap(func(topic string, payload []byte) error {
+		called = true
+		return nil
+	})
+	require.NoError(t, handler("devices/d1/telemetry", []byte(`{"battery_level":50}`)))
+	assert.True(t, called)
+}
+
+func TestPayloadCipher_WrapPassesPlaintextThrough(t *testing.T) {
+	servicePriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cipher, err := NewPayloadCipher(
+		base64.StdEncoding.EncodeToString(servicePriv.Bytes()),
+		nil,
+		zerolog.Nop(),
+	)
+	require.NoError(t, err)
+
+	var seen []byte
+	handler := cipher.Wrap(func(topic string, payload []byte) error {
+		seen = payload
+		return nil
+	})
+
+	plaintext := []byte(`{"battery_level":50}`)
+	require.NoError(t, handler("devices/d1/telemetry", plaintext))
+	assert.Equal(t, plaintext, seen)
+}
+
+func TestNewPayloadCipher_InvalidKey(t *testing.T) {
+	_, err := NewPayloadCipher("not-base64!", nil, zerolog.Nop())
+	assert.Error(t, err)
+
+	_, err = NewPayloadCipher(base64.StdEncoding.EncodeToString([]byte("short")), nil, zerolog.Nop())
+	assert.Error(t, err)
+}
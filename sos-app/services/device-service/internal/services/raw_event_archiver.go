@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/mqtt"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// RawEventArchiver persists every inbound event payload verbatim before any
+// parsing or validation, for the liability audit trail
+type RawEventArchiver struct {
+	repo   *repository.RawEventRepository
+	logger zerolog.Logger
+}
+
+// NewRawEventArchiver creates a new raw event archiver
+func NewRawEventArchiver(repo *repository.RawEventRepository, logger zerolog.Logger) *RawEventArchiver {
+	return &RawEventArchiver{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Wrap returns a handler that archives the raw payload before delegating.
+// Archiving failures are logged loudly but never block event handling — a
+// broken audit trail must not delay an SOS.
+func (a *RawEventArchiver) Wrap(handler mqtt.MessageHandler) mqtt.MessageHandler {
+	return func(topic string, payload []byte) error {
+		a.archive(topic, payload)
+		return handler(topic, payload)
+	}
+}
+
+// archive stores a verbatim copy of the payload with its SHA-256 hash
+func (a *RawEventArchiver) archive(topic string, payload []byte) {
+	hash := sha256.Sum256(payload)
+
+	event := &models.RawDeviceEvent{
+		Topic:       topic,
+		Payload:     payload,
+		PayloadHash: hex.EncodeToString(hash[:]),
+		ReceivedAt:  time.Now().UTC(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := a.repo.Insert(ctx, event); err != nil {
+		a.logger.Error().
+			Err(err).
+			Str("topic", topic).
+			Str("payload_hash", event.PayloadHash).
+			Msg("ALERT: failed to archive raw device event, audit trail has a gap")
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/kafka"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+// Fleet readiness criteria: a user is protected when at least one ACTIVE
+// device has battery above the floor and a heartbeat within the window
+const (
+	ReadinessMinBatteryPercent = 20
+	ReadinessHeartbeatWindow   = 10 * time.Minute
+
+	readinessCheckInterval = 1 * time.Minute
+)
+
+// FleetReadinessTracker maintains a per-user "is anything protecting this
+// user right now" boolean, recomputed on a monitor loop and on demand for
+// the readiness endpoint. Transitions are published to Kafka so the
+// emergency app can warn users the moment they become unprotected
+type FleetReadinessTracker struct {
+	deviceRepo *repository.DeviceRepository
+	publisher  *kafka.ReadinessPublisher
+	logger     zerolog.Logger
+
+	mu    sync.Mutex
+	state map[string]bool
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewFleetReadinessTracker creates a new fleet readiness tracker. publisher
+// may be nil to disable Kafka readiness events
+func NewFleetReadinessTracker(
+	deviceRepo *repository.DeviceRepository,
+	publisher *kafka.ReadinessPublisher,
+	logger zerolog.Logger,
+) *FleetReadinessTracker {
+	return &FleetReadinessTracker{
+		deviceRepo: deviceRepo,
+		publisher:  publisher,
+		logger:     logger,
+		state:      make(map[string]bool),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start begins the periodic fleet readiness sweep
+func (t *FleetReadinessTracker) Start(ctx context.Context) {
+	t.logger.Info().
+		Dur("check_interval", readinessCheckInterval).
+		Msg("Starting fleet readiness tracker")
+
+	t.wg.Add(1)
+	go t.monitorLoop(ctx)
+}
+
+// Stop stops the fleet readiness tracker
+func (t *FleetReadinessTracker) Stop() {
+	close(t.stopChan)
+	t.wg.Wait()
+	t.logger.Info().Msg("Fleet readiness tracker stopped")
+}
+
+// monitorLoop runs the periodic sweep
+func (t *FleetReadinessTracker) monitorLoop(ctx context.Context) {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(readinessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.sweepFleet(ctx); err != nil {
+				t.logger.Error().Err(err).Msg("Fleet readiness sweep failed")
+			}
+		case <-t.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sweepFleet recomputes readiness for every device owner and publishes
+// transitions
+func (t *FleetReadinessTracker) sweepFleet(ctx context.Context) error {
+	heartbeatAfter := time.Now().Add(-ReadinessHeartbeatWindow)
+	readiness, err := t.deviceRepo.GetFleetReadiness(ctx, ReadinessMinBatteryPercent, heartbeatAfter)
+	if err != nil {
+		return fmt.Errorf("failed to compute fleet readiness: %w", err)
+	}
+
+	for userID, ready := range readiness {
+		t.record(ctx, userID, ready)
+	}
+
+	return nil
+}
+
+// IsReady computes readiness for one user right now, updating tracked state
+// (and publishing a transition) on the way
+func (t *FleetReadinessTracker) IsReady(ctx context.Context, userID string) (bool, error) {
+	devices, err := t.deviceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user devices: %w", err)
+	}
+
+	ready := fleetReady(devices, time.Now())
+	t.record(ctx, userID, ready)
+
+	return ready, nil
+}
+
+// record stores the new state and publishes when it differs from the last
+// known one. The first observation for a user only primes the state: the
+// app learns the baseline from the readiness endpoint, not from Kafka
+func (t *FleetReadinessTracker) record(ctx context.Context, userID string, ready bool) {
+	t.mu.Lock()
+	previous, seen := t.state[userID]
+	t.state[userID] = ready
+	t.mu.Unlock()
+
+	if !seen || previous == ready {
+		return
+	}
+
+	t.logger.Info().
+		Str("user_id", userID).
+		Bool("ready", ready).
+		Msg("Fleet readiness changed")
+
+	if t.publisher == nil {
+		return
+	}
+	if err := t.publisher.Publish(ctx, userID, ready); err != nil {
+		t.logger.Error().
+			Err(err).
+			Str("user_id", userID).
+			Msg("Failed to publish readiness change")
+	}
+}
+
+// fleetReady reports whether any of the devices protects the user at now
+func fleetReady(devices []*models.Device, now time.Time) bool {
+	heartbeatAfter := now.Add(-ReadinessHeartbeatWindow)
+	for _, device := range devices {
+		if device.Status != models.DeviceStatusActive {
+			continue
+		}
+		if device.BatteryLevel <= ReadinessMinBatteryPercent {
+			continue
+		}
+		if device.LastSeenAt == nil || device.LastSeenAt.Before(heartbeatAfter) {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFleetReady(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-2 * time.Minute)
+	stale := now.Add(-ReadinessHeartbeatWindow - time.Minute)
+
+	tests := []struct {
+		name    string
+		devices []*models.Device
+		want    bool
+	}{
+		{
+			name: "active device with good battery and fresh heartbeat",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusActive, BatteryLevel: 80, LastSeenAt: &recent},
+			},
+			want: true,
+		},
+		{
+			name: "battery at the floor is not enough",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusActive, BatteryLevel: ReadinessMinBatteryPercent, LastSeenAt: &recent},
+			},
+			want: false,
+		},
+		{
+			name: "stale heartbeat",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusActive, BatteryLevel: 80, LastSeenAt: &stale},
+			},
+			want: false,
+		},
+		{
+			name: "never seen",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusActive, BatteryLevel: 80},
+			},
+			want: false,
+		},
+		{
+			name: "inactive device does not count",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusDisconnected, BatteryLevel: 80, LastSeenAt: &recent},
+			},
+			want: false,
+		},
+		{
+			name: "one healthy device among failing ones is enough",
+			devices: []*models.Device{
+				{Status: models.DeviceStatusActive, BatteryLevel: 5, LastSeenAt: &recent},
+				{Status: models.DeviceStatusActive, BatteryLevel: 95, LastSeenAt: &recent},
+			},
+			want: true,
+		},
+		{
+			name:    "no devices",
+			devices: nil,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fleetReady(tt.devices, now))
+		})
+	}
+}
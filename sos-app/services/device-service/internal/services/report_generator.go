@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+const (
+	// reportPeriod is the window each report covers and the cadence at
+	// which new reports are generated
+	reportPeriod = 7 * 24 * time.Hour
+	// reportCheckInterval is how often the generator looks for users whose
+	// latest report has aged out
+	reportCheckInterval = 1 * time.Hour
+	// reportUptimeBucketSeconds buckets battery samples per hour; a device
+	// counts as up for every hour it reported at least once
+	reportUptimeBucketSeconds = 3600
+)
+
+// ReportGenerator periodically produces per-user device reports summarizing
+// uptime, battery trend, alert counts and firmware status over the last week
+type ReportGenerator struct {
+	deviceRepo  *repository.DeviceRepository
+	historyRepo *repository.BatteryHistoryRepository
+	triggerRepo *repository.TriggerQueueRepository
+	reportRepo  *repository.DeviceReportRepository
+	logger      zerolog.Logger
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewReportGenerator creates a new report generator
+func NewReportGenerator(
+	deviceRepo *repository.DeviceRepository,
+	historyRepo *repository.BatteryHistoryRepository,
+	triggerRepo *repository.TriggerQueueRepository,
+	reportRepo *repository.DeviceReportRepository,
+	logger zerolog.Logger,
+) *ReportGenerator {
+	return &ReportGenerator{
+		deviceRepo:  deviceRepo,
+		historyRepo: historyRepo,
+		triggerRepo: triggerRepo,
+		reportRepo:  reportRepo,
+		logger:      logger,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the scheduled report generation loop
+func (g *ReportGenerator) Start(ctx context.Context) {
+	g.logger.Info().
+		Dur("period", reportPeriod).
+		Dur("check_interval", reportCheckInterval).
+		Msg("Starting device report generator")
+
+	g.wg.Add(1)
+	go g.run(ctx)
+}
+
+// Stop stops the report generator
+func (g *ReportGenerator) Stop() {
+	close(g.stopChan)
+	g.wg.Wait()
+	g.logger.Info().Msg("Device report generator stopped")
+}
+
+// run checks on an interval whether any user's report has aged out
+func (g *ReportGenerator) run(ctx context.Context) {
+	defer g.wg.Done()
+
+	ticker := time.NewTicker(reportCheckInterval)
+	defer ticker.Stop()
+
+	// Catch up on startup so a restarted service doesn't delay reports
+	g.generateDueReports(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			g.generateDueReports(ctx)
+		case <-g.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// generateDueReports generates a new report for every user whose latest
+// report is older than the reporting period (or who has none)
+func (g *ReportGenerator) generateDueReports(ctx context.Context) {
+	userIDs, err := g.deviceRepo.ListUserIDs(ctx)
+	if err != nil {
+		g.logger.Error().Err(err).Msg("Failed to list users for report generation")
+		return
+	}
+
+	for _, userID := range userIDs {
+		due, err := g.reportDue(ctx, userID)
+		if err != nil {
+			g.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to check report age")
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		if err := g.GenerateForUser(ctx, userID); err != nil {
+			g.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to generate device report")
+		}
+	}
+}
+
+// reportDue reports whether the user's latest report is older than the period
+func (g *ReportGenerator) reportDue(ctx context.Context, userID string) (bool, error) {
+	latest, err := g.reportRepo.GetLatestByUser(ctx, userID)
+	if err != nil {
+		if err == repository.ErrDeviceReportNotFound {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return time.Since(latest.GeneratedAt) >= reportPeriod, nil
+}
+
+// GenerateForUser builds and stores a report covering the last period for
+// all of the user's devices
+func (g *ReportGenerator) GenerateForUser(ctx context.Context, userID string) error {
+	devices, err := g.deviceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	periodEnd := time.Now().UTC()
+	periodStart := periodEnd.Add(-reportPeriod)
+
+	entries := make([]models.DeviceReportEntry, 0, len(devices))
+	for _, device := range devices {
+		entry, err := g.summarizeDevice(ctx, device, periodStart)
+		if err != nil {
+			g.logger.Error().
+				Err(err).
+				Str("device_id", device.ID).
+				Msg("Failed to summarize device, omitting from report")
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	report := &models.DeviceReport{
+		UserID:      userID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Devices:     entries,
+	}
+
+	if err := g.reportRepo.Insert(ctx, report); err != nil {
+		return err
+	}
+
+	// TODO: Hand the report to the notification service for email delivery
+	// once it exposes a digest channel. Until then reports are pull-only
+	// via GET /api/v1/devices/reports/latest
+
+	g.logger.Info().
+		Str("user_id", userID).
+		Int("devices", len(entries)).
+		Msg("Generated device report")
+
+	return nil
+}
+
+// summarizeDevice builds one report entry from the device's battery history
+// and trigger queue over the period
+func (g *ReportGenerator) summarizeDevice(ctx context.Context, device *models.Device, periodStart time.Time) (models.DeviceReportEntry, error) {
+	entry := models.DeviceReportEntry{
+		DeviceID:        device.ID,
+		DeviceType:      device.DeviceType,
+		Manufacturer:    device.Manufacturer,
+		Model:           device.Model,
+		Status:          device.Status,
+		FirmwareVersion: firmwareVersion(device),
+	}
+
+	alerts, err := g.triggerRepo.CountByDeviceSince(ctx, device.ID, periodStart)
+	if err != nil {
+		return entry, err
+	}
+	entry.AlertCount = alerts
+
+	buckets, err := g.historyRepo.History(ctx, device.ID, periodStart, reportUptimeBucketSeconds)
+	if err != nil {
+		return entry, err
+	}
+
+	// A device counts as up for every hour it reported at least one battery
+	// sample; this undercounts slightly for devices that only report on
+	// battery level changes
+	totalHours := time.Since(periodStart).Hours()
+	if totalHours > 0 {
+		entry.UptimePercent = math.Round(float64(len(buckets))/totalHours*1000) / 10
+		if entry.UptimePercent > 100 {
+			entry.UptimePercent = 100
+		}
+	}
+
+	if len(buckets) > 0 {
+		start := buckets[0].AvgLevel
+		end := buckets[len(buckets)-1].AvgLevel
+		min := buckets[0].MinLevel
+		for _, bucket := range buckets {
+			if bucket.MinLevel < min {
+				min = bucket.MinLevel
+			}
+		}
+		entry.BatteryStart = &start
+		entry.BatteryEnd = &end
+		entry.BatteryMin = &min
+	}
+
+	return entry, nil
+}
+
+// firmwareVersion extracts the firmware version a device last reported in its
+// settings, if any
+func firmwareVersion(device *models.Device) string {
+	if device.Settings == nil {
+		return ""
+	}
+	if version, ok := device.Settings["firmware_version"].(string); ok {
+		return version
+	}
+	return ""
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// settingsEnvelopeKey marks a setting value as an encryption envelope
+const settingsEnvelopeKey = "__vault"
+
+// settingsEnvelope is the persisted form of an encrypted setting value
+type settingsEnvelope struct {
+	KeyID      string `json:"key_id"`
+	WrappedKey string `json:"wrapped_key"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// SettingsCipher applies envelope encryption to designated sensitive device
+// setting keys (Wi-Fi credentials, SIM PINs) before persistence. Each value
+// is sealed with a fresh AES-256-GCM data key and the data key is wrapped by
+// the active master key. Master keys are configured as "id:base64" pairs; the
+// first encrypts new writes and the rest remain available for decryption, so
+// rotating is prepending a new key and re-saving settings at leisure
+type SettingsCipher struct {
+	activeKeyID string
+	masterKeys  map[string][]byte
+	sensitive   map[string]struct{}
+}
+
+// NewSettingsCipher creates a settings cipher from the configured master
+// keys ("id:base64,..." with the first key active) and sensitive key names.
+// An empty key list disables encryption
+func NewSettingsCipher(masterKeys string, sensitiveKeys []string) (*SettingsCipher, error) {
+	if strings.TrimSpace(masterKeys) == "" {
+		return nil, nil
+	}
+
+	c := &SettingsCipher{
+		masterKeys: make(map[string][]byte),
+		sensitive:  make(map[string]struct{}, len(sensitiveKeys)),
+	}
+
+	for _, pair := range strings.Split(masterKeys, ",") {
+		id, encoded, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || id == "" {
+			return nil, fmt.Errorf("settings master keys must be id:base64 pairs")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("settings master key %s is not valid base64: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("settings master key %s must be 32 bytes, got %d", id, len(key))
+		}
+
+		if c.activeKeyID == "" {
+			c.activeKeyID = id
+		}
+		c.masterKeys[id] = key
+	}
+
+	for _, key := range sensitiveKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			c.sensitive[key] = struct{}{}
+		}
+	}
+
+	return c, nil
+}
+
+// Enabled reports whether settings encryption is configured
+func (c *SettingsCipher) Enabled() bool {
+	return c != nil
+}
+
+// EncryptSettings returns a copy of settings with every sensitive key's value
+// replaced by an encryption envelope. Values that are already envelopes are
+// kept as-is so re-saving unchanged settings doesn't double-encrypt
+func (c *SettingsCipher) EncryptSettings(settings map[string]interface{}) (map[string]interface{}, error) {
+	if !c.Enabled() || settings == nil {
+		return settings, nil
+	}
+
+	encrypted := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if _, isSensitive := c.sensitive[key]; !isSensitive || isEnvelope(value) {
+			encrypted[key] = value
+			continue
+		}
+
+		envelope, err := c.seal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt setting %s: %w", key, err)
+		}
+		encrypted[key] = map[string]interface{}{settingsEnvelopeKey: envelope}
+	}
+
+	return encrypted, nil
+}
+
+// DecryptSettings returns a copy of settings with every encryption envelope
+// opened. Plaintext values pass through untouched
+func (c *SettingsCipher) DecryptSettings(settings map[string]interface{}) (map[string]interface{}, error) {
+	if !c.Enabled() || settings == nil {
+		return settings, nil
+	}
+
+	decrypted := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if !isEnvelope(value) {
+			decrypted[key] = value
+			continue
+		}
+
+		plaintext, err := c.open(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt setting %s: %w", key, err)
+		}
+		decrypted[key] = plaintext
+	}
+
+	return decrypted, nil
+}
+
+// seal encrypts one value with a fresh data key wrapped by the active master key
+func (c *SettingsCipher) seal(value interface{}) (*settingsEnvelope, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := gcmSeal(dataKey, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := gcmSeal(c.masterKeys[c.activeKeyID], dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settingsEnvelope{
+		KeyID:      c.activeKeyID,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrappedKey),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// open decrypts one envelope value using the master key it names
+func (c *SettingsCipher) open(value interface{}) (interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper struct {
+		Envelope settingsEnvelope `json:"__vault"`
+	}
+	if err := json.Unmarshal(raw, &wrapper); err != nil {
+		return nil, err
+	}
+	envelope := wrapper.Envelope
+
+	masterKey, ok := c.masterKeys[envelope.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown settings master key %s", envelope.KeyID)
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(envelope.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := gcmOpen(masterKey, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcmOpen(dataKey, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(plaintext, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// isEnvelope reports whether a setting value is an encryption envelope
+func isEnvelope(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = object[settingsEnvelopeKey]
+	return ok
+}
+
+// gcmSeal encrypts plaintext with AES-256-GCM, prepending the nonce
+func gcmSeal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// gcmOpen decrypts a nonce-prefixed AES-256-GCM ciphertext
+func gcmOpen(key, blob []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blob) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	return gcm.Open(nil, blob[:gcm.NonceSize()], blob[gcm.NonceSize():], nil)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMasterKey(t *testing.T) string {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestSettingsCipher_RoundTrip(t *testing.T) {
+	cipher, err := NewSettingsCipher("k1:"+testMasterKey(t), []string{"wifi_password", "sim_pin"})
+	require.NoError(t, err)
+	require.True(t, cipher.Enabled())
+
+	settings := map[string]interface{}{
+		"wifi_password": "hunter2",
+		"sim_pin":       "1234",
+		"brightness":    float64(80),
+	}
+
+	encrypted, err := cipher.EncryptSettings(settings)
+	require.NoError(t, err)
+
+	// Sensitive keys become envelopes, the rest stay in plaintext
+	assert.True(t, isEnvelope(encrypted["wifi_password"]))
+	assert.True(t, isEnvelope(encrypted["sim_pin"]))
+	assert.Equal(t, float64(80), encrypted["brightness"])
+	assert.NotEqual(t, "hunter2", encrypted["wifi_password"])
+
+	decrypted, err := cipher.DecryptSettings(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, settings, decrypted)
+}
+
+func TestSettingsCipher_DisabledPassthrough(t *testing.T) {
+	cipher, err := NewSettingsCipher("", []string{"wifi_password"})
+	require.NoError(t, err)
+	assert.False(t, cipher.Enabled())
+
+	settings := map[string]interface{}{"wifi_password": "hunter2"}
+
+	encrypted, err := cipher.EncryptSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, settings, encrypted)
+
+	decrypted, err := cipher.DecryptSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, settings, decrypted)
+}
+
+func TestSettingsCipher_KeyRotation(t *testing.T) {
+	oldKey := "old:" + testMasterKey(t)
+	oldCipher, err := NewSettingsCipher(oldKey, []string{"wifi_password"})
+	require.NoError(t, err)
+
+	encrypted, err := oldCipher.EncryptSettings(map[string]interface{}{"wifi_password": "hunter2"})
+	require.NoError(t, err)
+
+	// After rotation the new key encrypts writes and the old key still decrypts
+	rotated, err := NewSettingsCipher("new:"+testMasterKey(t)+","+oldKey, []string{"wifi_password"})
+	require.NoError(t, err)
+
+	decrypted, err := rotated.DecryptSettings(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypted["wifi_password"])
+
+	// Without the old key the envelope can no longer be opened
+	newOnly, err := NewSettingsCipher("new:"+testMasterKey(t), []string{"wifi_password"})
+	require.NoError(t, err)
+
+	_, err = newOnly.DecryptSettings(encrypted)
+	assert.Error(t, err)
+}
+
+func TestSettingsCipher_DoesNotDoubleEncrypt(t *testing.T) {
+	cipher, err := NewSettingsCipher("k1:"+testMasterKey(t), []string{"wifi_password"})
+	require.NoError(t, err)
+
+	encrypted, err := cipher.EncryptSettings(map[string]interface{}{"wifi_password": "hunter2"})
+	require.NoError(t, err)
+
+	// Re-saving already-encrypted settings keeps the envelope untouched
+	again, err := cipher.EncryptSettings(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, again)
+
+	decrypted, err := cipher.DecryptSettings(again)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypted["wifi_password"])
+}
+
+func TestSettingsCipher_RejectsBadKeyConfig(t *testing.T) {
+	_, err := NewSettingsCipher("not-a-pair", []string{"wifi_password"})
+	assert.Error(t, err)
+
+	_, err = NewSettingsCipher("k1:short", []string{"wifi_password"})
+	assert.Error(t, err)
+}
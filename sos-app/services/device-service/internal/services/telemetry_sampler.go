@@ -0,0 +1,78 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+)
+
+// TelemetrySampler decides which vitals readings are persisted. Outside an
+// active emergency only every Nth reading per device is stored, per the
+// admin-configured policy for the device type; during an active emergency
+// every reading is stored. Device types without a policy store everything
+type TelemetrySampler struct {
+	emergencyState *EmergencyStateCache
+	logger         zerolog.Logger
+
+	mu       sync.Mutex
+	policies map[models.DeviceType]int
+	counters map[string]int
+}
+
+// NewTelemetrySampler creates a new telemetry sampler
+func NewTelemetrySampler(emergencyState *EmergencyStateCache, logger zerolog.Logger) *TelemetrySampler {
+	return &TelemetrySampler{
+		emergencyState: emergencyState,
+		logger:         logger,
+		policies:       make(map[models.DeviceType]int),
+		counters:       make(map[string]int),
+	}
+}
+
+// SetPolicies replaces all policies, e.g. when loading from the database
+func (s *TelemetrySampler) SetPolicies(policies []models.SamplingPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policies = make(map[models.DeviceType]int, len(policies))
+	for _, policy := range policies {
+		s.policies[policy.DeviceType] = policy.SampleEvery
+	}
+}
+
+// SetPolicy installs or updates the policy for one device type
+func (s *TelemetrySampler) SetPolicy(deviceType models.DeviceType, sampleEvery int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies[deviceType] = sampleEvery
+}
+
+// RemovePolicy drops the policy for one device type, storing everything again
+func (s *TelemetrySampler) RemovePolicy(deviceType models.DeviceType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.policies, deviceType)
+}
+
+// ShouldStore reports whether this reading should be persisted. The first
+// reading after a policy takes effect is always stored so a sparse device
+// never goes completely dark
+func (s *TelemetrySampler) ShouldStore(device *models.Device) bool {
+	// Everything is stored while the owner has an active emergency
+	if s.emergencyState != nil && s.emergencyState.IsActive(device.UserID) {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sampleEvery, ok := s.policies[device.DeviceType]
+	if !ok || sampleEvery <= 1 {
+		return true
+	}
+
+	count := s.counters[device.ID]
+	s.counters[device.ID] = count + 1
+	return count%sampleEvery == 0
+}
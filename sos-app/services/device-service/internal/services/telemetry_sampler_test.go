@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func samplerDevice(id, userID string, deviceType models.DeviceType) *models.Device {
+	return &models.Device{ID: id, UserID: userID, DeviceType: deviceType}
+}
+
+func TestTelemetrySampler_NoPolicyStoresEverything(t *testing.T) {
+	sampler := NewTelemetrySampler(NewEmergencyStateCache(), zerolog.Nop())
+	device := samplerDevice("device-123", "user-1", models.DeviceTypeSmartWatch)
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, sampler.ShouldStore(device))
+	}
+}
+
+func TestTelemetrySampler_StoresEveryNthReading(t *testing.T) {
+	sampler := NewTelemetrySampler(NewEmergencyStateCache(), zerolog.Nop())
+	sampler.SetPolicy(models.DeviceTypeSmartWatch, 3)
+	device := samplerDevice("device-123", "user-1", models.DeviceTypeSmartWatch)
+
+	// First reading is stored, then every third
+	stored := 0
+	for i := 0; i < 9; i++ {
+		if sampler.ShouldStore(device) {
+			stored++
+		}
+	}
+	assert.Equal(t, 3, stored)
+
+	// Other device types are unaffected by the policy
+	other := samplerDevice("device-456", "user-2", models.DeviceTypePanicButton)
+	assert.True(t, sampler.ShouldStore(other))
+	assert.True(t, sampler.ShouldStore(other))
+}
+
+func TestTelemetrySampler_ActiveEmergencyStoresEverything(t *testing.T) {
+	cache := NewEmergencyStateCache()
+	sampler := NewTelemetrySampler(cache, zerolog.Nop())
+	sampler.SetPolicy(models.DeviceTypeSmartWatch, 10)
+	device := samplerDevice("device-123", "user-1", models.DeviceTypeSmartWatch)
+
+	cache.SetActive("user-1", "emergency-1")
+	for i := 0; i < 5; i++ {
+		assert.True(t, sampler.ShouldStore(device))
+	}
+
+	// Back to sampling once the emergency is over
+	cache.ClearActive("user-1")
+	assert.True(t, sampler.ShouldStore(device)) // counter at 0: stored
+	assert.False(t, sampler.ShouldStore(device))
+}
+
+func TestTelemetrySampler_RemovePolicyRestoresFullStorage(t *testing.T) {
+	sampler := NewTelemetrySampler(NewEmergencyStateCache(), zerolog.Nop())
+	sampler.SetPolicy(models.DeviceTypeHealthMonitor, 5)
+	device := samplerDevice("device-123", "user-1", models.DeviceTypeHealthMonitor)
+
+	assert.True(t, sampler.ShouldStore(device))
+	assert.False(t, sampler.ShouldStore(device))
+
+	sampler.RemovePolicy(models.DeviceTypeHealthMonitor)
+	assert.True(t, sampler.ShouldStore(device))
+	assert.True(t, sampler.ShouldStore(device))
+}
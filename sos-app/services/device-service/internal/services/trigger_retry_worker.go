@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/emergencyapi"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+const (
+	// How often the worker polls for due triggers
+	defaultRetryInterval = 5 * time.Second
+	// Backoff bounds between delivery attempts for a single trigger
+	retryBaseBackoff = 2 * time.Second
+	retryMaxBackoff  = 2 * time.Minute
+	// How many due triggers are picked up per poll
+	retryBatchSize = 20
+)
+
+// TriggerRetryWorker drains the durable trigger queue, re-attempting delivery
+// of SOS triggers to the emergency-service with exponential backoff. A
+// momentary outage of the emergency-service must never lose an SOS.
+type TriggerRetryWorker struct {
+	queueRepo       *repository.TriggerQueueRepository
+	emergencyClient *emergencyapi.Client
+	interval        time.Duration
+	stopChan        chan struct{}
+	logger          zerolog.Logger
+}
+
+// NewTriggerRetryWorker creates a new trigger retry worker
+func NewTriggerRetryWorker(
+	queueRepo *repository.TriggerQueueRepository,
+	emergencyServiceURL string,
+	logger zerolog.Logger,
+) *TriggerRetryWorker {
+	return &TriggerRetryWorker{
+		queueRepo:       queueRepo,
+		emergencyClient: emergencyapi.NewClient(emergencyServiceURL),
+		interval:        defaultRetryInterval,
+		stopChan:        make(chan struct{}),
+		logger:          logger,
+	}
+}
+
+// Start begins draining the retry queue in the background
+func (w *TriggerRetryWorker) Start(ctx context.Context) {
+	w.logger.Info().
+		Dur("interval", w.interval).
+		Msg("Starting emergency trigger retry worker")
+
+	go w.retryLoop(ctx)
+}
+
+// Stop stops the retry worker
+func (w *TriggerRetryWorker) Stop() {
+	w.logger.Info().Msg("Stopping emergency trigger retry worker")
+	close(w.stopChan)
+}
+
+// retryLoop polls for due triggers until stopped
+func (w *TriggerRetryWorker) retryLoop(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.drainDueTriggers(ctx)
+		case <-ctx.Done():
+			return
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// drainDueTriggers attempts delivery of every due trigger
+func (w *TriggerRetryWorker) drainDueTriggers(ctx context.Context) {
+	triggers, err := w.queueRepo.FetchDue(ctx, retryBatchSize)
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to fetch due triggers from retry queue")
+		return
+	}
+
+	if len(triggers) == 0 {
+		return
+	}
+
+	// Loud signal: an SOS is queued instead of delivered
+	undelivered, _ := w.queueRepo.CountUndelivered(ctx)
+	w.logger.Warn().
+		Int("due", len(triggers)).
+		Int("undelivered_total", undelivered).
+		Msg("ALARM: retrying undelivered emergency triggers")
+
+	for _, trigger := range triggers {
+		if err := w.deliver(ctx, trigger.Payload); err != nil {
+			backoff := backoffForAttempt(trigger.Attempts + 1)
+			w.logger.Error().
+				Err(err).
+				Str("trigger_id", trigger.ID).
+				Str("device_id", trigger.DeviceID).
+				Int("attempts", trigger.Attempts+1).
+				Dur("next_retry_in", backoff).
+				Msg("CRITICAL: emergency trigger delivery failed again")
+
+			if recErr := w.queueRepo.RecordFailure(ctx, trigger.ID, err.Error(), time.Now().Add(backoff)); recErr != nil {
+				w.logger.Error().Err(recErr).Str("trigger_id", trigger.ID).Msg("Failed to record trigger failure")
+			}
+			continue
+		}
+
+		if err := w.queueRepo.MarkDelivered(ctx, trigger.ID); err != nil {
+			w.logger.Error().Err(err).Str("trigger_id", trigger.ID).Msg("Failed to mark trigger delivered")
+			continue
+		}
+
+		w.logger.Info().
+			Str("trigger_id", trigger.ID).
+			Str("device_id", trigger.DeviceID).
+			Int("attempts", trigger.Attempts+1).
+			Msg("Queued emergency trigger delivered successfully")
+	}
+}
+
+// deliver posts the stored payload to the emergency-service auto-trigger endpoint
+func (w *TriggerRetryWorker) deliver(ctx context.Context, payload []byte) error {
+	err := w.emergencyClient.Deliver(ctx, payload)
+	// Conflict means an emergency already exists for the user: the SOS
+	// was not lost, so drop the queued trigger instead of retrying forever
+	if errors.Is(err, emergencyapi.ErrConflict) {
+		return nil
+	}
+	return err
+}
+
+// backoffForAttempt returns the exponential backoff delay for the given attempt
+func backoffForAttempt(attempt int) time.Duration {
+	backoff := retryBaseBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= retryMaxBackoff {
+			return retryMaxBackoff
+		}
+	}
+	return backoff
+}
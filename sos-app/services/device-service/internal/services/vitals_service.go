@@ -4,35 +4,57 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/sos-app/device-service/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
+// Alert types used as keys for per-alert-type policies
+const (
+	AlertTypeHeartRate     = "heart_rate"
+	AlertTypeSpO2          = "spo2"
+	AlertTypeTemperature   = "temperature"
+	AlertTypeBloodPressure = "blood_pressure"
+)
+
+// AlertPolicy controls how often an alert type may fire. SustainedReadings
+// is the number of consecutive breaching readings required before the first
+// alert; CooldownSeconds is the minimum gap between repeated alerts
+type AlertPolicy struct {
+	CooldownSeconds   int `yaml:"cooldown_seconds" json:"cooldown_seconds"`
+	SustainedReadings int `yaml:"sustained_readings" json:"sustained_readings"`
+}
+
 // VitalThresholds holds threshold values for vital signs
 type VitalThresholds struct {
 	HeartRate struct {
-		Min int `yaml:"min"`
-		Max int `yaml:"max"`
-	} `yaml:"heart_rate"`
+		Min int `yaml:"min" json:"min"`
+		Max int `yaml:"max" json:"max"`
+	} `yaml:"heart_rate" json:"heart_rate"`
 	SpO2 struct {
-		Min int `yaml:"min"`
-	} `yaml:"spo2"`
+		Min int `yaml:"min" json:"min"`
+	} `yaml:"spo2" json:"spo2"`
 	Temperature struct {
-		Min float64 `yaml:"min"`
-		Max float64 `yaml:"max"`
-	} `yaml:"temperature"`
+		Min float64 `yaml:"min" json:"min"`
+		Max float64 `yaml:"max" json:"max"`
+	} `yaml:"temperature" json:"temperature"`
 	BloodPressure struct {
 		Systolic struct {
-			Min int `yaml:"min"`
-			Max int `yaml:"max"`
-		} `yaml:"systolic"`
+			Min int `yaml:"min" json:"min"`
+			Max int `yaml:"max" json:"max"`
+		} `yaml:"systolic" json:"systolic"`
 		Diastolic struct {
-			Min int `yaml:"min"`
-			Max int `yaml:"max"`
-		} `yaml:"diastolic"`
-	} `yaml:"blood_pressure"`
+			Min int `yaml:"min" json:"min"`
+			Max int `yaml:"max" json:"max"`
+		} `yaml:"diastolic" json:"diastolic"`
+	} `yaml:"blood_pressure" json:"blood_pressure"`
+	// Per-alert-type cooldown and sustained-breach policies, keyed by
+	// alert type (heart_rate, spo2, temperature, blood_pressure)
+	Alerting map[string]AlertPolicy `yaml:"alerting" json:"alerting,omitempty"`
 }
 
 // ThresholdsConfig holds the complete thresholds configuration
@@ -41,10 +63,24 @@ type ThresholdsConfig struct {
 	PerUser map[string]VitalThresholds `yaml:"per_user"`
 }
 
+// alertKey identifies the alert state tracked per user and alert type
+type alertKey struct {
+	userID    string
+	alertType string
+}
+
 // VitalsService monitors vital signs and sends alerts
 type VitalsService struct {
-	thresholds ThresholdsConfig
-	logger     zerolog.Logger
+	configPath     string
+	thresholds     ThresholdsConfig
+	thresholdsLock sync.RWMutex
+	alertRecorder  *AlertRecorder
+	logger         zerolog.Logger
+
+	// Runtime alert state: consecutive breach counts and last alert times
+	stateLock     sync.Mutex
+	breachStreaks map[alertKey]int
+	lastAlerted   map[alertKey]time.Time
 }
 
 // NewVitalsService creates a new vitals monitoring service
@@ -66,23 +102,75 @@ func NewVitalsService(configPath string, logger zerolog.Logger) (*VitalsService,
 		Msg("Vitals service initialized with thresholds")
 
 	return &VitalsService{
-		thresholds: config,
-		logger:     logger,
+		configPath:    configPath,
+		thresholds:    config,
+		logger:        logger,
+		breachStreaks: make(map[alertKey]int),
+		lastAlerted:   make(map[alertKey]time.Time),
 	}, nil
 }
 
+// SetAlertRecorder enables alert history persistence. Call during startup,
+// before monitoring begins
+func (s *VitalsService) SetAlertRecorder(recorder *AlertRecorder) {
+	s.alertRecorder = recorder
+}
+
+// Reload re-reads the thresholds file and swaps it in, so threshold changes
+// apply without a restart. The file is the source of truth: per-user
+// overrides set via the API since startup are replaced by its contents
+func (s *VitalsService) Reload() error {
+	var config ThresholdsConfig
+
+	data, err := os.ReadFile(s.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read vitals config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse vitals config: %w", err)
+	}
+
+	s.thresholdsLock.Lock()
+	s.thresholds = config
+	s.thresholdsLock.Unlock()
+
+	s.logger.Info().
+		Str("config_path", s.configPath).
+		Msg("Vitals thresholds reloaded")
+
+	return nil
+}
+
+// RegisterUser pre-creates the breach-streak entries for a user's
+// configured alert policies so the runtime alert state doesn't grow map by
+// map under MQTT load (used by the startup cache warmer)
+func (s *VitalsService) RegisterUser(userID string) {
+	thresholds := s.getThresholdsForUser(userID)
+
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	for alertType := range thresholds.Alerting {
+		key := alertKey{userID: userID, alertType: alertType}
+		if _, ok := s.breachStreaks[key]; !ok {
+			s.breachStreaks[key] = 0
+		}
+	}
+}
+
 // MonitorVitalSigns checks vital signs against thresholds and sends alerts
 func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.Device, vitals *models.VitalSigns) error {
 	// Get thresholds for user (or use default)
 	thresholds := s.getThresholdsForUser(device.UserID)
 
-	var alerts []string
+	breaches := make(map[string][]string)
 
 	// Check heart rate
 	if vitals.HeartRate > 0 {
 		if vitals.HeartRate < thresholds.HeartRate.Min {
 			alert := fmt.Sprintf("Low heart rate: %d bpm (min: %d bpm)", vitals.HeartRate, thresholds.HeartRate.Min)
-			alerts = append(alerts, alert)
+			breaches[AlertTypeHeartRate] = append(breaches[AlertTypeHeartRate], alert)
 			s.logger.Warn().
 				Str("device_id", device.ID).
 				Str("user_id", device.UserID).
@@ -90,7 +178,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 				Msg(alert)
 		} else if vitals.HeartRate > thresholds.HeartRate.Max {
 			alert := fmt.Sprintf("High heart rate: %d bpm (max: %d bpm)", vitals.HeartRate, thresholds.HeartRate.Max)
-			alerts = append(alerts, alert)
+			breaches[AlertTypeHeartRate] = append(breaches[AlertTypeHeartRate], alert)
 			s.logger.Warn().
 				Str("device_id", device.ID).
 				Str("user_id", device.UserID).
@@ -103,7 +191,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 	if vitals.SpO2 > 0 {
 		if vitals.SpO2 < thresholds.SpO2.Min {
 			alert := fmt.Sprintf("Low SpO2: %d%% (min: %d%%)", vitals.SpO2, thresholds.SpO2.Min)
-			alerts = append(alerts, alert)
+			breaches[AlertTypeSpO2] = append(breaches[AlertTypeSpO2], alert)
 			s.logger.Warn().
 				Str("device_id", device.ID).
 				Str("user_id", device.UserID).
@@ -116,7 +204,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 	if vitals.Temperature > 0 {
 		if vitals.Temperature < thresholds.Temperature.Min {
 			alert := fmt.Sprintf("Low temperature: %.1f°C (min: %.1f°C)", vitals.Temperature, thresholds.Temperature.Min)
-			alerts = append(alerts, alert)
+			breaches[AlertTypeTemperature] = append(breaches[AlertTypeTemperature], alert)
 			s.logger.Warn().
 				Str("device_id", device.ID).
 				Str("user_id", device.UserID).
@@ -124,7 +212,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 				Msg(alert)
 		} else if vitals.Temperature > thresholds.Temperature.Max {
 			alert := fmt.Sprintf("High temperature: %.1f°C (max: %.1f°C)", vitals.Temperature, thresholds.Temperature.Max)
-			alerts = append(alerts, alert)
+			breaches[AlertTypeTemperature] = append(breaches[AlertTypeTemperature], alert)
 			s.logger.Warn().
 				Str("device_id", device.ID).
 				Str("user_id", device.UserID).
@@ -139,7 +227,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 			if vitals.BloodPressure.Systolic < thresholds.BloodPressure.Systolic.Min {
 				alert := fmt.Sprintf("Low systolic BP: %d mmHg (min: %d mmHg)",
 					vitals.BloodPressure.Systolic, thresholds.BloodPressure.Systolic.Min)
-				alerts = append(alerts, alert)
+				breaches[AlertTypeBloodPressure] = append(breaches[AlertTypeBloodPressure], alert)
 				s.logger.Warn().
 					Str("device_id", device.ID).
 					Str("user_id", device.UserID).
@@ -148,7 +236,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 			} else if vitals.BloodPressure.Systolic > thresholds.BloodPressure.Systolic.Max {
 				alert := fmt.Sprintf("High systolic BP: %d mmHg (max: %d mmHg)",
 					vitals.BloodPressure.Systolic, thresholds.BloodPressure.Systolic.Max)
-				alerts = append(alerts, alert)
+				breaches[AlertTypeBloodPressure] = append(breaches[AlertTypeBloodPressure], alert)
 				s.logger.Warn().
 					Str("device_id", device.ID).
 					Str("user_id", device.UserID).
@@ -161,7 +249,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 			if vitals.BloodPressure.Diastolic < thresholds.BloodPressure.Diastolic.Min {
 				alert := fmt.Sprintf("Low diastolic BP: %d mmHg (min: %d mmHg)",
 					vitals.BloodPressure.Diastolic, thresholds.BloodPressure.Diastolic.Min)
-				alerts = append(alerts, alert)
+				breaches[AlertTypeBloodPressure] = append(breaches[AlertTypeBloodPressure], alert)
 				s.logger.Warn().
 					Str("device_id", device.ID).
 					Str("user_id", device.UserID).
@@ -170,7 +258,7 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 			} else if vitals.BloodPressure.Diastolic > thresholds.BloodPressure.Diastolic.Max {
 				alert := fmt.Sprintf("High diastolic BP: %d mmHg (max: %d mmHg)",
 					vitals.BloodPressure.Diastolic, thresholds.BloodPressure.Diastolic.Max)
-				alerts = append(alerts, alert)
+				breaches[AlertTypeBloodPressure] = append(breaches[AlertTypeBloodPressure], alert)
 				s.logger.Warn().
 					Str("device_id", device.ID).
 					Str("user_id", device.UserID).
@@ -180,6 +268,10 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 		}
 	}
 
+	// Apply per-alert-type cooldown and sustained-breach policies
+	evaluated := evaluatedAlertTypes(vitals)
+	alerts := s.applyAlertPolicies(device.UserID, thresholds, evaluated, breaches)
+
 	// Send notifications for all alerts
 	if len(alerts) > 0 {
 		if err := s.sendVitalSignsAlert(ctx, device, vitals, alerts); err != nil {
@@ -190,14 +282,103 @@ func (s *VitalsService) MonitorVitalSigns(ctx context.Context, device *models.De
 	return nil
 }
 
+// evaluatedAlertTypes lists the alert types a telemetry packet carried data
+// for, so recovery readings reset breach streaks
+func evaluatedAlertTypes(vitals *models.VitalSigns) []string {
+	var types []string
+	if vitals.HeartRate > 0 {
+		types = append(types, AlertTypeHeartRate)
+	}
+	if vitals.SpO2 > 0 {
+		types = append(types, AlertTypeSpO2)
+	}
+	if vitals.Temperature > 0 {
+		types = append(types, AlertTypeTemperature)
+	}
+	if vitals.BloodPressure != nil && (vitals.BloodPressure.Systolic > 0 || vitals.BloodPressure.Diastolic > 0) {
+		types = append(types, AlertTypeBloodPressure)
+	}
+	return types
+}
+
+// applyAlertPolicies filters breaches through the per-alert-type policies:
+// a breach must be sustained for the configured number of consecutive
+// readings, and repeated alerts respect the cooldown window
+func (s *VitalsService) applyAlertPolicies(userID string, thresholds VitalThresholds, evaluated []string, breaches map[string][]string) []string {
+	now := time.Now()
+
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	var alerts []string
+	for _, alertType := range evaluated {
+		key := alertKey{userID: userID, alertType: alertType}
+		messages, breached := breaches[alertType]
+
+		if !breached {
+			// Reading back in range; reset the streak
+			delete(s.breachStreaks, key)
+			continue
+		}
+
+		s.breachStreaks[key]++
+		policy := thresholds.Alerting[alertType]
+
+		if s.breachStreaks[key] < policy.SustainedReadings {
+			s.logger.Debug().
+				Str("user_id", userID).
+				Str("alert_type", alertType).
+				Int("streak", s.breachStreaks[key]).
+				Int("required", policy.SustainedReadings).
+				Msg("Breach not yet sustained, suppressing alert")
+			continue
+		}
+
+		cooldown := time.Duration(policy.CooldownSeconds) * time.Second
+		if last, ok := s.lastAlerted[key]; ok && cooldown > 0 && now.Sub(last) < cooldown {
+			s.logger.Debug().
+				Str("user_id", userID).
+				Str("alert_type", alertType).
+				Dur("cooldown", cooldown).
+				Msg("Alert in cooldown window, suppressing")
+			continue
+		}
+
+		s.lastAlerted[key] = now
+		alerts = append(alerts, messages...)
+	}
+
+	return alerts
+}
+
 // getThresholdsForUser returns thresholds for a specific user or default
 func (s *VitalsService) getThresholdsForUser(userID string) VitalThresholds {
+	s.thresholdsLock.RLock()
+	defer s.thresholdsLock.RUnlock()
+
 	if thresholds, ok := s.thresholds.PerUser[userID]; ok {
 		return thresholds
 	}
 	return s.thresholds.Default
 }
 
+// ThresholdsForUser returns the effective thresholds for a user
+func (s *VitalsService) ThresholdsForUser(userID string) VitalThresholds {
+	return s.getThresholdsForUser(userID)
+}
+
+// SetUserThresholds installs a per-user threshold override at runtime. The
+// YAML config remains the durable source; restarts fall back to it
+func (s *VitalsService) SetUserThresholds(userID string, thresholds VitalThresholds) {
+	s.thresholdsLock.Lock()
+	defer s.thresholdsLock.Unlock()
+
+	if s.thresholds.PerUser == nil {
+		s.thresholds.PerUser = make(map[string]VitalThresholds)
+	}
+	s.thresholds.PerUser[userID] = thresholds
+}
+
 // sendVitalSignsAlert sends notification when vital signs exceed thresholds
 func (s *VitalsService) sendVitalSignsAlert(ctx context.Context, device *models.Device, vitals *models.VitalSigns, alerts []string) error {
 	// In a real implementation, this would call a notification service
@@ -210,6 +391,9 @@ func (s *VitalsService) sendVitalSignsAlert(ctx context.Context, device *models.
 		Interface("vitals", vitals).
 		Msg("Vital signs threshold exceeded - notification should be sent")
 
+	// Persist the alert so the user can review it later
+	s.alertRecorder.Record(ctx, device, models.AlertCategoryVitals, models.AlertTypeVitalSigns, "HIGH", strings.Join(alerts, "; "))
+
 	// TODO: Integrate with notification service
 	// Example:
 	// notificationPayload := map[string]interface{}{
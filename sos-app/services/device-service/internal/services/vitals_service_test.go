@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVitalsService(policies map[string]AlertPolicy) *VitalsService {
+	return &VitalsService{
+		thresholds: ThresholdsConfig{
+			Default: VitalThresholds{Alerting: policies},
+		},
+		logger:        zerolog.Nop(),
+		breachStreaks: make(map[alertKey]int),
+		lastAlerted:   make(map[alertKey]time.Time),
+	}
+}
+
+func TestApplyAlertPoliciesNoPolicyFiresImmediately(t *testing.T) {
+	s := newTestVitalsService(nil)
+	thresholds := s.thresholds.Default
+
+	alerts := s.applyAlertPolicies("user-1", thresholds,
+		[]string{AlertTypeHeartRate},
+		map[string][]string{AlertTypeHeartRate: {"Low heart rate"}})
+
+	assert.Equal(t, []string{"Low heart rate"}, alerts)
+}
+
+func TestApplyAlertPoliciesSustainedReadings(t *testing.T) {
+	s := newTestVitalsService(map[string]AlertPolicy{
+		AlertTypeHeartRate: {SustainedReadings: 3},
+	})
+	thresholds := s.thresholds.Default
+	evaluated := []string{AlertTypeHeartRate}
+	breaches := map[string][]string{AlertTypeHeartRate: {"Low heart rate"}}
+
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+	assert.Equal(t, []string{"Low heart rate"},
+		s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+}
+
+func TestApplyAlertPoliciesStreakResetsOnRecovery(t *testing.T) {
+	s := newTestVitalsService(map[string]AlertPolicy{
+		AlertTypeSpO2: {SustainedReadings: 2},
+	})
+	thresholds := s.thresholds.Default
+	evaluated := []string{AlertTypeSpO2}
+	breaches := map[string][]string{AlertTypeSpO2: {"Low SpO2"}}
+
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+
+	// In-range reading resets the streak
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, map[string][]string{}))
+
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+	assert.Equal(t, []string{"Low SpO2"},
+		s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+}
+
+func TestApplyAlertPoliciesCooldownSuppressesRepeats(t *testing.T) {
+	s := newTestVitalsService(map[string]AlertPolicy{
+		AlertTypeTemperature: {CooldownSeconds: 300},
+	})
+	thresholds := s.thresholds.Default
+	evaluated := []string{AlertTypeTemperature}
+	breaches := map[string][]string{AlertTypeTemperature: {"High temperature"}}
+
+	assert.NotEmpty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+
+	// Expire the cooldown window and the alert fires again
+	key := alertKey{userID: "user-1", alertType: AlertTypeTemperature}
+	s.lastAlerted[key] = time.Now().Add(-10 * time.Minute)
+	assert.NotEmpty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+}
+
+func TestApplyAlertPoliciesStatePerUser(t *testing.T) {
+	s := newTestVitalsService(map[string]AlertPolicy{
+		AlertTypeHeartRate: {SustainedReadings: 2},
+	})
+	thresholds := s.thresholds.Default
+	evaluated := []string{AlertTypeHeartRate}
+	breaches := map[string][]string{AlertTypeHeartRate: {"High heart rate"}}
+
+	assert.Empty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+
+	// A second user's streak is tracked independently
+	assert.Empty(t, s.applyAlertPolicies("user-2", thresholds, evaluated, breaches))
+	assert.NotEmpty(t, s.applyAlertPolicies("user-1", thresholds, evaluated, breaches))
+}
+
+func TestSetUserThresholdsOverridesDefault(t *testing.T) {
+	s := newTestVitalsService(nil)
+
+	override := VitalThresholds{}
+	override.HeartRate.Min = 50
+	override.HeartRate.Max = 100
+	s.SetUserThresholds("user-1", override)
+
+	assert.Equal(t, 50, s.ThresholdsForUser("user-1").HeartRate.Min)
+	assert.Equal(t, s.thresholds.Default, s.ThresholdsForUser("user-2"))
+}
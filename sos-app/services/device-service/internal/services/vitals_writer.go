@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+const (
+	// vitalsFlushInterval bounds how stale a buffered reading can get
+	vitalsFlushInterval = 5 * time.Second
+	// vitalsFlushBatchSize flushes early when the buffer fills up
+	vitalsFlushBatchSize = 100
+)
+
+// VitalsWriter buffers vitals readings and batch-inserts them with the COPY
+// protocol, so a chatty fleet costs a handful of round trips per flush
+// interval instead of one insert per reading
+type VitalsWriter struct {
+	readingRepo *repository.VitalsReadingRepository
+	logger      zerolog.Logger
+
+	mu     sync.Mutex
+	buffer []*models.VitalsReading
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewVitalsWriter creates a new vitals writer
+func NewVitalsWriter(readingRepo *repository.VitalsReadingRepository, logger zerolog.Logger) *VitalsWriter {
+	return &VitalsWriter{
+		readingRepo: readingRepo,
+		logger:      logger,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic flush loop
+func (w *VitalsWriter) Start(ctx context.Context) {
+	w.logger.Info().
+		Dur("interval", vitalsFlushInterval).
+		Int("batch_size", vitalsFlushBatchSize).
+		Msg("Starting vitals writer")
+
+	w.wg.Add(1)
+	go w.flushLoop(ctx)
+}
+
+// Stop flushes the remaining buffer and stops the writer
+func (w *VitalsWriter) Stop() {
+	close(w.stopChan)
+	w.wg.Wait()
+	w.flush(context.Background())
+	w.logger.Info().Msg("Vitals writer stopped")
+}
+
+// Enqueue buffers one reading for the next flush, stamping recorded_at so
+// the stored time is the arrival time rather than the flush time
+func (w *VitalsWriter) Enqueue(reading *models.VitalsReading) {
+	if reading.RecordedAt.IsZero() {
+		reading.RecordedAt = time.Now().UTC()
+	}
+
+	w.mu.Lock()
+	w.buffer = append(w.buffer, reading)
+	full := len(w.buffer) >= vitalsFlushBatchSize
+	w.mu.Unlock()
+
+	if full {
+		w.flush(context.Background())
+	}
+}
+
+// flushLoop flushes the buffer on every tick until stopped
+func (w *VitalsWriter) flushLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(vitalsFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush(ctx)
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flush batch-inserts the buffered readings. On failure the batch is dropped
+// rather than retried: vitals are a continuous stream and threshold alerts
+// have already been evaluated on the live values
+func (w *VitalsWriter) flush(ctx context.Context) {
+	w.mu.Lock()
+	batch := w.buffer
+	w.buffer = nil
+	w.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := w.readingRepo.InsertBatch(ctx, batch); err != nil {
+		w.logger.Error().
+			Err(err).
+			Int("count", len(batch)).
+			Msg("Failed to flush vitals readings")
+		return
+	}
+
+	w.logger.Debug().
+		Int("count", len(batch)).
+		Msg("Flushed vitals readings")
+}
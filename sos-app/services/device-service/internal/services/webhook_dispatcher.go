@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sos-app/device-service/internal/models"
+	"github.com/sos-app/device-service/internal/repository"
+)
+
+const (
+	// webhookMaxAttempts is how many times one event is tried per webhook
+	webhookMaxAttempts = 3
+	// webhookRetryBackoff is the base delay doubled between attempts
+	webhookRetryBackoff = 2 * time.Second
+)
+
+// WebhookDispatcher delivers device connectivity transitions to registered
+// organization webhooks with signed payloads and retries, logging every
+// delivery outcome
+type WebhookDispatcher struct {
+	webhookRepo *repository.ConnectivityWebhookRepository
+	alertRouter *AlertRouter
+	httpClient  *http.Client
+	logger      zerolog.Logger
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher. alertRouter may be
+// nil to deliver events without routing decisions
+func NewWebhookDispatcher(
+	webhookRepo *repository.ConnectivityWebhookRepository,
+	alertRouter *AlertRouter,
+	logger zerolog.Logger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		alertRouter: alertRouter,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		logger:      logger,
+	}
+}
+
+// NotifyConnectivityChange fans the transition out to every enabled webhook.
+// Delivery happens in the background so MQTT handlers and the connectivity
+// monitor are never blocked on third-party endpoints
+func (d *WebhookDispatcher) NotifyConnectivityChange(ctx context.Context, device *models.Device, eventType string) {
+	webhooks, err := d.webhookRepo.ListEnabled(ctx)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Failed to load connectivity webhooks")
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	event := map[string]interface{}{
+		"event":     eventType,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"device": map[string]interface{}{
+			"id":           device.ID,
+			"device_type":  device.DeviceType,
+			"manufacturer": device.Manufacturer,
+			"model":        device.Model,
+			"status":       device.Status,
+			"last_seen_at": device.LastSeenAt,
+		},
+	}
+
+	priority := models.AlertPriorityHigh
+	if eventType == models.WebhookEventDeviceReconnected {
+		priority = models.AlertPriorityLow
+	}
+	alert := models.AlertContext{
+		Category:   models.AlertCategoryConnectivity,
+		Priority:   priority,
+		DeviceType: string(device.DeviceType),
+	}
+
+	for _, webhook := range webhooks {
+		payload, err := d.payloadFor(ctx, webhook, event, alert)
+		if err != nil {
+			d.logger.Error().Err(err).Str("webhook_id", webhook.ID).Msg("Failed to marshal webhook payload")
+			continue
+		}
+		go d.deliver(webhook, device.ID, eventType, payload)
+	}
+}
+
+// payloadFor marshals the event for one webhook, attaching the organization's
+// routing decision when one of its rules matches the alert. Routing is
+// advisory: a rule-evaluation failure delivers the event without it
+func (d *WebhookDispatcher) payloadFor(ctx context.Context, webhook models.ConnectivityWebhook, event map[string]interface{}, alert models.AlertContext) ([]byte, error) {
+	if d.alertRouter != nil {
+		rule, err := d.alertRouter.Route(ctx, webhook.OrgID, alert)
+		if err != nil {
+			d.logger.Error().Err(err).Str("org_id", webhook.OrgID).Msg("Failed to evaluate alert routing rules")
+		} else if rule != nil {
+			routed := make(map[string]interface{}, len(event)+1)
+			for k, v := range event {
+				routed[k] = v
+			}
+			routed["routing"] = map[string]interface{}{
+				"rule_id":   rule.ID,
+				"rule_name": rule.Name,
+				"team":      rule.Team,
+			}
+			event = routed
+		}
+	}
+	return json.Marshal(event)
+}
+
+// deliver posts one event to one webhook with retries, then logs the outcome
+func (d *WebhookDispatcher) deliver(webhook models.ConnectivityWebhook, deviceID, eventType string, payload []byte) {
+	signature := signPayload(webhook.Secret, payload)
+
+	delivery := &models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		DeviceID:  deviceID,
+		EventType: eventType,
+	}
+
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+
+		statusCode, err := d.post(webhook.URL, signature, payload)
+		if statusCode != 0 {
+			code := statusCode
+			delivery.StatusCode = &code
+		}
+		if err == nil {
+			delivery.Succeeded = true
+			delivery.LastError = nil
+			break
+		}
+
+		errMsg := err.Error()
+		delivery.LastError = &errMsg
+		d.logger.Warn().
+			Err(err).
+			Str("webhook_id", webhook.ID).
+			Str("device_id", deviceID).
+			Int("attempt", attempt).
+			Msg("Webhook delivery attempt failed")
+
+		if attempt < webhookMaxAttempts {
+			time.Sleep(webhookRetryBackoff * time.Duration(1<<(attempt-1)))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.webhookRepo.RecordDelivery(ctx, delivery); err != nil {
+		d.logger.Error().Err(err).Str("webhook_id", webhook.ID).Msg("Failed to record webhook delivery")
+	}
+}
+
+// post performs one signed delivery attempt
+func (d *WebhookDispatcher) post(url, signature string, payload []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-SOS-Signature", signature)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// signPayload computes the HMAC-SHA256 signature receivers verify with the
+// webhook secret
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
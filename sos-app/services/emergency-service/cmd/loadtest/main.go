@@ -0,0 +1,349 @@
+// Command loadtest drives the emergency trigger path end to end against a
+// running stack: it fires concurrent emergency triggers, streams location
+// updates and attaches WebSocket subscribers, then reports p50/p95/p99
+// latencies per stage. Used to validate the "trigger to broadcast in under
+// 2 seconds" SLO.
+//
+// Usage:
+//
+//	go run ./cmd/loadtest \
+//	    -triggers 50 -updates-per-sec 100 -subscribers 200 -duration 30s \
+//	    -emergency-url http://localhost:8080 -location-url http://localhost:3003
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Stage names reported by the harness
+const (
+	stageTrigger        = "trigger"
+	stageLocationUpdate = "location_update"
+	stageBroadcast      = "update_to_broadcast"
+)
+
+// sloTriggerToBroadcast is the end-to-end latency budget being validated
+const sloTriggerToBroadcast = 2 * time.Second
+
+type options struct {
+	triggers      int
+	updatesPerSec int
+	subscribers   int
+	duration      time.Duration
+	emergencyURL  string
+	locationURL   string
+}
+
+// latencyRecorder collects per-stage latency samples from many goroutines
+type latencyRecorder struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	errors  map[string]int
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{
+		samples: make(map[string][]time.Duration),
+		errors:  make(map[string]int),
+	}
+}
+
+func (r *latencyRecorder) record(stage string, d time.Duration) {
+	r.mu.Lock()
+	r.samples[stage] = append(r.samples[stage], d)
+	r.mu.Unlock()
+}
+
+func (r *latencyRecorder) recordError(stage string) {
+	r.mu.Lock()
+	r.errors[stage]++
+	r.mu.Unlock()
+}
+
+// percentile returns the pth percentile of a sorted sample slice
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
+
+// report prints per-stage percentiles and returns the p95 per stage
+func (r *latencyRecorder) report() map[string]time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p95s := make(map[string]time.Duration)
+
+	stages := []string{stageTrigger, stageLocationUpdate, stageBroadcast}
+	fmt.Println()
+	fmt.Printf("%-22s %8s %8s %10s %10s %10s\n", "stage", "count", "errors", "p50", "p95", "p99")
+	fmt.Println(strings.Repeat("-", 74))
+
+	for _, stage := range stages {
+		samples := append([]time.Duration(nil), r.samples[stage]...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+		p50 := percentile(samples, 0.50)
+		p95 := percentile(samples, 0.95)
+		p99 := percentile(samples, 0.99)
+		p95s[stage] = p95
+
+		fmt.Printf("%-22s %8d %8d %10s %10s %10s\n",
+			stage, len(samples), r.errors[stage],
+			p50.Round(time.Millisecond),
+			p95.Round(time.Millisecond),
+			p99.Round(time.Millisecond))
+	}
+
+	return p95s
+}
+
+func main() {
+	opts := options{}
+	flag.IntVar(&opts.triggers, "triggers", 10, "number of concurrent emergency triggers")
+	flag.IntVar(&opts.updatesPerSec, "updates-per-sec", 10, "location updates per second across all emergencies")
+	flag.IntVar(&opts.subscribers, "subscribers", 10, "number of WebSocket subscribers")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long to stream updates")
+	flag.StringVar(&opts.emergencyURL, "emergency-url", "http://localhost:8080", "emergency-service base URL")
+	flag.StringVar(&opts.locationURL, "location-url", "http://localhost:3003", "location-service base URL")
+	flag.Parse()
+
+	recorder := newLatencyRecorder()
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	fmt.Printf("Triggering %d emergencies...\n", opts.triggers)
+	emergencies := triggerEmergencies(client, opts, recorder)
+	if len(emergencies) == 0 {
+		fmt.Println("No emergencies could be triggered; is the stack running?")
+		os.Exit(1)
+	}
+	fmt.Printf("Triggered %d emergencies\n", len(emergencies))
+
+	stopChan := make(chan struct{})
+	var wg sync.WaitGroup
+
+	fmt.Printf("Attaching %d WebSocket subscribers...\n", opts.subscribers)
+	for i := 0; i < opts.subscribers; i++ {
+		emergency := emergencies[i%len(emergencies)]
+		wg.Add(1)
+		go func(e triggeredEmergency) {
+			defer wg.Done()
+			runSubscriber(opts, e, recorder, stopChan)
+		}(emergency)
+	}
+
+	fmt.Printf("Streaming %d updates/sec for %s...\n", opts.updatesPerSec, opts.duration)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		streamLocationUpdates(client, opts, emergencies, recorder, stopChan)
+	}()
+
+	time.Sleep(opts.duration)
+	close(stopChan)
+	wg.Wait()
+
+	p95s := recorder.report()
+
+	// The SLO covers trigger plus fan-out to viewers
+	endToEnd := p95s[stageTrigger] + p95s[stageBroadcast]
+	fmt.Println()
+	fmt.Printf("p95 trigger + p95 broadcast: %s (SLO %s)\n",
+		endToEnd.Round(time.Millisecond), sloTriggerToBroadcast)
+	if endToEnd > sloTriggerToBroadcast {
+		fmt.Println("SLO: FAIL")
+		os.Exit(1)
+	}
+	fmt.Println("SLO: PASS")
+}
+
+type triggeredEmergency struct {
+	EmergencyID uuid.UUID
+	UserID      uuid.UUID
+}
+
+// triggerEmergencies fires opts.triggers concurrent trigger requests and
+// returns the emergencies that were created
+func triggerEmergencies(client *http.Client, opts options, recorder *latencyRecorder) []triggeredEmergency {
+	var mu sync.Mutex
+	var emergencies []triggeredEmergency
+	var wg sync.WaitGroup
+
+	for i := 0; i < opts.triggers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			userID := uuid.New()
+			countdown := 1
+			payload, _ := json.Marshal(map[string]interface{}{
+				"user_id":        userID,
+				"emergency_type": "GENERAL",
+				"location": map[string]interface{}{
+					"latitude":  37.7749,
+					"longitude": -122.4194,
+					"timestamp": time.Now().UTC(),
+				},
+				"triggered_by":      "loadtest",
+				"countdown_seconds": countdown,
+			})
+
+			start := time.Now()
+			resp, err := client.Post(
+				opts.emergencyURL+"/api/v1/emergency/trigger",
+				"application/json",
+				bytes.NewReader(payload),
+			)
+			if err != nil {
+				recorder.recordError(stageTrigger)
+				return
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				recorder.recordError(stageTrigger)
+				return
+			}
+			recorder.record(stageTrigger, time.Since(start))
+
+			var emergency struct {
+				ID uuid.UUID `json:"id"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&emergency); err != nil {
+				return
+			}
+
+			mu.Lock()
+			emergencies = append(emergencies, triggeredEmergency{
+				EmergencyID: emergency.ID,
+				UserID:      userID,
+			})
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return emergencies
+}
+
+// streamLocationUpdates posts updates round-robin across emergencies at the
+// requested aggregate rate until stopChan closes
+func streamLocationUpdates(client *http.Client, opts options, emergencies []triggeredEmergency, recorder *latencyRecorder, stopChan chan struct{}) {
+	if opts.updatesPerSec <= 0 {
+		return
+	}
+
+	interval := time.Second / time.Duration(opts.updatesPerSec)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	i := 0
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			emergency := emergencies[i%len(emergencies)]
+			i++
+
+			go func() {
+				payload, _ := json.Marshal(map[string]interface{}{
+					"emergencyId": emergency.EmergencyID,
+					"userId":      emergency.UserID,
+					"latitude":    37.7749,
+					"longitude":   -122.4194,
+					"provider":    "GPS",
+					// The subscriber derives broadcast latency from this
+					"timestamp": time.Now().UTC(),
+					"severity":  "CRITICAL",
+				})
+
+				start := time.Now()
+				resp, err := client.Post(
+					opts.locationURL+"/api/v1/location/update",
+					"application/json",
+					bytes.NewReader(payload),
+				)
+				if err != nil {
+					recorder.recordError(stageLocationUpdate)
+					return
+				}
+				resp.Body.Close()
+
+				if resp.StatusCode >= 300 {
+					recorder.recordError(stageLocationUpdate)
+					return
+				}
+				recorder.record(stageLocationUpdate, time.Since(start))
+			}()
+		}
+	}
+}
+
+// runSubscriber opens a WebSocket, subscribes to an emergency room and
+// records update-to-broadcast latency for every location update received
+func runSubscriber(opts options, emergency triggeredEmergency, recorder *latencyRecorder, stopChan chan struct{}) {
+	wsURL := strings.Replace(opts.locationURL, "http", "ws", 1) + "/api/v1/location/subscribe"
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		recorder.recordError(stageBroadcast)
+		return
+	}
+	defer conn.Close()
+
+	subscribe, _ := json.Marshal(map[string]interface{}{
+		"action":      "subscribe",
+		"emergencyId": emergency.EmergencyID,
+	})
+	if err := conn.WriteMessage(websocket.TextMessage, subscribe); err != nil {
+		recorder.recordError(stageBroadcast)
+		return
+	}
+
+	go func() {
+		<-stopChan
+		conn.Close()
+	}()
+
+	for {
+		var message struct {
+			Type     string `json:"type"`
+			Location *struct {
+				Timestamp time.Time `json:"timestamp"`
+			} `json:"location"`
+		}
+
+		if err := conn.ReadJSON(&message); err != nil {
+			select {
+			case <-stopChan:
+			default:
+				recorder.recordError(stageBroadcast)
+			}
+			return
+		}
+
+		if message.Type != "location:update" || message.Location == nil {
+			continue
+		}
+
+		latency := time.Since(message.Location.Timestamp)
+		if latency > 0 {
+			recorder.record(stageBroadcast, latency)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/sos-app/emergency-service
 
-go 1.23.0
+go 1.24.0
 
 require (
 	github.com/confluentinc/confluent-kafka-go/v2 v2.12.0
@@ -11,13 +11,41 @@ require (
 )
 
 require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/crypto v0.37.0 // indirect
-	golang.org/x/sync v0.13.0 // indirect
-	golang.org/x/sys v0.32.0 // indirect
-	golang.org/x/text v0.24.0 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	github.com/segmentio/kafka-go v0.4.48 // indirect
+	golang.org/x/crypto v0.42.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+	golang.org/x/sys v0.36.0 // indirect
+	golang.org/x/text v0.29.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/prometheus/client_golang v1.19.1
+	github.com/sos-app/pkg/common v0.0.0
+	github.com/sos-app/pkg/config v0.0.0
+	github.com/sos-app/pkg/httpserver v0.0.0
+	github.com/sos-app/pkg/kafka v0.0.0
+)
+
+replace github.com/sos-app/pkg/httpserver => ../../pkg/httpserver
+
+replace github.com/sos-app/pkg/config => ../../pkg/config
+
+replace github.com/sos-app/pkg/kafka => ../../pkg/kafka
+
+replace github.com/sos-app/pkg/common => ../../pkg/common
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"os"
-	"strconv"
 	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/redact"
+	sharedconfig "github.com/sos-app/pkg/config"
 )
 
 // Config holds all configuration for the Emergency Service
@@ -12,6 +16,8 @@ type Config struct {
 	Database DatabaseConfig
 	Kafka    KafkaConfig
 	Service  ServiceConfig
+
+	loader *sharedconfig.Loader
 }
 
 // ServerConfig contains HTTP server configuration
@@ -25,6 +31,7 @@ type ServerConfig struct {
 
 // DatabaseConfig contains database connection configuration
 type DatabaseConfig struct {
+	URL      string
 	Host     string
 	Port     string
 	User     string
@@ -41,88 +48,170 @@ type KafkaConfig struct {
 	EmergencyCreatedTopic  string
 	EmergencyResolvedTopic string
 	EmergencyCancelledTopic string
+	EmergencyClaimedTopic string
+	EmergencyEscalatedTopic string
 	ContactAcknowledgedTopic string
+	AcknowledgmentRevokedTopic string
+	NotificationStatusTopic    string
+	CountdownPushReceiptTopic  string
+	DataErasureTopic       string
+	ErasureCompletedTopic  string
 	LocationUpdatedTopic   string
+	CheckInReminderTopic   string
 	ConsumerGroup          string
 }
 
 // ServiceConfig contains business logic configuration
 type ServiceConfig struct {
 	CountdownSeconds     int
+	CountdownPushGraceSeconds int // 0 disables early activation on unconfirmed pushes
 	EscalationTimeoutMin int
+	RetriggerCooloffSeconds int
 	MaxEmergenciesPerUser int
+	AbuseCancelledPerDay int // 0 disables abuse detection
+	ActivationLocationMaxAge time.Duration // freshness bound for the pre-activation location check
+	StalePendingGrace    time.Duration // slack past countdown_seconds before a PENDING row counts as stale
+	StalePendingPolicy   string // what the sweeper does with stale rows: activate or expire
+	PublicBaseURL        string
+	POIProviderURL       string // empty disables nearby-facility enrichment
+	WeatherProviderURL   string // empty disables weather enrichment
+	CallBridgeProviderURL string // empty disables escalation call bridges
+	LogUnsafePayloads    bool // log full coordinates, phones and message bodies; never in production
 }
 
-// Load loads configuration from environment variables with defaults
-func Load() *Config {
-	return &Config{
-		Server: ServerConfig{
-			Port:            getEnv("PORT", "8080"),
-			ReadTimeout:     getDurationEnv("READ_TIMEOUT", 15*time.Second),
-			WriteTimeout:    getDurationEnv("WRITE_TIMEOUT", 15*time.Second),
-			IdleTimeout:     getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
-			ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
-		},
-		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "sos_app_emergency"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
-			MaxConns: getIntEnv("DB_MAX_CONNS", 25),
-			MinConns: getIntEnv("DB_MIN_CONNS", 5),
-		},
-		Kafka: KafkaConfig{
-			Brokers:                []string{getEnv("KAFKA_BROKERS", "localhost:9092")},
-			EmergencyCreatedTopic:  getEnv("KAFKA_EMERGENCY_CREATED_TOPIC", "emergency-created"),
-			EmergencyResolvedTopic: getEnv("KAFKA_EMERGENCY_RESOLVED_TOPIC", "emergency-resolved"),
-			EmergencyCancelledTopic: getEnv("KAFKA_EMERGENCY_CANCELLED_TOPIC", "emergency-cancelled"),
-			ContactAcknowledgedTopic: getEnv("KAFKA_CONTACT_ACKNOWLEDGED_TOPIC", "contact-acknowledged"),
-			LocationUpdatedTopic:   getEnv("KAFKA_LOCATION_UPDATED_TOPIC", "location-updated"),
-			ConsumerGroup:          getEnv("KAFKA_CONSUMER_GROUP", "emergency-service"),
-		},
-		Service: ServiceConfig{
-			CountdownSeconds:     getIntEnv("COUNTDOWN_SECONDS", 10),
-			EscalationTimeoutMin: getIntEnv("ESCALATION_TIMEOUT_MIN", 2),
-			MaxEmergenciesPerUser: getIntEnv("MAX_EMERGENCIES_PER_USER", 1),
-		},
+// Load layers the optional CONFIG_FILE (YAML or JSON) under environment
+// variable overrides, validates the result and watches SIGHUP so service
+// tunables refresh in place without a restart. Values copied out of the
+// Config at startup (server timeouts, connection strings) still need one.
+func Load() (*Config, error) {
+	loader, err := sharedconfig.New(
+		sharedconfig.WithFile(os.Getenv("CONFIG_FILE")),
+		sharedconfig.WithLogf(func(format string, v ...interface{}) {
+			log.Info().Msgf(format, v...)
+		}),
+		sharedconfig.WithValidator(validate),
+	)
+	if err != nil {
+		return nil, err
 	}
+
+	cfg := &Config{loader: loader}
+	cfg.apply(loader)
+	loader.OnReload(func(l *sharedconfig.Loader) {
+		cfg.applyTunables(l)
+		log.Info().
+			Int("countdown_seconds", cfg.Service.CountdownSeconds).
+			Int("escalation_timeout_min", cfg.Service.EscalationTimeoutMin).
+			Msg("Service tunables reloaded")
+	})
+	loader.WatchSIGHUP()
+
+	return cfg, nil
 }
 
-// ConnectionString returns PostgreSQL connection string
-func (c *DatabaseConfig) ConnectionString() string {
-	return "host=" + c.Host +
-		" port=" + c.Port +
-		" user=" + c.User +
-		" password=" + c.Password +
-		" dbname=" + c.DBName +
-		" sslmode=" + c.SSLMode
+// Close stops the configuration reload watcher
+func (c *Config) Close() {
+	c.loader.Close()
 }
 
-// Helper functions
+// apply populates every section from the loader
+func (c *Config) apply(l *sharedconfig.Loader) {
+	c.Server = ServerConfig{
+		Port:            l.String("PORT", "8080"),
+		ReadTimeout:     l.Duration("READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:    l.Duration("WRITE_TIMEOUT", 15*time.Second),
+		IdleTimeout:     l.Duration("IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout: l.Duration("SHUTDOWN_TIMEOUT", 30*time.Second),
+	}
+	c.Database = DatabaseConfig{
+		URL:      l.String("DATABASE_URL", ""),
+		Host:     l.String("DB_HOST", "localhost"),
+		Port:     l.String("DB_PORT", "5432"),
+		User:     l.String("DB_USER", "postgres"),
+		Password: l.String("DB_PASSWORD", "postgres"),
+		DBName:   l.String("DB_NAME", "sos_app_emergency"),
+		SSLMode:  l.String("DB_SSLMODE", "disable"),
+		MaxConns: l.Int("DB_MAX_CONNS", 25),
+		MinConns: l.Int("DB_MIN_CONNS", 5),
+	}
+	c.Kafka = KafkaConfig{
+		Brokers:                l.StringSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
+		EmergencyCreatedTopic:  l.String("KAFKA_EMERGENCY_CREATED_TOPIC", "emergency-created"),
+		EmergencyResolvedTopic: l.String("KAFKA_EMERGENCY_RESOLVED_TOPIC", "emergency-resolved"),
+		EmergencyCancelledTopic: l.String("KAFKA_EMERGENCY_CANCELLED_TOPIC", "emergency-cancelled"),
+		EmergencyClaimedTopic: l.String("KAFKA_EMERGENCY_CLAIMED_TOPIC", "emergency-claimed"),
+		EmergencyEscalatedTopic: l.String("KAFKA_EMERGENCY_ESCALATED_TOPIC", "emergency-escalated"),
+		ContactAcknowledgedTopic: l.String("KAFKA_CONTACT_ACKNOWLEDGED_TOPIC", "contact-acknowledged"),
+		AcknowledgmentRevokedTopic: l.String("KAFKA_ACK_REVOKED_TOPIC", "acknowledgment-revoked"),
+		NotificationStatusTopic: l.String("KAFKA_NOTIFICATION_STATUS_TOPIC", "notification-delivery-status"),
+		CountdownPushReceiptTopic: l.String("KAFKA_COUNTDOWN_PUSH_RECEIPT_TOPIC", "countdown-push-receipts"),
+		DataErasureTopic:       l.String("KAFKA_DATA_ERASURE_TOPIC", "data-erasure"),
+		ErasureCompletedTopic:  l.String("KAFKA_ERASURE_COMPLETED_TOPIC", "erasure-completed"),
+		LocationUpdatedTopic:   l.String("KAFKA_LOCATION_UPDATED_TOPIC", "location-updated"),
+		CheckInReminderTopic:   l.String("KAFKA_CHECK_IN_REMINDER_TOPIC", "check-in-reminders"),
+		ConsumerGroup:          l.String("KAFKA_CONSUMER_GROUP", "emergency-service"),
+	}
+	c.applyTunables(l)
+}
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// applyTunables refreshes the hot-reloadable business tunables; these are
+// also re-applied on SIGHUP
+func (c *Config) applyTunables(l *sharedconfig.Loader) {
+	c.Service = ServiceConfig{
+		CountdownSeconds:     l.Int("COUNTDOWN_SECONDS", 10),
+		CountdownPushGraceSeconds: l.Int("COUNTDOWN_PUSH_GRACE_SECONDS", 5),
+		EscalationTimeoutMin: l.Int("ESCALATION_TIMEOUT_MIN", 2),
+		RetriggerCooloffSeconds: l.Int("RETRIGGER_COOLOFF_SECONDS", 120),
+		MaxEmergenciesPerUser: l.Int("MAX_EMERGENCIES_PER_USER", 1),
+		AbuseCancelledPerDay: l.Int("ABUSE_CANCELLED_PER_DAY", 10),
+		ActivationLocationMaxAge: l.Duration("ACTIVATION_LOCATION_MAX_AGE", 2*time.Minute),
+		StalePendingGrace:    l.Duration("STALE_PENDING_GRACE", 5*time.Minute),
+		StalePendingPolicy:   l.String("STALE_PENDING_POLICY", "activate"),
+		PublicBaseURL:        l.String("PUBLIC_BASE_URL", "http://localhost:8080"),
+		POIProviderURL:       l.String("POI_PROVIDER_URL", ""),
+		WeatherProviderURL:   l.String("WEATHER_PROVIDER_URL", ""),
+		CallBridgeProviderURL: l.String("CALL_BRIDGE_PROVIDER_URL", ""),
+		LogUnsafePayloads:    l.Bool("LOG_UNSAFE_PAYLOADS", false),
 	}
-	return defaultValue
+
+	// Redaction follows the tunable, so SIGHUP can lift and restore payload
+	// masking without a restart
+	redact.SetUnsafe(c.Service.LogUnsafePayloads)
 }
 
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intVal, err := strconv.Atoi(value); err == nil {
-			return intVal
-		}
+// validate rejects configurations that would break the emergency flow
+func validate(l *sharedconfig.Loader) error {
+	if l.Int("COUNTDOWN_SECONDS", 10) <= 0 {
+		return errors.New("COUNTDOWN_SECONDS must be positive")
+	}
+	if l.Int("ESCALATION_TIMEOUT_MIN", 2) <= 0 {
+		return errors.New("ESCALATION_TIMEOUT_MIN must be positive")
+	}
+	if l.Int("RETRIGGER_COOLOFF_SECONDS", 120) < 0 {
+		return errors.New("RETRIGGER_COOLOFF_SECONDS must not be negative")
+	}
+	if l.Int("COUNTDOWN_PUSH_GRACE_SECONDS", 5) < 0 {
+		return errors.New("COUNTDOWN_PUSH_GRACE_SECONDS must not be negative")
+	}
+	if l.Int("ABUSE_CANCELLED_PER_DAY", 10) < 0 {
+		return errors.New("ABUSE_CANCELLED_PER_DAY must not be negative")
+	}
+	if policy := l.String("STALE_PENDING_POLICY", "activate"); policy != "activate" && policy != "expire" {
+		return errors.New("STALE_PENDING_POLICY must be activate or expire")
 	}
-	return defaultValue
+	return nil
 }
 
-func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
+// ConnectionString returns PostgreSQL connection string. A full DATABASE_URL
+// takes precedence over the individual DB_* settings
+func (c *DatabaseConfig) ConnectionString() string {
+	if c.URL != "" {
+		return c.URL
 	}
-	return defaultValue
+	return "host=" + c.Host +
+		" port=" + c.Port +
+		" user=" + c.User +
+		" password=" + c.Password +
+		" dbname=" + c.DBName +
+		" sslmode=" + c.SSLMode
 }
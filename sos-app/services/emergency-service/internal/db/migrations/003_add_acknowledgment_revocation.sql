@@ -0,0 +1,20 @@
+-- Migration: 003_add_acknowledgment_revocation
+-- Description: Add soft revocation columns to emergency_acknowledgments
+-- Created: 2025-11-11
+
+-- Revocation is soft: the row is kept for audit purposes and excluded
+-- from active acknowledgment counts used by escalation.
+ALTER TABLE emergency_acknowledgments
+    ADD COLUMN IF NOT EXISTS revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS revoked_reason TEXT;
+
+-- Partial index so active-acknowledgment counts stay cheap
+CREATE INDEX IF NOT EXISTS idx_acknowledgments_active
+    ON emergency_acknowledgments(emergency_id)
+    WHERE revoked = FALSE;
+
+-- Add comments for documentation
+COMMENT ON COLUMN emergency_acknowledgments.revoked IS 'TRUE if the contact revoked their acknowledgment';
+COMMENT ON COLUMN emergency_acknowledgments.revoked_at IS 'Timestamp when the acknowledgment was revoked';
+COMMENT ON COLUMN emergency_acknowledgments.revoked_reason IS 'Optional reason supplied by the contact when revoking';
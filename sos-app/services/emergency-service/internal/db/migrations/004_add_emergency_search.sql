@@ -0,0 +1,19 @@
+-- Add full-text search over emergencies
+-- Supports the support-team search endpoint over initial messages,
+-- resolution notes and metadata
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (
+            to_tsvector('english',
+                COALESCE(initial_message, '') || ' ' ||
+                COALESCE(resolution_notes, '') || ' ' ||
+                COALESCE(metadata::text, '')
+            )
+        ) STORED;
+
+-- GIN index for fast full-text queries
+CREATE INDEX IF NOT EXISTS idx_emergencies_search_vector
+    ON emergencies USING GIN (search_vector);
+
+COMMENT ON COLUMN emergencies.search_vector IS 'Generated tsvector over initial_message, resolution_notes and metadata for full-text search';
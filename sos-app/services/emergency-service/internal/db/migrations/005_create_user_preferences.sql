@@ -0,0 +1,18 @@
+-- Migration: 005_create_user_preferences
+-- Per-user emergency preferences consulted when a trigger request omits
+-- explicit overrides
+
+CREATE TABLE IF NOT EXISTS user_emergency_preferences (
+    user_id UUID PRIMARY KEY,
+    default_countdown_seconds INTEGER CHECK (default_countdown_seconds > 0 AND default_countdown_seconds <= 300),
+    silent_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    quick_action_types JSONB NOT NULL DEFAULT '[]'::jsonb,
+    message_templates JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN user_emergency_preferences.default_countdown_seconds IS 'Countdown applied when a trigger request omits countdown_seconds';
+COMMENT ON COLUMN user_emergency_preferences.silent_mode IS 'TRUE if the user prefers silent alerts (no audible countdown)';
+COMMENT ON COLUMN user_emergency_preferences.quick_action_types IS 'Ordered list of emergency types shown as quick actions';
+COMMENT ON COLUMN user_emergency_preferences.message_templates IS 'Default initial messages keyed by emergency type';
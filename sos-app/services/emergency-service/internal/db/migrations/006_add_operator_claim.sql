@@ -0,0 +1,16 @@
+-- Migration: 006_add_operator_claim
+-- Records monitoring-center operator takeover of an emergency
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS claimed_by UUID,
+    ADD COLUMN IF NOT EXISTS claimed_by_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
+
+-- Operators need to find unclaimed active emergencies quickly
+CREATE INDEX IF NOT EXISTS idx_emergencies_unclaimed_active
+    ON emergencies (created_at DESC)
+    WHERE status = 'ACTIVE' AND claimed_by IS NULL;
+
+COMMENT ON COLUMN emergencies.claimed_by IS 'Operator who took over handling of the emergency';
+COMMENT ON COLUMN emergencies.claimed_by_name IS 'Display name of the claiming operator';
+COMMENT ON COLUMN emergencies.claimed_at IS 'Timestamp of the operator takeover';
@@ -0,0 +1,39 @@
+-- Migration: 007_create_webhook_connectors
+-- Description: Create tables for per-organization Slack/Teams incident webhooks
+-- Created: 2026-08-29
+
+-- Connectors configured by monitoring organizations
+CREATE TABLE IF NOT EXISTS org_webhook_connectors (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL,
+    provider VARCHAR(10) NOT NULL CHECK (provider IN ('slack', 'teams')),
+    name VARCHAR(255) NOT NULL,
+    webhook_url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_connectors_org ON org_webhook_connectors(org_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_connectors_enabled ON org_webhook_connectors(enabled) WHERE enabled;
+
+-- Thread references so follow-up cards (escalation, resolution) land in the
+-- same Slack thread as the trigger card
+CREATE TABLE IF NOT EXISTS webhook_threads (
+    connector_id UUID NOT NULL REFERENCES org_webhook_connectors(id) ON DELETE CASCADE,
+    emergency_id UUID NOT NULL REFERENCES emergencies(id) ON DELETE CASCADE,
+    thread_ref VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT webhook_threads_pkey PRIMARY KEY (connector_id, emergency_id)
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE org_webhook_connectors IS 'Slack/Teams webhook connectors for organization monitoring channels';
+COMMENT ON COLUMN org_webhook_connectors.org_id IS 'Organization that owns the connector';
+COMMENT ON COLUMN org_webhook_connectors.provider IS 'Webhook provider: slack or teams';
+COMMENT ON COLUMN org_webhook_connectors.name IS 'Human-readable connector name, e.g. the target channel';
+COMMENT ON COLUMN org_webhook_connectors.webhook_url IS 'Provider webhook endpoint incident cards are posted to';
+COMMENT ON COLUMN org_webhook_connectors.enabled IS 'Whether incident cards are delivered to this connector';
+COMMENT ON TABLE webhook_threads IS 'Per-connector message thread references keyed by emergency';
+COMMENT ON COLUMN webhook_threads.thread_ref IS 'Provider thread identifier (Slack message ts) of the trigger card';
@@ -0,0 +1,24 @@
+-- Migration: 008_create_erasure_requests
+-- Description: Create data_erasure_requests table for GDPR/CCPA deletion scheduling
+-- Created: 2026-08-29
+
+CREATE TABLE IF NOT EXISTS data_erasure_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED' CHECK (status IN ('SCHEDULED', 'COMPLETED', 'CANCELLED')),
+    legal_hold_until TIMESTAMP WITH TIME ZONE,
+    requested_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+
+    -- One open request per user
+    CONSTRAINT unique_scheduled_erasure EXCLUDE (user_id WITH =) WHERE (status = 'SCHEDULED')
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_user ON data_erasure_requests(user_id);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_due ON data_erasure_requests(legal_hold_until) WHERE status = 'SCHEDULED';
+
+-- Add comments for documentation
+COMMENT ON TABLE data_erasure_requests IS 'Scheduled GDPR/CCPA data deletion requests';
+COMMENT ON COLUMN data_erasure_requests.status IS 'SCHEDULED, COMPLETED or CANCELLED';
+COMMENT ON COLUMN data_erasure_requests.legal_hold_until IS 'Erasure may not run before this time; NULL means immediately eligible';
+COMMENT ON COLUMN data_erasure_requests.processed_at IS 'When the erasure was executed';
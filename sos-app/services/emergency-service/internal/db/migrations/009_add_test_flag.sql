@@ -0,0 +1,8 @@
+-- Migration: 009_add_test_flag
+-- Flags drill/test-mode emergencies so they exercise the full pipeline while
+-- staying out of analytics and escalation-to-authorities connectors
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS is_test BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN emergencies.is_test IS 'Drill/test-mode emergency, excluded from stats and external connectors';
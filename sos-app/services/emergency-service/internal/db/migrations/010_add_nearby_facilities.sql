@@ -0,0 +1,9 @@
+-- Migration: 010_add_nearby_facilities
+-- Stores the nearest emergency facilities (hospital, police, fire station)
+-- resolved at activation time so responders and contacts see them without
+-- re-querying the POI provider
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS nearby_facilities JSONB;
+
+COMMENT ON COLUMN emergencies.nearby_facilities IS 'Nearest hospital/police/fire station snapshot taken at activation';
@@ -0,0 +1,18 @@
+-- Migration: 011_create_notification_statuses
+-- Per-contact, per-channel notification delivery outcomes reported by the
+-- notification service, so the victim's app can show exactly who was reached
+-- on which channel
+CREATE TABLE IF NOT EXISTS notification_statuses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    emergency_id UUID NOT NULL REFERENCES emergencies(id) ON DELETE CASCADE,
+    contact_id UUID NOT NULL,
+    contact_name VARCHAR(255) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_notification_channel UNIQUE (emergency_id, contact_id, channel)
+);
+
+-- Status lookups are always per emergency
+CREATE INDEX idx_notification_statuses_emergency ON notification_statuses(emergency_id);
@@ -0,0 +1,9 @@
+-- Migration: 012_add_ack_quorums
+-- Per-emergency-type acknowledgment quorums: escalation is only satisfied
+-- once K contacts have acknowledged (one family member may not be enough
+-- for a critical medical event)
+
+ALTER TABLE user_emergency_preferences
+    ADD COLUMN IF NOT EXISTS ack_quorums JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN user_emergency_preferences.ack_quorums IS 'Required acknowledgment count per emergency type before escalation stands down; missing types require 1';
@@ -0,0 +1,9 @@
+-- Migration: 013_add_weather_context
+-- Stores the weather and daylight snapshot taken at activation time so
+-- responders see the conditions on site (temperature, precipitation, whether
+-- it is dark) without re-querying the weather provider
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS weather_context JSONB;
+
+COMMENT ON COLUMN emergencies.weather_context IS 'Weather and daylight snapshot taken at activation';
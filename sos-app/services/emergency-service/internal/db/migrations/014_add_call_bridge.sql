@@ -0,0 +1,13 @@
+-- Migration: 014_add_call_bridge
+-- Stores the audio conference bridge created at the highest escalation level
+-- so contacts who join late can still dial in, and records the victim's phone
+-- number in preferences so they can optionally be dialed into the bridge
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS call_bridge JSONB;
+
+ALTER TABLE user_emergency_preferences
+    ADD COLUMN IF NOT EXISTS victim_phone VARCHAR(32);
+
+COMMENT ON COLUMN emergencies.call_bridge IS 'Conference bridge details created at escalation';
+COMMENT ON COLUMN user_emergency_preferences.victim_phone IS 'Phone number dialed into escalation call bridges, if set';
@@ -0,0 +1,31 @@
+-- Migration: 015_create_message_templates
+-- Description: Create per-organization notification message templates
+-- Created: 2026-08-29
+
+-- Templates let organizations put their own name and wording into alerts.
+-- One template per (org, event type, locale); the body carries {{variable}}
+-- placeholders substituted at publish time
+CREATE TABLE IF NOT EXISTS org_message_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL,
+    event_type VARCHAR(30) NOT NULL CHECK (event_type IN ('emergency.created', 'emergency.resolved', 'emergency.cancelled')),
+    locale VARCHAR(10) NOT NULL DEFAULT 'en',
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT unique_org_event_locale UNIQUE (org_id, event_type, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_templates_org ON org_message_templates(org_id);
+CREATE INDEX IF NOT EXISTS idx_message_templates_event ON org_message_templates(event_type) WHERE enabled;
+
+-- Add comments for documentation
+COMMENT ON TABLE org_message_templates IS 'Per-organization branded message templates for emergency notifications';
+COMMENT ON COLUMN org_message_templates.event_type IS 'Emergency lifecycle event the template applies to';
+COMMENT ON COLUMN org_message_templates.locale IS 'BCP 47 language tag, e.g. en or es-MX';
+COMMENT ON COLUMN org_message_templates.subject IS 'Notification title; supports {{variable}} placeholders';
+COMMENT ON COLUMN org_message_templates.body IS 'Notification body; supports {{variable}} placeholders';
+COMMENT ON COLUMN org_message_templates.enabled IS 'Whether rendered messages are included in events for this template';
@@ -0,0 +1,28 @@
+-- Migration: 016_create_abuse_flags
+-- Description: Create abuse flags for the per-user trigger quota and support-review workflow
+-- Created: 2026-08-29
+
+-- A user who cancels dozens of triggers a day desensitizes their contacts.
+-- Crossing the configured threshold raises a soft-lock flag; support reviews
+-- and clears it. A cleared flag is kept for the audit trail
+CREATE TABLE IF NOT EXISTS user_abuse_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    cancelled_count INTEGER NOT NULL,
+    flagged_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    cleared_at TIMESTAMP WITH TIME ZONE,
+    cleared_by VARCHAR(255),
+    review_note TEXT
+);
+
+-- At most one active (uncleared) flag per user
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_abuse_flag ON user_abuse_flags(user_id) WHERE cleared_at IS NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE user_abuse_flags IS 'Soft-lock flags raised when a user crosses the cancelled-trigger quota';
+COMMENT ON COLUMN user_abuse_flags.reason IS 'Why the flag was raised, shown to support during review';
+COMMENT ON COLUMN user_abuse_flags.cancelled_count IS 'Cancelled triggers counted in the window that raised the flag';
+COMMENT ON COLUMN user_abuse_flags.cleared_at IS 'When support cleared the flag; NULL while the soft-lock is active';
+COMMENT ON COLUMN user_abuse_flags.cleared_by IS 'Support agent who cleared the flag';
+COMMENT ON COLUMN user_abuse_flags.review_note IS 'Support note recorded when clearing the flag';
@@ -0,0 +1,9 @@
+-- Migration: 017_add_activation_checks
+-- Stores the outcomes of the pre-activation hook chain run when the
+-- countdown completes (location freshness, victim reachability, policy
+-- decisions) so support can audit what the service knew at activation time
+
+ALTER TABLE emergencies
+    ADD COLUMN IF NOT EXISTS activation_checks JSONB;
+
+COMMENT ON COLUMN emergencies.activation_checks IS 'Pre-activation hook outcomes recorded when the countdown completed';
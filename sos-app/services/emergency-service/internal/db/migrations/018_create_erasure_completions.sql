@@ -0,0 +1,21 @@
+-- Migration: 018_create_erasure_completions
+-- Description: Per-service completion tracking for the cross-service erasure saga
+-- Created: 2026-08-29
+
+CREATE TABLE IF NOT EXISTS data_erasure_completions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id UUID NOT NULL REFERENCES data_erasure_requests(id) ON DELETE CASCADE,
+    service VARCHAR(50) NOT NULL,
+    records_purged BIGINT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- One completion record per service per request
+    CONSTRAINT unique_erasure_completion UNIQUE (request_id, service)
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_completions_request ON data_erasure_completions(request_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE data_erasure_completions IS 'Which services have finished purging data for an erasure request';
+COMMENT ON COLUMN data_erasure_completions.service IS 'Reporting service, e.g. emergency-service, location-service, device-service';
+COMMENT ON COLUMN data_erasure_completions.records_purged IS 'How many records the service deleted or anonymized';
@@ -0,0 +1,30 @@
+-- Migration: 019_create_check_ins
+-- Description: Create scheduled check-ins with reminder bookkeeping
+-- Created: 2026-08-29
+
+-- A scheduled check-in is a deadline the user promises to report back by
+-- (hike, late-night commute). Reminders are pushed through the notification
+-- pipeline as the deadline approaches; reminders_sent records which
+-- minutes-before offsets already fired so the sweep never double-sends
+CREATE TABLE IF NOT EXISTS scheduled_check_ins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    deadline_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',
+    snooze_count INTEGER NOT NULL DEFAULT 0,
+    reminders_sent JSONB NOT NULL DEFAULT '[]'::jsonb,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- The reminder sweep only ever looks at open check-ins near their deadline
+CREATE INDEX IF NOT EXISTS idx_check_ins_scheduled_deadline ON scheduled_check_ins(deadline_at) WHERE status = 'SCHEDULED';
+CREATE INDEX IF NOT EXISTS idx_check_ins_user_id ON scheduled_check_ins(user_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE scheduled_check_ins IS 'User-scheduled check-in deadlines with reminder delivery bookkeeping';
+COMMENT ON COLUMN scheduled_check_ins.deadline_at IS 'When the user promised to check in; snoozing pushes this forward';
+COMMENT ON COLUMN scheduled_check_ins.snooze_count IS 'How many times the deadline was pushed via the snooze endpoint';
+COMMENT ON COLUMN scheduled_check_ins.reminders_sent IS 'Minutes-before offsets whose reminders were already published; reset on snooze';
+COMMENT ON COLUMN scheduled_check_ins.completed_at IS 'When the user checked in; NULL while the check-in is open';
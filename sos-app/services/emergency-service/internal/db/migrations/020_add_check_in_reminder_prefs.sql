@@ -0,0 +1,8 @@
+-- Migration: 020_add_check_in_reminder_prefs
+-- Per-user check-in reminder offsets: some users want more warning than the
+-- default T-10/T-2 push pair, others none at all
+
+ALTER TABLE user_emergency_preferences
+    ADD COLUMN IF NOT EXISTS check_in_reminder_minutes JSONB;
+
+COMMENT ON COLUMN user_emergency_preferences.check_in_reminder_minutes IS 'Minutes-before-deadline offsets for check-in reminders; NULL uses the default [10, 2], [] disables reminders';
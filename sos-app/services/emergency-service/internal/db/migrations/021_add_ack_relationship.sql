@@ -0,0 +1,11 @@
+-- Migration: 021_add_ack_relationship
+-- The contact's relationship to the victim (spouse, neighbor, professional
+-- caregiver), as reported by the contacts integration. Escalation treats a
+-- professional caregiver's acknowledgment as satisfying the quorum alone,
+-- and response listings order contacts by how useful the relationship is
+-- in an emergency
+
+ALTER TABLE emergency_acknowledgments
+    ADD COLUMN IF NOT EXISTS relationship VARCHAR(30);
+
+COMMENT ON COLUMN emergency_acknowledgments.relationship IS 'Contact relationship to the victim (SPOUSE, FAMILY, CAREGIVER, NEIGHBOR, FRIEND, OTHER); NULL when the contacts integration did not report one';
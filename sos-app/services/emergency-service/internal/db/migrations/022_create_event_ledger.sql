@@ -0,0 +1,18 @@
+-- Migration: 022_create_event_ledger
+-- Local ledger of lifecycle events this service published to Kafka, written
+-- alongside each publish. The nightly consistency checker cross-checks it
+-- against the emergencies table to find activations that never produced an
+-- EmergencyCreated event
+CREATE TABLE IF NOT EXISTS event_ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    emergency_id UUID NOT NULL REFERENCES emergencies(id) ON DELETE CASCADE,
+    event_type VARCHAR(40) NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- The checker probes for a specific event type per emergency
+CREATE INDEX idx_event_ledger_emergency_type ON event_ledger(emergency_id, event_type);
+
+COMMENT ON TABLE event_ledger IS 'Lifecycle events published to Kafka, recorded for consistency checking';
+COMMENT ON COLUMN event_ledger.event_type IS 'Event name, e.g. EmergencyCreated';
@@ -0,0 +1,7 @@
+-- Migration: 023_add_last_location_at
+-- When the last LocationUpdated event for this emergency was consumed,
+-- maintained by the Kafka consumer. An active emergency whose location has
+-- gone silent for over an hour is flagged by the nightly consistency checker
+ALTER TABLE emergencies ADD COLUMN IF NOT EXISTS last_location_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN emergencies.last_location_at IS 'Last consumed location update for this emergency; NULL when none was ever received';
@@ -0,0 +1,27 @@
+-- Migration: 024_create_incident_links
+-- Operators can merge separate emergencies for one real-world event (two
+-- household members both pressing SOS) into a single incident. Links are
+-- soft-deleted on split so the full merge/split history stays auditable
+CREATE TABLE IF NOT EXISTS incident_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    primary_emergency_id UUID NOT NULL REFERENCES emergencies(id) ON DELETE CASCADE,
+    linked_emergency_id UUID NOT NULL REFERENCES emergencies(id) ON DELETE CASCADE,
+    linked_by UUID NOT NULL,
+    linked_by_name VARCHAR(255) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    unlinked_at TIMESTAMP WITH TIME ZONE,
+    unlinked_by UUID,
+    unlinked_by_name VARCHAR(255),
+    unlink_reason TEXT,
+    CONSTRAINT no_self_link CHECK (primary_emergency_id <> linked_emergency_id)
+);
+
+-- An emergency belongs to at most one live incident at a time
+CREATE UNIQUE INDEX idx_incident_links_active_linked
+    ON incident_links(linked_emergency_id) WHERE unlinked_at IS NULL;
+
+CREATE INDEX idx_incident_links_primary ON incident_links(primary_emergency_id);
+
+COMMENT ON TABLE incident_links IS 'Merge links between emergencies belonging to one real-world incident';
+COMMENT ON COLUMN incident_links.unlinked_at IS 'Set when an operator splits the incident; the row stays as audit';
@@ -0,0 +1,13 @@
+-- Migration: 025_add_auto_resolution
+-- Emergencies nobody resolves manually would stay ACTIVE forever. A
+-- policy-driven sweep closes them: earlier when the user signalled safety by
+-- completing a check-in, later outright - except critical types, which wait
+-- for operator sign-off. auto_resolved keeps policy-closed emergencies
+-- distinguishable from manual resolutions in history and stats
+ALTER TABLE emergencies ADD COLUMN IF NOT EXISTS auto_resolved BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE emergencies ADD COLUMN IF NOT EXISTS auto_resolve_approved_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE emergencies ADD COLUMN IF NOT EXISTS auto_resolve_approved_by UUID;
+ALTER TABLE emergencies ADD COLUMN IF NOT EXISTS auto_resolve_approved_by_name VARCHAR(255);
+
+COMMENT ON COLUMN emergencies.auto_resolved IS 'Resolved by the auto-resolution policy rather than a person';
+COMMENT ON COLUMN emergencies.auto_resolve_approved_at IS 'Operator sign-off for auto-resolving a critical emergency';
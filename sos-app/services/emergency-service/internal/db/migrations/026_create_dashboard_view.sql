@@ -0,0 +1,27 @@
+-- Migration: 026_create_dashboard_view
+-- Read-optimized projection for monitoring-center dashboards. The normalized
+-- tables answer "all active emergencies with latest location, acknowledgment
+-- count and escalation level" poorly, so a projection worker consumes the
+-- service's own Kafka events and dual-writes this denormalized table. Rows
+-- exist only while an emergency is active; resolution and cancellation
+-- events delete them
+CREATE TABLE IF NOT EXISTS dashboard_active_emergencies (
+    emergency_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    emergency_type VARCHAR(50) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    auto_triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    ack_count INTEGER NOT NULL DEFAULT 0,
+    escalation_level INTEGER NOT NULL DEFAULT 0,
+    escalation_level_name VARCHAR(50),
+    last_location_at TIMESTAMP WITH TIME ZONE,
+    activated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Dashboards filter by type and sort newest-first
+CREATE INDEX idx_dashboard_active_type ON dashboard_active_emergencies(emergency_type);
+CREATE INDEX idx_dashboard_active_activated ON dashboard_active_emergencies(activated_at DESC);
+
+COMMENT ON TABLE dashboard_active_emergencies IS 'Denormalized projection of active emergencies maintained from Kafka events for dashboard queries';
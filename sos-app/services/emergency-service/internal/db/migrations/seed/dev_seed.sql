@@ -0,0 +1,115 @@
+-- Dev seed data for local development only
+-- Applied by scripts/run-migrations.sh when SEED_DEV_DATA=true (or via
+-- `make seed-dev`). Never part of the numbered migration chain and never
+-- run in production: every row uses a fixed UUID and ON CONFLICT DO
+-- NOTHING, so re-running is safe and timestamps stay relative to NOW()
+
+-- Well-known sample identities used across the seed rows:
+--   Alice (primary demo user):   11111111-1111-4111-a111-111111111111
+--   Bob (second demo user):      22222222-2222-4222-a222-222222222222
+--   Carol (emergency contact):   33333333-3333-4333-a333-333333333333
+--   Dave (emergency contact):    44444444-4444-4444-a444-444444444444
+--   Operator (monitoring desk):  55555555-5555-4555-a555-555555555555
+--   Alice's fall-detect pendant: device:66666666-6666-4666-a666-666666666666
+
+-- Preferences so the preferences screens render something meaningful
+INSERT INTO user_emergency_preferences
+    (user_id, default_countdown_seconds, silent_mode, quick_action_types, message_templates, ack_quorums)
+VALUES
+    ('11111111-1111-4111-a111-111111111111', 15, FALSE,
+     '["MEDICAL", "FALL_DETECTED"]'::jsonb,
+     '{"MEDICAL": "I need medical help at my location"}'::jsonb,
+     '{"MEDICAL": 2}'::jsonb),
+    ('22222222-2222-4222-a222-222222222222', 30, TRUE,
+     '["GENERAL"]'::jsonb, '{}'::jsonb, '{}'::jsonb)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- An ACTIVE medical emergency for Alice, auto-triggered by her pendant,
+-- already enriched with nearby facilities and weather context
+INSERT INTO emergencies
+    (id, user_id, emergency_type, status, initial_location, initial_message,
+     auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
+     nearby_facilities, weather_context)
+VALUES
+    ('aaaaaaa1-0000-4000-a000-000000000001',
+     '11111111-1111-4111-a111-111111111111', 'MEDICAL', 'ACTIVE',
+     '{"lat": 37.7749, "lng": -122.4194, "accuracy": 12.5}'::jsonb,
+     'Fall detected, no response to countdown',
+     TRUE, 'device:66666666-6666-4666-a666-666666666666', 15,
+     NOW() - INTERVAL '10 minutes', NOW() - INTERVAL '9 minutes 45 seconds',
+     '[{"name": "SF General Hospital", "category": "hospital", "lat": 37.7559, "lng": -122.4046, "distance_meters": 2400}]'::jsonb,
+     '{"temperature_c": 14.5, "conditions": "Overcast", "wind_kph": 18.0, "precipitation_mm": 0, "is_daylight": true, "retrieved_at": "2025-11-06T18:00:00Z"}'::jsonb)
+ON CONFLICT (id) DO NOTHING;
+
+-- A RESOLVED fall for Alice from yesterday, claimed by the demo operator
+INSERT INTO emergencies
+    (id, user_id, emergency_type, status, initial_location, auto_triggered,
+     triggered_by, countdown_seconds, created_at, activated_at, resolved_at,
+     resolution_notes, claimed_by, claimed_by_name, claimed_at)
+VALUES
+    ('aaaaaaa1-0000-4000-a000-000000000002',
+     '11111111-1111-4111-a111-111111111111', 'FALL_DETECTED', 'RESOLVED',
+     '{"lat": 37.7793, "lng": -122.4193, "accuracy": 8.0}'::jsonb,
+     TRUE, 'device:66666666-6666-4666-a666-666666666666', 15,
+     NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day' + INTERVAL '15 seconds',
+     NOW() - INTERVAL '1 day' + INTERVAL '25 minutes',
+     'Contact reached the user; minor fall, no injury',
+     '55555555-5555-4555-a555-555555555555', 'Demo Operator',
+     NOW() - INTERVAL '1 day' + INTERVAL '2 minutes')
+ON CONFLICT (id) DO NOTHING;
+
+-- A CANCELLED false alarm for Bob (cancelled during the countdown)
+INSERT INTO emergencies
+    (id, user_id, emergency_type, status, initial_location, initial_message,
+     auto_triggered, triggered_by, countdown_seconds, created_at, cancelled_at)
+VALUES
+    ('aaaaaaa1-0000-4000-a000-000000000003',
+     '22222222-2222-4222-a222-222222222222', 'GENERAL', 'CANCELLED',
+     '{"lat": 40.7128, "lng": -74.0060, "accuracy": 20.0}'::jsonb,
+     'Pressed by accident',
+     FALSE, 'user', 30,
+     NOW() - INTERVAL '3 hours', NOW() - INTERVAL '3 hours' + INTERVAL '12 seconds')
+ON CONFLICT (id) DO NOTHING;
+
+-- A resolved test drill for Bob so the is_test filtering paths have data
+INSERT INTO emergencies
+    (id, user_id, emergency_type, status, initial_location, auto_triggered,
+     triggered_by, countdown_seconds, created_at, activated_at, resolved_at,
+     resolution_notes, is_test)
+VALUES
+    ('aaaaaaa1-0000-4000-a000-000000000004',
+     '22222222-2222-4222-a222-222222222222', 'MEDICAL', 'RESOLVED',
+     '{"lat": 40.7306, "lng": -73.9866, "accuracy": 15.0}'::jsonb,
+     FALSE, 'user', 10,
+     NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days' + INTERVAL '10 seconds',
+     NOW() - INTERVAL '2 days' + INTERVAL '5 minutes',
+     'Monthly drill completed', TRUE)
+ON CONFLICT (id) DO NOTHING;
+
+-- Acknowledgments: Carol and Dave acknowledged Alice's active emergency
+-- (her MEDICAL quorum of 2 is satisfied), Carol also acknowledged the
+-- resolved fall from yesterday
+INSERT INTO emergency_acknowledgments
+    (id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
+     acknowledged_at, location, message)
+VALUES
+    ('bbbbbbb1-0000-4000-a000-000000000001',
+     'aaaaaaa1-0000-4000-a000-000000000001',
+     '33333333-3333-4333-a333-333333333333', 'Carol Chen',
+     '+14155550133', 'carol@example.com',
+     NOW() - INTERVAL '8 minutes',
+     '{"lat": 37.7790, "lng": -122.4140}'::jsonb,
+     'On my way, 10 minutes out'),
+    ('bbbbbbb1-0000-4000-a000-000000000002',
+     'aaaaaaa1-0000-4000-a000-000000000001',
+     '44444444-4444-4444-a444-444444444444', 'Dave Okafor',
+     '+14155550144', NULL,
+     NOW() - INTERVAL '6 minutes', NULL,
+     'Calling her now'),
+    ('bbbbbbb1-0000-4000-a000-000000000003',
+     'aaaaaaa1-0000-4000-a000-000000000002',
+     '33333333-3333-4333-a333-333333333333', 'Carol Chen',
+     '+14155550133', 'carol@example.com',
+     NOW() - INTERVAL '1 day' + INTERVAL '3 minutes', NULL,
+     'I am with her, she is okay')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// AbuseFlagHandler handles HTTP requests for the support-review workflow
+// around abuse soft-locks
+type AbuseFlagHandler struct {
+	flagRepo *repository.AbuseFlagRepository
+}
+
+// NewAbuseFlagHandler creates a new AbuseFlagHandler
+func NewAbuseFlagHandler(flagRepo *repository.AbuseFlagRepository) *AbuseFlagHandler {
+	return &AbuseFlagHandler{
+		flagRepo: flagRepo,
+	}
+}
+
+// ListFlags handles GET /api/v1/abuse-flags
+// Returns every active flag awaiting support review
+func (h *AbuseFlagHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.flagRepo.ListActive(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list abuse flags")
+		respondError(w, r, http.StatusInternalServerError, "Failed to list abuse flags")
+		return
+	}
+	if flags == nil {
+		flags = []models.AbuseFlag{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"flags": flags,
+		"count": len(flags),
+	})
+}
+
+// ClearFlag handles POST /api/v1/abuse-flags/{id}/clear
+// Support clears a flag after review, lifting the soft-lock
+func (h *AbuseFlagHandler) ClearFlag(w http.ResponseWriter, r *http.Request) {
+	flagID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid flag ID")
+		return
+	}
+
+	var req models.ClearAbuseFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Validate() {
+		respondError(w, r, http.StatusBadRequest, "cleared_by is required")
+		return
+	}
+
+	if err := h.flagRepo.Clear(r.Context(), flagID, req.ClearedBy, req.ReviewNote); err != nil {
+		if errors.Is(err, repository.ErrAbuseFlagNotFound) {
+			respondError(w, r, http.StatusNotFound, "Flag not found or already cleared")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to clear abuse flag")
+		respondError(w, r, http.StatusInternalServerError, "Failed to clear abuse flag")
+		return
+	}
+
+	log.Info().
+		Str("flag_id", flagID.String()).
+		Str("cleared_by", req.ClearedBy).
+		Msg("Abuse flag cleared")
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Flag cleared",
+	})
+}
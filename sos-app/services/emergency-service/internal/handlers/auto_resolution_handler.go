@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+	"github.com/sos-app/emergency-service/internal/services"
+)
+
+// AutoResolutionHandler exposes the operator side of auto-resolution:
+// listing critical emergencies the sweep is waiting on, and signing off so
+// they can close
+type AutoResolutionHandler struct {
+	autoResolution *services.AutoResolutionService
+}
+
+// NewAutoResolutionHandler creates a new AutoResolutionHandler
+func NewAutoResolutionHandler(autoResolution *services.AutoResolutionService) *AutoResolutionHandler {
+	return &AutoResolutionHandler{autoResolution: autoResolution}
+}
+
+// ListPendingApproval handles GET /api/v1/emergency/auto-resolve/pending
+// Lists critical emergencies past the unattended window that need operator
+// approval before the sweep may close them
+func (h *AutoResolutionHandler) ListPendingApproval(w http.ResponseWriter, r *http.Request) {
+	awaiting, err := h.autoResolution.ListAwaitingApproval(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list emergencies awaiting auto-resolve approval")
+		respondError(w, r, http.StatusInternalServerError, "Failed to list pending approvals")
+		return
+	}
+	if awaiting == nil {
+		awaiting = []models.Emergency{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"emergencies": awaiting,
+		"count":       len(awaiting),
+		"stats":       h.autoResolution.Stats(),
+	})
+}
+
+// ApproveAutoResolve handles POST /api/v1/emergency/{id}/approve-auto-resolve
+// Records operator sign-off; when the emergency is already past the
+// unattended window it is resolved on the spot
+func (h *AutoResolutionHandler) ApproveAutoResolve(w http.ResponseWriter, r *http.Request) {
+	emergencyID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	var req models.ApproveAutoResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.OperatorID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "operator_id is required")
+		return
+	}
+	if req.OperatorName == "" {
+		respondError(w, r, http.StatusBadRequest, "operator_name is required")
+		return
+	}
+
+	resolved, err := h.autoResolution.ApproveAndResolve(r.Context(), emergencyID, req.OperatorID, req.OperatorName)
+	if err != nil {
+		if err == repository.ErrEmergencyNotFound {
+			respondError(w, r, http.StatusNotFound, "No active emergency found")
+			return
+		}
+		log.Error().Err(err).Str("emergency_id", emergencyID.String()).Msg("Failed to approve auto-resolution")
+		respondError(w, r, http.StatusInternalServerError, "Failed to approve auto-resolution")
+		return
+	}
+
+	message := "Approval recorded; the emergency will auto-resolve once past the unattended window"
+	if resolved {
+		message = "Emergency auto-resolved"
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":  message,
+		"resolved": resolved,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// CheckInHandler handles scheduled check-in requests
+type CheckInHandler struct {
+	checkInRepo *repository.CheckInRepository
+}
+
+// NewCheckInHandler creates a new CheckInHandler
+func NewCheckInHandler(checkInRepo *repository.CheckInRepository) *CheckInHandler {
+	return &CheckInHandler{checkInRepo: checkInRepo}
+}
+
+// ScheduleCheckIn handles POST /api/v1/check-ins
+func (h *CheckInHandler) ScheduleCheckIn(w http.ResponseWriter, r *http.Request) {
+	var req models.ScheduleCheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	checkIn := &models.ScheduledCheckIn{
+		UserID:     req.UserID,
+		DeadlineAt: req.DeadlineAt,
+	}
+
+	if err := h.checkInRepo.Create(r.Context(), checkIn); err != nil {
+		log.Error().Err(err).Msg("Failed to create check-in")
+		respondError(w, r, http.StatusInternalServerError, "Failed to schedule check-in")
+		return
+	}
+
+	log.Info().
+		Str("check_in_id", checkIn.ID.String()).
+		Str("user_id", checkIn.UserID.String()).
+		Time("deadline_at", checkIn.DeadlineAt).
+		Msg("Check-in scheduled")
+
+	respondJSON(w, http.StatusCreated, checkIn)
+}
+
+// CompleteCheckIn handles POST /api/v1/check-ins/{id}/complete
+func (h *CheckInHandler) CompleteCheckIn(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid check-in ID")
+		return
+	}
+
+	var req models.CompleteCheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	checkIn, err := h.checkInRepo.Complete(r.Context(), id, req.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to complete check-in")
+		respondError(w, r, http.StatusInternalServerError, "Failed to complete check-in")
+		return
+	}
+
+	if checkIn == nil {
+		respondError(w, r, http.StatusNotFound, "No open check-in found")
+		return
+	}
+
+	log.Info().
+		Str("check_in_id", checkIn.ID.String()).
+		Str("user_id", checkIn.UserID.String()).
+		Msg("Check-in completed")
+
+	respondJSON(w, http.StatusOK, checkIn)
+}
+
+// SnoozeCheckIn handles POST /api/v1/check-ins/{id}/snooze
+// Snoozing pushes the deadline forward and re-arms the reminders against it
+func (h *CheckInHandler) SnoozeCheckIn(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid check-in ID")
+		return
+	}
+
+	var req models.SnoozeCheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	minutes := req.Minutes
+	if minutes == 0 {
+		minutes = models.DefaultCheckInSnoozeMinutes
+	}
+
+	checkIn, err := h.checkInRepo.Snooze(r.Context(), id, req.UserID, time.Now().Add(time.Duration(minutes)*time.Minute))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to snooze check-in")
+		respondError(w, r, http.StatusInternalServerError, "Failed to snooze check-in")
+		return
+	}
+
+	if checkIn == nil {
+		respondError(w, r, http.StatusNotFound, "No open check-in found")
+		return
+	}
+
+	log.Info().
+		Str("check_in_id", checkIn.ID.String()).
+		Str("user_id", checkIn.UserID.String()).
+		Int("minutes", minutes).
+		Time("deadline_at", checkIn.DeadlineAt).
+		Msg("Check-in snoozed")
+
+	respondJSON(w, http.StatusOK, checkIn)
+}
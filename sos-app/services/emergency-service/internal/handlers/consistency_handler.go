@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/sos-app/emergency-service/internal/services"
+)
+
+// ConsistencyHandler serves the cross-service discrepancy report produced
+// by the nightly consistency checker
+type ConsistencyHandler struct {
+	checker *services.ConsistencyChecker
+}
+
+// NewConsistencyHandler creates a new ConsistencyHandler
+func NewConsistencyHandler(checker *services.ConsistencyChecker) *ConsistencyHandler {
+	return &ConsistencyHandler{checker: checker}
+}
+
+// GetReport handles GET /debug/consistency
+// Returns the most recent reconciliation report
+func (h *ConsistencyHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.LatestReport()
+	if report == nil {
+		respondError(w, r, http.StatusNotFound, "No consistency report generated yet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// RunNow handles POST /debug/consistency
+// Runs the reconciliation immediately instead of waiting for the nightly
+// schedule, for operators investigating a suspected inconsistency
+func (h *ConsistencyHandler) RunNow(w http.ResponseWriter, r *http.Request) {
+	report, err := h.checker.Run(r.Context())
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Consistency check failed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
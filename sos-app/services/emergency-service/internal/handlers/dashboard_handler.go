@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// dashboardDefaultLimit bounds how many rows an unqualified dashboard query
+// returns; monitoring screens page rather than render everything
+const dashboardDefaultLimit = 100
+
+// DashboardHandler serves the read-optimized dashboard projection maintained
+// by the projection consumer
+type DashboardHandler struct {
+	dashboardRepo *repository.DashboardRepository
+}
+
+// NewDashboardHandler creates a new DashboardHandler
+func NewDashboardHandler(dashboardRepo *repository.DashboardRepository) *DashboardHandler {
+	return &DashboardHandler{dashboardRepo: dashboardRepo}
+}
+
+// GetActive handles GET /api/v1/dashboard/active
+// Returns active emergencies newest-first from the denormalized projection,
+// optionally filtered by emergency type. Query params: type, limit
+func (h *DashboardHandler) GetActive(w http.ResponseWriter, r *http.Request) {
+	emergencyType := r.URL.Query().Get("type")
+
+	limit := dashboardDefaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			respondError(w, r, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		limit = parsed
+	}
+
+	entries, err := h.dashboardRepo.ListActive(r.Context(), emergencyType, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to query dashboard")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"emergencies": entries,
+		"count":       len(entries),
+	})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// DataRequestHandler handles GDPR/CCPA data subject requests
+type DataRequestHandler struct {
+	emergencyRepo *repository.EmergencyRepository
+	ackRepo       *repository.AcknowledgmentRepository
+	prefsRepo     *repository.PreferencesRepository
+	erasureRepo   *repository.ErasureRepository
+}
+
+// NewDataRequestHandler creates a new DataRequestHandler
+func NewDataRequestHandler(
+	emergencyRepo *repository.EmergencyRepository,
+	ackRepo *repository.AcknowledgmentRepository,
+	prefsRepo *repository.PreferencesRepository,
+	erasureRepo *repository.ErasureRepository,
+) *DataRequestHandler {
+	return &DataRequestHandler{
+		emergencyRepo: emergencyRepo,
+		ackRepo:       ackRepo,
+		prefsRepo:     prefsRepo,
+		erasureRepo:   erasureRepo,
+	}
+}
+
+// ExportUserData handles GET /api/v1/users/{userId}/export
+// Returns everything this service stores about the user as a
+// machine-readable archive
+func (h *DataRequestHandler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(mux.Vars(r)["userId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	emergencies, err := h.emergencyRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export emergencies")
+		respondError(w, r, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	acknowledgments, err := h.ackRepo.GetByContactID(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export acknowledgments")
+		respondError(w, r, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	preferences, err := h.prefsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export preferences")
+		respondError(w, r, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	erasureRequests, err := h.erasureRepo.ListByUser(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export erasure requests")
+		respondError(w, r, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	export := models.UserDataExport{
+		UserID:                   userID,
+		GeneratedAt:              time.Now().UTC(),
+		Emergencies:              emergencies,
+		AcknowledgmentsAsContact: acknowledgments,
+		Preferences:              preferences,
+		ErasureRequests:          erasureRequests,
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"emergency-data-"+userID.String()+".json\"")
+
+	log.Info().
+		Str("user_id", userID.String()).
+		Int("emergencies", len(emergencies)).
+		Int("acknowledgments", len(acknowledgments)).
+		Msg("User data export generated")
+
+	respondJSON(w, http.StatusOK, export)
+}
+
+// ScheduleErasure handles POST /api/v1/users/{userId}/erasure
+// Schedules deletion/anonymization of the user's data, optionally deferred
+// until a legal hold expires
+func (h *DataRequestHandler) ScheduleErasure(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(mux.Vars(r)["userId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req models.ScheduleErasureRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	if req.LegalHoldUntil != nil && req.LegalHoldUntil.Before(time.Now()) {
+		respondError(w, r, http.StatusBadRequest, "legal_hold_until must be in the future")
+		return
+	}
+
+	request := &models.ErasureRequest{
+		UserID:         userID,
+		Status:         models.ErasureStatusScheduled,
+		LegalHoldUntil: req.LegalHoldUntil,
+	}
+
+	if err := h.erasureRepo.Create(r.Context(), request); err != nil {
+		log.Error().Err(err).Msg("Failed to schedule erasure request")
+		respondError(w, r, http.StatusConflict, "Failed to schedule erasure (already scheduled?)")
+		return
+	}
+
+	log.Info().
+		Str("request_id", request.ID.String()).
+		Str("user_id", userID.String()).
+		Msg("Data erasure request scheduled")
+
+	respondJSON(w, http.StatusAccepted, request)
+}
+
+// GetErasureStatus handles GET /api/v1/users/{userId}/erasure
+// Reports the user's most recent erasure request along with which services
+// have confirmed their purge, so the full cross-service saga can be audited
+func (h *DataRequestHandler) GetErasureStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(mux.Vars(r)["userId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	request, err := h.erasureRepo.GetLatestByUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrErasureNotFound) {
+			respondError(w, r, http.StatusNotFound, "No erasure request for user")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get erasure request")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get erasure status")
+		return
+	}
+
+	completions, err := h.erasureRepo.ListCompletions(r.Context(), request.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list erasure completions")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get erasure status")
+		return
+	}
+	if completions == nil {
+		completions = []models.ErasureCompletion{}
+	}
+
+	respondJSON(w, http.StatusOK, models.ErasureSagaStatus{
+		Request:     *request,
+		Completions: completions,
+	})
+}
+
+// CancelErasure handles DELETE /api/v1/users/{userId}/erasure
+// Withdraws a scheduled erasure request that has not yet been executed
+func (h *DataRequestHandler) CancelErasure(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(mux.Vars(r)["userId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.erasureRepo.Cancel(r.Context(), userID); err != nil {
+		if errors.Is(err, repository.ErrErasureNotFound) {
+			respondError(w, r, http.StatusNotFound, "No scheduled erasure request")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to cancel erasure request")
+		respondError(w, r, http.StatusInternalServerError, "Failed to cancel erasure request")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Erasure request cancelled",
+	})
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +18,8 @@ import (
 	"github.com/sos-app/emergency-service/internal/models"
 	"github.com/sos-app/emergency-service/internal/repository"
 	"github.com/sos-app/emergency-service/internal/services"
+	"github.com/sos-app/pkg/common/httpapi"
+	"github.com/sos-app/pkg/common/i18n"
 )
 
 // EmergencyHandler handles HTTP requests for emergency operations
@@ -22,7 +29,12 @@ type EmergencyHandler struct {
 	producer         *kafka.Producer
 	countdownService *services.CountdownService
 	escalationService *services.EscalationService
+	prefsRepo        *repository.PreferencesRepository
+	webhookNotifier  *services.WebhookNotifier
+	notifStatusRepo  *repository.NotificationStatusRepository
+	abuseDetector    *services.AbuseDetector
 	countdownSeconds int
+	retriggerCooloff time.Duration
 }
 
 // NewEmergencyHandler creates a new EmergencyHandler
@@ -32,7 +44,12 @@ func NewEmergencyHandler(
 	producer *kafka.Producer,
 	countdownService *services.CountdownService,
 	escalationService *services.EscalationService,
+	prefsRepo *repository.PreferencesRepository,
+	webhookNotifier *services.WebhookNotifier,
+	notifStatusRepo *repository.NotificationStatusRepository,
+	abuseDetector *services.AbuseDetector,
 	countdownSeconds int,
+	retriggerCooloff time.Duration,
 ) *EmergencyHandler {
 	return &EmergencyHandler{
 		emergencyRepo:     emergencyRepo,
@@ -40,7 +57,12 @@ func NewEmergencyHandler(
 		producer:          producer,
 		countdownService:  countdownService,
 		escalationService: escalationService,
+		prefsRepo:         prefsRepo,
+		webhookNotifier:   webhookNotifier,
+		notifStatusRepo:   notifStatusRepo,
+		abuseDetector:     abuseDetector,
 		countdownSeconds:  countdownSeconds,
+		retriggerCooloff:  retriggerCooloff,
 	}
 }
 
@@ -48,28 +70,150 @@ func NewEmergencyHandler(
 func (h *EmergencyHandler) TriggerEmergency(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateEmergencyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
+	// Resolve the trigger source against the registry: unknown or malformed
+	// sources are rejected, and each source's authentication requirement is
+	// enforced before anything else happens
+	if req.TriggeredBy == "" {
+		req.TriggeredBy = models.TriggerSourceApp
+	}
+	sourceSpec, sourceQualifier, err := models.ParseTriggerSource(req.TriggeredBy)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if sourceSpec.RequiredHeader != "" && r.Header.Get(sourceSpec.RequiredHeader) == "" {
+		respondError(w, r, http.StatusUnauthorized,
+			fmt.Sprintf("Trigger source %s requires the %s header", sourceSpec.Type, sourceSpec.RequiredHeader))
+		return
+	}
+	// A device may only raise triggers as itself
+	if sourceSpec.Type == models.TriggerSourceDevice && r.Header.Get("X-Device-ID") != sourceQualifier {
+		respondError(w, r, http.StatusForbidden, "triggered_by device does not match X-Device-ID")
+		return
+	}
+	if sourceSpec.AutoTriggered {
+		req.AutoTriggered = true
+	}
+
 	// Check if user already has an active emergency
 	activeEmergency, err := h.emergencyRepo.GetActiveByUserID(r.Context(), req.UserID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to check for active emergency")
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	if activeEmergency != nil {
-		respondError(w, http.StatusConflict, "User already has an active emergency")
+		respondError(w, r, http.StatusConflict, "User already has an active emergency")
+		return
+	}
+
+	// A soft-locked user's repeat triggers are held back until support
+	// clears the flag; their first trigger inside the window still goes
+	// through, so a real emergency is never silenced
+	if flag := h.abuseDetector.CheckTrigger(r.Context(), req.UserID); flag != nil {
+		log.Warn().
+			Str("user_id", req.UserID.String()).
+			Str("flag_id", flag.ID.String()).
+			Msg("Trigger held back by abuse soft-lock")
+		respondJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+			"error":      "Account is under review for repeated cancelled triggers; contact support",
+			"flagged_at": flag.FlaggedAt,
+		})
 		return
 	}
 
-	// Set countdown seconds (use default if not provided)
+	// A second press right after resolving is usually accidental and would
+	// re-alert every contact; within the cool-off, re-triggering the same
+	// type requires an explicit confirmation flag
+	if h.retriggerCooloff > 0 && !req.ConfirmRetrigger {
+		lastResolved, err := h.emergencyRepo.GetLastResolvedByUserAndType(r.Context(), req.UserID, req.EmergencyType)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to check for recently resolved emergency")
+			respondError(w, r, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if lastResolved != nil && lastResolved.ResolvedAt != nil {
+			remaining := h.retriggerCooloff - time.Since(*lastResolved.ResolvedAt)
+			if remaining > 0 {
+				respondJSON(w, http.StatusConflict, map[string]interface{}{
+					"error":                     "An emergency of this type was resolved moments ago; set confirm_retrigger to trigger another",
+					"resolved_emergency_id":     lastResolved.ID,
+					"resolved_at":               lastResolved.ResolvedAt,
+					"cooloff_remaining_seconds": int(remaining.Seconds()) + 1,
+				})
+				return
+			}
+		}
+	}
+
+	// Drill/test-mode can be requested in the body or via header; test
+	// emergencies run the full pipeline but stay out of stats and connectors
+	if strings.EqualFold(r.Header.Get("X-Test-Mode"), "true") {
+		req.IsTest = true
+	}
+
+	// Validate type-specific metadata against its schema
+	if fieldErrs := models.ValidateMetadata(req.EmergencyType, req.Metadata); len(fieldErrs) > 0 {
+		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":  "Invalid metadata for emergency type",
+			"fields": fieldErrs,
+		})
+		return
+	}
+
+	// Pick the best of any candidate fixes by provider priority, keeping
+	// every candidate in metadata for forensic use
+	if len(req.CandidateLocations) > 0 {
+		if len(req.CandidateLocations) > models.MaxCandidateLocations {
+			respondError(w, r, http.StatusBadRequest,
+				fmt.Sprintf("At most %d candidate locations are allowed", models.MaxCandidateLocations))
+			return
+		}
+
+		req.Location = models.SelectBestLocation(req.CandidateLocations)
+
+		metadata, err := models.AttachLocationCandidates(req.Metadata, req.CandidateLocations)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid metadata: "+err.Error())
+			return
+		}
+		req.Metadata = metadata
+	}
+
+	// Look up user preferences for defaults the request doesn't override
+	var prefs *models.UserPreferences
+	if h.prefsRepo != nil {
+		prefs, err = h.prefsRepo.GetByUserID(r.Context(), req.UserID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get user preferences, using defaults")
+			prefs = nil
+		}
+	}
+
+	// Set countdown seconds (request override, then user preference, then
+	// service default)
 	countdownSec := h.countdownSeconds
+	if prefs != nil && prefs.DefaultCountdownSeconds != nil {
+		countdownSec = *prefs.DefaultCountdownSeconds
+	}
 	if req.CountdownSeconds != nil && *req.CountdownSeconds > 0 {
 		countdownSec = *req.CountdownSeconds
 	}
+	// Error-prone channels keep a floor under the countdown so the user
+	// can always cancel a false alarm
+	if sourceSpec.MinCountdownSeconds > countdownSec {
+		countdownSec = sourceSpec.MinCountdownSeconds
+	}
+
+	// Fall back to the user's message template for this emergency type
+	if req.InitialMessage == nil {
+		req.InitialMessage = prefs.TemplateFor(req.EmergencyType)
+	}
 
 	// Create emergency
 	emergency := &models.Emergency{
@@ -82,29 +226,32 @@ func (h *EmergencyHandler) TriggerEmergency(w http.ResponseWriter, r *http.Reque
 		AutoTriggered:    req.AutoTriggered,
 		TriggeredBy:      req.TriggeredBy,
 		CountdownSeconds: countdownSec,
+		IsTest:           req.IsTest,
 		CreatedAt:        time.Now(),
+		Metadata:         req.Metadata,
 	}
 
 	// Validate emergency
 	if err := emergency.Validate(); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Save to database
 	if err := h.emergencyRepo.Create(r.Context(), emergency); err != nil {
 		log.Error().Err(err).Msg("Failed to create emergency")
-		respondError(w, http.StatusInternalServerError, "Failed to create emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to create emergency")
 		return
 	}
 
 	// Start countdown timer
-	h.countdownService.StartCountdown(r.Context(), emergency.ID, countdownSec)
+	h.countdownService.StartCountdown(r.Context(), emergency.ID, countdownSec, emergency.AutoTriggered)
 
 	log.Info().
 		Str("emergency_id", emergency.ID.String()).
 		Str("user_id", emergency.UserID.String()).
 		Str("type", string(emergency.EmergencyType)).
+		Bool("is_test", emergency.IsTest).
 		Msg("Emergency triggered successfully")
 
 	respondJSON(w, http.StatusOK, emergency)
@@ -114,7 +261,7 @@ func (h *EmergencyHandler) TriggerEmergency(w http.ResponseWriter, r *http.Reque
 func (h *EmergencyHandler) AutoTriggerEmergency(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateEmergencyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -127,7 +274,22 @@ func (h *EmergencyHandler) AutoTriggerEmergency(w http.ResponseWriter, r *http.R
 	req.AutoTriggered = true
 	req.CountdownSeconds = &countdownSec
 
-	// Reuse the regular trigger logic
+	// Device identity rides on the delivery headers, not the payload; record
+	// it so the triggering wearable can later cancel its own countdown
+	if deviceID := r.Header.Get("X-Device-ID"); deviceID != "" {
+		req.TriggeredBy = models.TriggeredByDevice(deviceID)
+	}
+
+	// Reuse the regular trigger logic; the decode above consumed the body,
+	// so put the adjusted request back in its place
+	body, err := json.Marshal(req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to re-marshal auto-trigger request")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
 	h.TriggerEmergency(w, r)
 }
 
@@ -136,7 +298,7 @@ func (h *EmergencyHandler) CancelEmergency(w http.ResponseWriter, r *http.Reques
 	vars := mux.Vars(r)
 	emergencyID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid emergency ID")
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
 		return
 	}
 
@@ -144,46 +306,189 @@ func (h *EmergencyHandler) CancelEmergency(w http.ResponseWriter, r *http.Reques
 	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
 	if err != nil {
 		if err == repository.ErrEmergencyNotFound {
-			respondError(w, http.StatusNotFound, "Emergency not found")
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
 			return
 		}
 		log.Error().Err(err).Msg("Failed to get emergency")
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if emergency can be cancelled
 	if !emergency.CanBeCancelled() {
-		respondError(w, http.StatusBadRequest, "Emergency cannot be cancelled")
+		respondError(w, r, http.StatusBadRequest, "Emergency cannot be cancelled")
 		return
 	}
 
-	// Cancel countdown if still pending
-	if emergency.IsPending() {
-		h.countdownService.CancelCountdown(emergencyID)
+	if err := h.cancelEmergency(r.Context(), emergency, "User cancelled"); err != nil {
+		log.Error().Err(err).Msg("Failed to cancel emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to cancel emergency")
+		return
 	}
 
-	// Update status to cancelled
-	if err := h.emergencyRepo.UpdateStatus(r.Context(), emergencyID, models.StatusCancelled); err != nil {
+	log.Info().
+		Str("emergency_id", emergencyID.String()).
+		Msg("Emergency cancelled successfully")
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Emergency cancelled successfully"})
+}
+
+// CancelByDevice handles POST /api/v1/emergency/device-cancel
+// A double-press of the wearable's button cancels the countdown it started.
+// The device does not know the emergency ID, so the pending emergency is
+// resolved from the user identity on the request headers
+func (h *EmergencyHandler) CancelByDevice(w http.ResponseWriter, r *http.Request) {
+	deviceID := r.Header.Get("X-Device-ID")
+	userID, err := uuid.Parse(r.Header.Get("X-User-ID"))
+	if deviceID == "" || err != nil {
+		respondError(w, r, http.StatusUnauthorized, "X-Device-ID and X-User-ID headers are required")
+		return
+	}
+
+	emergency, err := h.emergencyRepo.GetActiveByUserID(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check for active emergency")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if emergency == nil {
+		respondError(w, r, http.StatusNotFound, "No active emergency to cancel")
+		return
+	}
+
+	// Only the wearable that raised the trigger may cancel it; another
+	// paired device's button must not silence someone else's SOS
+	if !emergency.WasTriggeredByDevice(deviceID) {
+		log.Warn().
+			Str("emergency_id", emergency.ID.String()).
+			Str("device_id", deviceID).
+			Str("triggered_by", emergency.TriggeredBy).
+			Msg("Device cancel refused: not the triggering device")
+		respondError(w, r, http.StatusForbidden, "Emergency was not triggered by this device")
+		return
+	}
+
+	// Once the countdown has elapsed contacts are already being alerted;
+	// backing out from the wearable alone is no longer allowed
+	if !emergency.IsPending() {
+		respondError(w, r, http.StatusConflict, "Emergency countdown has already finished")
+		return
+	}
+
+	if err := h.cancelEmergency(r.Context(), emergency, "Cancelled from device"); err != nil {
 		log.Error().Err(err).Msg("Failed to cancel emergency")
-		respondError(w, http.StatusInternalServerError, "Failed to cancel emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to cancel emergency")
 		return
 	}
 
-	// Stop escalation monitoring
-	h.escalationService.StopMonitoring(emergencyID)
+	log.Info().
+		Str("emergency_id", emergency.ID.String()).
+		Str("device_id", deviceID).
+		Msg("Emergency cancelled from device")
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Emergency cancelled successfully"})
+}
+
+// cancelEmergency runs the shared cancellation flow: stop the countdown
+// while still pending, mark the emergency cancelled, stop escalation
+// monitoring and publish the cancelled event
+func (h *EmergencyHandler) cancelEmergency(ctx context.Context, emergency *models.Emergency, reason string) error {
+	if emergency.IsPending() {
+		h.countdownService.CancelCountdown(emergency.ID)
+	}
+
+	if err := h.emergencyRepo.UpdateStatus(ctx, emergency.ID, models.StatusCancelled); err != nil {
+		return err
+	}
+
+	h.escalationService.StopMonitoring(emergency.ID)
 
-	// Publish cancelled event
 	emergency.Status = models.StatusCancelled
-	if err := h.producer.PublishEmergencyCancelled(r.Context(), emergency, "User cancelled"); err != nil {
+	if err := h.producer.PublishEmergencyCancelled(ctx, emergency, reason); err != nil {
 		log.Error().Err(err).Msg("Failed to publish cancelled event")
 	}
 
+	// Count the cancellation against the abuse quota
+	h.abuseDetector.RecordCancellation(ctx, emergency)
+
+	return nil
+}
+
+// ClaimEmergency handles PUT /api/v1/emergency/{id}/claim
+// A monitoring-center operator takes over the emergency, which stops
+// automatic escalation
+func (h *EmergencyHandler) ClaimEmergency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	emergencyID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	var req models.ClaimEmergencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.OperatorID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "operator_id is required")
+		return
+	}
+	if req.OperatorName == "" {
+		respondError(w, r, http.StatusBadRequest, "operator_name is required")
+		return
+	}
+
+	// Get emergency
+	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
+	if err != nil {
+		if err == repository.ErrEmergencyNotFound {
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get emergency")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Only active emergencies can be taken over
+	if !emergency.IsActive() {
+		respondError(w, r, http.StatusBadRequest, "Only active emergencies can be claimed")
+		return
+	}
+
+	// Record the takeover
+	if err := h.emergencyRepo.Claim(r.Context(), emergencyID, req.OperatorID, req.OperatorName); err != nil {
+		if err == repository.ErrEmergencyAlreadyClaimed {
+			respondError(w, r, http.StatusConflict, "Emergency already claimed by an operator")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to claim emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to claim emergency")
+		return
+	}
+
+	// The operator now owns the incident; stop automatic escalation
+	h.escalationService.StopMonitoring(emergencyID)
+
+	now := time.Now()
+	emergency.ClaimedBy = &req.OperatorID
+	emergency.ClaimedByName = &req.OperatorName
+	emergency.ClaimedAt = &now
+
+	// Tell contacts a professional is handling the incident
+	if err := h.producer.PublishEmergencyClaimed(r.Context(), emergency); err != nil {
+		log.Error().Err(err).Msg("Failed to publish claimed event")
+	}
+
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
-		Msg("Emergency cancelled successfully")
+		Str("operator_id", req.OperatorID.String()).
+		Str("operator_name", req.OperatorName).
+		Msg("Emergency claimed by operator")
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "Emergency cancelled successfully"})
+	respondJSON(w, http.StatusOK, emergency)
 }
 
 // ResolveEmergency handles PUT /api/v1/emergency/{id}/resolve
@@ -191,7 +496,7 @@ func (h *EmergencyHandler) ResolveEmergency(w http.ResponseWriter, r *http.Reque
 	vars := mux.Vars(r)
 	emergencyID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid emergency ID")
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
 		return
 	}
 
@@ -206,24 +511,24 @@ func (h *EmergencyHandler) ResolveEmergency(w http.ResponseWriter, r *http.Reque
 	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
 	if err != nil {
 		if err == repository.ErrEmergencyNotFound {
-			respondError(w, http.StatusNotFound, "Emergency not found")
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
 			return
 		}
 		log.Error().Err(err).Msg("Failed to get emergency")
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if emergency can be resolved
 	if !emergency.CanBeResolved() {
-		respondError(w, http.StatusBadRequest, "Emergency is not active")
+		respondError(w, r, http.StatusBadRequest, "Emergency is not active")
 		return
 	}
 
 	// Resolve emergency
 	if err := h.emergencyRepo.Resolve(r.Context(), emergencyID, req.ResolutionNotes); err != nil {
 		log.Error().Err(err).Msg("Failed to resolve emergency")
-		respondError(w, http.StatusInternalServerError, "Failed to resolve emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to resolve emergency")
 		return
 	}
 
@@ -238,6 +543,11 @@ func (h *EmergencyHandler) ResolveEmergency(w http.ResponseWriter, r *http.Reque
 		log.Error().Err(err).Msg("Failed to publish resolved event")
 	}
 
+	// Post resolution cards to org monitoring channels
+	if h.webhookNotifier != nil {
+		go h.webhookNotifier.NotifyResolved(context.Background(), emergency)
+	}
+
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
 		Msg("Emergency resolved successfully")
@@ -250,7 +560,7 @@ func (h *EmergencyHandler) GetEmergency(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	emergencyID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid emergency ID")
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
 		return
 	}
 
@@ -258,11 +568,11 @@ func (h *EmergencyHandler) GetEmergency(w http.ResponseWriter, r *http.Request)
 	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
 	if err != nil {
 		if err == repository.ErrEmergencyNotFound {
-			respondError(w, http.StatusNotFound, "Emergency not found")
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
 			return
 		}
 		log.Error().Err(err).Msg("Failed to get emergency")
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -275,9 +585,17 @@ func (h *EmergencyHandler) GetEmergency(w http.ResponseWriter, r *http.Request)
 
 	response := models.EmergencyResponse{
 		Emergency:       *emergency,
+		StatusLabel:     i18n.StatusLabel(requestLocale(r), string(emergency.Status)),
 		Acknowledgments: acknowledgments,
 	}
 
+	// Include how far the emergency is from its acknowledgment quorum
+	if quorum, err := h.escalationService.EvaluateQuorum(r.Context(), emergency); err != nil {
+		log.Error().Err(err).Msg("Failed to evaluate acknowledgment quorum")
+	} else {
+		response.Quorum = quorum
+	}
+
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -286,13 +604,13 @@ func (h *EmergencyHandler) GetEmergencyHistory(w http.ResponseWriter, r *http.Re
 	// Parse query parameters
 	userIDStr := r.URL.Query().Get("user_id")
 	if userIDStr == "" {
-		respondError(w, http.StatusBadRequest, "user_id is required")
+		respondError(w, r, http.StatusBadRequest, "user_id is required")
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid user_id")
+		respondError(w, r, http.StatusBadRequest, "Invalid user_id")
 		return
 	}
 
@@ -320,7 +638,7 @@ func (h *EmergencyHandler) GetEmergencyHistory(w http.ResponseWriter, r *http.Re
 	emergencies, total, err := h.emergencyRepo.ListWithFilters(r.Context(), filters)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get emergency history")
-		respondError(w, http.StatusInternalServerError, "Failed to get emergency history")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get emergency history")
 		return
 	}
 
@@ -334,37 +652,130 @@ func (h *EmergencyHandler) GetEmergencyHistory(w http.ResponseWriter, r *http.Re
 	respondJSON(w, http.StatusOK, response)
 }
 
+// SearchEmergencies handles GET /api/v1/emergency/search
+// Full-text search over initial messages, resolution notes and metadata,
+// combined with the standard history filters
+func (h *EmergencyHandler) SearchEmergencies(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	searchQuery := r.URL.Query().Get("q")
+	if searchQuery == "" {
+		respondError(w, r, http.StatusBadRequest, "q is required")
+		return
+	}
+
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		respondError(w, r, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user_id")
+		return
+	}
+
+	filters := models.HistoryFilters{
+		UserID:   userID,
+		Search:   &searchQuery,
+		Page:     1,
+		PageSize: 20,
+	}
+
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := models.EmergencyStatus(statusStr)
+		filters.Status = &status
+	}
+
+	if typeStr := r.URL.Query().Get("type"); typeStr != "" {
+		emergencyType := models.EmergencyType(typeStr)
+		filters.Type = &emergencyType
+	}
+
+	if startStr := r.URL.Query().Get("start_date"); startStr != "" {
+		start, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid start_date, expected RFC3339")
+			return
+		}
+		filters.StartDate = &start
+	}
+
+	if endStr := r.URL.Query().Get("end_date"); endStr != "" {
+		end, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid end_date, expected RFC3339")
+			return
+		}
+		filters.EndDate = &end
+	}
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			filters.Page = p
+		}
+	}
+
+	if sizeStr := r.URL.Query().Get("page_size"); sizeStr != "" {
+		if s, err := strconv.Atoi(sizeStr); err == nil && s > 0 && s <= 100 {
+			filters.PageSize = s
+		}
+	}
+
+	emergencies, total, err := h.emergencyRepo.ListWithFilters(r.Context(), filters)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search emergencies")
+		respondError(w, r, http.StatusInternalServerError, "Failed to search emergencies")
+		return
+	}
+
+	response := models.EmergencyListResponse{
+		Emergencies: emergencies,
+		Total:       total,
+		Page:        filters.Page,
+		PageSize:    filters.PageSize,
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
 // AcknowledgeEmergency handles POST /api/v1/emergency/{id}/acknowledge
 func (h *EmergencyHandler) AcknowledgeEmergency(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	emergencyID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid emergency ID")
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
 		return
 	}
 
 	var req models.CreateAcknowledgmentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	req.EmergencyID = emergencyID
 
+	// Relationship values from the contacts integration are case-insensitive
+	if req.Relationship != nil {
+		rel := models.ContactRelationship(strings.ToUpper(strings.TrimSpace(string(*req.Relationship))))
+		req.Relationship = &rel
+	}
+
 	// Verify emergency exists and is active
 	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
 	if err != nil {
 		if err == repository.ErrEmergencyNotFound {
-			respondError(w, http.StatusNotFound, "Emergency not found")
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
 			return
 		}
 		log.Error().Err(err).Msg("Failed to get emergency")
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	if !emergency.IsActive() && !emergency.IsPending() {
-		respondError(w, http.StatusBadRequest, "Emergency is not active")
+		respondError(w, r, http.StatusBadRequest, "Emergency is not active")
 		return
 	}
 
@@ -375,24 +786,25 @@ func (h *EmergencyHandler) AcknowledgeEmergency(w http.ResponseWriter, r *http.R
 		ContactName:  req.ContactName,
 		ContactPhone: req.ContactPhone,
 		ContactEmail: req.ContactEmail,
+		Relationship: req.Relationship,
 		Location:     req.Location,
 		Message:      req.Message,
 	}
 
 	// Validate
 	if err := ack.Validate(); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Save acknowledgment
 	if err := h.ackRepo.Create(r.Context(), ack); err != nil {
 		if err == repository.ErrDuplicateAcknowledgment {
-			respondError(w, http.StatusConflict, "Already acknowledged")
+			respondError(w, r, http.StatusConflict, "Already acknowledged")
 			return
 		}
 		log.Error().Err(err).Msg("Failed to create acknowledgment")
-		respondError(w, http.StatusInternalServerError, "Failed to acknowledge emergency")
+		respondError(w, r, http.StatusInternalServerError, "Failed to acknowledge emergency")
 		return
 	}
 
@@ -401,19 +813,178 @@ func (h *EmergencyHandler) AcknowledgeEmergency(w http.ResponseWriter, r *http.R
 		Str("contact_id", req.ContactID.String()).
 		Msg("Emergency acknowledged successfully")
 
+	// Report quorum progress so the caller sees whether more contacts are
+	// still needed before escalation stands down
+	response := map[string]interface{}{
+		"acknowledgment": ack,
+	}
+	if quorum, err := h.escalationService.EvaluateQuorum(r.Context(), emergency); err != nil {
+		log.Error().Err(err).Msg("Failed to evaluate acknowledgment quorum")
+	} else {
+		response["quorum"] = quorum
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetEmergencyTypes handles GET /api/v1/emergency/types
+// It documents the allowed metadata fields per emergency type and the
+// registered trigger sources with their policies
+func (h *EmergencyHandler) GetEmergencyTypes(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"types":           models.MetadataSchemas(),
+		"trigger_sources": models.TriggerSources(),
+	})
+}
+
+// GetEmergencyStats handles GET /api/v1/emergency/stats
+// Aggregated analytics over real emergencies; test-mode drills are excluded
+func (h *EmergencyHandler) GetEmergencyStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.emergencyRepo.GetStats(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get emergency stats")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get emergency stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+// GetNotificationStatus handles GET /api/v1/emergency/{id}/notification-status
+// Returns the latest per-contact, per-channel delivery outcomes so the
+// victim's app can show who was actually reached
+func (h *EmergencyHandler) GetNotificationStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	emergencyID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	// Verify the emergency exists
+	if _, err := h.emergencyRepo.GetByID(r.Context(), emergencyID); err != nil {
+		if err == repository.ErrEmergencyNotFound {
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get emergency")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	statuses, err := h.notifStatusRepo.GetByEmergencyID(r.Context(), emergencyID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get notification statuses")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get notification statuses")
+		return
+	}
+	if statuses == nil {
+		statuses = []models.NotificationStatus{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"emergency_id": emergencyID,
+		"statuses":     statuses,
+	})
+}
+
+// RevokeAcknowledgment handles DELETE /api/v1/emergency/{id}/acknowledge/{contactId}
+func (h *EmergencyHandler) RevokeAcknowledgment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	emergencyID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	contactID, err := uuid.Parse(vars["contactId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	// Reason is optional ("I thought this was the test")
+	var req models.RevokeAcknowledgmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		req.Reason = nil
+	}
+
+	// Verify emergency exists
+	emergency, err := h.emergencyRepo.GetByID(r.Context(), emergencyID)
+	if err != nil {
+		if err == repository.ErrEmergencyNotFound {
+			respondError(w, r, http.StatusNotFound, "Emergency not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get emergency")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Revoke the acknowledgment (soft delete, kept for audit)
+	if err := h.ackRepo.Revoke(r.Context(), emergencyID, contactID, req.Reason); err != nil {
+		switch err {
+		case repository.ErrAcknowledgmentNotFound:
+			respondError(w, r, http.StatusNotFound, "Acknowledgment not found")
+		case repository.ErrAcknowledgmentRevoked:
+			respondError(w, r, http.StatusConflict, "Acknowledgment already revoked")
+		default:
+			log.Error().Err(err).Msg("Failed to revoke acknowledgment")
+			respondError(w, r, http.StatusInternalServerError, "Failed to revoke acknowledgment")
+		}
+		return
+	}
+
+	ack, err := h.ackRepo.GetByEmergencyAndContact(r.Context(), emergencyID, contactID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get revoked acknowledgment")
+		respondError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Re-evaluate escalation: if no active acknowledgments remain, the
+	// emergency is effectively unacknowledged again and the clock restarts
+	remaining, err := h.ackRepo.CountAcknowledgments(r.Context(), emergencyID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count remaining acknowledgments")
+		remaining = 0
+	}
+
+	if remaining == 0 && emergency.IsActive() {
+		h.escalationService.StartMonitoring(r.Context(), emergencyID)
+	}
+
+	// Publish revoked event
+	if err := h.producer.PublishAcknowledgmentRevoked(r.Context(), ack, remaining); err != nil {
+		log.Error().Err(err).Msg("Failed to publish acknowledgment revoked event")
+	}
+
+	log.Info().
+		Str("emergency_id", emergencyID.String()).
+		Str("contact_id", contactID.String()).
+		Int("remaining_acks", remaining).
+		Msg("Acknowledgment revoked")
+
 	respondJSON(w, http.StatusOK, ack)
 }
 
 // Helper functions
 
 func respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
+	httpapi.RespondJSON(w, statusCode, data)
+}
+
+// requestLocale negotiates the response locale from the Accept-Language
+// header
+func requestLocale(r *http.Request) string {
+	return i18n.MatchLocale(r.Header.Get("Accept-Language"))
 }
 
-func respondError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
+// respondError writes the standard error envelope with the message
+// translated to the negotiated locale, so mobile clients can show it
+// without their own mapping tables
+func respondError(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	locale := requestLocale(r)
+	w.Header().Set("Content-Language", locale)
+	httpapi.RespondError(w, statusCode, i18n.T(locale, message))
 }
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// IncidentHandler exposes operator tooling for merging emergencies that
+// belong to one real-world incident and splitting incorrectly merged ones
+type IncidentHandler struct {
+	emergencyRepo *repository.EmergencyRepository
+	linkRepo      *repository.IncidentLinkRepository
+	ackRepo       *repository.AcknowledgmentRepository
+}
+
+// NewIncidentHandler creates a new IncidentHandler
+func NewIncidentHandler(
+	emergencyRepo *repository.EmergencyRepository,
+	linkRepo *repository.IncidentLinkRepository,
+	ackRepo *repository.AcknowledgmentRepository,
+) *IncidentHandler {
+	return &IncidentHandler{
+		emergencyRepo: emergencyRepo,
+		linkRepo:      linkRepo,
+		ackRepo:       ackRepo,
+	}
+}
+
+// LinkEmergency handles POST /api/v1/emergency/{id}/link
+// Merges another emergency into {id}'s incident. Chains are rejected: every
+// member links directly to the incident's primary
+func (h *IncidentHandler) LinkEmergency(w http.ResponseWriter, r *http.Request) {
+	primaryID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	var req models.LinkIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.EmergencyID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "emergency_id is required")
+		return
+	}
+	if req.OperatorID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "operator_id is required")
+		return
+	}
+	if req.OperatorName == "" {
+		respondError(w, r, http.StatusBadRequest, "operator_name is required")
+		return
+	}
+	if req.EmergencyID == primaryID {
+		respondError(w, r, http.StatusBadRequest, "An emergency cannot be linked to itself")
+		return
+	}
+
+	for _, id := range []uuid.UUID{primaryID, req.EmergencyID} {
+		if _, err := h.emergencyRepo.GetByID(r.Context(), id); err != nil {
+			if err == repository.ErrEmergencyNotFound {
+				respondError(w, r, http.StatusNotFound, "Emergency not found")
+				return
+			}
+			log.Error().Err(err).Msg("Failed to get emergency")
+			respondError(w, r, http.StatusInternalServerError, "Failed to link emergencies")
+			return
+		}
+	}
+
+	// The primary must really be a primary: if it is itself merged under
+	// another emergency, the operator should link there instead
+	if parent, err := h.linkRepo.GetActiveByLinkedID(r.Context(), primaryID); err != nil {
+		log.Error().Err(err).Msg("Failed to check incident links")
+		respondError(w, r, http.StatusInternalServerError, "Failed to link emergencies")
+		return
+	} else if parent != nil {
+		respondError(w, r, http.StatusConflict, "Emergency is itself merged into another incident")
+		return
+	}
+
+	// The emergency being merged must not be a primary with members of its
+	// own, or the incident would become a chain
+	if count, err := h.linkRepo.CountActiveByPrimaryID(r.Context(), req.EmergencyID); err != nil {
+		log.Error().Err(err).Msg("Failed to check incident links")
+		respondError(w, r, http.StatusInternalServerError, "Failed to link emergencies")
+		return
+	} else if count > 0 {
+		respondError(w, r, http.StatusConflict, "Emergency has its own merged incident; split it first")
+		return
+	}
+
+	link := &models.IncidentLink{
+		PrimaryEmergencyID: primaryID,
+		LinkedEmergencyID:  req.EmergencyID,
+		LinkedBy:           req.OperatorID,
+		LinkedByName:       req.OperatorName,
+	}
+	if req.Reason != "" {
+		link.Reason = &req.Reason
+	}
+
+	if err := h.linkRepo.Create(r.Context(), link); err != nil {
+		if err == repository.ErrAlreadyLinked {
+			respondError(w, r, http.StatusConflict, "Emergency is already part of an incident")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to create incident link")
+		respondError(w, r, http.StatusInternalServerError, "Failed to link emergencies")
+		return
+	}
+
+	log.Info().
+		Str("primary_emergency_id", primaryID.String()).
+		Str("linked_emergency_id", req.EmergencyID.String()).
+		Str("operator_id", req.OperatorID.String()).
+		Msg("Emergencies merged into incident")
+
+	respondJSON(w, http.StatusCreated, link)
+}
+
+// UnlinkEmergency handles DELETE /api/v1/emergency/{id}/link/{linkedId}
+// Splits an incorrectly merged emergency back out of the incident; the link
+// row is kept as audit
+func (h *IncidentHandler) UnlinkEmergency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	primaryID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+	linkedID, err := uuid.Parse(vars["linkedId"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid linked emergency ID")
+		return
+	}
+
+	var req models.UnlinkIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.OperatorID == uuid.Nil {
+		respondError(w, r, http.StatusBadRequest, "operator_id is required")
+		return
+	}
+	if req.OperatorName == "" {
+		respondError(w, r, http.StatusBadRequest, "operator_name is required")
+		return
+	}
+
+	var reason *string
+	if req.Reason != "" {
+		reason = &req.Reason
+	}
+
+	if err := h.linkRepo.Unlink(r.Context(), primaryID, linkedID, req.OperatorID, req.OperatorName, reason); err != nil {
+		if err == repository.ErrIncidentLinkNotFound {
+			respondError(w, r, http.StatusNotFound, "No live link between these emergencies")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to unlink incident")
+		respondError(w, r, http.StatusInternalServerError, "Failed to split incident")
+		return
+	}
+
+	log.Info().
+		Str("primary_emergency_id", primaryID.String()).
+		Str("linked_emergency_id", linkedID.String()).
+		Str("operator_id", req.OperatorID.String()).
+		Msg("Emergency split out of incident")
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Incident split",
+	})
+}
+
+// GetIncident handles GET /api/v1/emergency/{id}/incident
+// Returns the merged incident view for an emergency: member emergencies,
+// the shared timeline and the location rooms to subscribe to. Works from
+// any member; the view is always built from the incident's primary
+func (h *IncidentHandler) GetIncident(w http.ResponseWriter, r *http.Request) {
+	emergencyID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid emergency ID")
+		return
+	}
+
+	primaryID := emergencyID
+	if link, err := h.linkRepo.GetActiveByLinkedID(r.Context(), emergencyID); err != nil {
+		log.Error().Err(err).Msg("Failed to check incident links")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get incident")
+		return
+	} else if link != nil {
+		primaryID = link.PrimaryEmergencyID
+	}
+
+	links, err := h.linkRepo.ListByPrimaryID(r.Context(), primaryID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list incident links")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get incident")
+		return
+	}
+
+	memberIDs := []uuid.UUID{primaryID}
+	for _, link := range links {
+		if link.UnlinkedAt == nil {
+			memberIDs = append(memberIDs, link.LinkedEmergencyID)
+		}
+	}
+
+	incident := &models.MergedIncident{
+		PrimaryEmergencyID: primaryID,
+		Links:              links,
+		LocationRooms:      memberIDs,
+	}
+	if incident.Links == nil {
+		incident.Links = []models.IncidentLink{}
+	}
+
+	for _, id := range memberIDs {
+		emergency, err := h.emergencyRepo.GetByID(r.Context(), id)
+		if err != nil {
+			if err == repository.ErrEmergencyNotFound && id == primaryID {
+				respondError(w, r, http.StatusNotFound, "Emergency not found")
+				return
+			}
+			log.Error().Err(err).Str("emergency_id", id.String()).Msg("Failed to get incident member")
+			respondError(w, r, http.StatusInternalServerError, "Failed to get incident")
+			return
+		}
+		incident.Emergencies = append(incident.Emergencies, *emergency)
+
+		acks, err := h.ackRepo.GetByEmergencyID(r.Context(), id)
+		if err != nil {
+			log.Error().Err(err).Str("emergency_id", id.String()).Msg("Failed to get incident acknowledgments")
+			respondError(w, r, http.StatusInternalServerError, "Failed to get incident")
+			return
+		}
+		incident.Timeline = append(incident.Timeline, timelineForEmergency(emergency, acks)...)
+	}
+	incident.Timeline = append(incident.Timeline, timelineForLinks(links)...)
+
+	sort.Slice(incident.Timeline, func(i, j int) bool {
+		return incident.Timeline[i].Timestamp.Before(incident.Timeline[j].Timestamp)
+	})
+
+	respondJSON(w, http.StatusOK, incident)
+}
+
+// timelineForEmergency extracts one member's lifecycle and acknowledgment
+// events for the shared timeline
+func timelineForEmergency(emergency *models.Emergency, acks []models.EmergencyAcknowledgment) []models.IncidentTimelineEvent {
+	events := []models.IncidentTimelineEvent{{
+		Timestamp:   emergency.CreatedAt,
+		EmergencyID: emergency.ID,
+		Type:        "triggered",
+		Detail:      string(emergency.EmergencyType),
+	}}
+
+	if emergency.ActivatedAt != nil {
+		events = append(events, models.IncidentTimelineEvent{
+			Timestamp:   *emergency.ActivatedAt,
+			EmergencyID: emergency.ID,
+			Type:        "activated",
+		})
+	}
+	if emergency.ClaimedAt != nil {
+		event := models.IncidentTimelineEvent{
+			Timestamp:   *emergency.ClaimedAt,
+			EmergencyID: emergency.ID,
+			Type:        "claimed",
+		}
+		if emergency.ClaimedByName != nil {
+			event.Detail = *emergency.ClaimedByName
+		}
+		events = append(events, event)
+	}
+	if emergency.CancelledAt != nil {
+		events = append(events, models.IncidentTimelineEvent{
+			Timestamp:   *emergency.CancelledAt,
+			EmergencyID: emergency.ID,
+			Type:        "cancelled",
+		})
+	}
+	if emergency.ResolvedAt != nil {
+		events = append(events, models.IncidentTimelineEvent{
+			Timestamp:   *emergency.ResolvedAt,
+			EmergencyID: emergency.ID,
+			Type:        "resolved",
+		})
+	}
+
+	for _, ack := range acks {
+		if ack.Revoked {
+			continue
+		}
+		events = append(events, models.IncidentTimelineEvent{
+			Timestamp:   ack.AcknowledgedAt,
+			EmergencyID: emergency.ID,
+			Type:        "acknowledged",
+			Detail:      ack.ContactName,
+		})
+	}
+
+	return events
+}
+
+// timelineForLinks turns the merge audit trail into timeline events
+func timelineForLinks(links []models.IncidentLink) []models.IncidentTimelineEvent {
+	var events []models.IncidentTimelineEvent
+	for _, link := range links {
+		events = append(events, models.IncidentTimelineEvent{
+			Timestamp:   link.CreatedAt,
+			EmergencyID: link.LinkedEmergencyID,
+			Type:        "merged",
+			Detail:      fmt.Sprintf("by %s", link.LinkedByName),
+		})
+		if link.UnlinkedAt != nil {
+			event := models.IncidentTimelineEvent{
+				Timestamp:   *link.UnlinkedAt,
+				EmergencyID: link.LinkedEmergencyID,
+				Type:        "split",
+			}
+			if link.UnlinkedByName != nil {
+				event.Detail = fmt.Sprintf("by %s", *link.UnlinkedByName)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// MessageTemplateHandler handles HTTP requests for message templates
+type MessageTemplateHandler struct {
+	templateRepo *repository.MessageTemplateRepository
+}
+
+// NewMessageTemplateHandler creates a new MessageTemplateHandler
+func NewMessageTemplateHandler(templateRepo *repository.MessageTemplateRepository) *MessageTemplateHandler {
+	return &MessageTemplateHandler{
+		templateRepo: templateRepo,
+	}
+}
+
+// ListTemplates handles GET /api/v1/templates?org_id=...
+func (h *MessageTemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(r.URL.Query().Get("org_id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "org_id query parameter is required")
+		return
+	}
+
+	templates, err := h.templateRepo.ListByOrg(r.Context(), orgID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list message templates")
+		respondError(w, r, http.StatusInternalServerError, "Failed to list templates")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// UpsertTemplate handles PUT /api/v1/templates. Replacing the wording for an
+// (org, event type, locale) slot is a PUT of the same resource, so creation
+// and update share one endpoint
+func (h *MessageTemplateHandler) UpsertTemplate(w http.ResponseWriter, r *http.Request) {
+	var req models.UpsertTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Validate() {
+		respondError(w, r, http.StatusBadRequest, "org_id, event_type (emergency.created|emergency.resolved|emergency.cancelled), locale, subject and body are required")
+		return
+	}
+
+	template := &models.MessageTemplate{
+		OrgID:     req.OrgID,
+		EventType: req.EventType,
+		Locale:    req.Locale,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		Enabled:   true,
+	}
+
+	if err := h.templateRepo.Upsert(r.Context(), template); err != nil {
+		log.Error().Err(err).Msg("Failed to upsert message template")
+		respondError(w, r, http.StatusInternalServerError, "Failed to save template")
+		return
+	}
+
+	log.Info().
+		Str("template_id", template.ID.String()).
+		Str("org_id", template.OrgID.String()).
+		Str("event_type", string(template.EventType)).
+		Str("locale", template.Locale).
+		Msg("Message template saved")
+
+	respondJSON(w, http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /api/v1/templates/{id}
+func (h *MessageTemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	if err := h.templateRepo.Delete(r.Context(), templateID); err != nil {
+		if errors.Is(err, repository.ErrTemplateNotFound) {
+			respondError(w, r, http.StatusNotFound, "Template not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to delete message template")
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete template")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Template deleted",
+	})
+}
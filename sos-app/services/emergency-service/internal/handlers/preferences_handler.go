@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// PreferencesHandler handles HTTP requests for user emergency preferences
+type PreferencesHandler struct {
+	prefsRepo *repository.PreferencesRepository
+}
+
+// NewPreferencesHandler creates a new PreferencesHandler
+func NewPreferencesHandler(prefsRepo *repository.PreferencesRepository) *PreferencesHandler {
+	return &PreferencesHandler{
+		prefsRepo: prefsRepo,
+	}
+}
+
+// GetPreferences handles GET /api/v1/emergency/preferences
+func (h *PreferencesHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		respondError(w, r, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid user_id")
+		return
+	}
+
+	prefs, err := h.prefsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get preferences")
+		respondError(w, r, http.StatusInternalServerError, "Failed to get preferences")
+		return
+	}
+
+	if prefs == nil {
+		respondError(w, r, http.StatusNotFound, "No preferences found for user")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PUT /api/v1/emergency/preferences
+func (h *PreferencesHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prefs := &models.UserPreferences{
+		UserID:                  req.UserID,
+		DefaultCountdownSeconds: req.DefaultCountdownSeconds,
+		SilentMode:              req.SilentMode,
+		QuickActionTypes:        req.QuickActionTypes,
+		MessageTemplates:        req.MessageTemplates,
+		AckQuorums:              req.AckQuorums,
+		VictimPhone:             req.VictimPhone,
+		CheckInReminderMinutes:  req.CheckInReminderMinutes,
+	}
+
+	if prefs.QuickActionTypes == nil {
+		prefs.QuickActionTypes = []models.EmergencyType{}
+	}
+	if prefs.MessageTemplates == nil {
+		prefs.MessageTemplates = map[models.EmergencyType]string{}
+	}
+
+	if err := h.prefsRepo.Upsert(r.Context(), prefs); err != nil {
+		log.Error().Err(err).Msg("Failed to update preferences")
+		respondError(w, r, http.StatusInternalServerError, "Failed to update preferences")
+		return
+	}
+
+	log.Info().
+		Str("user_id", req.UserID.String()).
+		Msg("User preferences updated")
+
+	respondJSON(w, http.StatusOK, prefs)
+}
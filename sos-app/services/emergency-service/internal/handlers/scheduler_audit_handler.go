@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/services"
+)
+
+// SchedulerAuditHandler serves the scheduling audit: every countdown,
+// push-check and escalation timer currently pending in memory
+type SchedulerAuditHandler struct {
+	audit *services.SchedulerAudit
+}
+
+// NewSchedulerAuditHandler creates a new SchedulerAuditHandler
+func NewSchedulerAuditHandler(audit *services.SchedulerAudit) *SchedulerAuditHandler {
+	return &SchedulerAuditHandler{audit: audit}
+}
+
+// GetScheduledTimers handles GET /debug/timers
+func (h *SchedulerAuditHandler) GetScheduledTimers(w http.ResponseWriter, r *http.Request) {
+	timers := h.audit.Snapshot()
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"generated_at": time.Now().UTC(),
+		"total":        len(timers),
+		"timers":       timers,
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// WebhookConnectorHandler handles HTTP requests for webhook connectors
+type WebhookConnectorHandler struct {
+	connectorRepo *repository.WebhookConnectorRepository
+}
+
+// NewWebhookConnectorHandler creates a new WebhookConnectorHandler
+func NewWebhookConnectorHandler(connectorRepo *repository.WebhookConnectorRepository) *WebhookConnectorHandler {
+	return &WebhookConnectorHandler{
+		connectorRepo: connectorRepo,
+	}
+}
+
+// ListConnectors handles GET /api/v1/webhooks/connectors?org_id=...
+func (h *WebhookConnectorHandler) ListConnectors(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(r.URL.Query().Get("org_id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "org_id query parameter is required")
+		return
+	}
+
+	connectors, err := h.connectorRepo.ListByOrg(r.Context(), orgID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook connectors")
+		respondError(w, r, http.StatusInternalServerError, "Failed to list connectors")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"connectors": connectors,
+		"count":      len(connectors),
+	})
+}
+
+// CreateConnector handles POST /api/v1/webhooks/connectors
+func (h *WebhookConnectorHandler) CreateConnector(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateConnectorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Validate() {
+		respondError(w, r, http.StatusBadRequest, "org_id, provider (slack|teams), name and webhook_url are required")
+		return
+	}
+
+	connector := &models.WebhookConnector{
+		OrgID:      req.OrgID,
+		Provider:   req.Provider,
+		Name:       req.Name,
+		WebhookURL: req.WebhookURL,
+		Enabled:    true,
+	}
+
+	if err := h.connectorRepo.Create(r.Context(), connector); err != nil {
+		log.Error().Err(err).Msg("Failed to create webhook connector")
+		respondError(w, r, http.StatusInternalServerError, "Failed to create connector")
+		return
+	}
+
+	log.Info().
+		Str("connector_id", connector.ID.String()).
+		Str("org_id", connector.OrgID.String()).
+		Str("provider", string(connector.Provider)).
+		Msg("Webhook connector created")
+
+	respondJSON(w, http.StatusCreated, connector)
+}
+
+// DeleteConnector handles DELETE /api/v1/webhooks/connectors/{id}
+func (h *WebhookConnectorHandler) DeleteConnector(w http.ResponseWriter, r *http.Request) {
+	connectorID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid connector ID")
+		return
+	}
+
+	if err := h.connectorRepo.Delete(r.Context(), connectorID); err != nil {
+		if errors.Is(err, repository.ErrConnectorNotFound) {
+			respondError(w, r, http.StatusNotFound, "Connector not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to delete webhook connector")
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete connector")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Connector deleted",
+	})
+}
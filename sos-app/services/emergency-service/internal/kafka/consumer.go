@@ -4,20 +4,58 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/redact"
 	"github.com/sos-app/emergency-service/internal/repository"
 )
 
-// Consumer handles consuming events from Kafka
+const (
+	// partitionQueueSize bounds in-flight messages per partition; once a
+	// partition's queue fills the partition is paused at the broker
+	partitionQueueSize = 64
+	// resumeQueueDepth is the queue depth at which a paused partition is
+	// fetched from again
+	resumeQueueDepth = partitionQueueSize / 4
+)
+
+// Consumer handles consuming events from Kafka. Each assigned partition gets
+// its own bounded worker so a slow partition applies backpressure (pause at
+// the broker) instead of growing memory, and rebalances drain workers and
+// commit offsets before partitions move away. This consumer stays on the
+// native client rather than pkg/kafka because per-partition pause/resume is
+// not exposed by the shared wrapper's reader
 type Consumer struct {
-	consumer    *kafka.Consumer
-	ackRepo     *repository.AcknowledgmentRepository
-	running     bool
-	stopChan    chan struct{}
+	consumer        *kafka.Consumer
+	ackRepo         *repository.AcknowledgmentRepository
+	notifStatusRepo *repository.NotificationStatusRepository
+	erasureRepo     *repository.ErasureRepository
+	emergencyRepo   *repository.EmergencyRepository
+
+	// onCountdownPushReceipt is invoked when the victim's phone confirms
+	// delivery of a countdown-start push (may be nil)
+	onCountdownPushReceipt func(emergencyID uuid.UUID)
+
+	mu      sync.Mutex
+	workers map[string]*partitionWorker
+	running bool
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// partitionWorker processes one partition's messages in order
+type partitionWorker struct {
+	tp       kafka.TopicPartition
+	messages chan *kafka.Message
+	paused   bool
+	wg       sync.WaitGroup
 }
 
 // ConsumerConfig holds configuration for Kafka consumer
@@ -26,10 +64,14 @@ type ConsumerConfig struct {
 	ConsumerGroup              string
 	ContactAcknowledgedTopic   string
 	LocationUpdatedTopic       string
+	NotificationStatusTopic    string
+	CountdownPushReceiptTopic  string
+	ErasureCompletedTopic      string
 }
 
-// NewConsumer creates a new Kafka consumer
-func NewConsumer(config ConsumerConfig, ackRepo *repository.AcknowledgmentRepository) (*Consumer, error) {
+// NewConsumer creates a new Kafka consumer. onCountdownPushReceipt may be
+// nil when countdown push tracking is disabled
+func NewConsumer(config ConsumerConfig, ackRepo *repository.AcknowledgmentRepository, notifStatusRepo *repository.NotificationStatusRepository, erasureRepo *repository.ErasureRepository, emergencyRepo *repository.EmergencyRepository, onCountdownPushReceipt func(emergencyID uuid.UUID)) (*Consumer, error) {
 	kafkaConfig := &kafka.ConfigMap{
 		"bootstrap.servers":  config.Brokers[0],
 		"group.id":           config.ConsumerGroup,
@@ -42,24 +84,32 @@ func NewConsumer(config ConsumerConfig, ackRepo *repository.AcknowledgmentReposi
 		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
 	}
 
-	// Subscribe to topics
+	c := &Consumer{
+		consumer:               consumer,
+		ackRepo:                ackRepo,
+		notifStatusRepo:        notifStatusRepo,
+		erasureRepo:            erasureRepo,
+		emergencyRepo:          emergencyRepo,
+		onCountdownPushReceipt: onCountdownPushReceipt,
+		workers:         make(map[string]*partitionWorker),
+		stopChan:        make(chan struct{}),
+		doneChan:        make(chan struct{}),
+	}
+
+	// Subscribe with a rebalance callback so partition assignment and
+	// revocation go through the worker lifecycle
 	topics := []string{
 		config.ContactAcknowledgedTopic,
 		config.LocationUpdatedTopic,
+		config.NotificationStatusTopic,
+		config.CountdownPushReceiptTopic,
+		config.ErasureCompletedTopic,
 	}
 
-	err = consumer.SubscribeTopics(topics, nil)
-	if err != nil {
+	if err := consumer.SubscribeTopics(topics, c.rebalance); err != nil {
 		return nil, fmt.Errorf("failed to subscribe to topics: %w", err)
 	}
 
-	c := &Consumer{
-		consumer: consumer,
-		ackRepo:  ackRepo,
-		running:  false,
-		stopChan: make(chan struct{}),
-	}
-
 	log.Info().
 		Str("brokers", config.Brokers[0]).
 		Str("group", config.ConsumerGroup).
@@ -69,6 +119,196 @@ func NewConsumer(config ConsumerConfig, ackRepo *repository.AcknowledgmentReposi
 	return c, nil
 }
 
+// partitionKey identifies a worker by topic and partition
+func partitionKey(tp kafka.TopicPartition) string {
+	topic := ""
+	if tp.Topic != nil {
+		topic = *tp.Topic
+	}
+	return fmt.Sprintf("%s/%d", topic, tp.Partition)
+}
+
+// rebalance is invoked by the client during group rebalances
+func (c *Consumer) rebalance(consumer *kafka.Consumer, event kafka.Event) error {
+	switch e := event.(type) {
+	case kafka.AssignedPartitions:
+		log.Info().
+			Int("partitions", len(e.Partitions)).
+			Msg("Partitions assigned")
+
+		c.mu.Lock()
+		for _, tp := range e.Partitions {
+			c.startWorkerLocked(tp)
+		}
+		c.mu.Unlock()
+
+		if err := consumer.Assign(e.Partitions); err != nil {
+			return err
+		}
+
+	case kafka.RevokedPartitions:
+		log.Info().
+			Int("partitions", len(e.Partitions)).
+			Msg("Partitions revoked - draining workers")
+
+		// Drain the revoked partitions' workers so their offsets are
+		// committed before another consumer takes over. If the assignment
+		// was lost (e.g. session timeout) offsets can no longer be
+		// committed, so just stop the workers
+		c.stopWorkers(e.Partitions)
+		if !consumer.AssignmentLost() {
+			c.commitOffsets()
+		}
+
+		if err := consumer.Unassign(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startWorkerLocked creates and starts the worker for one partition.
+// The caller holds c.mu
+func (c *Consumer) startWorkerLocked(tp kafka.TopicPartition) {
+	key := partitionKey(tp)
+	if _, exists := c.workers[key]; exists {
+		return
+	}
+
+	worker := &partitionWorker{
+		tp:       tp,
+		messages: make(chan *kafka.Message, partitionQueueSize),
+	}
+	c.workers[key] = worker
+
+	worker.wg.Add(1)
+	go func() {
+		defer worker.wg.Done()
+
+		for msg := range worker.messages {
+			c.handleMessage(context.Background(), msg)
+
+			// Commit offset after successful processing
+			if _, err := c.consumer.CommitMessage(msg); err != nil {
+				log.Error().Err(err).Msg("Error committing Kafka offset")
+			}
+
+			c.maybeResume(worker)
+		}
+	}()
+}
+
+// stopWorkers drains and removes the workers for the given partitions
+func (c *Consumer) stopWorkers(partitions []kafka.TopicPartition) {
+	c.mu.Lock()
+	var stopping []*partitionWorker
+	for _, tp := range partitions {
+		key := partitionKey(tp)
+		if worker, exists := c.workers[key]; exists {
+			close(worker.messages)
+			stopping = append(stopping, worker)
+			delete(c.workers, key)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, worker := range stopping {
+		worker.wg.Wait()
+	}
+}
+
+// stopAllWorkers drains every worker, used on shutdown
+func (c *Consumer) stopAllWorkers() {
+	c.mu.Lock()
+	var stopping []*partitionWorker
+	for key, worker := range c.workers {
+		close(worker.messages)
+		stopping = append(stopping, worker)
+		delete(c.workers, key)
+	}
+	c.mu.Unlock()
+
+	for _, worker := range stopping {
+		worker.wg.Wait()
+	}
+}
+
+// dispatch hands a message to its partition's worker, pausing the partition
+// at the broker when the worker's queue is full
+func (c *Consumer) dispatch(msg *kafka.Message) {
+	c.mu.Lock()
+	worker, exists := c.workers[partitionKey(msg.TopicPartition)]
+	c.mu.Unlock()
+
+	if !exists {
+		// Raced with a revocation; the partition's new owner will redeliver
+		log.Debug().
+			Str("partition", partitionKey(msg.TopicPartition)).
+			Msg("Dropping message for unassigned partition")
+		return
+	}
+
+	select {
+	case worker.messages <- msg:
+		return
+	default:
+	}
+
+	// Queue is full: stop fetching this partition until the worker catches
+	// up, then enqueue (bounded by the queue size, so this blocks briefly)
+	c.pause(worker)
+	worker.messages <- msg
+}
+
+// pause stops broker fetches for a worker's partition
+func (c *Consumer) pause(worker *partitionWorker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if worker.paused {
+		return
+	}
+	if err := c.consumer.Pause([]kafka.TopicPartition{worker.tp}); err != nil {
+		log.Error().Err(err).Msg("Error pausing partition")
+		return
+	}
+	worker.paused = true
+
+	log.Warn().
+		Str("partition", partitionKey(worker.tp)).
+		Msg("Partition paused - downstream pressure")
+}
+
+// maybeResume resumes a paused partition once its queue has drained
+func (c *Consumer) maybeResume(worker *partitionWorker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !worker.paused || len(worker.messages) > resumeQueueDepth {
+		return
+	}
+	if err := c.consumer.Resume([]kafka.TopicPartition{worker.tp}); err != nil {
+		log.Error().Err(err).Msg("Error resuming partition")
+		return
+	}
+	worker.paused = false
+
+	log.Info().
+		Str("partition", partitionKey(worker.tp)).
+		Msg("Partition resumed")
+}
+
+// commitOffsets commits any stored offsets, ignoring the no-offsets case
+func (c *Consumer) commitOffsets() {
+	if _, err := c.consumer.Commit(); err != nil {
+		if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrNoOffset {
+			return
+		}
+		log.Error().Err(err).Msg("Error committing Kafka offsets")
+	}
+}
+
 // Start begins consuming messages from Kafka
 func (c *Consumer) Start(ctx context.Context) {
 	c.running = true
@@ -76,14 +316,17 @@ func (c *Consumer) Start(ctx context.Context) {
 	log.Info().Msg("Starting Kafka consumer...")
 
 	go func() {
+		defer close(c.doneChan)
+
 		for c.running {
 			select {
 			case <-c.stopChan:
 				return
 			case <-ctx.Done():
-				c.Stop()
+				go c.Stop()
 				return
 			default:
+				// ReadMessage also drives the rebalance callback
 				msg, err := c.consumer.ReadMessage(100 * time.Millisecond)
 				if err != nil {
 					if err.(kafka.Error).Code() == kafka.ErrTimedOut {
@@ -93,12 +336,7 @@ func (c *Consumer) Start(ctx context.Context) {
 					continue
 				}
 
-				c.handleMessage(ctx, msg)
-
-				// Commit offset after successful processing
-				if _, err := c.consumer.CommitMessage(msg); err != nil {
-					log.Error().Err(err).Msg("Error committing Kafka offset")
-				}
+				c.dispatch(msg)
 			}
 		}
 	}()
@@ -120,6 +358,12 @@ func (c *Consumer) handleMessage(ctx context.Context, msg *kafka.Message) {
 		c.handleContactAcknowledged(ctx, msg.Value)
 	case "location-updated":
 		c.handleLocationUpdated(ctx, msg.Value)
+	case "notification-delivery-status":
+		c.handleNotificationStatus(ctx, msg.Value)
+	case "countdown-push-receipts":
+		c.handleCountdownPushReceipt(ctx, msg.Value)
+	case "erasure-completed":
+		c.handleErasureCompleted(ctx, msg.Value)
 	default:
 		log.Warn().Str("topic", topic).Msg("Unknown topic received")
 	}
@@ -144,6 +388,7 @@ func (c *Consumer) handleContactAcknowledged(ctx context.Context, data []byte) {
 		EmergencyID:    event.EmergencyID,
 		ContactID:      event.ContactID,
 		ContactName:    event.ContactName,
+		Relationship:   event.Relationship,
 		AcknowledgedAt: event.AcknowledgedAt,
 		Location:       event.Location,
 		Message:        event.Message,
@@ -174,7 +419,119 @@ func (c *Consumer) handleContactAcknowledged(ctx context.Context, data []byte) {
 		Msg("Contact acknowledgment recorded successfully")
 }
 
-// handleLocationUpdated processes location update events (for future use)
+// handleNotificationStatus processes notification delivery status events,
+// keeping the latest outcome per contact/channel
+func (c *Consumer) handleNotificationStatus(ctx context.Context, data []byte) {
+	var event models.NotificationStatusEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal NotificationStatusEvent")
+		return
+	}
+
+	status := &models.NotificationStatus{
+		EmergencyID: event.EmergencyID,
+		ContactID:   event.ContactID,
+		ContactName: event.ContactName,
+		Channel:     event.Channel,
+		Status:      event.Status,
+		Error:       event.Error,
+	}
+
+	if err := status.Validate(); err != nil {
+		log.Error().Err(err).Msg("Invalid notification status event")
+		return
+	}
+
+	if err := c.notifStatusRepo.Upsert(ctx, status); err != nil {
+		log.Error().Err(err).Msg("Failed to store notification status")
+		return
+	}
+
+	log.Info().
+		Str("emergency_id", event.EmergencyID.String()).
+		Str("contact_id", event.ContactID.String()).
+		Str("channel", string(event.Channel)).
+		Str("status", string(event.Status)).
+		Msg("Notification delivery status recorded")
+}
+
+// handleCountdownPushReceipt processes countdown-start push delivery
+// receipts so the countdown service can shorten countdowns nobody can see
+func (c *Consumer) handleCountdownPushReceipt(ctx context.Context, data []byte) {
+	var event models.CountdownPushReceiptEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal CountdownPushReceiptEvent")
+		return
+	}
+
+	if event.EmergencyID == uuid.Nil {
+		log.Error().Msg("Countdown push receipt without emergency_id")
+		return
+	}
+
+	// Only a confirmed delivery proves the user can see the countdown;
+	// SENT and FAILED receipts change nothing
+	if event.Status != models.DeliveryDelivered {
+		log.Debug().
+			Str("emergency_id", event.EmergencyID.String()).
+			Str("status", string(event.Status)).
+			Msg("Ignoring non-delivered countdown push receipt")
+		return
+	}
+
+	if c.onCountdownPushReceipt != nil {
+		c.onCountdownPushReceipt(event.EmergencyID)
+	}
+
+	log.Info().
+		Str("emergency_id", event.EmergencyID.String()).
+		Msg("Countdown push delivery receipt recorded")
+}
+
+// handleErasureCompleted records a downstream service's confirmation that it
+// purged a user's data, so the erasure status endpoint can report which legs
+// of the saga are done
+func (c *Consumer) handleErasureCompleted(ctx context.Context, data []byte) {
+	var event ErasureCompletedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal ErasureCompletedEvent")
+		return
+	}
+
+	if event.RequestID == uuid.Nil || event.Service == "" {
+		log.Error().Msg("Erasure completion missing request_id or service")
+		return
+	}
+
+	completedAt := event.Timestamp
+	if completedAt.IsZero() {
+		completedAt = time.Now().UTC()
+	}
+
+	completion := &models.ErasureCompletion{
+		RequestID:     event.RequestID,
+		Service:       event.Service,
+		RecordsPurged: event.RecordsPurged,
+		CompletedAt:   completedAt,
+	}
+
+	if err := c.erasureRepo.RecordCompletion(ctx, completion); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", event.RequestID.String()).
+			Str("service", event.Service).
+			Msg("Failed to record erasure completion")
+		return
+	}
+
+	log.Info().
+		Str("request_id", event.RequestID.String()).
+		Str("service", event.Service).
+		Int64("records_purged", event.RecordsPurged).
+		Msg("Erasure completion recorded")
+}
+
+// handleLocationUpdated processes location update events
 func (c *Consumer) handleLocationUpdated(ctx context.Context, data []byte) {
 	var event LocationUpdatedEvent
 	if err := json.Unmarshal(data, &event); err != nil {
@@ -185,28 +542,36 @@ func (c *Consumer) handleLocationUpdated(ctx context.Context, data []byte) {
 	log.Debug().
 		Str("emergency_id", event.EmergencyID.String()).
 		Str("user_id", event.UserID.String()).
-		Float64("lat", event.Location.Latitude).
-		Float64("lng", event.Location.Longitude).
+		Str("location", redact.Coordinates(event.Location.Latitude, event.Location.Longitude)).
 		Msg("Received location update")
 
-	// Location updates are primarily handled by the Location Service
-	// This service may use them for escalation logic or analytics
-	// For now, we just log them
+	// The location history itself lives in the Location Service; only the
+	// freshness timestamp is kept here, so the consistency checker can flag
+	// active emergencies whose location stream has gone silent
+	if err := c.emergencyRepo.UpdateLastLocation(ctx, event.EmergencyID, time.Now()); err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", event.EmergencyID.String()).
+			Msg("Failed to record location freshness")
+	}
 }
 
-// Stop gracefully shuts down the consumer
+// Stop gracefully shuts down the consumer: stop fetching, drain every
+// worker, commit what was processed, then close
 func (c *Consumer) Stop() {
-	if !c.running {
-		return
-	}
+	c.stopOnce.Do(func() {
+		log.Info().Msg("Stopping Kafka consumer...")
+		c.running = false
+		close(c.stopChan)
+		<-c.doneChan
 
-	log.Info().Msg("Stopping Kafka consumer...")
-	c.running = false
-	close(c.stopChan)
+		c.stopAllWorkers()
+		c.commitOffsets()
 
-	if err := c.consumer.Close(); err != nil {
-		log.Error().Err(err).Msg("Error closing Kafka consumer")
-	}
+		if err := c.consumer.Close(); err != nil {
+			log.Error().Err(err).Msg("Error closing Kafka consumer")
+		}
 
-	log.Info().Msg("Kafka consumer stopped")
+		log.Info().Msg("Kafka consumer stopped")
+	})
 }
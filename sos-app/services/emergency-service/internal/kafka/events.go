@@ -7,6 +7,16 @@ import (
 	"github.com/sos-app/emergency-service/internal/models"
 )
 
+// RenderedMessage is an organization's branded wording for a lifecycle event,
+// rendered from its message template. The notification service delivers the
+// subject and body verbatim
+type RenderedMessage struct {
+	OrgID   uuid.UUID `json:"org_id"`
+	Locale  string    `json:"locale"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+}
+
 // EmergencyCreatedEvent represents an event when an emergency is created/activated
 type EmergencyCreatedEvent struct {
 	EmergencyID   uuid.UUID           `json:"emergency_id"`
@@ -17,6 +27,8 @@ type EmergencyCreatedEvent struct {
 	AutoTriggered bool                `json:"auto_triggered"`
 	TriggeredBy   string              `json:"triggered_by"`
 	ContactIDs    []uuid.UUID         `json:"contact_ids"` // To be populated by user service
+	NearbyFacilities models.NearbyFacilities `json:"nearby_facilities,omitempty"` // Nearest hospital/police/fire stations
+	Messages      []RenderedMessage   `json:"messages,omitempty"` // Per-organization branded wording
 	Timestamp     time.Time           `json:"timestamp"`
 }
 
@@ -26,6 +38,7 @@ type EmergencyResolvedEvent struct {
 	UserID          uuid.UUID `json:"user_id"`
 	Duration        int64     `json:"duration_seconds"`
 	ResolutionNotes *string   `json:"resolution_notes,omitempty"`
+	Messages        []RenderedMessage `json:"messages,omitempty"` // Per-organization branded wording
 	Timestamp       time.Time `json:"timestamp"`
 }
 
@@ -34,9 +47,44 @@ type EmergencyCancelledEvent struct {
 	EmergencyID uuid.UUID `json:"emergency_id"`
 	UserID      uuid.UUID `json:"user_id"`
 	Reason      string    `json:"reason"`
+	Messages    []RenderedMessage `json:"messages,omitempty"` // Per-organization branded wording
 	Timestamp   time.Time `json:"timestamp"`
 }
 
+// EmergencyEscalatedEvent represents an event when an unacknowledged
+// emergency escalates to the next level of the escalation ladder. The
+// notification service fans it out to the audience for that level
+type EmergencyEscalatedEvent struct {
+	EmergencyID uuid.UUID            `json:"emergency_id"`
+	UserID      uuid.UUID            `json:"user_id"`
+	Type        models.EmergencyType `json:"type"`
+	Level       int                  `json:"level"`
+	LevelName   string               `json:"level_name"`
+	Location    models.Location      `json:"location"`
+	CallBridge  *models.CallBridge   `json:"call_bridge,omitempty"`
+	Timestamp   time.Time            `json:"timestamp"`
+}
+
+// AcknowledgmentRevokedEvent represents an event when a contact revokes their acknowledgment
+type AcknowledgmentRevokedEvent struct {
+	EmergencyID   uuid.UUID `json:"emergency_id"`
+	ContactID     uuid.UUID `json:"contact_id"`
+	ContactName   string    `json:"contact_name"`
+	Reason        *string   `json:"reason,omitempty"`
+	RemainingAcks int       `json:"remaining_acks"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// EmergencyClaimedEvent represents an event when an operator takes over an
+// emergency, so contacts can be told a professional is handling the incident
+type EmergencyClaimedEvent struct {
+	EmergencyID  uuid.UUID `json:"emergency_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	OperatorID   uuid.UUID `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
 // LocationUpdatedEvent represents a location update event (consumed, not produced by this service)
 type LocationUpdatedEvent struct {
 	EmergencyID uuid.UUID       `json:"emergency_id"`
@@ -44,3 +92,32 @@ type LocationUpdatedEvent struct {
 	Location    models.Location `json:"location"`
 	Timestamp   time.Time       `json:"timestamp"`
 }
+
+// DataErasureEvent asks downstream services (device, location) to erase or
+// anonymize all data they hold for a user (GDPR/CCPA)
+type DataErasureEvent struct {
+	RequestID uuid.UUID `json:"request_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ErasureCompletedEvent is a downstream service's confirmation that it has
+// purged a user's data for an erasure request (consumed, not produced by
+// this service)
+type ErasureCompletedEvent struct {
+	RequestID     uuid.UUID `json:"request_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Service       string    `json:"service"`
+	RecordsPurged int64     `json:"records_purged"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// CheckInReminderEvent asks the notification pipeline to push a check-in
+// reminder to the user as their deadline approaches
+type CheckInReminderEvent struct {
+	CheckInID     uuid.UUID `json:"check_in_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	DeadlineAt    time.Time `json:"deadline_at"`
+	MinutesBefore int       `json:"minutes_before"`
+	Timestamp     time.Time `json:"timestamp"`
+}
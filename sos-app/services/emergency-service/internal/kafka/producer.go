@@ -2,22 +2,44 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	pkgkafka "github.com/sos-app/pkg/kafka"
+
 	"github.com/sos-app/emergency-service/internal/models"
 )
 
+// MessageRenderer renders per-organization branded messages for a lifecycle
+// event from the configured templates. Implementations return nil when no
+// organization has a template for the event
+type MessageRenderer interface {
+	RenderMessages(ctx context.Context, eventType models.TemplateEventType, vars map[string]string) []RenderedMessage
+}
+
+// EventRecorder records successfully published lifecycle events in a local
+// ledger, so the consistency checker can detect lost events. Implementations
+// must not block the publish path on failure
+type EventRecorder interface {
+	RecordEvent(ctx context.Context, emergencyID uuid.UUID, eventType, topic string) error
+}
+
 // Producer handles publishing events to Kafka
 type Producer struct {
-	producer                  *kafka.Producer
-	emergencyCreatedTopic     string
-	emergencyResolvedTopic    string
-	emergencyCancelledTopic   string
+	producer                *pkgkafka.Producer
+	renderer                MessageRenderer
+	recorder                EventRecorder
+	emergencyCreatedTopic   string
+	emergencyResolvedTopic  string
+	emergencyCancelledTopic string
+	emergencyClaimedTopic   string
+	emergencyEscalatedTopic string
+	ackRevokedTopic         string
+	dataErasureTopic        string
+	checkInReminderTopic    string
 }
 
 // ProducerConfig holds configuration for Kafka producer
@@ -26,56 +48,104 @@ type ProducerConfig struct {
 	EmergencyCreatedTopic       string
 	EmergencyResolvedTopic      string
 	EmergencyCancelledTopic     string
+	EmergencyClaimedTopic       string
+	EmergencyEscalatedTopic     string
+	AcknowledgmentRevokedTopic  string
+	DataErasureTopic            string
+	CheckInReminderTopic        string
 }
 
 // NewProducer creates a new Kafka producer
 func NewProducer(config ProducerConfig) (*Producer, error) {
-	kafkaConfig := &kafka.ConfigMap{
-		"bootstrap.servers": config.Brokers[0],
-		"client.id":         "emergency-service-producer",
-		"acks":              "all",
-		"compression.type":  "snappy",
-		"retries":           3,
-		"retry.backoff.ms":  100,
-	}
-
-	producer, err := kafka.NewProducer(kafkaConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
-	}
+	producer := pkgkafka.NewProducer(pkgkafka.ProducerConfig{
+		Brokers:  config.Brokers,
+		ClientID: "emergency-service",
+		Logf: func(format string, args ...interface{}) {
+			log.Warn().Msg(fmt.Sprintf(format, args...))
+		},
+	})
 
 	p := &Producer{
 		producer:                producer,
 		emergencyCreatedTopic:   config.EmergencyCreatedTopic,
 		emergencyResolvedTopic:  config.EmergencyResolvedTopic,
 		emergencyCancelledTopic: config.EmergencyCancelledTopic,
+		emergencyClaimedTopic:   config.EmergencyClaimedTopic,
+		emergencyEscalatedTopic: config.EmergencyEscalatedTopic,
+		ackRevokedTopic:         config.AcknowledgmentRevokedTopic,
+		dataErasureTopic:        config.DataErasureTopic,
+		checkInReminderTopic:    config.CheckInReminderTopic,
 	}
 
-	// Start delivery report handler
-	go p.handleDeliveryReports()
-
 	log.Info().
-		Str("brokers", config.Brokers[0]).
+		Str("brokers", strings.Join(config.Brokers, ",")).
 		Msg("Kafka producer initialized")
 
 	return p, nil
 }
 
+// SetMessageRenderer attaches the template renderer used to include branded
+// per-organization messages in lifecycle events; nil disables rendering
+func (p *Producer) SetMessageRenderer(renderer MessageRenderer) {
+	p.renderer = renderer
+}
+
+// SetEventRecorder attaches the ledger recorder for published lifecycle
+// events; nil disables recording
+func (p *Producer) SetEventRecorder(recorder EventRecorder) {
+	p.recorder = recorder
+}
+
+// recordEvent writes a published event to the ledger. Recording failures
+// are logged but never fail the publish: the event is already out
+func (p *Producer) recordEvent(ctx context.Context, emergencyID uuid.UUID, eventType, topic string) {
+	if p.recorder == nil {
+		return
+	}
+	if err := p.recorder.RecordEvent(ctx, emergencyID, eventType, topic); err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergencyID.String()).
+			Str("event_type", eventType).
+			Msg("Failed to record published event in ledger")
+	}
+}
+
+// renderMessages returns the branded messages for an event, or nil when no
+// renderer is attached
+func (p *Producer) renderMessages(ctx context.Context, eventType models.TemplateEventType, vars map[string]string) []RenderedMessage {
+	if p.renderer == nil {
+		return nil
+	}
+	return p.renderer.RenderMessages(ctx, eventType, vars)
+}
+
 // PublishEmergencyCreated publishes an emergency created event
 func (p *Producer) PublishEmergencyCreated(ctx context.Context, emergency *models.Emergency) error {
 	event := EmergencyCreatedEvent{
-		EmergencyID:    emergency.ID,
-		UserID:         emergency.UserID,
-		Type:           emergency.EmergencyType,
-		Location:       emergency.InitialLocation,
-		InitialMessage: emergency.InitialMessage,
-		AutoTriggered:  emergency.AutoTriggered,
-		TriggeredBy:    emergency.TriggeredBy,
-		ContactIDs:     []uuid.UUID{}, // Will be populated by notification service
-		Timestamp:      time.Now(),
+		EmergencyID:      emergency.ID,
+		UserID:           emergency.UserID,
+		Type:             emergency.EmergencyType,
+		Location:         emergency.InitialLocation,
+		InitialMessage:   emergency.InitialMessage,
+		AutoTriggered:    emergency.AutoTriggered,
+		TriggeredBy:      emergency.TriggeredBy,
+		ContactIDs:       []uuid.UUID{}, // Will be populated by notification service
+		NearbyFacilities: emergency.NearbyFacilities,
+		Timestamp:        time.Now(),
+	}
+	event.Messages = p.renderMessages(ctx, models.TemplateEventEmergencyCreated, map[string]string{
+		"emergency_id":   emergency.ID.String(),
+		"user_id":        emergency.UserID.String(),
+		"emergency_type": string(emergency.EmergencyType),
+	})
+
+	if err := p.publish(ctx, p.emergencyCreatedTopic, emergency.ID.String(), event); err != nil {
+		return err
 	}
+	p.recordEvent(ctx, emergency.ID, models.LedgerEventEmergencyCreated, p.emergencyCreatedTopic)
 
-	return p.publish(p.emergencyCreatedTopic, emergency.ID.String(), event)
+	return nil
 }
 
 // PublishEmergencyResolved publishes an emergency resolved event
@@ -93,8 +163,19 @@ func (p *Producer) PublishEmergencyResolved(ctx context.Context, emergency *mode
 		ResolutionNotes: emergency.ResolutionNotes,
 		Timestamp:       time.Now(),
 	}
+	event.Messages = p.renderMessages(ctx, models.TemplateEventEmergencyResolved, map[string]string{
+		"emergency_id":     emergency.ID.String(),
+		"user_id":          emergency.UserID.String(),
+		"emergency_type":   string(emergency.EmergencyType),
+		"duration_minutes": fmt.Sprintf("%.0f", duration.Minutes()),
+	})
+
+	if err := p.publish(ctx, p.emergencyResolvedTopic, emergency.ID.String(), event); err != nil {
+		return err
+	}
+	p.recordEvent(ctx, emergency.ID, models.LedgerEventEmergencyResolved, p.emergencyResolvedTopic)
 
-	return p.publish(p.emergencyResolvedTopic, emergency.ID.String(), event)
+	return nil
 }
 
 // PublishEmergencyCancelled publishes an emergency cancelled event
@@ -105,93 +186,128 @@ func (p *Producer) PublishEmergencyCancelled(ctx context.Context, emergency *mod
 		Reason:      reason,
 		Timestamp:   time.Now(),
 	}
+	event.Messages = p.renderMessages(ctx, models.TemplateEventEmergencyCancelled, map[string]string{
+		"emergency_id":   emergency.ID.String(),
+		"user_id":        emergency.UserID.String(),
+		"emergency_type": string(emergency.EmergencyType),
+		"reason":         reason,
+	})
 
-	return p.publish(p.emergencyCancelledTopic, emergency.ID.String(), event)
+	if err := p.publish(ctx, p.emergencyCancelledTopic, emergency.ID.String(), event); err != nil {
+		return err
+	}
+	p.recordEvent(ctx, emergency.ID, models.LedgerEventEmergencyCancelled, p.emergencyCancelledTopic)
+
+	return nil
 }
 
-// publish is a generic method to publish any event to a topic
-func (p *Producer) publish(topic, key string, event interface{}) error {
-	// Serialize event to JSON
-	value, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	// Create Kafka message
-	message := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{
-			Topic:     &topic,
-			Partition: kafka.PartitionAny,
-		},
-		Key:   []byte(key),
-		Value: value,
-		Headers: []kafka.Header{
-			{Key: "event_type", Value: []byte(fmt.Sprintf("%T", event))},
-			{Key: "timestamp", Value: []byte(time.Now().Format(time.RFC3339))},
-		},
+// PublishEmergencyClaimed publishes an operator takeover event
+func (p *Producer) PublishEmergencyClaimed(ctx context.Context, emergency *models.Emergency) error {
+	event := EmergencyClaimedEvent{
+		EmergencyID: emergency.ID,
+		UserID:      emergency.UserID,
+		Timestamp:   time.Now(),
+	}
+	if emergency.ClaimedBy != nil {
+		event.OperatorID = *emergency.ClaimedBy
+	}
+	if emergency.ClaimedByName != nil {
+		event.OperatorName = *emergency.ClaimedByName
+	}
+
+	return p.publish(ctx, p.emergencyClaimedTopic, emergency.ID.String(), event)
+}
+
+// PublishEmergencyEscalated publishes an escalation event for one level of
+// the escalation ladder; the notification service notifies that level's
+// audience (primary contacts, secondary contacts, emergency services)
+func (p *Producer) PublishEmergencyEscalated(ctx context.Context, emergency *models.Emergency, level int, levelName string) error {
+	event := EmergencyEscalatedEvent{
+		EmergencyID: emergency.ID,
+		UserID:      emergency.UserID,
+		Type:        emergency.EmergencyType,
+		Level:       level,
+		LevelName:   levelName,
+		Location:    emergency.InitialLocation,
+		CallBridge:  emergency.CallBridge,
+		Timestamp:   time.Now(),
+	}
+
+	if err := p.publish(ctx, p.emergencyEscalatedTopic, emergency.ID.String(), event); err != nil {
+		return err
 	}
+	p.recordEvent(ctx, emergency.ID, models.LedgerEventEmergencyEscalated, p.emergencyEscalatedTopic)
 
-	// Produce message
-	deliveryChan := make(chan kafka.Event, 1)
-	err = p.producer.Produce(message, deliveryChan)
-	if err != nil {
-		return fmt.Errorf("failed to produce message: %w", err)
+	return nil
+}
+
+// PublishAcknowledgmentRevoked publishes an acknowledgment revoked event
+func (p *Producer) PublishAcknowledgmentRevoked(ctx context.Context, ack *models.EmergencyAcknowledgment, remainingAcks int) error {
+	event := AcknowledgmentRevokedEvent{
+		EmergencyID:   ack.EmergencyID,
+		ContactID:     ack.ContactID,
+		ContactName:   ack.ContactName,
+		Reason:        ack.RevokedReason,
+		RemainingAcks: remainingAcks,
+		Timestamp:     time.Now(),
+	}
+
+	return p.publish(ctx, p.ackRevokedTopic, ack.EmergencyID.String(), event)
+}
+
+// PublishDataErasure publishes a data erasure event so device-service and
+// location-service purge their copies of the user's data
+func (p *Producer) PublishDataErasure(ctx context.Context, requestID, userID uuid.UUID) error {
+	event := DataErasureEvent{
+		RequestID: requestID,
+		UserID:    userID,
+		Timestamp: time.Now(),
+	}
+
+	return p.publish(ctx, p.dataErasureTopic, userID.String(), event)
+}
+
+// PublishCheckInReminder publishes a check-in reminder for the notification
+// pipeline to push to the user
+func (p *Producer) PublishCheckInReminder(ctx context.Context, checkIn *models.ScheduledCheckIn, minutesBefore int) error {
+	event := CheckInReminderEvent{
+		CheckInID:     checkIn.ID,
+		UserID:        checkIn.UserID,
+		DeadlineAt:    checkIn.DeadlineAt,
+		MinutesBefore: minutesBefore,
+		Timestamp:     time.Now(),
 	}
 
-	// Wait for delivery report
-	e := <-deliveryChan
-	m := e.(*kafka.Message)
+	return p.publish(ctx, p.checkInReminderTopic, checkIn.UserID.String(), event)
+}
 
-	if m.TopicPartition.Error != nil {
+// publish is a generic method to publish any event to a topic
+func (p *Producer) publish(ctx context.Context, topic, key string, event interface{}) error {
+	if err := p.producer.Publish(ctx, topic, key, event); err != nil {
 		log.Error().
-			Err(m.TopicPartition.Error).
+			Err(err).
 			Str("topic", topic).
 			Str("key", key).
 			Msg("Failed to deliver message to Kafka")
-		return fmt.Errorf("failed to deliver message: %w", m.TopicPartition.Error)
+		return err
 	}
 
 	log.Debug().
 		Str("topic", topic).
 		Str("key", key).
-		Int32("partition", m.TopicPartition.Partition).
-		Int64("offset", int64(m.TopicPartition.Offset)).
 		Msg("Message delivered to Kafka")
 
 	return nil
 }
 
-// handleDeliveryReports listens for delivery reports from Kafka
-func (p *Producer) handleDeliveryReports() {
-	for e := range p.producer.Events() {
-		switch ev := e.(type) {
-		case *kafka.Message:
-			if ev.TopicPartition.Error != nil {
-				log.Error().
-					Err(ev.TopicPartition.Error).
-					Str("topic", *ev.TopicPartition.Topic).
-					Msg("Delivery failed")
-			}
-		case kafka.Error:
-			log.Error().
-				Err(ev).
-				Msg("Kafka error")
-		}
-	}
-}
-
 // Close gracefully shuts down the producer
 func (p *Producer) Close() {
 	log.Info().Msg("Closing Kafka producer...")
 
-	// Flush any remaining messages (wait up to 10 seconds)
-	remaining := p.producer.Flush(10 * 1000)
-	if remaining > 0 {
-		log.Warn().
-			Int("remaining", remaining).
-			Msg("Not all messages were flushed before closing")
+	if err := p.producer.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close Kafka producer")
+		return
 	}
 
-	p.producer.Close()
 	log.Info().Msg("Kafka producer closed")
 }
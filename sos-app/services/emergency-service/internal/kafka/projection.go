@@ -0,0 +1,183 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	pkgkafka "github.com/sos-app/pkg/kafka"
+
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// ProjectionConsumer feeds the dashboard read model from the service's own
+// lifecycle events plus the acknowledgment and location streams. It runs in
+// its own consumer group so projection lag never applies backpressure to the
+// operational consumer, and it uses the shared pkg/kafka reader because every
+// projection write is idempotent - redelivery after a retry is harmless
+type ProjectionConsumer struct {
+	consumer      *pkgkafka.Consumer
+	dashboardRepo *repository.DashboardRepository
+	config        ProjectionConsumerConfig
+}
+
+// ProjectionConsumerConfig holds configuration for the dashboard projection
+// consumer
+type ProjectionConsumerConfig struct {
+	Brokers                    []string
+	ConsumerGroup              string
+	EmergencyCreatedTopic      string
+	EmergencyResolvedTopic     string
+	EmergencyCancelledTopic    string
+	EmergencyEscalatedTopic    string
+	AcknowledgmentRevokedTopic string
+	ContactAcknowledgedTopic   string
+	LocationUpdatedTopic       string
+}
+
+// NewProjectionConsumer creates the dashboard projection consumer
+func NewProjectionConsumer(config ProjectionConsumerConfig, dashboardRepo *repository.DashboardRepository) *ProjectionConsumer {
+	p := &ProjectionConsumer{
+		dashboardRepo: dashboardRepo,
+		config:        config,
+	}
+
+	p.consumer = pkgkafka.NewConsumer(pkgkafka.ConsumerConfig{
+		Brokers: config.Brokers,
+		GroupID: config.ConsumerGroup,
+		Topics: []string{
+			config.EmergencyCreatedTopic,
+			config.EmergencyResolvedTopic,
+			config.EmergencyCancelledTopic,
+			config.EmergencyEscalatedTopic,
+			config.AcknowledgmentRevokedTopic,
+			config.ContactAcknowledgedTopic,
+			config.LocationUpdatedTopic,
+		},
+		Logf: func(format string, args ...interface{}) {
+			log.Warn().Msg(fmt.Sprintf(format, args...))
+		},
+	}, p.handle)
+
+	return p
+}
+
+// Start begins consuming in the background
+func (p *ProjectionConsumer) Start() {
+	p.consumer.Start()
+	log.Info().
+		Str("group", p.config.ConsumerGroup).
+		Msg("Dashboard projection consumer started")
+}
+
+// Stop drains the consumer
+func (p *ProjectionConsumer) Stop() {
+	if err := p.consumer.Close(); err != nil {
+		log.Error().Err(err).Msg("Error closing dashboard projection consumer")
+	}
+	log.Info().Msg("Dashboard projection consumer stopped")
+}
+
+// handle applies one event to the projection. Malformed payloads are dropped
+// (retrying cannot fix them); repository errors are returned so the shared
+// consumer retries and eventually dead-letters
+func (p *ProjectionConsumer) handle(ctx context.Context, message pkgkafka.Message) error {
+	switch message.Topic {
+	case p.config.EmergencyCreatedTopic:
+		return p.applyCreated(ctx, message.Value)
+	case p.config.EmergencyResolvedTopic, p.config.EmergencyCancelledTopic:
+		return p.applyEnded(ctx, message.Value)
+	case p.config.EmergencyEscalatedTopic:
+		return p.applyEscalated(ctx, message.Value)
+	case p.config.ContactAcknowledgedTopic:
+		return p.applyAckAdjustment(ctx, message.Value, 1)
+	case p.config.AcknowledgmentRevokedTopic:
+		return p.applyAckAdjustment(ctx, message.Value, -1)
+	case p.config.LocationUpdatedTopic:
+		return p.applyLocationUpdated(ctx, message.Value)
+	}
+	return nil
+}
+
+// applyCreated adds a newly activated emergency to the projection
+func (p *ProjectionConsumer) applyCreated(ctx context.Context, data []byte) error {
+	var event EmergencyCreatedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Projection: failed to unmarshal EmergencyCreatedEvent")
+		return nil
+	}
+
+	activatedAt := event.Timestamp
+	if activatedAt.IsZero() {
+		activatedAt = time.Now().UTC()
+	}
+
+	return p.dashboardRepo.UpsertActive(ctx, &models.DashboardEntry{
+		EmergencyID:   event.EmergencyID,
+		UserID:        event.UserID,
+		EmergencyType: event.Type,
+		Latitude:      event.Location.Latitude,
+		Longitude:     event.Location.Longitude,
+		AutoTriggered: event.AutoTriggered,
+		ActivatedAt:   activatedAt,
+	})
+}
+
+// applyEnded removes an emergency once it resolves or is cancelled. Both
+// event shapes carry emergency_id, which is all the removal needs
+func (p *ProjectionConsumer) applyEnded(ctx context.Context, data []byte) error {
+	var event struct {
+		EmergencyID uuid.UUID `json:"emergency_id"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Projection: failed to unmarshal lifecycle end event")
+		return nil
+	}
+
+	return p.dashboardRepo.Remove(ctx, event.EmergencyID)
+}
+
+// applyEscalated records the escalation ladder rung an emergency reached
+func (p *ProjectionConsumer) applyEscalated(ctx context.Context, data []byte) error {
+	var event EmergencyEscalatedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Projection: failed to unmarshal EmergencyEscalatedEvent")
+		return nil
+	}
+
+	return p.dashboardRepo.SetEscalationLevel(ctx, event.EmergencyID, event.Level, event.LevelName)
+}
+
+// applyAckAdjustment moves the acknowledgment count for an acknowledgment
+// (delta 1) or a revocation (delta -1)
+func (p *ProjectionConsumer) applyAckAdjustment(ctx context.Context, data []byte, delta int) error {
+	var event struct {
+		EmergencyID uuid.UUID `json:"emergency_id"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Projection: failed to unmarshal acknowledgment event")
+		return nil
+	}
+
+	return p.dashboardRepo.AdjustAckCount(ctx, event.EmergencyID, delta)
+}
+
+// applyLocationUpdated refreshes the latest coordinates on the projection
+func (p *ProjectionConsumer) applyLocationUpdated(ctx context.Context, data []byte) error {
+	var event LocationUpdatedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		log.Error().Err(err).Msg("Projection: failed to unmarshal LocationUpdatedEvent")
+		return nil
+	}
+
+	at := event.Timestamp
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	return p.dashboardRepo.UpdateLocation(ctx, event.EmergencyID, event.Location.Latitude, event.Location.Longitude, at)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AbuseFlag is a soft-lock raised when a user crosses the cancelled-trigger
+// quota. While active (uncleared) the user's repeat triggers are held back;
+// support reviews the flag and clears it, which keeps the row as an audit
+// trail
+type AbuseFlag struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"userId" db:"user_id"`
+	Reason         string     `json:"reason" db:"reason"`
+	CancelledCount int        `json:"cancelledCount" db:"cancelled_count"`
+	FlaggedAt      time.Time  `json:"flaggedAt" db:"flagged_at"`
+	ClearedAt      *time.Time `json:"clearedAt,omitempty" db:"cleared_at"`
+	ClearedBy      *string    `json:"clearedBy,omitempty" db:"cleared_by"`
+	ReviewNote     *string    `json:"reviewNote,omitempty" db:"review_note"`
+}
+
+// ClearAbuseFlagRequest is the payload support submits when clearing a flag
+type ClearAbuseFlagRequest struct {
+	ClearedBy  string `json:"clearedBy"`
+	ReviewNote string `json:"reviewNote"`
+}
+
+// Validate checks the clear abuse flag request
+func (r *ClearAbuseFlagRequest) Validate() bool {
+	return r.ClearedBy != ""
+}
@@ -2,11 +2,61 @@ package models
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ContactRelationship is the contact's relationship to the victim, as
+// reported by the contacts integration
+type ContactRelationship string
+
+const (
+	RelationshipSpouse    ContactRelationship = "SPOUSE"
+	RelationshipFamily    ContactRelationship = "FAMILY"
+	RelationshipCaregiver ContactRelationship = "CAREGIVER" // professional caregiver
+	RelationshipNeighbor  ContactRelationship = "NEIGHBOR"
+	RelationshipFriend    ContactRelationship = "FRIEND"
+	RelationshipOther     ContactRelationship = "OTHER"
+)
+
+// ValidRelationship reports whether rel is a known contact relationship
+func ValidRelationship(rel ContactRelationship) bool {
+	switch rel {
+	case RelationshipSpouse, RelationshipFamily, RelationshipCaregiver,
+		RelationshipNeighbor, RelationshipFriend, RelationshipOther:
+		return true
+	}
+	return false
+}
+
+// IsProfessional reports whether the relationship is a professional
+// responder; their acknowledgment satisfies the quorum alone
+func (r ContactRelationship) IsProfessional() bool {
+	return r == RelationshipCaregiver
+}
+
+// Priority orders relationships by how useful the contact typically is in
+// an emergency: the professional caregiver first, then household, then
+// people nearby. Lower is better; unknown relationships sort last
+func (r ContactRelationship) Priority() int {
+	switch r {
+	case RelationshipCaregiver:
+		return 0
+	case RelationshipSpouse:
+		return 1
+	case RelationshipFamily:
+		return 2
+	case RelationshipNeighbor:
+		return 3
+	case RelationshipFriend:
+		return 4
+	default:
+		return 5
+	}
+}
+
 // EmergencyAcknowledgment represents a contact's acknowledgment of an emergency
 type EmergencyAcknowledgment struct {
 	ID           uuid.UUID  `json:"id" db:"id"`
@@ -15,9 +65,13 @@ type EmergencyAcknowledgment struct {
 	ContactName  string     `json:"contact_name" db:"contact_name"`
 	ContactPhone *string    `json:"contact_phone,omitempty" db:"contact_phone"`
 	ContactEmail *string    `json:"contact_email,omitempty" db:"contact_email"`
+	Relationship *ContactRelationship `json:"relationship,omitempty" db:"relationship"`
 	AcknowledgedAt time.Time `json:"acknowledged_at" db:"acknowledged_at"`
 	Location     *Location  `json:"location,omitempty" db:"location"` // Contact's location when acknowledging
 	Message      *string    `json:"message,omitempty" db:"message"`
+	Revoked      bool       `json:"revoked" db:"revoked"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedReason *string   `json:"revoked_reason,omitempty" db:"revoked_reason"`
 }
 
 // CreateAcknowledgmentRequest represents a request to acknowledge an emergency
@@ -27,10 +81,16 @@ type CreateAcknowledgmentRequest struct {
 	ContactName  string    `json:"contact_name"`
 	ContactPhone *string   `json:"contact_phone,omitempty"`
 	ContactEmail *string   `json:"contact_email,omitempty"`
+	Relationship *ContactRelationship `json:"relationship,omitempty"`
 	Location     *Location `json:"location,omitempty"`
 	Message      *string   `json:"message,omitempty"`
 }
 
+// RevokeAcknowledgmentRequest represents a request to revoke an acknowledgment
+type RevokeAcknowledgmentRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
 // AcknowledgmentResponse represents the API response for an acknowledgment
 type AcknowledgmentResponse struct {
 	Acknowledgment EmergencyAcknowledgment `json:"acknowledgment"`
@@ -56,6 +116,10 @@ func (a *EmergencyAcknowledgment) Validate() error {
 		return errors.New("at least one contact method (phone or email) is required")
 	}
 
+	if a.Relationship != nil && !ValidRelationship(*a.Relationship) {
+		return errors.New("relationship must be one of SPOUSE, FAMILY, CAREGIVER, NEIGHBOR, FRIEND, OTHER")
+	}
+
 	// Validate location if provided
 	if a.Location != nil {
 		if a.Location.Latitude < -90 || a.Location.Latitude > 90 {
@@ -69,11 +133,49 @@ func (a *EmergencyAcknowledgment) Validate() error {
 	return nil
 }
 
+// IsRevoked returns true if the acknowledgment has been revoked
+func (a *EmergencyAcknowledgment) IsRevoked() bool {
+	return a.Revoked
+}
+
+// relationshipPriority is the sort rank of an acknowledgment; missing
+// relationships sort last
+func (a *EmergencyAcknowledgment) relationshipPriority() int {
+	if a.Relationship == nil {
+		return RelationshipOther.Priority() + 1
+	}
+	return a.Relationship.Priority()
+}
+
+// SortByRelationship orders acknowledgments by relationship priority
+// (professional caregiver first), breaking ties by acknowledgment time, so
+// responders see the most useful contacts at the top
+func SortByRelationship(acks []EmergencyAcknowledgment) {
+	sort.SliceStable(acks, func(i, j int) bool {
+		pi, pj := acks[i].relationshipPriority(), acks[j].relationshipPriority()
+		if pi != pj {
+			return pi < pj
+		}
+		return acks[i].AcknowledgedAt.Before(acks[j].AcknowledgedAt)
+	})
+}
+
+// QuorumStatus reports how far an emergency is from satisfying its
+// acknowledgment quorum (K-of-N contacts acknowledged). A professional
+// caregiver's acknowledgment satisfies the quorum on its own
+type QuorumStatus struct {
+	Required                 int  `json:"required"`
+	Acknowledged             int  `json:"acknowledged"`
+	ProfessionalAcknowledged bool `json:"professional_acknowledged"`
+	Satisfied                bool `json:"satisfied"`
+}
+
 // ContactAcknowledgedEvent represents a Kafka event for contact acknowledgment
 type ContactAcknowledgedEvent struct {
 	EmergencyID    uuid.UUID `json:"emergency_id"`
 	ContactID      uuid.UUID `json:"contact_id"`
 	ContactName    string    `json:"contact_name"`
+	Relationship   *ContactRelationship `json:"relationship,omitempty"`
 	AcknowledgedAt time.Time `json:"acknowledged_at"`
 	Location       *Location `json:"location,omitempty"`
 	Message        *string   `json:"message,omitempty"`
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutoResolveCandidate pairs an unattended ACTIVE emergency with its
+// operator approval state, so the auto-resolution sweep can tell which
+// critical emergencies are cleared to close
+type AutoResolveCandidate struct {
+	Emergency  Emergency  `json:"emergency"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+}
+
+// ApproveAutoResolveRequest is the operator sign-off for auto-resolving a
+// critical emergency
+type ApproveAutoResolveRequest struct {
+	OperatorID   uuid.UUID `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
+}
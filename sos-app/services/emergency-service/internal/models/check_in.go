@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CheckInStatus represents the lifecycle state of a scheduled check-in
+type CheckInStatus string
+
+const (
+	CheckInStatusScheduled CheckInStatus = "SCHEDULED"
+	CheckInStatusCompleted CheckInStatus = "COMPLETED"
+)
+
+// Check-in reminder bounds
+const (
+	// MaxCheckInReminderMinutes bounds how far before the deadline a
+	// reminder offset may be configured
+	MaxCheckInReminderMinutes = 60
+	// MaxCheckInReminders bounds how many reminder offsets a user may
+	// configure per check-in deadline
+	MaxCheckInReminders = 5
+	// MaxCheckInSnoozeMinutes bounds how far a single snooze may push the
+	// deadline
+	MaxCheckInSnoozeMinutes = 60
+	// DefaultCheckInSnoozeMinutes is used when a snooze request omits the
+	// duration
+	DefaultCheckInSnoozeMinutes = 10
+)
+
+// DefaultCheckInReminderMinutes are the minutes-before-deadline offsets used
+// when the user has not configured their own
+var DefaultCheckInReminderMinutes = []int{10, 2}
+
+// ScheduledCheckIn represents a deadline the user promised to report back
+// by. Reminders are published as the deadline approaches; RemindersSent
+// records which offsets already fired so the sweep never double-sends
+type ScheduledCheckIn struct {
+	ID            uuid.UUID     `json:"id" db:"id"`
+	UserID        uuid.UUID     `json:"user_id" db:"user_id"`
+	DeadlineAt    time.Time     `json:"deadline_at" db:"deadline_at"`
+	Status        CheckInStatus `json:"status" db:"status"`
+	SnoozeCount   int           `json:"snooze_count" db:"snooze_count"`
+	RemindersSent []int         `json:"reminders_sent" db:"reminders_sent"`
+	CompletedAt   *time.Time    `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// ReminderSent reports whether the reminder for a minutes-before offset has
+// already been published for this deadline
+func (c *ScheduledCheckIn) ReminderSent(minutesBefore int) bool {
+	for _, sent := range c.RemindersSent {
+		if sent == minutesBefore {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleCheckInRequest represents a request to schedule a check-in
+type ScheduleCheckInRequest struct {
+	UserID     uuid.UUID `json:"user_id"`
+	DeadlineAt time.Time `json:"deadline_at"`
+}
+
+// Validate validates the schedule request
+func (r *ScheduleCheckInRequest) Validate() error {
+	if r.UserID == uuid.Nil {
+		return errors.New("user_id is required")
+	}
+	if r.DeadlineAt.IsZero() {
+		return errors.New("deadline_at is required")
+	}
+	if !r.DeadlineAt.After(time.Now()) {
+		return errors.New("deadline_at must be in the future")
+	}
+	return nil
+}
+
+// SnoozeCheckInRequest represents a request to push a check-in deadline
+// forward. A zero Minutes uses the default snooze duration
+type SnoozeCheckInRequest struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Minutes int       `json:"minutes,omitempty"`
+}
+
+// Validate validates the snooze request
+func (r *SnoozeCheckInRequest) Validate() error {
+	if r.UserID == uuid.Nil {
+		return errors.New("user_id is required")
+	}
+	if r.Minutes < 0 || r.Minutes > MaxCheckInSnoozeMinutes {
+		return errors.New("minutes must be between 0 and 60")
+	}
+	return nil
+}
+
+// CompleteCheckInRequest represents a request to mark a check-in done
+type CompleteCheckInRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
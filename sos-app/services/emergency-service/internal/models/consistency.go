@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event ledger event types, matching the Kafka event names the producer
+// publishes
+const (
+	LedgerEventEmergencyCreated   = "EmergencyCreated"
+	LedgerEventEmergencyResolved  = "EmergencyResolved"
+	LedgerEventEmergencyCancelled = "EmergencyCancelled"
+	LedgerEventEmergencyEscalated = "EmergencyEscalated"
+)
+
+// ConsistencyFinding is one discrepancy found by the nightly consistency
+// checker: an emergency for which some cross-service expectation does not
+// hold. Since is when the expectation last held (or when it should have
+// started holding), so operators can judge how long the discrepancy has
+// existed
+type ConsistencyFinding struct {
+	EmergencyID uuid.UUID `json:"emergency_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Since       time.Time `json:"since"`
+}
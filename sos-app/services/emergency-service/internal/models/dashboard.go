@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DashboardEntry is one row of the read-optimized dashboard projection: an
+// active emergency denormalized with its latest location, acknowledgment
+// count and escalation level. The projection worker maintains it from the
+// service's own Kafka events; rows disappear when the emergency ends
+type DashboardEntry struct {
+	EmergencyID         uuid.UUID     `json:"emergency_id"`
+	UserID              uuid.UUID     `json:"user_id"`
+	EmergencyType       EmergencyType `json:"emergency_type"`
+	Latitude            float64       `json:"latitude"`
+	Longitude           float64       `json:"longitude"`
+	AutoTriggered       bool          `json:"auto_triggered"`
+	AckCount            int           `json:"ack_count"`
+	EscalationLevel     int           `json:"escalation_level"`
+	EscalationLevelName *string       `json:"escalation_level_name,omitempty"`
+	LastLocationAt      *time.Time    `json:"last_location_at,omitempty"`
+	ActivatedAt         time.Time     `json:"activated_at"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErasureStatus represents the state of a data erasure request
+type ErasureStatus string
+
+const (
+	ErasureStatusScheduled ErasureStatus = "SCHEDULED"
+	ErasureStatusCompleted ErasureStatus = "COMPLETED"
+	ErasureStatusCancelled ErasureStatus = "CANCELLED"
+)
+
+// ErasureRequest is a scheduled GDPR/CCPA deletion request for a user
+type ErasureRequest struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	UserID         uuid.UUID     `json:"user_id" db:"user_id"`
+	Status         ErasureStatus `json:"status" db:"status"`
+	LegalHoldUntil *time.Time    `json:"legal_hold_until,omitempty" db:"legal_hold_until"`
+	RequestedAt    time.Time     `json:"requested_at" db:"requested_at"`
+	ProcessedAt    *time.Time    `json:"processed_at,omitempty" db:"processed_at"`
+}
+
+// ErasureCompletion records that one service finished purging a user's data
+// for an erasure request
+type ErasureCompletion struct {
+	RequestID     uuid.UUID `json:"request_id" db:"request_id"`
+	Service       string    `json:"service" db:"service"`
+	RecordsPurged int64     `json:"records_purged" db:"records_purged"`
+	CompletedAt   time.Time `json:"completed_at" db:"completed_at"`
+}
+
+// ErasureSagaStatus reports an erasure request together with which services
+// have confirmed their purge so far
+type ErasureSagaStatus struct {
+	Request     ErasureRequest      `json:"request"`
+	Completions []ErasureCompletion `json:"completions"`
+}
+
+// ScheduleErasureRequest is the payload for scheduling a deletion
+type ScheduleErasureRequest struct {
+	LegalHoldUntil *time.Time `json:"legal_hold_until,omitempty"`
+}
+
+// UserDataExport is the machine-readable archive returned for a data
+// subject access request
+type UserDataExport struct {
+	UserID                 uuid.UUID                 `json:"user_id"`
+	GeneratedAt            time.Time                 `json:"generated_at"`
+	Emergencies            []Emergency               `json:"emergencies"`
+	AcknowledgmentsAsContact []EmergencyAcknowledgment `json:"acknowledgments_as_contact"`
+	Preferences            *UserPreferences          `json:"preferences,omitempty"`
+	ErasureRequests        []ErasureRequest          `json:"erasure_requests"`
+}
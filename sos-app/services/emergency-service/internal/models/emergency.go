@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sos-app/pkg/common/geo"
 )
 
 // EmergencyType represents the type of emergency
@@ -31,33 +32,162 @@ const (
 	StatusResolved  EmergencyStatus = "RESOLVED"  // Emergency resolved
 )
 
-// Location represents a geographic location
-type Location struct {
-	Latitude  float64  `json:"latitude"`
-	Longitude float64  `json:"longitude"`
-	Accuracy  *float64 `json:"accuracy,omitempty"`   // meters
-	Altitude  *float64 `json:"altitude,omitempty"`   // meters
-	Address   *string  `json:"address,omitempty"`    // reverse geocoded address
-	Timestamp time.Time `json:"timestamp"`
+// Location is the shared geographic location type; the alias keeps existing
+// references while the struct itself lives in pkg/common
+type Location = geo.Location
+
+// FacilityCategory identifies the kind of emergency facility
+type FacilityCategory string
+
+const (
+	FacilityHospital    FacilityCategory = "hospital"
+	FacilityPolice      FacilityCategory = "police"
+	FacilityFireStation FacilityCategory = "fire_station"
+)
+
+// NearbyFacility is one emergency facility resolved near the initial location
+type NearbyFacility struct {
+	Category       FacilityCategory `json:"category"`
+	Name           string           `json:"name"`
+	Latitude       float64          `json:"latitude"`
+	Longitude      float64          `json:"longitude"`
+	DistanceMeters float64          `json:"distance_meters"`
+	Address        *string          `json:"address,omitempty"`
+	Phone          *string          `json:"phone,omitempty"`
+}
+
+// NearbyFacilities is the per-emergency facility snapshot (PostgreSQL JSONB)
+type NearbyFacilities []NearbyFacility
+
+// Value implements driver.Valuer for NearbyFacilities
+func (f NearbyFacilities) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements sql.Scanner for NearbyFacilities
+func (f *NearbyFacilities) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan NearbyFacilities: invalid type")
+	}
+
+	return json.Unmarshal(bytes, f)
 }
 
-// Value implements driver.Valuer for Location (PostgreSQL JSONB)
-func (l Location) Value() (driver.Value, error) {
-	return json.Marshal(l)
+// WeatherContext is the weather and daylight snapshot taken at activation
+// (PostgreSQL JSONB); responders read conditions differently for a fall
+// outdoors at night in freezing rain than at midday
+type WeatherContext struct {
+	TemperatureC    *float64   `json:"temperature_c,omitempty"`
+	Conditions      string     `json:"conditions,omitempty"` // e.g. "clear", "rain", "snow"
+	WindKph         *float64   `json:"wind_kph,omitempty"`
+	PrecipitationMm *float64   `json:"precipitation_mm,omitempty"`
+	Sunrise         *time.Time `json:"sunrise,omitempty"`
+	Sunset          *time.Time `json:"sunset,omitempty"`
+	IsDaylight      *bool      `json:"is_daylight,omitempty"` // derived from sunrise/sunset
+	RetrievedAt     time.Time  `json:"retrieved_at"`
 }
 
-// Scan implements sql.Scanner for Location (PostgreSQL JSONB)
-func (l *Location) Scan(value interface{}) error {
+// Value implements driver.Valuer for WeatherContext
+func (w WeatherContext) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
+
+// Scan implements sql.Scanner for WeatherContext
+func (w *WeatherContext) Scan(value interface{}) error {
 	if value == nil {
 		return nil
 	}
 
 	bytes, ok := value.([]byte)
 	if !ok {
-		return errors.New("failed to scan Location: invalid type")
+		return errors.New("failed to scan WeatherContext: invalid type")
 	}
 
-	return json.Unmarshal(bytes, l)
+	return json.Unmarshal(bytes, w)
+}
+
+// CallBridge is the audio conference bridge created at the highest
+// escalation level (PostgreSQL JSONB); contacts and optionally the victim
+// are dialed in, and late responders use the dial-in details to join
+type CallBridge struct {
+	Provider     string    `json:"provider"` // e.g. "twilio"
+	ConferenceID string    `json:"conference_id"`
+	DialIn       string    `json:"dial_in,omitempty"` // number late joiners can call
+	AccessCode   string    `json:"access_code,omitempty"`
+	Participants []string  `json:"participants,omitempty"` // numbers dialed into the bridge
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Value implements driver.Valuer for CallBridge
+func (b CallBridge) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
+
+// Scan implements sql.Scanner for CallBridge
+func (b *CallBridge) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan CallBridge: invalid type")
+	}
+
+	return json.Unmarshal(bytes, b)
+}
+
+// ActivationCheckOutcome classifies how one pre-activation hook concluded
+type ActivationCheckOutcome string
+
+const (
+	CheckPassed ActivationCheckOutcome = "PASSED"
+	CheckFailed ActivationCheckOutcome = "FAILED"
+	CheckError  ActivationCheckOutcome = "ERROR" // hook itself failed; activation proceeds
+)
+
+// ActivationCheck is the recorded outcome of one pre-activation hook. Checks
+// are advisory: a FAILED or ERROR outcome never blocks activation, it only
+// documents what the service knew when the countdown completed
+type ActivationCheck struct {
+	Name       string                 `json:"name"`
+	Outcome    ActivationCheckOutcome `json:"outcome"`
+	Detail     string                 `json:"detail,omitempty"`
+	CheckedAt  time.Time              `json:"checked_at"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+// ActivationChecks is the per-emergency hook audit trail (PostgreSQL JSONB)
+type ActivationChecks []ActivationCheck
+
+// Value implements driver.Valuer for ActivationChecks
+func (c ActivationChecks) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner for ActivationChecks
+func (c *ActivationChecks) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan ActivationChecks: invalid type")
+	}
+
+	return json.Unmarshal(bytes, c)
 }
 
 // Emergency represents an emergency alert
@@ -69,7 +199,8 @@ type Emergency struct {
 	InitialLocation  Location        `json:"initial_location" db:"initial_location"`
 	InitialMessage   *string         `json:"initial_message,omitempty" db:"initial_message"`
 	AutoTriggered    bool            `json:"auto_triggered" db:"auto_triggered"`
-	TriggeredBy      string          `json:"triggered_by" db:"triggered_by"` // user, device:dev_123, system
+	TriggeredBy      string          `json:"triggered_by" db:"triggered_by"` // registered trigger source, see trigger_source.go
+	IsTest           bool            `json:"is_test" db:"is_test"` // Drill/test-mode, excluded from stats and connectors
 	CountdownSeconds int             `json:"countdown_seconds" db:"countdown_seconds"`
 	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
 	ActivatedAt      *time.Time      `json:"activated_at,omitempty" db:"activated_at"`
@@ -77,17 +208,38 @@ type Emergency struct {
 	ResolvedAt       *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
 	ResolutionNotes  *string         `json:"resolution_notes,omitempty" db:"resolution_notes"`
 	Metadata         *json.RawMessage `json:"metadata,omitempty" db:"metadata"` // Additional context data
+	ClaimedBy        *uuid.UUID      `json:"claimed_by,omitempty" db:"claimed_by"` // Operator who took over
+	ClaimedByName    *string         `json:"claimed_by_name,omitempty" db:"claimed_by_name"`
+	ClaimedAt        *time.Time      `json:"claimed_at,omitempty" db:"claimed_at"`
+	NearbyFacilities NearbyFacilities `json:"nearby_facilities,omitempty" db:"nearby_facilities"` // Nearest hospital/police/fire, set at activation
+	WeatherContext   *WeatherContext `json:"weather_context,omitempty" db:"weather_context"` // Weather/daylight snapshot, set at activation
+	CallBridge       *CallBridge     `json:"call_bridge,omitempty" db:"call_bridge"` // Conference bridge, set at escalation
+	ActivationChecks ActivationChecks `json:"activation_checks,omitempty" db:"activation_checks"` // Pre-activation hook outcomes, set at activation
+	AutoResolved     bool            `json:"auto_resolved" db:"auto_resolved"` // Resolved by the auto-resolution policy, not a person
 }
 
+// MaxCandidateLocations caps how many candidate fixes a trigger may carry
+const MaxCandidateLocations = 5
+
 // CreateEmergencyRequest represents a request to create a new emergency
 type CreateEmergencyRequest struct {
 	UserID           uuid.UUID     `json:"user_id"`
 	EmergencyType    EmergencyType `json:"emergency_type"`
 	Location         Location      `json:"location"`
+	CandidateLocations []Location  `json:"candidate_locations,omitempty"` // Recent fixes from several providers
 	InitialMessage   *string       `json:"initial_message,omitempty"`
 	AutoTriggered    bool          `json:"auto_triggered"`
 	TriggeredBy      string        `json:"triggered_by"`
 	CountdownSeconds *int          `json:"countdown_seconds,omitempty"` // Optional override
+	IsTest           bool          `json:"is_test"`                     // Drill/test-mode emergency
+	ConfirmRetrigger bool          `json:"confirm_retrigger,omitempty"` // Bypass the post-resolution cool-off
+	Metadata         *json.RawMessage `json:"metadata,omitempty"`       // Type-specific context, validated per schema
+}
+
+// ClaimEmergencyRequest represents an operator takeover request
+type ClaimEmergencyRequest struct {
+	OperatorID   uuid.UUID `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
 }
 
 // UpdateEmergencyRequest represents a request to update an emergency
@@ -99,7 +251,9 @@ type UpdateEmergencyRequest struct {
 // EmergencyResponse represents the API response for an emergency
 type EmergencyResponse struct {
 	Emergency       Emergency                `json:"emergency"`
+	StatusLabel     string                    `json:"statusLabel,omitempty"` // Localized per the request's Accept-Language
 	Acknowledgments []EmergencyAcknowledgment `json:"acknowledgments,omitempty"`
+	Quorum          *QuorumStatus             `json:"quorum,omitempty"`
 }
 
 // EmergencyListResponse represents a paginated list of emergencies
@@ -110,6 +264,15 @@ type EmergencyListResponse struct {
 	PageSize    int         `json:"page_size"`
 }
 
+// EmergencyStats aggregates emergency counts for analytics dashboards.
+// Test-mode emergencies are excluded
+type EmergencyStats struct {
+	Total                int                     `json:"total"`
+	ByStatus             map[EmergencyStatus]int `json:"by_status"`
+	ByType               map[EmergencyType]int   `json:"by_type"`
+	AvgResolutionSeconds *float64                `json:"avg_resolution_seconds,omitempty"`
+}
+
 // HistoryFilters represents filters for emergency history queries
 type HistoryFilters struct {
 	UserID    uuid.UUID
@@ -117,10 +280,60 @@ type HistoryFilters struct {
 	Type      *EmergencyType
 	StartDate *time.Time
 	EndDate   *time.Time
+	Search    *string // Full-text query over messages, notes and metadata
 	Page      int
 	PageSize  int
 }
 
+// SelectBestLocation chooses the best candidate fix by provider priority,
+// breaking ties within a provider by reported accuracy
+func SelectBestLocation(candidates []Location) Location {
+	best := candidates[0]
+	for _, current := range candidates[1:] {
+		if geo.ProviderPriority(current.Provider) > geo.ProviderPriority(best.Provider) {
+			best = current
+			continue
+		}
+
+		if current.Provider == best.Provider {
+			if current.Accuracy != nil && best.Accuracy != nil && *current.Accuracy < *best.Accuracy {
+				best = current
+			}
+		}
+	}
+	return best
+}
+
+// AttachLocationCandidates merges the candidate fixes into the metadata
+// document under "location_candidates" for forensic use
+func AttachLocationCandidates(metadata *json.RawMessage, candidates []Location) (*json.RawMessage, error) {
+	doc := map[string]interface{}{}
+	if metadata != nil && len(*metadata) > 0 {
+		if err := json.Unmarshal(*metadata, &doc); err != nil {
+			return nil, errors.New("metadata is not a JSON object")
+		}
+	}
+
+	doc["location_candidates"] = candidates
+	merged, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := json.RawMessage(merged)
+	return &raw, nil
+}
+
+// isValidEmergencyType reports whether the type is a known emergency type
+func isValidEmergencyType(emergencyType EmergencyType) bool {
+	switch emergencyType {
+	case EmergencyTypeMedical, EmergencyTypeFire, EmergencyTypePolice,
+		EmergencyTypeGeneral, EmergencyTypeFallDetected, EmergencyTypeDeviceAlert:
+		return true
+	}
+	return false
+}
+
 // Validate validates the emergency data
 func (e *Emergency) Validate() error {
 	if e.UserID == uuid.Nil {
@@ -164,6 +377,13 @@ func (e *Emergency) Validate() error {
 		return errors.New("invalid status")
 	}
 
+	// Validate trigger source against the registry
+	if e.TriggeredBy != "" {
+		if _, _, err := ParseTriggerSource(e.TriggeredBy); err != nil {
+			return err
+		}
+	}
+
 	// Validate location
 	if e.InitialLocation.Latitude < -90 || e.InitialLocation.Latitude > 90 {
 		return errors.New("invalid latitude: must be between -90 and 90")
@@ -194,6 +414,23 @@ func (e *Emergency) CanBeCancelled() bool {
 	return e.Status == StatusPending || e.Status == StatusActive
 }
 
+// TriggeredByDevice formats the TriggeredBy value for an emergency raised
+// by a paired device, e.g. "device:dev_123"
+func TriggeredByDevice(deviceID string) string {
+	return "device:" + deviceID
+}
+
+// WasTriggeredByDevice returns true if the emergency was triggered by the
+// given device
+func (e *Emergency) WasTriggeredByDevice(deviceID string) bool {
+	return e.TriggeredBy == TriggeredByDevice(deviceID)
+}
+
+// IsClaimed returns true if an operator has taken over the emergency
+func (e *Emergency) IsClaimed() bool {
+	return e.ClaimedBy != nil
+}
+
 // CanBeResolved returns true if the emergency can be resolved
 func (e *Emergency) CanBeResolved() bool {
 	return e.Status == StatusActive
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncidentLink records that an emergency was merged into another one's
+// incident. Split links are kept with their unlink fields set, forming the
+// audit trail of every merge and split decision
+type IncidentLink struct {
+	ID                 uuid.UUID  `json:"id" db:"id"`
+	PrimaryEmergencyID uuid.UUID  `json:"primary_emergency_id" db:"primary_emergency_id"`
+	LinkedEmergencyID  uuid.UUID  `json:"linked_emergency_id" db:"linked_emergency_id"`
+	LinkedBy           uuid.UUID  `json:"linked_by" db:"linked_by"`
+	LinkedByName       string     `json:"linked_by_name" db:"linked_by_name"`
+	Reason             *string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UnlinkedAt         *time.Time `json:"unlinked_at,omitempty" db:"unlinked_at"`
+	UnlinkedBy         *uuid.UUID `json:"unlinked_by,omitempty" db:"unlinked_by"`
+	UnlinkedByName     *string    `json:"unlinked_by_name,omitempty" db:"unlinked_by_name"`
+	UnlinkReason       *string    `json:"unlink_reason,omitempty" db:"unlink_reason"`
+}
+
+// LinkIncidentRequest is the request body for merging an emergency into an
+// incident
+type LinkIncidentRequest struct {
+	EmergencyID  uuid.UUID `json:"emergency_id"`
+	OperatorID   uuid.UUID `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// UnlinkIncidentRequest is the request body for splitting an emergency out
+// of an incident
+type UnlinkIncidentRequest struct {
+	OperatorID   uuid.UUID `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// IncidentTimelineEvent is one entry in a merged incident's shared timeline
+type IncidentTimelineEvent struct {
+	Timestamp   time.Time `json:"timestamp"`
+	EmergencyID uuid.UUID `json:"emergency_id"`
+	Type        string    `json:"type"`
+	Detail      string    `json:"detail,omitempty"`
+}
+
+// MergedIncident is the combined view of a primary emergency and everything
+// merged into it: the member emergencies, one shared timeline, and the
+// location room references responders subscribe to for the whole incident
+type MergedIncident struct {
+	PrimaryEmergencyID uuid.UUID               `json:"primary_emergency_id"`
+	Emergencies        []Emergency             `json:"emergencies"`
+	Links              []IncidentLink          `json:"links"`
+	Timeline           []IncidentTimelineEvent `json:"timeline"`
+	// LocationRooms are the location-service room IDs (one per member
+	// emergency) a responder console joins to see every trail
+	LocationRooms []uuid.UUID `json:"location_rooms"`
+}
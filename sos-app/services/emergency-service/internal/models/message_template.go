@@ -0,0 +1,87 @@
+package models
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateEventType identifies the lifecycle event a message template applies to
+type TemplateEventType string
+
+const (
+	TemplateEventEmergencyCreated   TemplateEventType = "emergency.created"
+	TemplateEventEmergencyResolved  TemplateEventType = "emergency.resolved"
+	TemplateEventEmergencyCancelled TemplateEventType = "emergency.cancelled"
+)
+
+// isValidTemplateEventType checks whether the event type is supported
+func isValidTemplateEventType(eventType TemplateEventType) bool {
+	switch eventType {
+	case TemplateEventEmergencyCreated, TemplateEventEmergencyResolved, TemplateEventEmergencyCancelled:
+		return true
+	}
+	return false
+}
+
+// templateVariablePattern matches {{variable}} placeholders in subjects and bodies
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// MessageTemplate is an organization's branded wording for one lifecycle
+// event in one locale. Subject and body carry {{variable}} placeholders
+// substituted when the event is published
+type MessageTemplate struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	OrgID     uuid.UUID         `json:"orgId" db:"org_id"`
+	EventType TemplateEventType `json:"eventType" db:"event_type"`
+	Locale    string            `json:"locale" db:"locale"`
+	Subject   string            `json:"subject" db:"subject"`
+	Body      string            `json:"body" db:"body"`
+	Enabled   bool              `json:"enabled" db:"enabled"`
+	CreatedAt time.Time         `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time         `json:"updatedAt" db:"updated_at"`
+}
+
+// RenderSubject substitutes variables into the template subject
+func (t *MessageTemplate) RenderSubject(vars map[string]string) string {
+	return substituteVariables(t.Subject, vars)
+}
+
+// RenderBody substitutes variables into the template body
+func (t *MessageTemplate) RenderBody(vars map[string]string) string {
+	return substituteVariables(t.Body, vars)
+}
+
+// substituteVariables replaces {{name}} placeholders with their values;
+// unknown placeholders render as empty rather than leaking braces to users
+func substituteVariables(text string, vars map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		return vars[name]
+	})
+}
+
+// UpsertTemplateRequest is the payload for creating or replacing a message
+// template for an (org, event type, locale) slot
+type UpsertTemplateRequest struct {
+	OrgID     uuid.UUID         `json:"orgId"`
+	EventType TemplateEventType `json:"eventType"`
+	Locale    string            `json:"locale"`
+	Subject   string            `json:"subject"`
+	Body      string            `json:"body"`
+}
+
+// Validate checks the upsert template request
+func (r *UpsertTemplateRequest) Validate() bool {
+	if r.OrgID == uuid.Nil {
+		return false
+	}
+	if !isValidTemplateEventType(r.EventType) {
+		return false
+	}
+	if r.Locale == "" || len(r.Locale) > 10 {
+		return false
+	}
+	return r.Subject != "" && r.Body != ""
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MetadataFieldSchema describes a single allowed metadata field for an
+// emergency type: its JSON type, whether it is required at trigger time, and
+// a human-readable description surfaced via GET /emergency/types.
+type MetadataFieldSchema struct {
+	Type        string `json:"type"` // string, number, boolean, object
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// MetadataSchema maps allowed metadata field names to their schemas.
+type MetadataSchema map[string]MetadataFieldSchema
+
+// FieldError is a field-level validation error returned for malformed metadata.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// metadataSchemas defines the allowed metadata per emergency type. Fields not
+// listed here are rejected: a FIRE emergency shouldn't carry vitals, and a
+// FALL_DETECTED one must carry confidence.
+var metadataSchemas = map[EmergencyType]MetadataSchema{
+	EmergencyTypeMedical: {
+		"vitals":     {Type: "object", Description: "Latest vital signs snapshot (heart rate, SpO2, ...)"},
+		"symptoms":   {Type: "string", Description: "Free-text description of symptoms"},
+		"blood_type": {Type: "string", Description: "Blood type of the user, if known"},
+		"allergies":  {Type: "string", Description: "Known allergies relevant to responders"},
+	},
+	EmergencyTypeFire: {
+		"smoke_detected": {Type: "boolean", Description: "Whether a smoke detector triggered"},
+		"room":           {Type: "string", Description: "Room or area where the fire was reported"},
+		"people_inside":  {Type: "number", Description: "Estimated number of people still inside"},
+	},
+	EmergencyTypePolice: {
+		"description": {Type: "string", Description: "Description of the incident"},
+		"suspects":    {Type: "number", Description: "Number of suspects involved"},
+	},
+	EmergencyTypeGeneral: {
+		"note": {Type: "string", Description: "Additional free-text context"},
+	},
+	EmergencyTypeFallDetected: {
+		"confidence":   {Type: "number", Required: true, Description: "Fall detection confidence between 0 and 1"},
+		"impact_force": {Type: "number", Description: "Measured impact force in g"},
+		"device_id":    {Type: "string", Description: "Device that detected the fall"},
+	},
+	EmergencyTypeDeviceAlert: {
+		"device_id":  {Type: "string", Required: true, Description: "Device that raised the alert"},
+		"alert_code": {Type: "string", Description: "Device-specific alert code"},
+		"event_data": {Type: "object", Description: "Raw event payload from the device"},
+	},
+}
+
+// MetadataSchemaFor returns the metadata schema for an emergency type. The
+// second return value is false for unknown types.
+func MetadataSchemaFor(emergencyType EmergencyType) (MetadataSchema, bool) {
+	schema, ok := metadataSchemas[emergencyType]
+	return schema, ok
+}
+
+// MetadataSchemas returns the full type-to-schema mapping for documentation
+// endpoints.
+func MetadataSchemas() map[EmergencyType]MetadataSchema {
+	return metadataSchemas
+}
+
+// ValidateMetadata checks raw metadata against the schema for the emergency
+// type and returns field-level errors. A nil/empty metadata payload is valid
+// unless the schema has required fields.
+func ValidateMetadata(emergencyType EmergencyType, raw *json.RawMessage) []FieldError {
+	schema, ok := metadataSchemas[emergencyType]
+	if !ok {
+		// Unknown types are caught by Emergency.Validate; nothing to check here
+		return nil
+	}
+
+	fields := map[string]interface{}{}
+	if raw != nil && len(*raw) > 0 {
+		if err := json.Unmarshal(*raw, &fields); err != nil {
+			return []FieldError{{Field: "metadata", Message: "metadata must be a JSON object"}}
+		}
+	}
+
+	var errs []FieldError
+
+	// Reject fields the type does not allow, and type-check the rest
+	for name, value := range fields {
+		fieldSchema, allowed := schema[name]
+		if !allowed {
+			errs = append(errs, FieldError{
+				Field:   name,
+				Message: fmt.Sprintf("field is not allowed for emergency type %s", emergencyType),
+			})
+			continue
+		}
+
+		if !matchesType(value, fieldSchema.Type) {
+			errs = append(errs, FieldError{
+				Field:   name,
+				Message: fmt.Sprintf("expected %s", fieldSchema.Type),
+			})
+		}
+	}
+
+	// Enforce required fields
+	for name, fieldSchema := range schema {
+		if fieldSchema.Required {
+			if _, present := fields[name]; !present {
+				errs = append(errs, FieldError{Field: name, Message: "field is required"})
+			}
+		}
+	}
+
+	return errs
+}
+
+// matchesType checks a decoded JSON value against a schema type name
+func matchesType(value interface{}, schemaType string) bool {
+	if value == nil {
+		return false
+	}
+
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannel identifies how a contact was notified
+type NotificationChannel string
+
+const (
+	ChannelSMS   NotificationChannel = "SMS"
+	ChannelPush  NotificationChannel = "PUSH"
+	ChannelEmail NotificationChannel = "EMAIL"
+	ChannelVoice NotificationChannel = "VOICE"
+)
+
+// NotificationDeliveryState is the delivery outcome for one channel
+type NotificationDeliveryState string
+
+const (
+	DeliverySent      NotificationDeliveryState = "SENT"
+	DeliveryDelivered NotificationDeliveryState = "DELIVERED"
+	DeliveryFailed    NotificationDeliveryState = "FAILED"
+)
+
+// NotificationStatus is the latest delivery outcome for one contact on one
+// channel of one emergency
+type NotificationStatus struct {
+	ID          uuid.UUID                 `json:"id" db:"id"`
+	EmergencyID uuid.UUID                 `json:"emergency_id" db:"emergency_id"`
+	ContactID   uuid.UUID                 `json:"contact_id" db:"contact_id"`
+	ContactName string                    `json:"contact_name" db:"contact_name"`
+	Channel     NotificationChannel       `json:"channel" db:"channel"`
+	Status      NotificationDeliveryState `json:"status" db:"status"`
+	Error       *string                   `json:"error,omitempty" db:"error"`
+	UpdatedAt   time.Time                 `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the status fields are usable
+func (n *NotificationStatus) Validate() error {
+	if n.EmergencyID == uuid.Nil {
+		return errors.New("emergency_id is required")
+	}
+	if n.ContactID == uuid.Nil {
+		return errors.New("contact_id is required")
+	}
+	if n.Channel == "" {
+		return errors.New("channel is required")
+	}
+	switch n.Status {
+	case DeliverySent, DeliveryDelivered, DeliveryFailed:
+		return nil
+	default:
+		return errors.New("invalid delivery status")
+	}
+}
+
+// CountdownPushReceiptEvent is the delivery receipt for the countdown-start
+// push sent to the victim's own phone. Unlike contact notifications there is
+// no contact: the receipt only says whether the user can see the countdown
+type CountdownPushReceiptEvent struct {
+	EmergencyID uuid.UUID                 `json:"emergency_id"`
+	Status      NotificationDeliveryState `json:"status"`
+	Timestamp   time.Time                 `json:"timestamp"`
+}
+
+// NotificationStatusEvent represents a Kafka event for one delivery outcome
+type NotificationStatusEvent struct {
+	EmergencyID uuid.UUID                 `json:"emergency_id"`
+	ContactID   uuid.UUID                 `json:"contact_id"`
+	ContactName string                    `json:"contact_name"`
+	Channel     NotificationChannel       `json:"channel"`
+	Status      NotificationDeliveryState `json:"status"`
+	Error       *string                   `json:"error,omitempty"`
+	Timestamp   time.Time                 `json:"timestamp"`
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCountdownSeconds bounds user-configured countdown durations
+const MaxCountdownSeconds = 300
+
+// MaxAckQuorum bounds per-type acknowledgment quorums
+const MaxAckQuorum = 10
+
+// UserPreferences represents a user's emergency preferences, consulted by
+// TriggerEmergency when the request omits explicit overrides
+type UserPreferences struct {
+	UserID                  uuid.UUID                `json:"user_id" db:"user_id"`
+	DefaultCountdownSeconds *int                     `json:"default_countdown_seconds,omitempty" db:"default_countdown_seconds"`
+	SilentMode              bool                     `json:"silent_mode" db:"silent_mode"`
+	QuickActionTypes        []EmergencyType          `json:"quick_action_types" db:"quick_action_types"`
+	MessageTemplates        map[EmergencyType]string `json:"message_templates" db:"message_templates"`
+	AckQuorums              map[EmergencyType]int    `json:"ack_quorums" db:"ack_quorums"`
+	VictimPhone             *string                  `json:"victim_phone,omitempty" db:"victim_phone"`
+	CheckInReminderMinutes  []int                    `json:"check_in_reminder_minutes,omitempty" db:"check_in_reminder_minutes"`
+	CreatedAt               time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time                `json:"updated_at" db:"updated_at"`
+}
+
+// UpdatePreferencesRequest represents a request to update user preferences
+type UpdatePreferencesRequest struct {
+	UserID                  uuid.UUID                `json:"user_id"`
+	DefaultCountdownSeconds *int                     `json:"default_countdown_seconds,omitempty"`
+	SilentMode              bool                     `json:"silent_mode"`
+	QuickActionTypes        []EmergencyType          `json:"quick_action_types,omitempty"`
+	MessageTemplates        map[EmergencyType]string `json:"message_templates,omitempty"`
+	AckQuorums              map[EmergencyType]int    `json:"ack_quorums,omitempty"`
+	VictimPhone             *string                  `json:"victim_phone,omitempty"`
+	CheckInReminderMinutes  []int                    `json:"check_in_reminder_minutes,omitempty"`
+}
+
+// Validate validates the preferences update request
+func (r *UpdatePreferencesRequest) Validate() error {
+	if r.UserID == uuid.Nil {
+		return errors.New("user_id is required")
+	}
+
+	if r.DefaultCountdownSeconds != nil {
+		if *r.DefaultCountdownSeconds <= 0 || *r.DefaultCountdownSeconds > MaxCountdownSeconds {
+			return errors.New("default_countdown_seconds must be between 1 and 300")
+		}
+	}
+
+	for _, emergencyType := range r.QuickActionTypes {
+		if !isValidEmergencyType(emergencyType) {
+			return errors.New("invalid emergency type in quick_action_types: " + string(emergencyType))
+		}
+	}
+
+	for emergencyType := range r.MessageTemplates {
+		if !isValidEmergencyType(emergencyType) {
+			return errors.New("invalid emergency type in message_templates: " + string(emergencyType))
+		}
+	}
+
+	if r.VictimPhone != nil {
+		if *r.VictimPhone == "" || len(*r.VictimPhone) > 32 {
+			return errors.New("victim_phone must be between 1 and 32 characters")
+		}
+	}
+
+	if len(r.CheckInReminderMinutes) > MaxCheckInReminders {
+		return errors.New("check_in_reminder_minutes may hold at most 5 offsets")
+	}
+	for _, minutes := range r.CheckInReminderMinutes {
+		if minutes < 1 || minutes > MaxCheckInReminderMinutes {
+			return errors.New("check_in_reminder_minutes values must be between 1 and 60")
+		}
+	}
+
+	for emergencyType, quorum := range r.AckQuorums {
+		if !isValidEmergencyType(emergencyType) {
+			return errors.New("invalid emergency type in ack_quorums: " + string(emergencyType))
+		}
+		if quorum < 1 || quorum > MaxAckQuorum {
+			return errors.New("ack_quorums values must be between 1 and 10")
+		}
+	}
+
+	return nil
+}
+
+// TemplateFor returns the default message template for an emergency type,
+// or nil if none is configured
+func (p *UserPreferences) TemplateFor(emergencyType EmergencyType) *string {
+	if p == nil || p.MessageTemplates == nil {
+		return nil
+	}
+	if template, ok := p.MessageTemplates[emergencyType]; ok && template != "" {
+		return &template
+	}
+	return nil
+}
+
+// CheckInReminders returns the minutes-before-deadline offsets for check-in
+// reminders: the user's configured offsets, or the default T-10/T-2 pair
+// when none are stored. An explicitly empty list disables reminders
+func (p *UserPreferences) CheckInReminders() []int {
+	if p == nil || p.CheckInReminderMinutes == nil {
+		return DefaultCheckInReminderMinutes
+	}
+	return p.CheckInReminderMinutes
+}
+
+// QuorumFor returns the required acknowledgment count for an emergency
+// type; types without a configured quorum require a single acknowledgment
+func (p *UserPreferences) QuorumFor(emergencyType EmergencyType) int {
+	if p == nil || p.AckQuorums == nil {
+		return 1
+	}
+	if quorum, ok := p.AckQuorums[emergencyType]; ok && quorum >= 1 {
+		return quorum
+	}
+	return 1
+}
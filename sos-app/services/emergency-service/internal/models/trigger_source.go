@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Registered trigger source types. Qualified sources carry an identifier
+// after the colon, e.g. "device:dev_123" or "partner-api:alexa".
+const (
+	TriggerSourceApp            = "app"
+	TriggerSourceUser           = "user" // legacy alias for app triggers
+	TriggerSourceSystem         = "system"
+	TriggerSourceDevice         = "device"
+	TriggerSourceVoiceAssistant = "voice-assistant"
+	TriggerSourcePartnerAPI     = "partner-api"
+)
+
+// TriggerSourceSpec describes one registered origin of emergency triggers:
+// whether its TriggeredBy value carries a qualifier, which delivery header
+// the gateway must have authenticated before forwarding the request, and the
+// default policies applied to triggers from that source.
+type TriggerSourceSpec struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	// RequiresQualifier means the value must be "<type>:<qualifier>", e.g.
+	// the device ID or the partner integration name
+	RequiresQualifier bool `json:"requires_qualifier"`
+	// RequiredHeader must be present on the trigger request; the gateway
+	// verifies the credential it carries before forwarding
+	RequiredHeader string `json:"required_header,omitempty"`
+	// AutoTriggered marks triggers from this source as automatic: they
+	// always run the full countdown so the user can cancel a false alarm
+	AutoTriggered bool `json:"auto_triggered"`
+	// MinCountdownSeconds floors the countdown for this source, giving the
+	// user extra time to cancel triggers from error-prone channels
+	MinCountdownSeconds int `json:"min_countdown_seconds,omitempty"`
+}
+
+// triggerSources is the registry of allowed trigger origins. Unlisted types
+// are rejected at trigger time: an integration must be registered here, with
+// its safeguards, before it can raise emergencies.
+var triggerSources = map[string]TriggerSourceSpec{
+	TriggerSourceApp: {
+		Type:        TriggerSourceApp,
+		Description: "In-app SOS button pressed by the user",
+	},
+	TriggerSourceUser: {
+		Type:        TriggerSourceUser,
+		Description: "Legacy value for in-app triggers; equivalent to app",
+	},
+	TriggerSourceSystem: {
+		Type:        TriggerSourceSystem,
+		Description: "Internal services, e.g. escalation of an unanswered check-in",
+	},
+	TriggerSourceDevice: {
+		Type:              TriggerSourceDevice,
+		Description:       "Paired IoT device (fall detector, panic button); qualifier is the device ID",
+		RequiresQualifier: true,
+		RequiredHeader:    "X-Device-ID",
+		AutoTriggered:     true,
+	},
+	TriggerSourceVoiceAssistant: {
+		Type:                TriggerSourceVoiceAssistant,
+		Description:         "Voice assistant integrations (Alexa, Google Assistant); misheard phrases make this channel error-prone",
+		RequiredHeader:      "X-Assistant-ID",
+		AutoTriggered:       true,
+		MinCountdownSeconds: 30,
+	},
+	TriggerSourcePartnerAPI: {
+		Type:                TriggerSourcePartnerAPI,
+		Description:         "Third-party partner integration; qualifier is the registered partner name",
+		RequiresQualifier:   true,
+		RequiredHeader:      "X-Partner-ID",
+		AutoTriggered:       true,
+		MinCountdownSeconds: 15,
+	},
+}
+
+// TriggerSources returns the full source registry for documentation
+// endpoints.
+func TriggerSources() map[string]TriggerSourceSpec {
+	return triggerSources
+}
+
+// ParseTriggerSource validates a TriggeredBy value against the registry and
+// returns the source spec plus the qualifier, if any.
+func ParseTriggerSource(value string) (TriggerSourceSpec, string, error) {
+	sourceType := value
+	qualifier := ""
+	if idx := strings.Index(value, ":"); idx >= 0 {
+		sourceType = value[:idx]
+		qualifier = value[idx+1:]
+	}
+
+	spec, ok := triggerSources[sourceType]
+	if !ok {
+		return TriggerSourceSpec{}, "", fmt.Errorf("unknown trigger source %q", sourceType)
+	}
+
+	if spec.RequiresQualifier && qualifier == "" {
+		return TriggerSourceSpec{}, "", fmt.Errorf("trigger source %s requires a qualifier, e.g. %s:<id>", sourceType, sourceType)
+	}
+	if !spec.RequiresQualifier && qualifier != "" {
+		return TriggerSourceSpec{}, "", fmt.Errorf("trigger source %s does not take a qualifier", sourceType)
+	}
+
+	return spec, qualifier, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConnectorProvider identifies the webhook provider type
+type ConnectorProvider string
+
+const (
+	ConnectorProviderSlack ConnectorProvider = "slack"
+	ConnectorProviderTeams ConnectorProvider = "teams"
+)
+
+// isValidConnectorProvider checks whether the provider is supported
+func isValidConnectorProvider(provider ConnectorProvider) bool {
+	switch provider {
+	case ConnectorProviderSlack, ConnectorProviderTeams:
+		return true
+	}
+	return false
+}
+
+// WebhookConnector is a Slack/Teams webhook an organization has configured
+// for its monitoring channel
+type WebhookConnector struct {
+	ID         uuid.UUID         `json:"id" db:"id"`
+	OrgID      uuid.UUID         `json:"orgId" db:"org_id"`
+	Provider   ConnectorProvider `json:"provider" db:"provider"`
+	Name       string            `json:"name" db:"name"`
+	WebhookURL string            `json:"webhookUrl" db:"webhook_url"`
+	Enabled    bool              `json:"enabled" db:"enabled"`
+	CreatedAt  time.Time         `json:"createdAt" db:"created_at"`
+	UpdatedAt  time.Time         `json:"updatedAt" db:"updated_at"`
+}
+
+// CreateConnectorRequest is the payload for registering a webhook connector
+type CreateConnectorRequest struct {
+	OrgID      uuid.UUID         `json:"orgId"`
+	Provider   ConnectorProvider `json:"provider"`
+	Name       string            `json:"name"`
+	WebhookURL string            `json:"webhookUrl"`
+}
+
+// Validate checks the create connector request
+func (r *CreateConnectorRequest) Validate() bool {
+	if r.OrgID == uuid.Nil {
+		return false
+	}
+	if !isValidConnectorProvider(r.Provider) {
+		return false
+	}
+	return r.Name != "" && r.WebhookURL != ""
+}
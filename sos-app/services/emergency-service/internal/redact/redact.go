@@ -0,0 +1,81 @@
+// Package redact centralizes masking of sensitive payload fields before
+// they reach the logs. Emergency bodies carry precise coordinates, phone
+// numbers and free-text messages; log sites pass those through the helpers
+// here so debug logging never leaks them. Masking is on by default and is
+// only lifted by the LOG_UNSAFE_PAYLOADS tunable, meant for debugging in
+// non-production environments.
+package redact
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+var unsafePayloads atomic.Bool
+
+// SetUnsafe toggles full-payload logging. Called from configuration
+// loading, including on SIGHUP reloads, so masking can be lifted and
+// restored without a restart
+func SetUnsafe(enabled bool) {
+	unsafePayloads.Store(enabled)
+}
+
+// Unsafe reports whether full payloads are currently being logged
+func Unsafe() bool {
+	return unsafePayloads.Load()
+}
+
+// Coordinates formats a coordinate pair for logging, coarsened to one
+// decimal place (roughly 11 km) unless unsafe logging is enabled
+func Coordinates(lat, lng float64) string {
+	if Unsafe() {
+		return fmt.Sprintf("%.6f,%.6f", lat, lng)
+	}
+	return fmt.Sprintf("%.1f,%.1f", lat, lng)
+}
+
+// Phone masks a phone number down to its last two digits unless unsafe
+// logging is enabled
+func Phone(phone string) string {
+	if Unsafe() || phone == "" {
+		return phone
+	}
+
+	runes := []rune(phone)
+	keep := 2
+	if len(runes) <= keep {
+		keep = 0
+	}
+	masked := make([]rune, len(runes))
+	for i := range runes {
+		if i < len(runes)-keep {
+			masked[i] = '*'
+		} else {
+			masked[i] = runes[i]
+		}
+	}
+	return string(masked)
+}
+
+// Message replaces a free-text message body with its length unless unsafe
+// logging is enabled
+func Message(message string) string {
+	if Unsafe() || message == "" {
+		return message
+	}
+	return fmt.Sprintf("[%d chars]", len(message))
+}
+
+// Hook is a zerolog hook that stamps events while unsafe logging is
+// enabled, so log output that suddenly carries full payloads is
+// self-explaining (and easy to alert on if it shows up in production)
+type Hook struct{}
+
+// Run implements zerolog.Hook
+func (Hook) Run(e *zerolog.Event, level zerolog.Level, message string) {
+	if Unsafe() {
+		e.Bool("unsafe_payloads", true)
+	}
+}
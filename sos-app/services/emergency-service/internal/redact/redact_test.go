@@ -0,0 +1,45 @@
+package redact
+
+import "testing"
+
+func TestCoordinatesMaskedByDefault(t *testing.T) {
+	SetUnsafe(false)
+
+	if got := Coordinates(37.774929, -122.419418); got != "37.8,-122.4" {
+		t.Errorf("expected coarsened coordinates, got %q", got)
+	}
+
+	SetUnsafe(true)
+	defer SetUnsafe(false)
+	if got := Coordinates(37.774929, -122.419418); got != "37.774929,-122.419418" {
+		t.Errorf("expected full coordinates in unsafe mode, got %q", got)
+	}
+}
+
+func TestPhoneKeepsLastTwoDigits(t *testing.T) {
+	SetUnsafe(false)
+
+	if got := Phone("+15551234567"); got != "**********67" {
+		t.Errorf("expected masked phone, got %q", got)
+	}
+	if got := Phone("12"); got != "**" {
+		t.Errorf("expected short numbers fully masked, got %q", got)
+	}
+	if got := Phone(""); got != "" {
+		t.Errorf("expected empty phone untouched, got %q", got)
+	}
+}
+
+func TestMessageReplacedWithLength(t *testing.T) {
+	SetUnsafe(false)
+
+	if got := Message("I'm diabetic, insulin in my bag"); got != "[31 chars]" {
+		t.Errorf("expected length placeholder, got %q", got)
+	}
+
+	SetUnsafe(true)
+	defer SetUnsafe(false)
+	if got := Message("help"); got != "help" {
+		t.Errorf("expected full message in unsafe mode, got %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+var (
+	// ErrAbuseFlagNotFound is returned when an abuse flag doesn't exist or is
+	// already cleared
+	ErrAbuseFlagNotFound = errors.New("abuse flag not found")
+)
+
+// AbuseFlagRepository handles database operations for abuse flags
+type AbuseFlagRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAbuseFlagRepository creates a new AbuseFlagRepository
+func NewAbuseFlagRepository(db *pgxpool.Pool) *AbuseFlagRepository {
+	return &AbuseFlagRepository{db: db}
+}
+
+// Create raises an abuse flag for a user. The partial unique index allows at
+// most one active flag per user; a conflicting insert is treated as already
+// flagged and returns the existing state unchanged
+func (r *AbuseFlagRepository) Create(ctx context.Context, flag *models.AbuseFlag) error {
+	query := `
+		INSERT INTO user_abuse_flags (user_id, reason, cancelled_count)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) WHERE cleared_at IS NULL DO NOTHING
+		RETURNING id, flagged_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		flag.UserID,
+		flag.Reason,
+		flag.CancelledCount,
+	).Scan(&flag.ID, &flag.FlaggedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Lost the race against a concurrent flag for the same user
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create abuse flag: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveByUserID retrieves the user's active (uncleared) flag, or nil
+func (r *AbuseFlagRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.AbuseFlag, error) {
+	query := `
+		SELECT id, user_id, reason, cancelled_count, flagged_at, cleared_at, cleared_by, review_note
+		FROM user_abuse_flags
+		WHERE user_id = $1 AND cleared_at IS NULL
+	`
+
+	var flag models.AbuseFlag
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&flag.ID,
+		&flag.UserID,
+		&flag.Reason,
+		&flag.CancelledCount,
+		&flag.FlaggedAt,
+		&flag.ClearedAt,
+		&flag.ClearedBy,
+		&flag.ReviewNote,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abuse flag: %w", err)
+	}
+
+	return &flag, nil
+}
+
+// ListActive retrieves all active flags awaiting support review, oldest first
+func (r *AbuseFlagRepository) ListActive(ctx context.Context) ([]models.AbuseFlag, error) {
+	query := `
+		SELECT id, user_id, reason, cancelled_count, flagged_at, cleared_at, cleared_by, review_note
+		FROM user_abuse_flags
+		WHERE cleared_at IS NULL
+		ORDER BY flagged_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list abuse flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []models.AbuseFlag
+	for rows.Next() {
+		var flag models.AbuseFlag
+		err := rows.Scan(
+			&flag.ID,
+			&flag.UserID,
+			&flag.Reason,
+			&flag.CancelledCount,
+			&flag.FlaggedAt,
+			&flag.ClearedAt,
+			&flag.ClearedBy,
+			&flag.ReviewNote,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan abuse flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, rows.Err()
+}
+
+// Clear resolves an active flag after support review, recording who cleared
+// it and why
+func (r *AbuseFlagRepository) Clear(ctx context.Context, id uuid.UUID, clearedBy, reviewNote string) error {
+	query := `
+		UPDATE user_abuse_flags
+		SET cleared_at = NOW(), cleared_by = $2, review_note = NULLIF($3, '')
+		WHERE id = $1 AND cleared_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, clearedBy, reviewNote)
+	if err != nil {
+		return fmt.Errorf("failed to clear abuse flag: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAbuseFlagNotFound
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 var (
 	ErrAcknowledgmentNotFound      = errors.New("acknowledgment not found")
 	ErrDuplicateAcknowledgment     = errors.New("contact already acknowledged this emergency")
+	ErrAcknowledgmentRevoked       = errors.New("acknowledgment already revoked")
 )
 
 // AcknowledgmentRepository handles database operations for acknowledgments
@@ -32,9 +33,9 @@ func (r *AcknowledgmentRepository) Create(ctx context.Context, ack *models.Emerg
 	query := `
 		INSERT INTO emergency_acknowledgments (
 			id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
-			acknowledged_at, location, message
+			relationship, acknowledged_at, location, message
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 		)
 	`
 
@@ -48,6 +49,7 @@ func (r *AcknowledgmentRepository) Create(ctx context.Context, ack *models.Emerg
 		ack.ContactName,
 		ack.ContactPhone,
 		ack.ContactEmail,
+		ack.Relationship,
 		ack.AcknowledgedAt,
 		ack.Location,
 		ack.Message,
@@ -68,7 +70,7 @@ func (r *AcknowledgmentRepository) Create(ctx context.Context, ack *models.Emerg
 func (r *AcknowledgmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EmergencyAcknowledgment, error) {
 	query := `
 		SELECT id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
-		       acknowledged_at, location, message
+		       relationship, acknowledged_at, location, message, revoked, revoked_at, revoked_reason
 		FROM emergency_acknowledgments
 		WHERE id = $1
 	`
@@ -81,9 +83,13 @@ func (r *AcknowledgmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		&ack.ContactName,
 		&ack.ContactPhone,
 		&ack.ContactEmail,
+		&ack.Relationship,
 		&ack.AcknowledgedAt,
 		&ack.Location,
 		&ack.Message,
+		&ack.Revoked,
+		&ack.RevokedAt,
+		&ack.RevokedReason,
 	)
 
 	if err != nil {
@@ -100,7 +106,7 @@ func (r *AcknowledgmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 func (r *AcknowledgmentRepository) GetByEmergencyID(ctx context.Context, emergencyID uuid.UUID) ([]models.EmergencyAcknowledgment, error) {
 	query := `
 		SELECT id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
-		       acknowledged_at, location, message
+		       relationship, acknowledged_at, location, message, revoked, revoked_at, revoked_reason
 		FROM emergency_acknowledgments
 		WHERE emergency_id = $1
 		ORDER BY acknowledged_at ASC
@@ -122,9 +128,13 @@ func (r *AcknowledgmentRepository) GetByEmergencyID(ctx context.Context, emergen
 			&ack.ContactName,
 			&ack.ContactPhone,
 			&ack.ContactEmail,
+			&ack.Relationship,
 			&ack.AcknowledgedAt,
 			&ack.Location,
 			&ack.Message,
+			&ack.Revoked,
+			&ack.RevokedAt,
+			&ack.RevokedReason,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan acknowledgment: %w", err)
@@ -136,15 +146,80 @@ func (r *AcknowledgmentRepository) GetByEmergencyID(ctx context.Context, emergen
 		return nil, fmt.Errorf("error iterating acknowledgments: %w", err)
 	}
 
+	// Most useful contacts first: professional caregiver, then household,
+	// then people nearby
+	models.SortByRelationship(acknowledgments)
+
 	return acknowledgments, nil
 }
 
+// GetByContactID retrieves every acknowledgment made by a contact, across
+// all emergencies (used for data subject access requests)
+func (r *AcknowledgmentRepository) GetByContactID(ctx context.Context, contactID uuid.UUID) ([]models.EmergencyAcknowledgment, error) {
+	query := `
+		SELECT id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
+		       relationship, acknowledged_at, location, message, revoked, revoked_at, revoked_reason
+		FROM emergency_acknowledgments
+		WHERE contact_id = $1
+		ORDER BY acknowledged_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acknowledgments by contact: %w", err)
+	}
+	defer rows.Close()
+
+	var acknowledgments []models.EmergencyAcknowledgment
+	for rows.Next() {
+		var ack models.EmergencyAcknowledgment
+		err := rows.Scan(
+			&ack.ID,
+			&ack.EmergencyID,
+			&ack.ContactID,
+			&ack.ContactName,
+			&ack.ContactPhone,
+			&ack.ContactEmail,
+			&ack.Relationship,
+			&ack.AcknowledgedAt,
+			&ack.Location,
+			&ack.Message,
+			&ack.Revoked,
+			&ack.RevokedAt,
+			&ack.RevokedReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan acknowledgment: %w", err)
+		}
+		acknowledgments = append(acknowledgments, ack)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating acknowledgments: %w", err)
+	}
+
+	return acknowledgments, nil
+}
+
+// DeleteByContactID removes every acknowledgment made by a contact (data
+// erasure). Returns the number of rows removed
+func (r *AcknowledgmentRepository) DeleteByContactID(ctx context.Context, contactID uuid.UUID) (int64, error) {
+	query := `DELETE FROM emergency_acknowledgments WHERE contact_id = $1`
+
+	result, err := r.db.Exec(ctx, query, contactID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete acknowledgments by contact: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // HasContactAcknowledged checks if a specific contact has acknowledged an emergency
 func (r *AcknowledgmentRepository) HasContactAcknowledged(ctx context.Context, emergencyID, contactID uuid.UUID) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM emergency_acknowledgments
-			WHERE emergency_id = $1 AND contact_id = $2
+			WHERE emergency_id = $1 AND contact_id = $2 AND revoked = FALSE
 		)
 	`
 
@@ -157,9 +232,28 @@ func (r *AcknowledgmentRepository) HasContactAcknowledged(ctx context.Context, e
 	return exists, nil
 }
 
-// CountAcknowledgments returns the number of acknowledgments for an emergency
+// HasProfessionalAcknowledgment checks whether a professional responder
+// (CAREGIVER relationship) has an active acknowledgment for an emergency
+func (r *AcknowledgmentRepository) HasProfessionalAcknowledgment(ctx context.Context, emergencyID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM emergency_acknowledgments
+			WHERE emergency_id = $1 AND relationship = $2 AND revoked = FALSE
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(ctx, query, emergencyID, models.RelationshipCaregiver).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check professional acknowledgment: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountAcknowledgments returns the number of active (non-revoked) acknowledgments for an emergency
 func (r *AcknowledgmentRepository) CountAcknowledgments(ctx context.Context, emergencyID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM emergency_acknowledgments WHERE emergency_id = $1`
+	query := `SELECT COUNT(*) FROM emergency_acknowledgments WHERE emergency_id = $1 AND revoked = FALSE`
 
 	var count int
 	err := r.db.QueryRow(ctx, query, emergencyID).Scan(&count)
@@ -170,6 +264,103 @@ func (r *AcknowledgmentRepository) CountAcknowledgments(ctx context.Context, eme
 	return count, nil
 }
 
+// GetByEmergencyAndContact retrieves a contact's acknowledgment for an emergency
+func (r *AcknowledgmentRepository) GetByEmergencyAndContact(ctx context.Context, emergencyID, contactID uuid.UUID) (*models.EmergencyAcknowledgment, error) {
+	query := `
+		SELECT id, emergency_id, contact_id, contact_name, contact_phone, contact_email,
+		       relationship, acknowledged_at, location, message, revoked, revoked_at, revoked_reason
+		FROM emergency_acknowledgments
+		WHERE emergency_id = $1 AND contact_id = $2
+	`
+
+	var ack models.EmergencyAcknowledgment
+	err := r.db.QueryRow(ctx, query, emergencyID, contactID).Scan(
+		&ack.ID,
+		&ack.EmergencyID,
+		&ack.ContactID,
+		&ack.ContactName,
+		&ack.ContactPhone,
+		&ack.ContactEmail,
+		&ack.Relationship,
+		&ack.AcknowledgedAt,
+		&ack.Location,
+		&ack.Message,
+		&ack.Revoked,
+		&ack.RevokedAt,
+		&ack.RevokedReason,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAcknowledgmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get acknowledgment: %w", err)
+	}
+
+	return &ack, nil
+}
+
+// Revoke soft-deletes a contact's acknowledgment, keeping the row for audit
+func (r *AcknowledgmentRepository) Revoke(ctx context.Context, emergencyID, contactID uuid.UUID, reason *string) error {
+	query := `
+		UPDATE emergency_acknowledgments
+		SET revoked = TRUE, revoked_at = $3, revoked_reason = $4
+		WHERE emergency_id = $1 AND contact_id = $2 AND revoked = FALSE
+	`
+
+	result, err := r.db.Exec(ctx, query, emergencyID, contactID, time.Now(), reason)
+	if err != nil {
+		return fmt.Errorf("failed to revoke acknowledgment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		// Distinguish "never acknowledged" from "already revoked"
+		if _, getErr := r.GetByEmergencyAndContact(ctx, emergencyID, contactID); getErr != nil {
+			return getErr
+		}
+		return ErrAcknowledgmentRevoked
+	}
+
+	return nil
+}
+
+// ListAcknowledgedNeverNotified returns acknowledgments by contacts who
+// have no notification delivery record at all for that emergency - someone
+// responded to a notification this service never saw go out, which points
+// at a lost notification status stream
+func (r *AcknowledgmentRepository) ListAcknowledgedNeverNotified(ctx context.Context) ([]models.ConsistencyFinding, error) {
+	query := `
+		SELECT a.emergency_id, e.user_id, a.acknowledged_at
+		FROM emergency_acknowledgments a
+		JOIN emergencies e ON e.id = a.emergency_id
+		LEFT JOIN notification_statuses n
+		  ON n.emergency_id = a.emergency_id AND n.contact_id = a.contact_id
+		WHERE n.id IS NULL AND a.revoked_at IS NULL
+		ORDER BY a.acknowledged_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unnotified acknowledgments: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []models.ConsistencyFinding
+	for rows.Next() {
+		var finding models.ConsistencyFinding
+		if err := rows.Scan(&finding.EmergencyID, &finding.UserID, &finding.Since); err != nil {
+			return nil, fmt.Errorf("failed to scan consistency finding: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating consistency findings: %w", err)
+	}
+
+	return findings, nil
+}
+
 // Delete deletes an acknowledgment (for testing only)
 func (r *AcknowledgmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM emergency_acknowledgments WHERE id = $1`
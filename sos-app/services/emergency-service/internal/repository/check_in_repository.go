@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// CheckInRepository handles database operations for scheduled check-ins
+type CheckInRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCheckInRepository creates a new CheckInRepository
+func NewCheckInRepository(db *pgxpool.Pool) *CheckInRepository {
+	return &CheckInRepository{db: db}
+}
+
+// Create stores a new scheduled check-in
+func (r *CheckInRepository) Create(ctx context.Context, checkIn *models.ScheduledCheckIn) error {
+	query := `
+		INSERT INTO scheduled_check_ins (user_id, deadline_at)
+		VALUES ($1, $2)
+		RETURNING id, status, snooze_count, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, checkIn.UserID, checkIn.DeadlineAt).Scan(
+		&checkIn.ID,
+		&checkIn.Status,
+		&checkIn.SnoozeCount,
+		&checkIn.CreatedAt,
+		&checkIn.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create check-in: %w", err)
+	}
+
+	checkIn.RemindersSent = []int{}
+	return nil
+}
+
+// GetByID retrieves a check-in by ID, or nil if none exists
+func (r *CheckInRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ScheduledCheckIn, error) {
+	query := `
+		SELECT id, user_id, deadline_at, status, snooze_count, reminders_sent,
+		       completed_at, created_at, updated_at
+		FROM scheduled_check_ins
+		WHERE id = $1
+	`
+
+	return r.scanCheckIn(r.db.QueryRow(ctx, query, id))
+}
+
+// ListScheduledBefore returns open check-ins whose deadline falls before the
+// horizon, oldest deadline first. The reminder sweep uses this to find
+// check-ins that may be due a reminder
+func (r *CheckInRepository) ListScheduledBefore(ctx context.Context, horizon time.Time) ([]*models.ScheduledCheckIn, error) {
+	query := `
+		SELECT id, user_id, deadline_at, status, snooze_count, reminders_sent,
+		       completed_at, created_at, updated_at
+		FROM scheduled_check_ins
+		WHERE status = 'SCHEDULED' AND deadline_at <= $1
+		ORDER BY deadline_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, horizon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check-ins: %w", err)
+	}
+	defer rows.Close()
+
+	var checkIns []*models.ScheduledCheckIn
+	for rows.Next() {
+		checkIn, err := r.scanCheckIn(rows)
+		if err != nil {
+			return nil, err
+		}
+		checkIns = append(checkIns, checkIn)
+	}
+
+	return checkIns, rows.Err()
+}
+
+// MarkReminderSent records that the reminder for a minutes-before offset was
+// published, so the sweep never double-sends it
+func (r *CheckInRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, minutesBefore int) error {
+	query := `
+		UPDATE scheduled_check_ins
+		SET reminders_sent = reminders_sent || to_jsonb($2::int), updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, minutesBefore); err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+
+	return nil
+}
+
+// Complete marks an open check-in as completed. Returns nil, nil when the
+// check-in does not exist, belongs to another user or is already closed
+func (r *CheckInRepository) Complete(ctx context.Context, id, userID uuid.UUID) (*models.ScheduledCheckIn, error) {
+	query := `
+		UPDATE scheduled_check_ins
+		SET status = 'COMPLETED', completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status = 'SCHEDULED'
+		RETURNING id, user_id, deadline_at, status, snooze_count, reminders_sent,
+		          completed_at, created_at, updated_at
+	`
+
+	return r.scanCheckIn(r.db.QueryRow(ctx, query, id, userID))
+}
+
+// Snooze pushes an open check-in's deadline to newDeadline and clears the
+// sent-reminder bookkeeping so reminders fire again against the new
+// deadline. Returns nil, nil when the check-in does not exist, belongs to
+// another user or is already closed
+func (r *CheckInRepository) Snooze(ctx context.Context, id, userID uuid.UUID, newDeadline time.Time) (*models.ScheduledCheckIn, error) {
+	query := `
+		UPDATE scheduled_check_ins
+		SET deadline_at = $3, snooze_count = snooze_count + 1,
+		    reminders_sent = '[]'::jsonb, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status = 'SCHEDULED'
+		RETURNING id, user_id, deadline_at, status, snooze_count, reminders_sent,
+		          completed_at, created_at, updated_at
+	`
+
+	return r.scanCheckIn(r.db.QueryRow(ctx, query, id, userID, newDeadline))
+}
+
+// DeleteForUser removes a user's check-ins (data erasure)
+func (r *CheckInRepository) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM scheduled_check_ins WHERE user_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete check-ins: %w", err)
+	}
+
+	return nil
+}
+
+// scanCheckIn scans one check-in row, mapping pgx.ErrNoRows to nil, nil
+func (r *CheckInRepository) scanCheckIn(row pgx.Row) (*models.ScheduledCheckIn, error) {
+	checkIn := &models.ScheduledCheckIn{}
+	var remindersSent []byte
+
+	err := row.Scan(
+		&checkIn.ID,
+		&checkIn.UserID,
+		&checkIn.DeadlineAt,
+		&checkIn.Status,
+		&checkIn.SnoozeCount,
+		&remindersSent,
+		&checkIn.CompletedAt,
+		&checkIn.CreatedAt,
+		&checkIn.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan check-in: %w", err)
+	}
+
+	if err := json.Unmarshal(remindersSent, &checkIn.RemindersSent); err != nil {
+		return nil, fmt.Errorf("failed to parse reminders_sent: %w", err)
+	}
+
+	return checkIn, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// DashboardRepository maintains the read-optimized dashboard projection.
+// Writes come only from the projection worker; the table is rebuilt from the
+// event stream, so every mutation is idempotent and tolerates rows that do
+// not exist yet (events can arrive out of order across topics)
+type DashboardRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDashboardRepository creates a new dashboard repository
+func NewDashboardRepository(db *pgxpool.Pool) *DashboardRepository {
+	return &DashboardRepository{db: db}
+}
+
+// UpsertActive adds an activated emergency to the projection, or refreshes
+// the denormalized fields when the event is redelivered
+func (r *DashboardRepository) UpsertActive(ctx context.Context, entry *models.DashboardEntry) error {
+	query := `
+		INSERT INTO dashboard_active_emergencies
+			(emergency_id, user_id, emergency_type, latitude, longitude, auto_triggered, activated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (emergency_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			emergency_type = EXCLUDED.emergency_type,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			auto_triggered = EXCLUDED.auto_triggered,
+			activated_at = EXCLUDED.activated_at,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query,
+		entry.EmergencyID,
+		entry.UserID,
+		entry.EmergencyType,
+		entry.Latitude,
+		entry.Longitude,
+		entry.AutoTriggered,
+		entry.ActivatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert dashboard entry: %w", err)
+	}
+
+	return nil
+}
+
+// AdjustAckCount moves the acknowledgment count by delta (positive for an
+// acknowledgment, negative for a revocation), never below zero
+func (r *DashboardRepository) AdjustAckCount(ctx context.Context, emergencyID uuid.UUID, delta int) error {
+	query := `
+		UPDATE dashboard_active_emergencies
+		SET ack_count = GREATEST(ack_count + $2, 0), updated_at = NOW()
+		WHERE emergency_id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, emergencyID, delta); err != nil {
+		return fmt.Errorf("failed to adjust dashboard ack count: %w", err)
+	}
+
+	return nil
+}
+
+// SetEscalationLevel records the highest escalation level reached
+func (r *DashboardRepository) SetEscalationLevel(ctx context.Context, emergencyID uuid.UUID, level int, levelName string) error {
+	query := `
+		UPDATE dashboard_active_emergencies
+		SET escalation_level = GREATEST(escalation_level, $2),
+		    escalation_level_name = $3,
+		    updated_at = NOW()
+		WHERE emergency_id = $1 AND escalation_level < $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, emergencyID, level, levelName); err != nil {
+		return fmt.Errorf("failed to set dashboard escalation level: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLocation refreshes the latest coordinates and their freshness
+func (r *DashboardRepository) UpdateLocation(ctx context.Context, emergencyID uuid.UUID, latitude, longitude float64, at time.Time) error {
+	query := `
+		UPDATE dashboard_active_emergencies
+		SET latitude = $2, longitude = $3, last_location_at = $4, updated_at = NOW()
+		WHERE emergency_id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, emergencyID, latitude, longitude, at); err != nil {
+		return fmt.Errorf("failed to update dashboard location: %w", err)
+	}
+
+	return nil
+}
+
+// Remove drops an emergency from the projection once it is no longer active
+func (r *DashboardRepository) Remove(ctx context.Context, emergencyID uuid.UUID) error {
+	query := `DELETE FROM dashboard_active_emergencies WHERE emergency_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, emergencyID); err != nil {
+		return fmt.Errorf("failed to remove dashboard entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListActive returns active emergencies newest-first, optionally filtered by
+// emergency type. One indexed scan over the denormalized table, no joins
+func (r *DashboardRepository) ListActive(ctx context.Context, emergencyType string, limit int) ([]models.DashboardEntry, error) {
+	query := `
+		SELECT emergency_id, user_id, emergency_type, latitude, longitude,
+		       auto_triggered, ack_count, escalation_level, escalation_level_name,
+		       last_location_at, activated_at, updated_at
+		FROM dashboard_active_emergencies
+		WHERE ($1 = '' OR emergency_type = $1)
+		ORDER BY activated_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, emergencyType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dashboard entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.DashboardEntry
+	for rows.Next() {
+		var entry models.DashboardEntry
+		err := rows.Scan(
+			&entry.EmergencyID,
+			&entry.UserID,
+			&entry.EmergencyType,
+			&entry.Latitude,
+			&entry.Longitude,
+			&entry.AutoTriggered,
+			&entry.AckCount,
+			&entry.EscalationLevel,
+			&entry.EscalationLevelName,
+			&entry.LastLocationAt,
+			&entry.ActivatedAt,
+			&entry.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dashboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
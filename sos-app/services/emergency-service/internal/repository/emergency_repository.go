@@ -13,9 +13,10 @@ import (
 )
 
 var (
-	ErrEmergencyNotFound      = errors.New("emergency not found")
-	ErrEmergencyAlreadyActive = errors.New("user already has an active emergency")
-	ErrInvalidStatus          = errors.New("invalid status transition")
+	ErrEmergencyNotFound       = errors.New("emergency not found")
+	ErrEmergencyAlreadyActive  = errors.New("user already has an active emergency")
+	ErrInvalidStatus           = errors.New("invalid status transition")
+	ErrEmergencyAlreadyClaimed = errors.New("emergency already claimed by an operator")
 )
 
 // EmergencyRepository handles database operations for emergencies
@@ -33,9 +34,9 @@ func (r *EmergencyRepository) Create(ctx context.Context, emergency *models.Emer
 	query := `
 		INSERT INTO emergencies (
 			id, user_id, emergency_type, status, initial_location, initial_message,
-			auto_triggered, triggered_by, countdown_seconds, created_at, metadata
+			auto_triggered, triggered_by, countdown_seconds, created_at, metadata, is_test
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 		)
 	`
 
@@ -51,6 +52,7 @@ func (r *EmergencyRepository) Create(ctx context.Context, emergency *models.Emer
 		emergency.CountdownSeconds,
 		emergency.CreatedAt,
 		emergency.Metadata,
+		emergency.IsTest,
 	)
 
 	if err != nil {
@@ -65,7 +67,8 @@ func (r *EmergencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*model
 	query := `
 		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
 		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
-		       cancelled_at, resolved_at, resolution_notes, metadata
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
 		FROM emergencies
 		WHERE id = $1
 	`
@@ -87,6 +90,15 @@ func (r *EmergencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*model
 		&emergency.ResolvedAt,
 		&emergency.ResolutionNotes,
 		&emergency.Metadata,
+		&emergency.ClaimedBy,
+		&emergency.ClaimedByName,
+		&emergency.ClaimedAt,
+		&emergency.IsTest,
+		&emergency.NearbyFacilities,
+		&emergency.WeatherContext,
+		&emergency.CallBridge,
+		&emergency.ActivationChecks,
+		&emergency.AutoResolved,
 	)
 
 	if err != nil {
@@ -99,12 +111,76 @@ func (r *EmergencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*model
 	return &emergency, nil
 }
 
+// ListStalePending retrieves PENDING emergencies whose countdown plus the
+// given grace has fully elapsed - rows a countdown timer should already have
+// resolved, indicating the timer was lost to a bug or crash loop
+func (r *EmergencyRepository) ListStalePending(ctx context.Context, grace time.Duration) ([]models.Emergency, error) {
+	query := `
+		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
+		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
+		FROM emergencies
+		WHERE status = 'PENDING'
+		  AND created_at + make_interval(secs => countdown_seconds) + make_interval(secs => $1) < NOW()
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, grace.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale pending emergencies: %w", err)
+	}
+	defer rows.Close()
+
+	var emergencies []models.Emergency
+	for rows.Next() {
+		var emergency models.Emergency
+		err := rows.Scan(
+			&emergency.ID,
+			&emergency.UserID,
+			&emergency.EmergencyType,
+			&emergency.Status,
+			&emergency.InitialLocation,
+			&emergency.InitialMessage,
+			&emergency.AutoTriggered,
+			&emergency.TriggeredBy,
+			&emergency.CountdownSeconds,
+			&emergency.CreatedAt,
+			&emergency.ActivatedAt,
+			&emergency.CancelledAt,
+			&emergency.ResolvedAt,
+			&emergency.ResolutionNotes,
+			&emergency.Metadata,
+			&emergency.ClaimedBy,
+			&emergency.ClaimedByName,
+			&emergency.ClaimedAt,
+			&emergency.IsTest,
+			&emergency.NearbyFacilities,
+			&emergency.WeatherContext,
+			&emergency.CallBridge,
+			&emergency.ActivationChecks,
+			&emergency.AutoResolved,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan emergency: %w", err)
+		}
+		emergencies = append(emergencies, emergency)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating emergencies: %w", err)
+	}
+
+	return emergencies, nil
+}
+
 // GetByUserID retrieves all emergencies for a specific user
 func (r *EmergencyRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.Emergency, error) {
 	query := `
 		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
 		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
-		       cancelled_at, resolved_at, resolution_notes, metadata
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
 		FROM emergencies
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -135,6 +211,15 @@ func (r *EmergencyRepository) GetByUserID(ctx context.Context, userID uuid.UUID)
 			&emergency.ResolvedAt,
 			&emergency.ResolutionNotes,
 			&emergency.Metadata,
+			&emergency.ClaimedBy,
+			&emergency.ClaimedByName,
+			&emergency.ClaimedAt,
+			&emergency.IsTest,
+			&emergency.NearbyFacilities,
+			&emergency.WeatherContext,
+			&emergency.CallBridge,
+			&emergency.ActivationChecks,
+			&emergency.AutoResolved,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan emergency: %w", err)
@@ -154,7 +239,8 @@ func (r *EmergencyRepository) GetActiveByUserID(ctx context.Context, userID uuid
 	query := `
 		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
 		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
-		       cancelled_at, resolved_at, resolution_notes, metadata
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
 		FROM emergencies
 		WHERE user_id = $1 AND status IN ('PENDING', 'ACTIVE')
 		ORDER BY created_at DESC
@@ -178,6 +264,15 @@ func (r *EmergencyRepository) GetActiveByUserID(ctx context.Context, userID uuid
 		&emergency.ResolvedAt,
 		&emergency.ResolutionNotes,
 		&emergency.Metadata,
+		&emergency.ClaimedBy,
+		&emergency.ClaimedByName,
+		&emergency.ClaimedAt,
+		&emergency.IsTest,
+		&emergency.NearbyFacilities,
+		&emergency.WeatherContext,
+		&emergency.CallBridge,
+		&emergency.ActivationChecks,
+		&emergency.AutoResolved,
 	)
 
 	if err != nil {
@@ -190,6 +285,85 @@ func (r *EmergencyRepository) GetActiveByUserID(ctx context.Context, userID uuid
 	return &emergency, nil
 }
 
+// GetLastResolvedByUserAndType returns the user's most recently resolved
+// emergency of the given type, or nil when none exists. Used to enforce the
+// post-resolution re-trigger cool-off
+func (r *EmergencyRepository) GetLastResolvedByUserAndType(ctx context.Context, userID uuid.UUID, emergencyType models.EmergencyType) (*models.Emergency, error) {
+	query := `
+		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
+		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
+		FROM emergencies
+		WHERE user_id = $1 AND emergency_type = $2 AND status = 'RESOLVED'
+		ORDER BY resolved_at DESC
+		LIMIT 1
+	`
+
+	var emergency models.Emergency
+	err := r.db.QueryRow(ctx, query, userID, emergencyType).Scan(
+		&emergency.ID,
+		&emergency.UserID,
+		&emergency.EmergencyType,
+		&emergency.Status,
+		&emergency.InitialLocation,
+		&emergency.InitialMessage,
+		&emergency.AutoTriggered,
+		&emergency.TriggeredBy,
+		&emergency.CountdownSeconds,
+		&emergency.CreatedAt,
+		&emergency.ActivatedAt,
+		&emergency.CancelledAt,
+		&emergency.ResolvedAt,
+		&emergency.ResolutionNotes,
+		&emergency.Metadata,
+		&emergency.ClaimedBy,
+		&emergency.ClaimedByName,
+		&emergency.ClaimedAt,
+		&emergency.IsTest,
+		&emergency.NearbyFacilities,
+		&emergency.WeatherContext,
+		&emergency.CallBridge,
+		&emergency.ActivationChecks,
+		&emergency.AutoResolved,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // No resolved emergency of this type
+		}
+		return nil, fmt.Errorf("failed to get last resolved emergency: %w", err)
+	}
+
+	return &emergency, nil
+}
+
+// CountByUserSince counts non-test emergencies a user created after the
+// given time
+func (r *EmergencyRepository) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM emergencies WHERE user_id = $1 AND created_at >= $2 AND is_test = FALSE`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count emergencies: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountCancelledByUserSince counts non-test emergencies a user cancelled
+// after the given time
+func (r *EmergencyRepository) CountCancelledByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM emergencies WHERE user_id = $1 AND status = 'CANCELLED' AND created_at >= $2 AND is_test = FALSE`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count cancelled emergencies: %w", err)
+	}
+
+	return count, nil
+}
+
 // UpdateStatus updates the status of an emergency
 func (r *EmergencyRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.EmergencyStatus) error {
 	var query string
@@ -221,6 +395,118 @@ func (r *EmergencyRepository) UpdateStatus(ctx context.Context, id uuid.UUID, st
 	return nil
 }
 
+// UpdateLastLocation records when the most recent location update for an
+// emergency was consumed; the consistency checker flags active emergencies
+// whose location has gone silent
+func (r *EmergencyRepository) UpdateLastLocation(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE emergencies SET last_location_at = $1 WHERE id = $2`
+
+	if _, err := r.db.Exec(ctx, query, at, id); err != nil {
+		return fmt.Errorf("failed to update last location timestamp: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveStaleLocation returns ACTIVE emergencies that have received no
+// location update since the cutoff. Emergencies that never received one are
+// measured from their activation
+func (r *EmergencyRepository) ListActiveStaleLocation(ctx context.Context, cutoff time.Time) ([]models.ConsistencyFinding, error) {
+	query := `
+		SELECT id, user_id, COALESCE(last_location_at, activated_at, created_at)
+		FROM emergencies
+		WHERE status = 'ACTIVE'
+		  AND COALESCE(last_location_at, activated_at, created_at) < $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emergencies with stale locations: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []models.ConsistencyFinding
+	for rows.Next() {
+		var finding models.ConsistencyFinding
+		if err := rows.Scan(&finding.EmergencyID, &finding.UserID, &finding.Since); err != nil {
+			return nil, fmt.Errorf("failed to scan consistency finding: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating consistency findings: %w", err)
+	}
+
+	return findings, nil
+}
+
+// SetNearbyFacilities stores the facility snapshot resolved at activation
+func (r *EmergencyRepository) SetNearbyFacilities(ctx context.Context, id uuid.UUID, facilities models.NearbyFacilities) error {
+	query := `UPDATE emergencies SET nearby_facilities = $1 WHERE id = $2`
+
+	result, err := r.db.Exec(ctx, query, facilities, id)
+	if err != nil {
+		return fmt.Errorf("failed to set nearby facilities: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
+// SetWeatherContext stores the weather snapshot resolved at activation
+func (r *EmergencyRepository) SetWeatherContext(ctx context.Context, id uuid.UUID, weather *models.WeatherContext) error {
+	query := `UPDATE emergencies SET weather_context = $1 WHERE id = $2`
+
+	result, err := r.db.Exec(ctx, query, weather, id)
+	if err != nil {
+		return fmt.Errorf("failed to set weather context: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
+// SetActivationChecks stores the pre-activation hook outcomes recorded when
+// the countdown completed
+func (r *EmergencyRepository) SetActivationChecks(ctx context.Context, id uuid.UUID, checks models.ActivationChecks) error {
+	query := `UPDATE emergencies SET activation_checks = $1 WHERE id = $2`
+
+	result, err := r.db.Exec(ctx, query, checks, id)
+	if err != nil {
+		return fmt.Errorf("failed to set activation checks: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
+// SetCallBridge stores the conference bridge created at escalation
+func (r *EmergencyRepository) SetCallBridge(ctx context.Context, id uuid.UUID, bridge *models.CallBridge) error {
+	query := `UPDATE emergencies SET call_bridge = $1 WHERE id = $2`
+
+	result, err := r.db.Exec(ctx, query, bridge, id)
+	if err != nil {
+		return fmt.Errorf("failed to set call bridge: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
 // Resolve updates an emergency to resolved status with notes
 func (r *EmergencyRepository) Resolve(ctx context.Context, id uuid.UUID, notes string) error {
 	query := `
@@ -241,6 +527,175 @@ func (r *EmergencyRepository) Resolve(ctx context.Context, id uuid.UUID, notes s
 	return nil
 }
 
+// ListActiveSafeCheckedIn returns ACTIVE emergencies activated before the
+// cutoff whose user completed a scheduled check-in after activation - the
+// user has signalled they are safe, so the emergency can be auto-resolved
+func (r *EmergencyRepository) ListActiveSafeCheckedIn(ctx context.Context, cutoff time.Time) ([]models.Emergency, error) {
+	query := `
+		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
+		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
+		FROM emergencies
+		WHERE status = 'ACTIVE'
+		  AND activated_at <= $1
+		  AND EXISTS (
+			SELECT 1 FROM scheduled_check_ins
+			WHERE scheduled_check_ins.user_id = emergencies.user_id
+			  AND scheduled_check_ins.status = 'COMPLETED'
+			  AND scheduled_check_ins.completed_at >= emergencies.activated_at
+		  )
+		ORDER BY activated_at
+	`
+
+	rows, err := r.db.Query(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list safe checked-in emergencies: %w", err)
+	}
+	defer rows.Close()
+
+	var emergencies []models.Emergency
+	for rows.Next() {
+		var emergency models.Emergency
+		err := rows.Scan(
+			&emergency.ID,
+			&emergency.UserID,
+			&emergency.EmergencyType,
+			&emergency.Status,
+			&emergency.InitialLocation,
+			&emergency.InitialMessage,
+			&emergency.AutoTriggered,
+			&emergency.TriggeredBy,
+			&emergency.CountdownSeconds,
+			&emergency.CreatedAt,
+			&emergency.ActivatedAt,
+			&emergency.CancelledAt,
+			&emergency.ResolvedAt,
+			&emergency.ResolutionNotes,
+			&emergency.Metadata,
+			&emergency.ClaimedBy,
+			&emergency.ClaimedByName,
+			&emergency.ClaimedAt,
+			&emergency.IsTest,
+			&emergency.NearbyFacilities,
+			&emergency.WeatherContext,
+			&emergency.CallBridge,
+			&emergency.ActivationChecks,
+			&emergency.AutoResolved,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan emergency: %w", err)
+		}
+		emergencies = append(emergencies, emergency)
+	}
+
+	return emergencies, rows.Err()
+}
+
+// ListActiveUnattended returns ACTIVE emergencies activated before the
+// cutoff together with their auto-resolve approval state, oldest first
+func (r *EmergencyRepository) ListActiveUnattended(ctx context.Context, cutoff time.Time) ([]models.AutoResolveCandidate, error) {
+	query := `
+		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
+		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved,
+		       auto_resolve_approved_at
+		FROM emergencies
+		WHERE status = 'ACTIVE'
+		  AND activated_at <= $1
+		ORDER BY activated_at
+	`
+
+	rows, err := r.db.Query(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unattended emergencies: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.AutoResolveCandidate
+	for rows.Next() {
+		var candidate models.AutoResolveCandidate
+		emergency := &candidate.Emergency
+		err := rows.Scan(
+			&emergency.ID,
+			&emergency.UserID,
+			&emergency.EmergencyType,
+			&emergency.Status,
+			&emergency.InitialLocation,
+			&emergency.InitialMessage,
+			&emergency.AutoTriggered,
+			&emergency.TriggeredBy,
+			&emergency.CountdownSeconds,
+			&emergency.CreatedAt,
+			&emergency.ActivatedAt,
+			&emergency.CancelledAt,
+			&emergency.ResolvedAt,
+			&emergency.ResolutionNotes,
+			&emergency.Metadata,
+			&emergency.ClaimedBy,
+			&emergency.ClaimedByName,
+			&emergency.ClaimedAt,
+			&emergency.IsTest,
+			&emergency.NearbyFacilities,
+			&emergency.WeatherContext,
+			&emergency.CallBridge,
+			&emergency.ActivationChecks,
+			&emergency.AutoResolved,
+			&candidate.ApprovedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unattended emergency: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// ApproveAutoResolve records operator sign-off for auto-resolving an ACTIVE
+// emergency; re-approval overwrites the previous sign-off
+func (r *EmergencyRepository) ApproveAutoResolve(ctx context.Context, id, operatorID uuid.UUID, operatorName string) error {
+	query := `
+		UPDATE emergencies
+		SET auto_resolve_approved_at = NOW(), auto_resolve_approved_by = $2, auto_resolve_approved_by_name = $3
+		WHERE id = $1 AND status = 'ACTIVE'
+	`
+
+	result, err := r.db.Exec(ctx, query, id, operatorID, operatorName)
+	if err != nil {
+		return fmt.Errorf("failed to approve auto-resolution: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
+// AutoResolve resolves an ACTIVE emergency on the policy's behalf, setting
+// the auto_resolved flag that keeps it distinguishable from a manual
+// resolution
+func (r *EmergencyRepository) AutoResolve(ctx context.Context, id uuid.UUID, notes string) error {
+	query := `
+		UPDATE emergencies
+		SET status = $1, resolved_at = $2, resolution_notes = $3, auto_resolved = TRUE
+		WHERE id = $4 AND status = 'ACTIVE'
+	`
+
+	result, err := r.db.Exec(ctx, query, models.StatusResolved, time.Now(), notes, id)
+	if err != nil {
+		return fmt.Errorf("failed to auto-resolve emergency: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmergencyNotFound
+	}
+
+	return nil
+}
+
 // Delete soft deletes an emergency (not used in production, for testing only)
 func (r *EmergencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM emergencies WHERE id = $1`
@@ -263,7 +718,8 @@ func (r *EmergencyRepository) ListWithFilters(ctx context.Context, filters model
 	query := `
 		SELECT id, user_id, emergency_type, status, initial_location, initial_message,
 		       auto_triggered, triggered_by, countdown_seconds, created_at, activated_at,
-		       cancelled_at, resolved_at, resolution_notes, metadata
+		       cancelled_at, resolved_at, resolution_notes, metadata,
+		       claimed_by, claimed_by_name, claimed_at, is_test, nearby_facilities, weather_context, call_bridge, activation_checks, auto_resolved
 		FROM emergencies
 		WHERE user_id = $1
 	`
@@ -295,6 +751,14 @@ func (r *EmergencyRepository) ListWithFilters(ctx context.Context, filters model
 		argPos++
 	}
 
+	searchArgPos := 0
+	if filters.Search != nil {
+		query += fmt.Sprintf(" AND search_vector @@ websearch_to_tsquery('english', $%d)", argPos)
+		args = append(args, *filters.Search)
+		searchArgPos = argPos
+		argPos++
+	}
+
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS filtered", query)
 	var total int
@@ -303,8 +767,12 @@ func (r *EmergencyRepository) ListWithFilters(ctx context.Context, filters model
 		return nil, 0, fmt.Errorf("failed to count emergencies: %w", err)
 	}
 
-	// Add ordering and pagination
-	query += " ORDER BY created_at DESC"
+	// Add ordering and pagination; search results are ranked by relevance
+	if searchArgPos > 0 {
+		query += fmt.Sprintf(" ORDER BY ts_rank(search_vector, websearch_to_tsquery('english', $%d)) DESC, created_at DESC", searchArgPos)
+	} else {
+		query += " ORDER BY created_at DESC"
+	}
 
 	if filters.PageSize > 0 {
 		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argPos, argPos+1)
@@ -337,6 +805,15 @@ func (r *EmergencyRepository) ListWithFilters(ctx context.Context, filters model
 			&emergency.ResolvedAt,
 			&emergency.ResolutionNotes,
 			&emergency.Metadata,
+			&emergency.ClaimedBy,
+			&emergency.ClaimedByName,
+			&emergency.ClaimedAt,
+			&emergency.IsTest,
+			&emergency.NearbyFacilities,
+			&emergency.WeatherContext,
+			&emergency.CallBridge,
+			&emergency.ActivationChecks,
+			&emergency.AutoResolved,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan emergency: %w", err)
@@ -350,3 +827,103 @@ func (r *EmergencyRepository) ListWithFilters(ctx context.Context, filters model
 
 	return emergencies, total, nil
 }
+
+// AnonymizeUserData strips personal content from a user's emergencies while
+// retaining the anonymized records for operational statistics (data erasure).
+// Returns the number of rows touched
+func (r *EmergencyRepository) AnonymizeUserData(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE emergencies
+		SET initial_message = NULL,
+		    resolution_notes = NULL,
+		    metadata = NULL,
+		    initial_location = '{}'::jsonb
+		WHERE user_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize emergencies: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// Claim records an operator takeover of an emergency. Returns
+// ErrEmergencyAlreadyClaimed if another operator already claimed it
+func (r *EmergencyRepository) Claim(ctx context.Context, emergencyID, operatorID uuid.UUID, operatorName string) error {
+	query := `
+		UPDATE emergencies
+		SET claimed_by = $2, claimed_by_name = $3, claimed_at = $4
+		WHERE id = $1 AND claimed_by IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, emergencyID, operatorID, operatorName, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to claim emergency: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		// Distinguish a missing emergency from one already claimed
+		var claimedBy *uuid.UUID
+		err := r.db.QueryRow(ctx, "SELECT claimed_by FROM emergencies WHERE id = $1", emergencyID).Scan(&claimedBy)
+		if err == pgx.ErrNoRows {
+			return ErrEmergencyNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check claim state: %w", err)
+		}
+		return ErrEmergencyAlreadyClaimed
+	}
+
+	return nil
+}
+
+// GetStats aggregates emergency counts by status and type plus the average
+// time-to-resolution. Test-mode emergencies (drills) are excluded so they
+// don't skew analytics
+func (r *EmergencyRepository) GetStats(ctx context.Context) (*models.EmergencyStats, error) {
+	stats := &models.EmergencyStats{
+		ByStatus: make(map[models.EmergencyStatus]int),
+		ByType:   make(map[models.EmergencyType]int),
+	}
+
+	query := `
+		SELECT status, emergency_type, COUNT(*)
+		FROM emergencies
+		WHERE NOT is_test
+		GROUP BY status, emergency_type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status models.EmergencyStatus
+		var emergencyType models.EmergencyType
+		var count int
+		if err := rows.Scan(&status, &emergencyType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan emergency stats: %w", err)
+		}
+		stats.Total += count
+		stats.ByStatus[status] += count
+		stats.ByType[emergencyType] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read emergency stats: %w", err)
+	}
+
+	avgQuery := `
+		SELECT AVG(EXTRACT(EPOCH FROM (resolved_at - created_at)))
+		FROM emergencies
+		WHERE NOT is_test AND resolved_at IS NOT NULL
+	`
+	if err := r.db.QueryRow(ctx, avgQuery).Scan(&stats.AvgResolutionSeconds); err != nil {
+		return nil, fmt.Errorf("failed to get average resolution time: %w", err)
+	}
+
+	return stats, nil
+}
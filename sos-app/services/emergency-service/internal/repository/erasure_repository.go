@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+var (
+	// ErrErasureNotFound is returned when no matching erasure request exists
+	ErrErasureNotFound = errors.New("erasure request not found")
+)
+
+// ErasureRepository handles database operations for data erasure requests
+type ErasureRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewErasureRepository creates a new ErasureRepository
+func NewErasureRepository(db *pgxpool.Pool) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// Create schedules an erasure request
+func (r *ErasureRepository) Create(ctx context.Context, request *models.ErasureRequest) error {
+	query := `
+		INSERT INTO data_erasure_requests (user_id, status, legal_hold_until)
+		VALUES ($1, $2, $3)
+		RETURNING id, requested_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		request.UserID,
+		request.Status,
+		request.LegalHoldUntil,
+	).Scan(&request.ID, &request.RequestedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create erasure request: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser retrieves all erasure requests for a user, newest first
+func (r *ErasureRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.ErasureRequest, error) {
+	query := `
+		SELECT id, user_id, status, legal_hold_until, requested_at, processed_at
+		FROM data_erasure_requests
+		WHERE user_id = $1
+		ORDER BY requested_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasure requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []models.ErasureRequest
+	for rows.Next() {
+		var request models.ErasureRequest
+		err := rows.Scan(
+			&request.ID,
+			&request.UserID,
+			&request.Status,
+			&request.LegalHoldUntil,
+			&request.RequestedAt,
+			&request.ProcessedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan erasure request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+// ListDue retrieves scheduled requests whose legal hold has expired
+func (r *ErasureRepository) ListDue(ctx context.Context) ([]models.ErasureRequest, error) {
+	query := `
+		SELECT id, user_id, status, legal_hold_until, requested_at, processed_at
+		FROM data_erasure_requests
+		WHERE status = 'SCHEDULED'
+		  AND (legal_hold_until IS NULL OR legal_hold_until <= NOW())
+		ORDER BY requested_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due erasure requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []models.ErasureRequest
+	for rows.Next() {
+		var request models.ErasureRequest
+		err := rows.Scan(
+			&request.ID,
+			&request.UserID,
+			&request.Status,
+			&request.LegalHoldUntil,
+			&request.RequestedAt,
+			&request.ProcessedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan erasure request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+// GetLatestByUser retrieves the most recent erasure request for a user
+func (r *ErasureRepository) GetLatestByUser(ctx context.Context, userID uuid.UUID) (*models.ErasureRequest, error) {
+	query := `
+		SELECT id, user_id, status, legal_hold_until, requested_at, processed_at
+		FROM data_erasure_requests
+		WHERE user_id = $1
+		ORDER BY requested_at DESC
+		LIMIT 1
+	`
+
+	var request models.ErasureRequest
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&request.ID,
+		&request.UserID,
+		&request.Status,
+		&request.LegalHoldUntil,
+		&request.RequestedAt,
+		&request.ProcessedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrErasureNotFound
+		}
+		return nil, fmt.Errorf("failed to get erasure request: %w", err)
+	}
+
+	return &request, nil
+}
+
+// RecordCompletion upserts one service's purge confirmation for a request.
+// A re-delivered completion event simply refreshes the existing row
+func (r *ErasureRepository) RecordCompletion(ctx context.Context, completion *models.ErasureCompletion) error {
+	query := `
+		INSERT INTO data_erasure_completions (request_id, service, records_purged, completed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (request_id, service)
+		DO UPDATE SET records_purged = EXCLUDED.records_purged, completed_at = EXCLUDED.completed_at
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		completion.RequestID,
+		completion.Service,
+		completion.RecordsPurged,
+		completion.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record erasure completion: %w", err)
+	}
+
+	return nil
+}
+
+// ListCompletions retrieves the per-service purge confirmations for a request
+func (r *ErasureRepository) ListCompletions(ctx context.Context, requestID uuid.UUID) ([]models.ErasureCompletion, error) {
+	query := `
+		SELECT request_id, service, records_purged, completed_at
+		FROM data_erasure_completions
+		WHERE request_id = $1
+		ORDER BY completed_at
+	`
+
+	rows, err := r.db.Query(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasure completions: %w", err)
+	}
+	defer rows.Close()
+
+	var completions []models.ErasureCompletion
+	for rows.Next() {
+		var completion models.ErasureCompletion
+		err := rows.Scan(
+			&completion.RequestID,
+			&completion.Service,
+			&completion.RecordsPurged,
+			&completion.CompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan erasure completion: %w", err)
+		}
+		completions = append(completions, completion)
+	}
+
+	return completions, rows.Err()
+}
+
+// MarkCompleted records that an erasure request has been executed
+func (r *ErasureRepository) MarkCompleted(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE data_erasure_requests
+		SET status = 'COMPLETED', processed_at = $2
+		WHERE id = $1 AND status = 'SCHEDULED'
+	`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to complete erasure request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrErasureNotFound
+	}
+
+	return nil
+}
+
+// Cancel withdraws a scheduled erasure request for a user
+func (r *ErasureRepository) Cancel(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE data_erasure_requests
+		SET status = 'CANCELLED', processed_at = $2
+		WHERE user_id = $1 AND status = 'SCHEDULED'
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to cancel erasure request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrErasureNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// EventLedgerRepository records which lifecycle events were published to
+// Kafka, so the consistency checker can find emergencies whose events never
+// made it out
+type EventLedgerRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewEventLedgerRepository creates a new event ledger repository
+func NewEventLedgerRepository(db *pgxpool.Pool) *EventLedgerRepository {
+	return &EventLedgerRepository{db: db}
+}
+
+// RecordEvent appends a published event to the ledger
+func (r *EventLedgerRepository) RecordEvent(ctx context.Context, emergencyID uuid.UUID, eventType, topic string) error {
+	query := `
+		INSERT INTO event_ledger (emergency_id, event_type, topic)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := r.db.Exec(ctx, query, emergencyID, eventType, topic); err != nil {
+		return fmt.Errorf("failed to record ledger event: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveMissingActivation returns ACTIVE emergencies with no
+// EmergencyCreated entry in the ledger - activations whose Kafka event was
+// lost, meaning downstream services never heard about the emergency
+func (r *EventLedgerRepository) ListActiveMissingActivation(ctx context.Context) ([]models.ConsistencyFinding, error) {
+	query := `
+		SELECT e.id, e.user_id, COALESCE(e.activated_at, e.created_at)
+		FROM emergencies e
+		LEFT JOIN event_ledger l ON l.emergency_id = e.id AND l.event_type = $1
+		WHERE e.status = 'ACTIVE' AND l.id IS NULL
+		ORDER BY e.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, models.LedgerEventEmergencyCreated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emergencies missing activation events: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []models.ConsistencyFinding
+	for rows.Next() {
+		var finding models.ConsistencyFinding
+		if err := rows.Scan(&finding.EmergencyID, &finding.UserID, &finding.Since); err != nil {
+			return nil, fmt.Errorf("failed to scan consistency finding: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating consistency findings: %w", err)
+	}
+
+	return findings, nil
+}
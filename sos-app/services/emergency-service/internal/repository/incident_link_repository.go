@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// Incident link errors
+var (
+	ErrIncidentLinkNotFound = errors.New("incident link not found")
+	ErrAlreadyLinked        = errors.New("emergency is already part of an incident")
+)
+
+// IncidentLinkRepository stores merge links between emergencies that belong
+// to one real-world incident
+type IncidentLinkRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIncidentLinkRepository creates a new incident link repository
+func NewIncidentLinkRepository(db *pgxpool.Pool) *IncidentLinkRepository {
+	return &IncidentLinkRepository{db: db}
+}
+
+// Create links an emergency into an incident, filling in the link's
+// generated ID and creation time. Returns ErrAlreadyLinked when the
+// emergency is already part of a live incident
+func (r *IncidentLinkRepository) Create(ctx context.Context, link *models.IncidentLink) error {
+	query := `
+		INSERT INTO incident_links (primary_emergency_id, linked_emergency_id, linked_by, linked_by_name, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		link.PrimaryEmergencyID,
+		link.LinkedEmergencyID,
+		link.LinkedBy,
+		link.LinkedByName,
+		link.Reason,
+	).Scan(&link.ID, &link.CreatedAt)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "idx_incident_links_active_linked") {
+			return ErrAlreadyLinked
+		}
+		return fmt.Errorf("failed to create incident link: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveByLinkedID returns the live link an emergency is merged under,
+// or nil when it is not part of an incident
+func (r *IncidentLinkRepository) GetActiveByLinkedID(ctx context.Context, emergencyID uuid.UUID) (*models.IncidentLink, error) {
+	query := `
+		SELECT id, primary_emergency_id, linked_emergency_id, linked_by, linked_by_name, reason,
+		       created_at, unlinked_at, unlinked_by, unlinked_by_name, unlink_reason
+		FROM incident_links
+		WHERE linked_emergency_id = $1 AND unlinked_at IS NULL
+	`
+
+	var link models.IncidentLink
+	err := r.db.QueryRow(ctx, query, emergencyID).Scan(
+		&link.ID,
+		&link.PrimaryEmergencyID,
+		&link.LinkedEmergencyID,
+		&link.LinkedBy,
+		&link.LinkedByName,
+		&link.Reason,
+		&link.CreatedAt,
+		&link.UnlinkedAt,
+		&link.UnlinkedBy,
+		&link.UnlinkedByName,
+		&link.UnlinkReason,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// ListByPrimaryID returns every link ever made under a primary emergency,
+// including split ones, oldest first - the incident's merge audit trail
+func (r *IncidentLinkRepository) ListByPrimaryID(ctx context.Context, primaryID uuid.UUID) ([]models.IncidentLink, error) {
+	query := `
+		SELECT id, primary_emergency_id, linked_emergency_id, linked_by, linked_by_name, reason,
+		       created_at, unlinked_at, unlinked_by, unlinked_by_name, unlink_reason
+		FROM incident_links
+		WHERE primary_emergency_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, primaryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.IncidentLink
+	for rows.Next() {
+		var link models.IncidentLink
+		err := rows.Scan(
+			&link.ID,
+			&link.PrimaryEmergencyID,
+			&link.LinkedEmergencyID,
+			&link.LinkedBy,
+			&link.LinkedByName,
+			&link.Reason,
+			&link.CreatedAt,
+			&link.UnlinkedAt,
+			&link.UnlinkedBy,
+			&link.UnlinkedByName,
+			&link.UnlinkReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// CountActiveByPrimaryID returns how many emergencies are currently merged
+// under a primary
+func (r *IncidentLinkRepository) CountActiveByPrimaryID(ctx context.Context, primaryID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM incident_links WHERE primary_emergency_id = $1 AND unlinked_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, primaryID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count incident links: %w", err)
+	}
+
+	return count, nil
+}
+
+// Unlink splits an emergency out of an incident, keeping the row as audit
+func (r *IncidentLinkRepository) Unlink(ctx context.Context, primaryID, linkedID, operatorID uuid.UUID, operatorName string, reason *string) error {
+	query := `
+		UPDATE incident_links
+		SET unlinked_at = NOW(), unlinked_by = $3, unlinked_by_name = $4, unlink_reason = $5
+		WHERE primary_emergency_id = $1 AND linked_emergency_id = $2 AND unlinked_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, primaryID, linkedID, operatorID, operatorName, reason)
+	if err != nil {
+		return fmt.Errorf("failed to unlink incident: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrIncidentLinkNotFound
+	}
+
+	return nil
+}
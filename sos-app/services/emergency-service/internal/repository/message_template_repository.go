@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+var (
+	// ErrTemplateNotFound is returned when a message template doesn't exist
+	ErrTemplateNotFound = errors.New("message template not found")
+)
+
+// MessageTemplateRepository handles database operations for message templates
+type MessageTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewMessageTemplateRepository creates a new MessageTemplateRepository
+func NewMessageTemplateRepository(db *pgxpool.Pool) *MessageTemplateRepository {
+	return &MessageTemplateRepository{db: db}
+}
+
+// Upsert creates a template or replaces the wording of the existing template
+// for the same (org, event type, locale) slot
+func (r *MessageTemplateRepository) Upsert(ctx context.Context, template *models.MessageTemplate) error {
+	query := `
+		INSERT INTO org_message_templates (org_id, event_type, locale, subject, body, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (org_id, event_type, locale) DO UPDATE
+		SET subject = EXCLUDED.subject, body = EXCLUDED.body, enabled = EXCLUDED.enabled, updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		template.OrgID,
+		template.EventType,
+		template.Locale,
+		template.Subject,
+		template.Body,
+		template.Enabled,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert message template: %w", err)
+	}
+
+	return nil
+}
+
+// ListByOrg retrieves all templates configured by an organization
+func (r *MessageTemplateRepository) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]models.MessageTemplate, error) {
+	query := `
+		SELECT id, org_id, event_type, locale, subject, body, enabled, created_at, updated_at
+		FROM org_message_templates
+		WHERE org_id = $1
+		ORDER BY event_type, locale
+	`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message templates: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTemplates(rows)
+}
+
+// ListEnabledByEvent retrieves every organization's enabled templates for one
+// lifecycle event, across all locales. Emergencies are not yet org-scoped,
+// so events carry a rendered message for each organization with a template
+func (r *MessageTemplateRepository) ListEnabledByEvent(ctx context.Context, eventType models.TemplateEventType) ([]models.MessageTemplate, error) {
+	query := `
+		SELECT id, org_id, event_type, locale, subject, body, enabled, created_at, updated_at
+		FROM org_message_templates
+		WHERE event_type = $1 AND enabled
+		ORDER BY org_id, locale
+	`
+
+	rows, err := r.db.Query(ctx, query, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message templates by event: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTemplates(rows)
+}
+
+// Delete removes a message template
+func (r *MessageTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM org_message_templates WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete message template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// scanTemplates reads template rows into models
+func scanTemplates(rows pgx.Rows) ([]models.MessageTemplate, error) {
+	var templates []models.MessageTemplate
+	for rows.Next() {
+		var template models.MessageTemplate
+		err := rows.Scan(
+			&template.ID,
+			&template.OrgID,
+			&template.EventType,
+			&template.Locale,
+			&template.Subject,
+			&template.Body,
+			&template.Enabled,
+			&template.CreatedAt,
+			&template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan message template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
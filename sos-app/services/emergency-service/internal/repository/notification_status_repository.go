@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// NotificationStatusRepository stores per-contact, per-channel notification
+// delivery outcomes
+type NotificationStatusRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationStatusRepository creates a new NotificationStatusRepository
+func NewNotificationStatusRepository(db *pgxpool.Pool) *NotificationStatusRepository {
+	return &NotificationStatusRepository{db: db}
+}
+
+// Upsert records the latest delivery outcome for a contact/channel pair
+func (r *NotificationStatusRepository) Upsert(ctx context.Context, status *models.NotificationStatus) error {
+	query := `
+		INSERT INTO notification_statuses (emergency_id, contact_id, contact_name, channel, status, error, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (emergency_id, contact_id, channel)
+		DO UPDATE SET contact_name = EXCLUDED.contact_name,
+		              status = EXCLUDED.status,
+		              error = EXCLUDED.error,
+		              updated_at = NOW()
+		RETURNING id, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		status.EmergencyID,
+		status.ContactID,
+		status.ContactName,
+		status.Channel,
+		status.Status,
+		status.Error,
+	).Scan(&status.ID, &status.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert notification status: %w", err)
+	}
+
+	return nil
+}
+
+// GetByEmergencyID retrieves all delivery outcomes for an emergency
+func (r *NotificationStatusRepository) GetByEmergencyID(ctx context.Context, emergencyID uuid.UUID) ([]models.NotificationStatus, error) {
+	query := `
+		SELECT id, emergency_id, contact_id, contact_name, channel, status, error, updated_at
+		FROM notification_statuses
+		WHERE emergency_id = $1
+		ORDER BY contact_name, channel
+	`
+
+	rows, err := r.db.Query(ctx, query, emergencyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []models.NotificationStatus
+	for rows.Next() {
+		var status models.NotificationStatus
+		err := rows.Scan(
+			&status.ID,
+			&status.EmergencyID,
+			&status.ContactID,
+			&status.ContactName,
+			&status.Channel,
+			&status.Status,
+			&status.Error,
+			&status.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
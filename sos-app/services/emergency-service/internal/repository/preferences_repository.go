@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// PreferencesRepository handles database operations for user preferences
+type PreferencesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPreferencesRepository creates a new PreferencesRepository
+func NewPreferencesRepository(db *pgxpool.Pool) *PreferencesRepository {
+	return &PreferencesRepository{db: db}
+}
+
+// GetByUserID retrieves a user's preferences, or nil if none are stored
+func (r *PreferencesRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	query := `
+		SELECT user_id, default_countdown_seconds, silent_mode,
+		       quick_action_types, message_templates, ack_quorums, victim_phone,
+		       check_in_reminder_minutes, created_at, updated_at
+		FROM user_emergency_preferences
+		WHERE user_id = $1
+	`
+
+	prefs := &models.UserPreferences{}
+	var quickActions, templates, quorums, reminderMinutes []byte
+
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&prefs.UserID,
+		&prefs.DefaultCountdownSeconds,
+		&prefs.SilentMode,
+		&quickActions,
+		&templates,
+		&quorums,
+		&prefs.VictimPhone,
+		&reminderMinutes,
+		&prefs.CreatedAt,
+		&prefs.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	if err := json.Unmarshal(quickActions, &prefs.QuickActionTypes); err != nil {
+		return nil, fmt.Errorf("failed to parse quick_action_types: %w", err)
+	}
+	if err := json.Unmarshal(templates, &prefs.MessageTemplates); err != nil {
+		return nil, fmt.Errorf("failed to parse message_templates: %w", err)
+	}
+	if err := json.Unmarshal(quorums, &prefs.AckQuorums); err != nil {
+		return nil, fmt.Errorf("failed to parse ack_quorums: %w", err)
+	}
+	// NULL means the user never configured reminder offsets (defaults apply)
+	if reminderMinutes != nil {
+		if err := json.Unmarshal(reminderMinutes, &prefs.CheckInReminderMinutes); err != nil {
+			return nil, fmt.Errorf("failed to parse check_in_reminder_minutes: %w", err)
+		}
+	}
+
+	return prefs, nil
+}
+
+// Upsert creates or replaces a user's preferences
+func (r *PreferencesRepository) Upsert(ctx context.Context, prefs *models.UserPreferences) error {
+	quickActions, err := json.Marshal(prefs.QuickActionTypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quick_action_types: %w", err)
+	}
+
+	templates, err := json.Marshal(prefs.MessageTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message_templates: %w", err)
+	}
+
+	quorums, err := json.Marshal(prefs.AckQuorums)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ack_quorums: %w", err)
+	}
+
+	// nil keeps the column NULL so the default reminder offsets apply; an
+	// explicitly empty list is stored and disables reminders
+	var reminderMinutes []byte
+	if prefs.CheckInReminderMinutes != nil {
+		reminderMinutes, err = json.Marshal(prefs.CheckInReminderMinutes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal check_in_reminder_minutes: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO user_emergency_preferences (
+			user_id, default_countdown_seconds, silent_mode,
+			quick_action_types, message_templates, ack_quorums, victim_phone,
+			check_in_reminder_minutes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_countdown_seconds = EXCLUDED.default_countdown_seconds,
+			silent_mode = EXCLUDED.silent_mode,
+			quick_action_types = EXCLUDED.quick_action_types,
+			message_templates = EXCLUDED.message_templates,
+			ack_quorums = EXCLUDED.ack_quorums,
+			victim_phone = EXCLUDED.victim_phone,
+			check_in_reminder_minutes = EXCLUDED.check_in_reminder_minutes,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		prefs.UserID,
+		prefs.DefaultCountdownSeconds,
+		prefs.SilentMode,
+		quickActions,
+		templates,
+		quorums,
+		prefs.VictimPhone,
+		reminderMinutes,
+	).Scan(&prefs.CreatedAt, &prefs.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert preferences: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a user's stored preferences (data erasure)
+func (r *PreferencesRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM user_emergency_preferences WHERE user_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+var (
+	// ErrConnectorNotFound is returned when a webhook connector doesn't exist
+	ErrConnectorNotFound = errors.New("webhook connector not found")
+)
+
+// WebhookConnectorRepository handles database operations for webhook connectors
+type WebhookConnectorRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookConnectorRepository creates a new WebhookConnectorRepository
+func NewWebhookConnectorRepository(db *pgxpool.Pool) *WebhookConnectorRepository {
+	return &WebhookConnectorRepository{db: db}
+}
+
+// Create registers a new webhook connector
+func (r *WebhookConnectorRepository) Create(ctx context.Context, connector *models.WebhookConnector) error {
+	query := `
+		INSERT INTO org_webhook_connectors (org_id, provider, name, webhook_url, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		connector.OrgID,
+		connector.Provider,
+		connector.Name,
+		connector.WebhookURL,
+		connector.Enabled,
+	).Scan(&connector.ID, &connector.CreatedAt, &connector.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook connector: %w", err)
+	}
+
+	return nil
+}
+
+// ListByOrg retrieves all connectors configured by an organization
+func (r *WebhookConnectorRepository) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]models.WebhookConnector, error) {
+	query := `
+		SELECT id, org_id, provider, name, webhook_url, enabled, created_at, updated_at
+		FROM org_webhook_connectors
+		WHERE org_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook connectors: %w", err)
+	}
+	defer rows.Close()
+
+	return scanConnectors(rows)
+}
+
+// ListEnabled retrieves all enabled connectors across organizations.
+// Emergencies are not yet org-scoped, so every enabled connector receives
+// incident cards for the deployment
+func (r *WebhookConnectorRepository) ListEnabled(ctx context.Context) ([]models.WebhookConnector, error) {
+	query := `
+		SELECT id, org_id, provider, name, webhook_url, enabled, created_at, updated_at
+		FROM org_webhook_connectors
+		WHERE enabled
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enabled webhook connectors: %w", err)
+	}
+	defer rows.Close()
+
+	return scanConnectors(rows)
+}
+
+// Delete removes a webhook connector
+func (r *WebhookConnectorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM org_webhook_connectors WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook connector: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrConnectorNotFound
+	}
+
+	return nil
+}
+
+// GetThreadRef retrieves the stored thread reference for a connector and
+// emergency, or "" if no trigger card has been threaded yet
+func (r *WebhookConnectorRepository) GetThreadRef(ctx context.Context, connectorID, emergencyID uuid.UUID) (string, error) {
+	query := `
+		SELECT thread_ref FROM webhook_threads
+		WHERE connector_id = $1 AND emergency_id = $2
+	`
+
+	var threadRef string
+	err := r.db.QueryRow(ctx, query, connectorID, emergencyID).Scan(&threadRef)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get webhook thread ref: %w", err)
+	}
+
+	return threadRef, nil
+}
+
+// SaveThreadRef stores the thread reference returned for a trigger card
+func (r *WebhookConnectorRepository) SaveThreadRef(ctx context.Context, connectorID, emergencyID uuid.UUID, threadRef string) error {
+	query := `
+		INSERT INTO webhook_threads (connector_id, emergency_id, thread_ref)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (connector_id, emergency_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, connectorID, emergencyID, threadRef); err != nil {
+		return fmt.Errorf("failed to save webhook thread ref: %w", err)
+	}
+
+	return nil
+}
+
+// scanConnectors reads connector rows into models
+func scanConnectors(rows pgx.Rows) ([]models.WebhookConnector, error) {
+	var connectors []models.WebhookConnector
+	for rows.Next() {
+		var connector models.WebhookConnector
+		err := rows.Scan(
+			&connector.ID,
+			&connector.OrgID,
+			&connector.Provider,
+			&connector.Name,
+			&connector.WebhookURL,
+			&connector.Enabled,
+			&connector.CreatedAt,
+			&connector.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook connector: %w", err)
+		}
+		connectors = append(connectors, connector)
+	}
+
+	return connectors, rows.Err()
+}
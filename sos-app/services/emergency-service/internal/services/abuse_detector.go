@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// abuseWindow is the sliding window cancelled triggers are counted over
+const abuseWindow = 24 * time.Hour
+
+// AbuseDetector raises soft-lock flags for users whose cancelled triggers
+// cross the configured daily quota, so prank usage doesn't desensitize their
+// contacts. A soft-lock holds back repeat triggers until support clears the
+// flag; a user's first trigger of the day always goes through
+type AbuseDetector struct {
+	emergencyRepo   *repository.EmergencyRepository
+	flagRepo        *repository.AbuseFlagRepository
+	cancelledPerDay int
+}
+
+// NewAbuseDetector creates a new abuse detector. cancelledPerDay is the
+// cancelled-trigger quota per 24 hours; zero disables detection
+func NewAbuseDetector(emergencyRepo *repository.EmergencyRepository, flagRepo *repository.AbuseFlagRepository, cancelledPerDay int) *AbuseDetector {
+	return &AbuseDetector{
+		emergencyRepo:   emergencyRepo,
+		flagRepo:        flagRepo,
+		cancelledPerDay: cancelledPerDay,
+	}
+}
+
+// Enabled reports whether abuse detection is configured
+func (d *AbuseDetector) Enabled() bool {
+	return d != nil && d.cancelledPerDay > 0
+}
+
+// RecordCancellation re-counts the user's cancelled triggers after a
+// cancellation and raises a flag when the quota is crossed. Failures are
+// logged and swallowed: abuse accounting never breaks a cancellation
+func (d *AbuseDetector) RecordCancellation(ctx context.Context, emergency *models.Emergency) {
+	if !d.Enabled() || emergency.IsTest {
+		return
+	}
+
+	cancelled, err := d.emergencyRepo.CountCancelledByUserSince(ctx, emergency.UserID, time.Now().Add(-abuseWindow))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", emergency.UserID.String()).Msg("Failed to count cancelled triggers")
+		return
+	}
+	if cancelled < d.cancelledPerDay {
+		return
+	}
+
+	flag := &models.AbuseFlag{
+		UserID:         emergency.UserID,
+		Reason:         fmt.Sprintf("%d cancelled triggers within 24 hours (quota %d)", cancelled, d.cancelledPerDay),
+		CancelledCount: cancelled,
+	}
+	if err := d.flagRepo.Create(ctx, flag); err != nil {
+		log.Error().Err(err).Str("user_id", emergency.UserID.String()).Msg("Failed to raise abuse flag")
+		return
+	}
+
+	log.Warn().
+		Str("user_id", emergency.UserID.String()).
+		Int("cancelled_count", cancelled).
+		Msg("Abuse flag raised: cancelled-trigger quota crossed")
+}
+
+// CheckTrigger returns the user's active flag when the trigger should be
+// held back. A user with no emergency inside the window is never blocked:
+// the soft-lock must not stop a legitimate first trigger. Lookup failures
+// fail open — a database hiccup must not block an SOS
+func (d *AbuseDetector) CheckTrigger(ctx context.Context, userID uuid.UUID) *models.AbuseFlag {
+	if !d.Enabled() {
+		return nil
+	}
+
+	flag, err := d.flagRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to check abuse flag")
+		return nil
+	}
+	if flag == nil {
+		return nil
+	}
+
+	recent, err := d.emergencyRepo.CountByUserSince(ctx, userID, time.Now().Add(-abuseWindow))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to count recent emergencies")
+		return nil
+	}
+	if recent == 0 {
+		return nil
+	}
+
+	return flag
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// activationHookTimeout bounds each hook so one slow policy lookup cannot
+// delay the whole activation
+const activationHookTimeout = 3 * time.Second
+
+// ActivationHook is one pre-activation check run when a countdown completes.
+// Hooks are strictly advisory: whatever they conclude, the emergency
+// activates. Their value is the audit trail - when a contact asks why an
+// alert went out with a stale location, the recorded outcomes answer it
+type ActivationHook interface {
+	// Name identifies the hook in the recorded audit trail
+	Name() string
+	// Check inspects the emergency about to activate. passed reports the
+	// check's verdict and detail carries a human-readable explanation or
+	// policy decision; a non-nil error records the hook itself as broken
+	Check(ctx context.Context, emergency *models.Emergency) (passed bool, detail string, err error)
+}
+
+// RunActivationHooks executes the hook chain against an emergency and
+// returns the recorded outcomes. Hooks fail open: an error, timeout or
+// panic is recorded as an ERROR outcome and the chain continues - a broken
+// check must never stand between a user and their alert
+func RunActivationHooks(ctx context.Context, hooks []ActivationHook, emergency *models.Emergency) models.ActivationChecks {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	checks := make(models.ActivationChecks, 0, len(hooks))
+	for _, hook := range hooks {
+		checks = append(checks, runActivationHook(ctx, hook, emergency))
+	}
+	return checks
+}
+
+// runActivationHook executes a single hook with a timeout and panic guard
+func runActivationHook(ctx context.Context, hook ActivationHook, emergency *models.Emergency) (check models.ActivationCheck) {
+	hookCtx, cancel := context.WithTimeout(ctx, activationHookTimeout)
+	defer cancel()
+
+	start := time.Now()
+	check = models.ActivationCheck{
+		Name:      hook.Name(),
+		CheckedAt: start.UTC(),
+	}
+	defer func() {
+		check.DurationMs = time.Since(start).Milliseconds()
+		if recovered := recover(); recovered != nil {
+			log.Error().
+				Str("hook", hook.Name()).
+				Str("emergency_id", emergency.ID.String()).
+				Interface("panic", recovered).
+				Msg("Activation hook panicked")
+			check.Outcome = models.CheckError
+			check.Detail = fmt.Sprintf("hook panicked: %v", recovered)
+		}
+	}()
+
+	passed, detail, err := hook.Check(hookCtx, emergency)
+	check.Detail = detail
+	switch {
+	case err != nil:
+		log.Error().
+			Err(err).
+			Str("hook", hook.Name()).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Activation hook failed to run")
+		check.Outcome = models.CheckError
+		check.Detail = err.Error()
+	case passed:
+		check.Outcome = models.CheckPassed
+	default:
+		check.Outcome = models.CheckFailed
+	}
+	return check
+}
+
+// LocationFreshnessHook flags activations whose initial fix is older than
+// maxAge, so responders know the position on the alert may be stale
+type LocationFreshnessHook struct {
+	maxAge time.Duration
+}
+
+// NewLocationFreshnessHook creates a location freshness hook
+func NewLocationFreshnessHook(maxAge time.Duration) *LocationFreshnessHook {
+	return &LocationFreshnessHook{maxAge: maxAge}
+}
+
+// Name implements ActivationHook
+func (h *LocationFreshnessHook) Name() string {
+	return "location_freshness"
+}
+
+// Check implements ActivationHook
+func (h *LocationFreshnessHook) Check(ctx context.Context, emergency *models.Emergency) (bool, string, error) {
+	timestamp := emergency.InitialLocation.Timestamp
+	if timestamp.IsZero() {
+		return false, "initial location carries no timestamp", nil
+	}
+
+	age := time.Since(timestamp)
+	if age > h.maxAge {
+		return false, fmt.Sprintf("initial location is %s old (limit %s)", age.Round(time.Second), h.maxAge), nil
+	}
+	return true, fmt.Sprintf("initial location is %s old", age.Round(time.Second)), nil
+}
+
+// VictimReachabilityHook flags activations for users with no callback number
+// on file: escalation can still notify contacts, but cannot dial the victim
+// into a call bridge
+type VictimReachabilityHook struct {
+	prefsRepo *repository.PreferencesRepository
+}
+
+// NewVictimReachabilityHook creates a victim reachability hook
+func NewVictimReachabilityHook(prefsRepo *repository.PreferencesRepository) *VictimReachabilityHook {
+	return &VictimReachabilityHook{prefsRepo: prefsRepo}
+}
+
+// Name implements ActivationHook
+func (h *VictimReachabilityHook) Name() string {
+	return "victim_reachability"
+}
+
+// Check implements ActivationHook
+func (h *VictimReachabilityHook) Check(ctx context.Context, emergency *models.Emergency) (bool, string, error) {
+	prefs, err := h.prefsRepo.GetByUserID(ctx, emergency.UserID)
+	if err != nil {
+		return false, "", err
+	}
+
+	if prefs == nil || prefs.VictimPhone == nil || *prefs.VictimPhone == "" {
+		return false, "no victim callback number on file", nil
+	}
+	return true, "victim callback number on file", nil
+}
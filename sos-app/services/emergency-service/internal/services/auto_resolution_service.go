@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/kafka"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// autoResolutionSweepInterval is how often the auto-resolution sweep runs
+const autoResolutionSweepInterval = 15 * time.Minute
+
+// Default auto-resolution policy windows
+const (
+	// DefaultAutoResolveSafeAfter is how long an ACTIVE emergency must be
+	// old before a completed check-in from its user counts as "safe" and
+	// lets the sweep close it
+	DefaultAutoResolveSafeAfter = 24 * time.Hour
+	// DefaultAutoResolveUnattendedAfter is how long an ACTIVE emergency
+	// may sit without any resolution before the sweep closes it outright
+	DefaultAutoResolveUnattendedAfter = 72 * time.Hour
+)
+
+// DefaultAutoResolveCriticalTypes are the emergency types the sweep never
+// closes without explicit operator sign-off
+var DefaultAutoResolveCriticalTypes = []models.EmergencyType{
+	models.EmergencyTypeMedical,
+	models.EmergencyTypeFire,
+	models.EmergencyTypeFallDetected,
+}
+
+// AutoResolutionPolicy tunes when unattended emergencies are auto-resolved.
+// Zero values fall back to the defaults
+type AutoResolutionPolicy struct {
+	SafeCheckInAfter time.Duration
+	UnattendedAfter  time.Duration
+	CriticalTypes    []models.EmergencyType
+}
+
+// AutoResolutionStats counts what the sweep has done since startup
+type AutoResolutionStats struct {
+	ResolvedSafe       int64 `json:"resolved_safe"`
+	ResolvedUnattended int64 `json:"resolved_unattended"`
+	AwaitingApproval   int64 `json:"awaiting_approval"`
+	Failures           int64 `json:"failures"`
+}
+
+// AutoResolutionService closes emergencies nobody resolves manually, so
+// nothing stays ACTIVE forever: earlier when the user signalled safety by
+// completing a check-in, later outright - except critical types, which wait
+// for operator approval. Auto-resolved emergencies carry the auto_resolved
+// flag and all parties are notified through the normal resolution events
+type AutoResolutionService struct {
+	emergencyRepo   *repository.EmergencyRepository
+	producer        *kafka.Producer
+	webhookNotifier *WebhookNotifier
+	policy          AutoResolutionPolicy
+	stopChan        chan struct{}
+
+	resolvedSafe       int64
+	resolvedUnattended int64
+	awaitingApproval   int64
+	failures           int64
+}
+
+// NewAutoResolutionService creates a new AutoResolutionService. producer and
+// webhookNotifier may be nil, disabling the respective notifications
+func NewAutoResolutionService(
+	emergencyRepo *repository.EmergencyRepository,
+	producer *kafka.Producer,
+	webhookNotifier *WebhookNotifier,
+	policy AutoResolutionPolicy,
+) *AutoResolutionService {
+	if policy.SafeCheckInAfter <= 0 {
+		policy.SafeCheckInAfter = DefaultAutoResolveSafeAfter
+	}
+	if policy.UnattendedAfter <= 0 {
+		policy.UnattendedAfter = DefaultAutoResolveUnattendedAfter
+	}
+	if policy.CriticalTypes == nil {
+		policy.CriticalTypes = DefaultAutoResolveCriticalTypes
+	}
+
+	return &AutoResolutionService{
+		emergencyRepo:   emergencyRepo,
+		producer:        producer,
+		webhookNotifier: webhookNotifier,
+		policy:          policy,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins the periodic auto-resolution sweep
+func (s *AutoResolutionService) Start(ctx context.Context) {
+	log.Info().
+		Dur("safe_after", s.policy.SafeCheckInAfter).
+		Dur("unattended_after", s.policy.UnattendedAfter).
+		Interface("critical_types", s.policy.CriticalTypes).
+		Msg("Auto-resolution service started")
+
+	go func() {
+		ticker := time.NewTicker(autoResolutionSweepInterval)
+		defer ticker.Stop()
+
+		s.sweep(ctx)
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep(ctx)
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic sweep
+func (s *AutoResolutionService) Stop() {
+	close(s.stopChan)
+}
+
+// Stats returns what the sweep has done since startup
+func (s *AutoResolutionService) Stats() AutoResolutionStats {
+	return AutoResolutionStats{
+		ResolvedSafe:       atomic.LoadInt64(&s.resolvedSafe),
+		ResolvedUnattended: atomic.LoadInt64(&s.resolvedUnattended),
+		AwaitingApproval:   atomic.LoadInt64(&s.awaitingApproval),
+		Failures:           atomic.LoadInt64(&s.failures),
+	}
+}
+
+// sweep applies the policy once
+func (s *AutoResolutionService) sweep(ctx context.Context) {
+	now := time.Now()
+
+	// A completed check-in after activation means the user reported safe;
+	// those emergencies close after the shorter window
+	safe, err := s.emergencyRepo.ListActiveSafeCheckedIn(ctx, now.Add(-s.policy.SafeCheckInAfter))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list safe checked-in emergencies")
+	} else {
+		for _, emergency := range safe {
+			if s.resolve(ctx, &emergency, "Auto-resolved: user reported safe via a completed check-in") {
+				atomic.AddInt64(&s.resolvedSafe, 1)
+			}
+		}
+	}
+
+	// Anything still ACTIVE past the unattended window closes outright,
+	// unless its type is critical and no operator has signed off yet
+	candidates, err := s.emergencyRepo.ListActiveUnattended(ctx, now.Add(-s.policy.UnattendedAfter))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list unattended emergencies")
+		return
+	}
+
+	var awaiting int64
+	for _, candidate := range candidates {
+		emergency := candidate.Emergency
+		if s.isCritical(emergency.EmergencyType) && candidate.ApprovedAt == nil {
+			awaiting++
+			log.Warn().
+				Str("emergency_id", emergency.ID.String()).
+				Str("emergency_type", string(emergency.EmergencyType)).
+				Time("activated_at", *emergency.ActivatedAt).
+				Msg("Critical emergency eligible for auto-resolution awaits operator approval")
+			continue
+		}
+
+		notes := fmt.Sprintf("Auto-resolved after %s unattended", s.policy.UnattendedAfter)
+		if candidate.ApprovedAt != nil {
+			notes += " (operator approved)"
+		}
+		if s.resolve(ctx, &emergency, notes) {
+			atomic.AddInt64(&s.resolvedUnattended, 1)
+		}
+	}
+	atomic.StoreInt64(&s.awaitingApproval, awaiting)
+}
+
+// ListAwaitingApproval returns the critical emergencies past the unattended
+// window that cannot be auto-resolved until an operator signs off
+func (s *AutoResolutionService) ListAwaitingApproval(ctx context.Context) ([]models.Emergency, error) {
+	candidates, err := s.emergencyRepo.ListActiveUnattended(ctx, time.Now().Add(-s.policy.UnattendedAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	var awaiting []models.Emergency
+	for _, candidate := range candidates {
+		if s.isCritical(candidate.Emergency.EmergencyType) && candidate.ApprovedAt == nil {
+			awaiting = append(awaiting, candidate.Emergency)
+		}
+	}
+	return awaiting, nil
+}
+
+// ApproveAndResolve records operator sign-off for an emergency. When it is
+// already past the unattended window it is resolved immediately; otherwise
+// the approval is kept and a later sweep closes it. Returns the resolved
+// flag so callers can tell the operator what happened
+func (s *AutoResolutionService) ApproveAndResolve(ctx context.Context, id, operatorID uuid.UUID, operatorName string) (bool, error) {
+	if err := s.emergencyRepo.ApproveAutoResolve(ctx, id, operatorID, operatorName); err != nil {
+		return false, err
+	}
+
+	log.Info().
+		Str("emergency_id", id.String()).
+		Str("operator_id", operatorID.String()).
+		Msg("Auto-resolution approved by operator")
+
+	emergency, err := s.emergencyRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if emergency.ActivatedAt == nil || time.Since(*emergency.ActivatedAt) < s.policy.UnattendedAfter {
+		return false, nil
+	}
+
+	notes := fmt.Sprintf("Auto-resolved after %s unattended (operator approved)", s.policy.UnattendedAfter)
+	if !s.resolve(ctx, emergency, notes) {
+		return false, nil
+	}
+	atomic.AddInt64(&s.resolvedUnattended, 1)
+	return true, nil
+}
+
+// resolve closes one emergency and notifies all parties through the normal
+// resolution channels
+func (s *AutoResolutionService) resolve(ctx context.Context, emergency *models.Emergency, notes string) bool {
+	if err := s.emergencyRepo.AutoResolve(ctx, emergency.ID, notes); err != nil {
+		// Someone else resolved it between listing and closing; not a failure
+		if err == repository.ErrEmergencyNotFound {
+			return false
+		}
+		atomic.AddInt64(&s.failures, 1)
+		log.Error().Err(err).Str("emergency_id", emergency.ID.String()).Msg("Failed to auto-resolve emergency")
+		return false
+	}
+
+	now := time.Now()
+	emergency.Status = models.StatusResolved
+	emergency.ResolvedAt = &now
+	emergency.ResolutionNotes = &notes
+	emergency.AutoResolved = true
+
+	log.Info().
+		Str("emergency_id", emergency.ID.String()).
+		Str("user_id", emergency.UserID.String()).
+		Str("notes", notes).
+		Msg("Emergency auto-resolved")
+
+	if s.producer != nil {
+		if err := s.producer.PublishEmergencyResolved(ctx, emergency); err != nil {
+			log.Error().Err(err).Str("emergency_id", emergency.ID.String()).Msg("Failed to publish auto-resolution event")
+		}
+	}
+	if s.webhookNotifier != nil {
+		s.webhookNotifier.NotifyResolved(ctx, emergency)
+	}
+
+	return true
+}
+
+// isCritical reports whether the type requires operator approval
+func (s *AutoResolutionService) isCritical(emergencyType models.EmergencyType) bool {
+	for _, critical := range s.policy.CriticalTypes {
+		if emergencyType == critical {
+			return true
+		}
+	}
+	return false
+}
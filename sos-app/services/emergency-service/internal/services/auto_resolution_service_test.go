@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func TestNewAutoResolutionServiceDefaults(t *testing.T) {
+	service := NewAutoResolutionService(nil, nil, nil, AutoResolutionPolicy{})
+
+	if service.policy.SafeCheckInAfter != DefaultAutoResolveSafeAfter {
+		t.Errorf("expected default safe window %s, got %s", DefaultAutoResolveSafeAfter, service.policy.SafeCheckInAfter)
+	}
+	if service.policy.UnattendedAfter != DefaultAutoResolveUnattendedAfter {
+		t.Errorf("expected default unattended window %s, got %s", DefaultAutoResolveUnattendedAfter, service.policy.UnattendedAfter)
+	}
+	if len(service.policy.CriticalTypes) == 0 {
+		t.Error("expected default critical types to be set")
+	}
+}
+
+func TestAutoResolutionIsCritical(t *testing.T) {
+	service := NewAutoResolutionService(nil, nil, nil, AutoResolutionPolicy{
+		SafeCheckInAfter: time.Hour,
+		UnattendedAfter:  2 * time.Hour,
+	})
+
+	if !service.isCritical(models.EmergencyTypeMedical) {
+		t.Error("expected MEDICAL to require operator approval by default")
+	}
+	if service.isCritical(models.EmergencyTypeGeneral) {
+		t.Error("expected GENERAL to auto-resolve without approval")
+	}
+
+	// An explicit critical set replaces the default entirely
+	service = NewAutoResolutionService(nil, nil, nil, AutoResolutionPolicy{
+		CriticalTypes: []models.EmergencyType{models.EmergencyTypeGeneral},
+	})
+	if service.isCritical(models.EmergencyTypeMedical) {
+		t.Error("expected MEDICAL to be non-critical under a custom policy")
+	}
+	if !service.isCritical(models.EmergencyTypeGeneral) {
+		t.Error("expected GENERAL to be critical under a custom policy")
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// bridgeCreateTimeout bounds the provider round trip; bridge creation runs
+// inside the escalation path and must not stall it
+const bridgeCreateTimeout = 10 * time.Second
+
+// CallBridgeService creates audio conference bridges through the configured
+// bridge provider. The provider exposes POST {base}/conferences, accepts the
+// emergency ID plus the participant numbers to dial out to, and returns the
+// conference details; a thin Twilio conferencing adapter typically sits
+// behind this URL
+type CallBridgeService struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewCallBridgeService creates a new CallBridgeService. baseURL is the
+// bridge provider endpoint; an empty baseURL disables call bridges
+func NewCallBridgeService(baseURL string) *CallBridgeService {
+	return &CallBridgeService{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: bridgeCreateTimeout},
+	}
+}
+
+// Enabled reports whether a bridge provider is configured
+func (s *CallBridgeService) Enabled() bool {
+	return s != nil && s.baseURL != ""
+}
+
+// bridgeProviderRequest is the payload sent to the bridge provider
+type bridgeProviderRequest struct {
+	EmergencyID  string   `json:"emergency_id"`
+	Participants []string `json:"participants"`
+}
+
+// bridgeProviderResponse is the provider's conference details
+type bridgeProviderResponse struct {
+	Provider     string `json:"provider"`
+	ConferenceID string `json:"conference_id"`
+	DialIn       string `json:"dial_in"`
+	AccessCode   string `json:"access_code"`
+}
+
+// CreateBridge creates a conference bridge for an emergency and dials the
+// given participant numbers into it
+func (s *CallBridgeService) CreateBridge(ctx context.Context, emergency *models.Emergency, participants []string) (*models.CallBridge, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("no participants to dial into the bridge")
+	}
+
+	body, err := json.Marshal(bridgeProviderRequest{
+		EmergencyID:  emergency.ID.String(),
+		Participants: participants,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bridge request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/conferences", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bridge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bridge provider request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("bridge provider returned status %d", resp.StatusCode)
+	}
+
+	var providerResp bridgeProviderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&providerResp); err != nil {
+		return nil, fmt.Errorf("failed to decode bridge response: %w", err)
+	}
+	if providerResp.ConferenceID == "" {
+		return nil, fmt.Errorf("bridge provider returned no conference ID")
+	}
+
+	provider := providerResp.Provider
+	if provider == "" {
+		provider = "twilio"
+	}
+
+	return &models.CallBridge{
+		Provider:     provider,
+		ConferenceID: providerResp.ConferenceID,
+		DialIn:       providerResp.DialIn,
+		AccessCode:   providerResp.AccessCode,
+		Participants: participants,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func TestCallBridgeService_CreateBridge(t *testing.T) {
+	var gotRequest bridgeProviderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conferences" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"conference_id": "CF123", "dial_in": "+15550100", "access_code": "4321"}`))
+	}))
+	defer server.Close()
+
+	service := NewCallBridgeService(server.URL)
+	emergency := &models.Emergency{ID: uuid.New()}
+	participants := []string{"+15550101", "+15550102"}
+
+	bridge, err := service.CreateBridge(context.Background(), emergency, participants)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotRequest.EmergencyID != emergency.ID.String() {
+		t.Errorf("provider got emergency_id %s, expected %s", gotRequest.EmergencyID, emergency.ID)
+	}
+	if len(gotRequest.Participants) != 2 {
+		t.Errorf("provider got %d participants, expected 2", len(gotRequest.Participants))
+	}
+
+	if bridge.ConferenceID != "CF123" || bridge.DialIn != "+15550100" || bridge.AccessCode != "4321" {
+		t.Errorf("unexpected bridge details: %+v", bridge)
+	}
+	// Provider omitted its name; default to twilio
+	if bridge.Provider != "twilio" {
+		t.Errorf("expected default provider twilio, got %s", bridge.Provider)
+	}
+	if len(bridge.Participants) != 2 {
+		t.Errorf("expected 2 recorded participants, got %d", len(bridge.Participants))
+	}
+}
+
+func TestCallBridgeService_Disabled(t *testing.T) {
+	// A nil or unconfigured service is safe to call and reports disabled
+	var service *CallBridgeService
+	if service.Enabled() {
+		t.Error("nil service must report disabled")
+	}
+	if NewCallBridgeService("").Enabled() {
+		t.Error("unconfigured service must report disabled")
+	}
+}
+
+func TestCallBridgeService_NoParticipants(t *testing.T) {
+	service := NewCallBridgeService("http://localhost:0")
+	if _, err := service.CreateBridge(context.Background(), &models.Emergency{ID: uuid.New()}, nil); err == nil {
+		t.Error("expected an error when there is nobody to dial in")
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/kafka"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// checkInReminderSweepInterval is how often the sweep for due check-in
+// reminders runs. It bounds how late a reminder can fire past its offset
+const checkInReminderSweepInterval = 30 * time.Second
+
+// CheckInReminderService publishes reminders to the notification pipeline as
+// scheduled check-in deadlines approach. Offsets come from the user's
+// reminder preferences (default T-10 and T-2 minutes); delivery bookkeeping
+// lives on the check-in row, so a restart never double-sends and a snooze
+// re-arms the reminders against the new deadline
+type CheckInReminderService struct {
+	checkInRepo *repository.CheckInRepository
+	prefsRepo   *repository.PreferencesRepository
+	producer    *kafka.Producer
+	stopChan    chan struct{}
+}
+
+// NewCheckInReminderService creates a new CheckInReminderService. prefsRepo
+// may be nil, in which case every user gets the default reminder offsets
+func NewCheckInReminderService(
+	checkInRepo *repository.CheckInRepository,
+	prefsRepo *repository.PreferencesRepository,
+	producer *kafka.Producer,
+) *CheckInReminderService {
+	return &CheckInReminderService{
+		checkInRepo: checkInRepo,
+		prefsRepo:   prefsRepo,
+		producer:    producer,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic sweep for due check-in reminders
+func (s *CheckInReminderService) Start(ctx context.Context) {
+	log.Info().
+		Dur("interval", checkInReminderSweepInterval).
+		Msg("Check-in reminder service started")
+
+	go func() {
+		ticker := time.NewTicker(checkInReminderSweepInterval)
+		defer ticker.Stop()
+
+		// Sweep once at startup so reminders missed during a restart still
+		// go out
+		s.sweep(ctx)
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep(ctx)
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic sweep
+func (s *CheckInReminderService) Stop() {
+	close(s.stopChan)
+}
+
+// sweep publishes every reminder whose offset window has opened. A publish
+// failure leaves the offset unmarked so the next sweep retries it
+func (s *CheckInReminderService) sweep(ctx context.Context) {
+	// The widest configurable offset bounds how far ahead a deadline can be
+	// and still owe a reminder
+	horizon := time.Now().Add(models.MaxCheckInReminderMinutes * time.Minute)
+	checkIns, err := s.checkInRepo.ListScheduledBefore(ctx, horizon)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list check-ins for reminder sweep")
+		return
+	}
+
+	for _, checkIn := range checkIns {
+		offsets := models.DefaultCheckInReminderMinutes
+		if s.prefsRepo != nil {
+			prefs, err := s.prefsRepo.GetByUserID(ctx, checkIn.UserID)
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("check_in_id", checkIn.ID.String()).
+					Msg("Failed to get reminder preferences, using defaults")
+			} else {
+				offsets = prefs.CheckInReminders()
+			}
+		}
+
+		for _, minutes := range dueReminders(checkIn, offsets, time.Now()) {
+			s.sendReminder(ctx, checkIn, minutes)
+		}
+	}
+}
+
+// dueReminders returns the minutes-before offsets whose reminder should go
+// out now: the window has opened, the deadline has not passed, and the
+// reminder was not already sent
+func dueReminders(checkIn *models.ScheduledCheckIn, offsets []int, now time.Time) []int {
+	var due []int
+	for _, minutes := range offsets {
+		fireAt := checkIn.DeadlineAt.Add(-time.Duration(minutes) * time.Minute)
+		if now.Before(fireAt) || !now.Before(checkIn.DeadlineAt) {
+			continue
+		}
+		if checkIn.ReminderSent(minutes) {
+			continue
+		}
+		due = append(due, minutes)
+	}
+	return due
+}
+
+// sendReminder publishes one reminder and records the delivery
+func (s *CheckInReminderService) sendReminder(ctx context.Context, checkIn *models.ScheduledCheckIn, minutesBefore int) {
+	if err := s.producer.PublishCheckInReminder(ctx, checkIn, minutesBefore); err != nil {
+		log.Error().
+			Err(err).
+			Str("check_in_id", checkIn.ID.String()).
+			Int("minutes_before", minutesBefore).
+			Msg("Failed to publish check-in reminder")
+		return
+	}
+
+	if err := s.checkInRepo.MarkReminderSent(ctx, checkIn.ID, minutesBefore); err != nil {
+		log.Error().
+			Err(err).
+			Str("check_in_id", checkIn.ID.String()).
+			Int("minutes_before", minutesBefore).
+			Msg("Failed to record sent reminder - it may be sent again")
+		return
+	}
+
+	log.Info().
+		Str("check_in_id", checkIn.ID.String()).
+		Str("user_id", checkIn.UserID.String()).
+		Time("deadline_at", checkIn.DeadlineAt).
+		Int("minutes_before", minutesBefore).
+		Msg("Check-in reminder published")
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func TestDueReminders(t *testing.T) {
+	now := time.Date(2026, 8, 29, 12, 0, 0, 0, time.UTC)
+	offsets := models.DefaultCheckInReminderMinutes
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		sent     []int
+		expected []int
+	}{
+		{
+			name:     "deadline far away - nothing due",
+			deadline: now.Add(30 * time.Minute),
+			expected: nil,
+		},
+		{
+			name:     "inside T-10 window",
+			deadline: now.Add(9 * time.Minute),
+			expected: []int{10},
+		},
+		{
+			name:     "inside T-2 window with T-10 already sent",
+			deadline: now.Add(time.Minute),
+			sent:     []int{10},
+			expected: []int{2},
+		},
+		{
+			name:     "both due after a long sweep gap",
+			deadline: now.Add(time.Minute),
+			expected: []int{10, 2},
+		},
+		{
+			name:     "deadline passed - too late to remind",
+			deadline: now.Add(-time.Minute),
+			expected: nil,
+		},
+		{
+			name:     "everything already sent",
+			deadline: now.Add(time.Minute),
+			sent:     []int{10, 2},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIn := &models.ScheduledCheckIn{
+				ID:            uuid.New(),
+				DeadlineAt:    tt.deadline,
+				RemindersSent: tt.sent,
+			}
+
+			due := dueReminders(checkIn, offsets, now)
+
+			if len(due) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, due)
+			}
+			for i := range due {
+				if due[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, due)
+				}
+			}
+		})
+	}
+}
+
+func TestDueReminders_CustomOffsets(t *testing.T) {
+	now := time.Date(2026, 8, 29, 12, 0, 0, 0, time.UTC)
+	checkIn := &models.ScheduledCheckIn{ID: uuid.New(), DeadlineAt: now.Add(25 * time.Minute)}
+
+	due := dueReminders(checkIn, []int{30, 5}, now)
+
+	if len(due) != 1 || due[0] != 30 {
+		t.Errorf("expected [30], got %v", due)
+	}
+
+	// An explicitly empty offset list disables reminders entirely
+	if due := dueReminders(checkIn, nil, now); due != nil {
+		t.Errorf("expected no reminders for empty offsets, got %v", due)
+	}
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+const (
+	// consistencyCheckHourUTC is when the nightly reconciliation runs
+	consistencyCheckHourUTC = 3
+
+	// consistencyLocationStaleness is how long an active emergency may go
+	// without a location update before it is flagged
+	consistencyLocationStaleness = time.Hour
+)
+
+// Consistency check names, used as the metric label and report keys
+const (
+	CheckStaleLocation            = "stale_location"
+	CheckMissingActivationEvent   = "missing_activation_event"
+	CheckUnnotifiedAcknowledgment = "unnotified_acknowledgment"
+)
+
+// ConsistencyReport is one run of the cross-service reconciliation
+type ConsistencyReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Total       int       `json:"total"`
+
+	// StaleLocations are ACTIVE emergencies with no location update in the
+	// last hour - the victim's location stream has gone silent
+	StaleLocations []models.ConsistencyFinding `json:"stale_locations"`
+
+	// MissingActivationEvents are ACTIVE emergencies with no EmergencyCreated
+	// entry in the event ledger - downstream services never heard about them
+	MissingActivationEvents []models.ConsistencyFinding `json:"missing_activation_events"`
+
+	// UnnotifiedAcknowledgments are acknowledgments by contacts with no
+	// notification delivery record - the notification status stream was lost
+	UnnotifiedAcknowledgments []models.ConsistencyFinding `json:"unnotified_acknowledgments"`
+}
+
+// ConsistencyChecker is the nightly reconciliation job that cross-checks
+// this service's state against what the rest of the platform should have
+// done with it. Each run produces a discrepancy report, kept in memory for
+// the admin endpoint and exported per-check via prometheus.Collector;
+// register it with the default registry at wiring time
+type ConsistencyChecker struct {
+	emergencyRepo *repository.EmergencyRepository
+	ledgerRepo    *repository.EventLedgerRepository
+	ackRepo       *repository.AcknowledgmentRepository
+
+	mu       sync.Mutex
+	latest   *ConsistencyReport
+	stopChan chan struct{}
+
+	discrepanciesDesc *prometheus.Desc
+}
+
+// NewConsistencyChecker creates a new ConsistencyChecker
+func NewConsistencyChecker(
+	emergencyRepo *repository.EmergencyRepository,
+	ledgerRepo *repository.EventLedgerRepository,
+	ackRepo *repository.AcknowledgmentRepository,
+) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		emergencyRepo: emergencyRepo,
+		ledgerRepo:    ledgerRepo,
+		ackRepo:       ackRepo,
+		stopChan:      make(chan struct{}),
+		discrepanciesDesc: prometheus.NewDesc(
+			"sos_emergency_consistency_discrepancies",
+			"Discrepancies found by the last consistency check, by check.",
+			[]string{"check"}, nil,
+		),
+	}
+}
+
+// Start schedules the nightly reconciliation run
+func (c *ConsistencyChecker) Start(ctx context.Context) {
+	log.Info().
+		Int("hour_utc", consistencyCheckHourUTC).
+		Msg("Consistency checker started")
+
+	go func() {
+		for {
+			timer := time.NewTimer(nextConsistencyRun(time.Now().UTC()))
+			select {
+			case <-timer.C:
+				if _, err := c.Run(ctx); err != nil {
+					log.Error().Err(err).Msg("Nightly consistency check failed")
+				}
+			case <-c.stopChan:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the nightly schedule
+func (c *ConsistencyChecker) Stop() {
+	close(c.stopChan)
+}
+
+// Run executes all consistency checks and stores the report for the admin
+// endpoint. A repository failure aborts the run rather than publishing a
+// partial report that would clear metrics for checks that never ran
+func (c *ConsistencyChecker) Run(ctx context.Context) (*ConsistencyReport, error) {
+	staleLocations, err := c.emergencyRepo.ListActiveStaleLocation(ctx, time.Now().Add(-consistencyLocationStaleness))
+	if err != nil {
+		return nil, err
+	}
+
+	missingEvents, err := c.ledgerRepo.ListActiveMissingActivation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unnotifiedAcks, err := c.ackRepo.ListAcknowledgedNeverNotified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConsistencyReport{
+		GeneratedAt:               time.Now().UTC(),
+		Total:                     len(staleLocations) + len(missingEvents) + len(unnotifiedAcks),
+		StaleLocations:            staleLocations,
+		MissingActivationEvents:   missingEvents,
+		UnnotifiedAcknowledgments: unnotifiedAcks,
+	}
+
+	c.mu.Lock()
+	c.latest = report
+	c.mu.Unlock()
+
+	// A discrepancy means state two services agree on has diverged; every
+	// run with findings deserves on-call attention
+	logEvent := log.Info()
+	if report.Total > 0 {
+		logEvent = log.Error()
+	}
+	logEvent.
+		Int("stale_locations", len(staleLocations)).
+		Int("missing_activation_events", len(missingEvents)).
+		Int("unnotified_acknowledgments", len(unnotifiedAcks)).
+		Msg("Consistency check completed")
+
+	return report, nil
+}
+
+// LatestReport returns the most recent report, or nil when no run has
+// completed yet
+func (c *ConsistencyChecker) LatestReport() *ConsistencyReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest
+}
+
+// Describe implements prometheus.Collector
+func (c *ConsistencyChecker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.discrepanciesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ConsistencyChecker) Collect(ch chan<- prometheus.Metric) {
+	report := c.LatestReport()
+	if report == nil {
+		return
+	}
+
+	counts := map[string]int{
+		CheckStaleLocation:            len(report.StaleLocations),
+		CheckMissingActivationEvent:   len(report.MissingActivationEvents),
+		CheckUnnotifiedAcknowledgment: len(report.UnnotifiedAcknowledgments),
+	}
+	for check, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.discrepanciesDesc, prometheus.GaugeValue, float64(count), check)
+	}
+}
+
+// nextConsistencyRun returns how long until the next nightly run after now
+func nextConsistencyRun(now time.Time) time.Duration {
+	next := time.Date(now.Year(), now.Month(), now.Day(), consistencyCheckHourUTC, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(now)
+}
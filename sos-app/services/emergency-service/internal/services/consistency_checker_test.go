@@ -0,0 +1,38 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextConsistencyRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "before today's run",
+			now:      time.Date(2026, 8, 29, 1, 0, 0, 0, time.UTC),
+			expected: 2 * time.Hour,
+		},
+		{
+			name:     "after today's run waits for tomorrow",
+			now:      time.Date(2026, 8, 29, 4, 0, 0, 0, time.UTC),
+			expected: 23 * time.Hour,
+		},
+		{
+			name:     "exactly at the run hour waits a full day",
+			now:      time.Date(2026, 8, 29, 3, 0, 0, 0, time.UTC),
+			expected: 24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextConsistencyRun(tt.now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -10,27 +10,71 @@ import (
 	"github.com/sos-app/emergency-service/internal/kafka"
 	"github.com/sos-app/emergency-service/internal/models"
 	"github.com/sos-app/emergency-service/internal/repository"
+	"github.com/sos-app/emergency-service/internal/slo"
 )
 
 // CountdownService manages countdown timers for emergency triggers
 type CountdownService struct {
-	emergencyRepo *repository.EmergencyRepository
-	producer      *kafka.Producer
-	timers        map[uuid.UUID]*time.Timer
-	mu            sync.RWMutex
+	emergencyRepo     *repository.EmergencyRepository
+	producer          *kafka.Producer
+	webhookNotifier   *WebhookNotifier
+	poiService        *POIService
+	weatherService    *WeatherService
+	pushGrace         time.Duration
+	activationHooks   []ActivationHook
+	escalationStarter func(ctx context.Context, emergencyID uuid.UUID)
+	timers            map[uuid.UUID]*scheduledEntry
+	pushChecks        map[uuid.UUID]*scheduledEntry
+	mu                sync.RWMutex
 }
 
-// NewCountdownService creates a new CountdownService
-func NewCountdownService(emergencyRepo *repository.EmergencyRepository, producer *kafka.Producer) *CountdownService {
+// scheduledEntry pairs a running timer with its expected fire time so the
+// scheduling audit can report what is pending and when
+type scheduledEntry struct {
+	timer   *time.Timer
+	firesAt time.Time
+}
+
+// NewCountdownService creates a new CountdownService. webhookNotifier may be
+// nil when no incident webhooks are configured; poiService and weatherService
+// may be nil when their providers are not configured. pushGrace is how long
+// an auto-triggered countdown waits for the victim's phone to confirm it
+// received the countdown-start push before activating early (0 disables)
+func NewCountdownService(emergencyRepo *repository.EmergencyRepository, producer *kafka.Producer, webhookNotifier *WebhookNotifier, poiService *POIService, weatherService *WeatherService, pushGrace time.Duration) *CountdownService {
 	return &CountdownService{
-		emergencyRepo: emergencyRepo,
-		producer:      producer,
-		timers:        make(map[uuid.UUID]*time.Timer),
+		emergencyRepo:   emergencyRepo,
+		producer:        producer,
+		webhookNotifier: webhookNotifier,
+		poiService:      poiService,
+		weatherService:  weatherService,
+		pushGrace:       pushGrace,
+		timers:          make(map[uuid.UUID]*scheduledEntry),
+		pushChecks:      make(map[uuid.UUID]*scheduledEntry),
 	}
 }
 
-// StartCountdown initiates a countdown timer for an emergency
-func (s *CountdownService) StartCountdown(ctx context.Context, emergencyID uuid.UUID, countdownSeconds int) {
+// RegisterActivationHook appends a pre-activation hook to the chain run when
+// a countdown completes. Call during startup, before countdowns are started
+func (s *CountdownService) RegisterActivationHook(hook ActivationHook) {
+	s.activationHooks = append(s.activationHooks, hook)
+}
+
+// SetEscalationStarter attaches the callback that arms escalation monitoring
+// when an emergency activates (EscalationService.StartMonitoring); nil leaves
+// activated emergencies unmonitored. Call during startup
+func (s *CountdownService) SetEscalationStarter(starter func(ctx context.Context, emergencyID uuid.UUID)) {
+	s.escalationStarter = starter
+}
+
+// StartCountdown initiates a countdown timer for an emergency. For
+// auto-triggered emergencies the countdown only protects the user if their
+// phone actually shows it, so a push-receipt check is scheduled alongside
+func (s *CountdownService) StartCountdown(ctx context.Context, emergencyID uuid.UUID, countdownSeconds int, autoTriggered bool) {
+	// The caller's context is typically a request context that is cancelled
+	// as soon as the response is written; the timer callbacks fire long
+	// after that and must not inherit the cancellation
+	ctx = context.WithoutCancel(ctx)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -55,7 +99,63 @@ func (s *CountdownService) StartCountdown(ctx context.Context, emergencyID uuid.
 	})
 
 	// Store timer
-	s.timers[emergencyID] = timer
+	s.timers[emergencyID] = &scheduledEntry{timer: timer, firesAt: time.Now().Add(duration)}
+
+	// An auto-triggered countdown the user never sees is just a delay
+	// before help: if the countdown-start push is not confirmed delivered
+	// within the grace window, activate early
+	if autoTriggered && s.pushGrace > 0 && s.pushGrace < duration {
+		s.pushChecks[emergencyID] = &scheduledEntry{
+			timer: time.AfterFunc(s.pushGrace, func() {
+				s.onPushUnconfirmed(ctx, emergencyID)
+			}),
+			firesAt: time.Now().Add(s.pushGrace),
+		}
+	}
+}
+
+// ConfirmCountdownPush records that the victim's phone confirmed delivery
+// of the countdown-start push, so the full countdown can run
+func (s *CountdownService) ConfirmCountdownPush(emergencyID uuid.UUID) {
+	s.mu.Lock()
+	check, exists := s.pushChecks[emergencyID]
+	if exists {
+		check.timer.Stop()
+		delete(s.pushChecks, emergencyID)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		log.Info().
+			Str("emergency_id", emergencyID.String()).
+			Msg("Countdown push confirmed delivered")
+	}
+}
+
+// onPushUnconfirmed fires when the push-receipt grace window expires without
+// a delivery confirmation: the user cannot cancel a countdown they cannot
+// see, so the remaining countdown is skipped
+func (s *CountdownService) onPushUnconfirmed(ctx context.Context, emergencyID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.pushChecks, emergencyID)
+	entry, exists := s.timers[emergencyID]
+	// Stop() reports whether we won the race against the regular expiry;
+	// if the main timer already fired there is nothing left to shorten
+	if exists && !entry.timer.Stop() {
+		exists = false
+	}
+	s.mu.Unlock()
+
+	if !exists {
+		return
+	}
+
+	log.Warn().
+		Str("emergency_id", emergencyID.String()).
+		Dur("push_grace", s.pushGrace).
+		Msg("Countdown push not confirmed delivered - activating early")
+
+	s.onCountdownComplete(ctx, emergencyID)
 }
 
 // CancelCountdown cancels an ongoing countdown timer
@@ -63,7 +163,7 @@ func (s *CountdownService) CancelCountdown(emergencyID uuid.UUID) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	timer, exists := s.timers[emergencyID]
+	entry, exists := s.timers[emergencyID]
 	if !exists {
 		log.Warn().
 			Str("emergency_id", emergencyID.String()).
@@ -72,11 +172,17 @@ func (s *CountdownService) CancelCountdown(emergencyID uuid.UUID) bool {
 	}
 
 	// Stop the timer
-	stopped := timer.Stop()
+	stopped := entry.timer.Stop()
 
 	// Remove from map
 	delete(s.timers, emergencyID)
 
+	// Drop the pending push-receipt check, if any
+	if check, ok := s.pushChecks[emergencyID]; ok {
+		check.timer.Stop()
+		delete(s.pushChecks, emergencyID)
+	}
+
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
 		Bool("stopped", stopped).
@@ -87,6 +193,10 @@ func (s *CountdownService) CancelCountdown(emergencyID uuid.UUID) bool {
 
 // onCountdownComplete is called when countdown timer expires
 func (s *CountdownService) onCountdownComplete(ctx context.Context, emergencyID uuid.UUID) {
+	// Everything between here and the EmergencyCreated publish (status
+	// update, enrichment) counts against the activation publish SLO
+	expiredAt := time.Now()
+
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
 		Msg("Countdown complete - activating emergency")
@@ -94,6 +204,10 @@ func (s *CountdownService) onCountdownComplete(ctx context.Context, emergencyID
 	// Remove timer from map
 	s.mu.Lock()
 	delete(s.timers, emergencyID)
+	if check, ok := s.pushChecks[emergencyID]; ok {
+		check.timer.Stop()
+		delete(s.pushChecks, emergencyID)
+	}
 	s.mu.Unlock()
 
 	// Retrieve emergency
@@ -115,6 +229,28 @@ func (s *CountdownService) onCountdownComplete(ctx context.Context, emergencyID
 		return
 	}
 
+	// Run the pre-activation hook chain and record its outcomes for audit.
+	// Checks are advisory and fail open: a failed or broken check is stored
+	// on the emergency but never blocks activation
+	if checks := RunActivationHooks(ctx, s.activationHooks, emergency); len(checks) > 0 {
+		for _, check := range checks {
+			if check.Outcome != models.CheckPassed {
+				log.Warn().
+					Str("emergency_id", emergencyID.String()).
+					Str("hook", check.Name).
+					Str("outcome", string(check.Outcome)).
+					Str("detail", check.Detail).
+					Msg("Pre-activation check did not pass")
+			}
+		}
+		if err := s.emergencyRepo.SetActivationChecks(ctx, emergencyID, checks); err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergencyID.String()).
+				Msg("Failed to store activation checks")
+		}
+	}
+
 	// Update status to ACTIVE
 	err = s.emergencyRepo.UpdateStatus(ctx, emergencyID, models.StatusActive)
 	if err != nil {
@@ -129,6 +265,12 @@ func (s *CountdownService) onCountdownComplete(ctx context.Context, emergencyID
 		Str("emergency_id", emergencyID.String()).
 		Msg("Emergency activated successfully")
 
+	// Arm escalation monitoring so an active emergency that nobody
+	// acknowledges climbs the escalation ladder
+	if s.escalationStarter != nil {
+		s.escalationStarter(ctx, emergencyID)
+	}
+
 	// Retrieve updated emergency
 	emergency, err = s.emergencyRepo.GetByID(ctx, emergencyID)
 	if err != nil {
@@ -139,15 +281,65 @@ func (s *CountdownService) onCountdownComplete(ctx context.Context, emergencyID
 		return
 	}
 
+	// Best-effort facility enrichment: the nearest hospital/police/fire
+	// stations go out with the event so contacts get actionable options
+	// immediately. A provider failure never blocks activation
+	if s.poiService.Enabled() {
+		facilities, err := s.poiService.NearestFacilities(ctx, emergency.InitialLocation)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergencyID.String()).
+				Msg("Failed to resolve nearby facilities")
+		} else if len(facilities) > 0 {
+			emergency.NearbyFacilities = facilities
+			if err := s.emergencyRepo.SetNearbyFacilities(ctx, emergencyID, facilities); err != nil {
+				log.Error().
+					Err(err).
+					Str("emergency_id", emergencyID.String()).
+					Msg("Failed to store nearby facilities")
+			}
+		}
+	}
+
+	// Best-effort weather enrichment: responders behave differently for a
+	// fall outdoors at -10°C at night than at midday, so the conditions and
+	// daylight window ride along on the event. A provider failure never
+	// blocks activation
+	if s.weatherService.Enabled() {
+		weather, err := s.weatherService.CurrentConditions(ctx, emergency.InitialLocation)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergencyID.String()).
+				Msg("Failed to resolve weather context")
+		} else if weather != nil {
+			emergency.WeatherContext = weather
+			if err := s.emergencyRepo.SetWeatherContext(ctx, emergencyID, weather); err != nil {
+				log.Error().
+					Err(err).
+					Str("emergency_id", emergencyID.String()).
+					Msg("Failed to store weather context")
+			}
+		}
+	}
+
+	// Post incident cards to org monitoring channels
+	if s.webhookNotifier != nil {
+		go s.webhookNotifier.NotifyTriggered(context.Background(), emergency)
+	}
+
 	// Publish EmergencyCreated event to Kafka
 	err = s.producer.PublishEmergencyCreated(ctx, emergency)
 	if err != nil {
+		slo.ObserveActivationPublish(time.Since(expiredAt), false)
 		log.Error().
 			Err(err).
 			Str("emergency_id", emergencyID.String()).
 			Msg("Failed to publish EmergencyCreated event")
 		return
 	}
+	slo.ObserveActivationPublish(time.Since(expiredAt), true)
 
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
@@ -178,13 +370,35 @@ func (s *CountdownService) Cleanup() {
 		Int("count", len(s.timers)).
 		Msg("Cleaning up countdown timers")
 
-	for id, timer := range s.timers {
-		timer.Stop()
+	for id, entry := range s.timers {
+		entry.timer.Stop()
 		log.Debug().
 			Str("emergency_id", id.String()).
 			Msg("Stopped countdown timer")
 	}
 
-	// Clear map
-	s.timers = make(map[uuid.UUID]*time.Timer)
+	for _, check := range s.pushChecks {
+		check.timer.Stop()
+	}
+
+	// Clear maps
+	s.timers = make(map[uuid.UUID]*scheduledEntry)
+	s.pushChecks = make(map[uuid.UUID]*scheduledEntry)
+}
+
+// ScheduledTimers returns a snapshot of all pending countdown and push-check
+// timers for the scheduling audit
+func (s *CountdownService) ScheduledTimers() []ScheduledTimer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	timers := make([]ScheduledTimer, 0, len(s.timers)+len(s.pushChecks))
+	for id, entry := range s.timers {
+		timers = append(timers, newScheduledTimer(id, TimerKindCountdown, entry.firesAt, now))
+	}
+	for id, check := range s.pushChecks {
+		timers = append(timers, newScheduledTimer(id, TimerKindPushCheck, check.firesAt, now))
+	}
+	return timers
 }
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/kafka"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// erasureCheckInterval is how often scheduled erasure requests are swept
+const erasureCheckInterval = 1 * time.Hour
+
+// ErasureService executes scheduled data erasure requests once their legal
+// hold expires: local data is anonymized or deleted and a Kafka event asks
+// device-service and location-service to do the same
+type ErasureService struct {
+	emergencyRepo *repository.EmergencyRepository
+	ackRepo       *repository.AcknowledgmentRepository
+	prefsRepo     *repository.PreferencesRepository
+	erasureRepo   *repository.ErasureRepository
+	producer      *kafka.Producer
+	stopChan      chan struct{}
+}
+
+// NewErasureService creates a new ErasureService
+func NewErasureService(
+	emergencyRepo *repository.EmergencyRepository,
+	ackRepo *repository.AcknowledgmentRepository,
+	prefsRepo *repository.PreferencesRepository,
+	erasureRepo *repository.ErasureRepository,
+	producer *kafka.Producer,
+) *ErasureService {
+	return &ErasureService{
+		emergencyRepo: emergencyRepo,
+		ackRepo:       ackRepo,
+		prefsRepo:     prefsRepo,
+		erasureRepo:   erasureRepo,
+		producer:      producer,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins the periodic sweep for due erasure requests
+func (s *ErasureService) Start(ctx context.Context) {
+	log.Info().
+		Dur("interval", erasureCheckInterval).
+		Msg("Erasure service started")
+
+	go func() {
+		ticker := time.NewTicker(erasureCheckInterval)
+		defer ticker.Stop()
+
+		// Sweep once at startup to catch requests that came due while down
+		s.processDueRequests(ctx)
+
+		for {
+			select {
+			case <-ticker.C:
+				s.processDueRequests(ctx)
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic sweep
+func (s *ErasureService) Stop() {
+	close(s.stopChan)
+}
+
+// processDueRequests executes every scheduled request past its legal hold
+func (s *ErasureService) processDueRequests(ctx context.Context) {
+	requests, err := s.erasureRepo.ListDue(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list due erasure requests")
+		return
+	}
+
+	for _, request := range requests {
+		s.execute(ctx, &request)
+	}
+}
+
+// execute erases one user's data and fans the erasure out via Kafka
+func (s *ErasureService) execute(ctx context.Context, request *models.ErasureRequest) {
+	log.Info().
+		Str("request_id", request.ID.String()).
+		Str("user_id", request.UserID.String()).
+		Msg("Executing data erasure request")
+
+	anonymized, err := s.emergencyRepo.AnonymizeUserData(ctx, request.UserID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to anonymize emergencies")
+		return
+	}
+
+	deletedAcks, err := s.ackRepo.DeleteByContactID(ctx, request.UserID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to delete acknowledgments")
+		return
+	}
+
+	if err := s.prefsRepo.Delete(ctx, request.UserID); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to delete preferences")
+		return
+	}
+
+	// Record this service's own leg of the saga; downstream services report
+	// theirs via erasure-completed events
+	completion := &models.ErasureCompletion{
+		RequestID:     request.ID,
+		Service:       "emergency-service",
+		RecordsPurged: anonymized + deletedAcks,
+		CompletedAt:   time.Now().UTC(),
+	}
+	if err := s.erasureRepo.RecordCompletion(ctx, completion); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to record local erasure completion")
+	}
+
+	// Tell device-service and location-service to erase their copies
+	if err := s.producer.PublishDataErasure(ctx, request.ID, request.UserID); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to publish data erasure event - will retry next sweep")
+		return
+	}
+
+	if err := s.erasureRepo.MarkCompleted(ctx, request.ID); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", request.ID.String()).
+			Msg("Failed to mark erasure request completed")
+		return
+	}
+
+	log.Info().
+		Str("request_id", request.ID.String()).
+		Str("user_id", request.UserID.String()).
+		Int64("emergencies_anonymized", anonymized).
+		Int64("acknowledgments_deleted", deletedAcks).
+		Msg("Data erasure request completed")
+}
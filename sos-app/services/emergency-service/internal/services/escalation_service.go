@@ -7,34 +7,127 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/kafka"
+	"github.com/sos-app/emergency-service/internal/models"
 	"github.com/sos-app/emergency-service/internal/repository"
 )
 
+// EscalationLevel is one rung of the escalation ladder: who gets notified
+// next and how long to wait for the quorum before moving to the rung after.
+// The notification service maps the level name to its audience
+type EscalationLevel struct {
+	Level   int           `json:"level"`
+	Name    string        `json:"name"`
+	Timeout time.Duration `json:"-"`
+}
+
+// defaultEscalationLevels builds the standard three-rung ladder, each rung
+// waiting the configured escalation timeout before the next one fires
+func defaultEscalationLevels(timeout time.Duration) []EscalationLevel {
+	return []EscalationLevel{
+		{Level: 1, Name: "primary-contacts", Timeout: timeout},
+		{Level: 2, Name: "secondary-contacts", Timeout: timeout},
+		{Level: 3, Name: "emergency-services", Timeout: timeout},
+	}
+}
+
 // EscalationService manages escalation logic for unacknowledged emergencies
 type EscalationService struct {
 	emergencyRepo      *repository.EmergencyRepository
 	ackRepo            *repository.AcknowledgmentRepository
+	prefsRepo          *repository.PreferencesRepository
+	producer           *kafka.Producer
+	webhookNotifier    *WebhookNotifier
+	callBridgeService  *CallBridgeService
 	escalationTimeout  time.Duration
-	activeEscalations  map[uuid.UUID]*time.Timer
+	levels             []EscalationLevel
+	activeEscalations  map[uuid.UUID]*scheduledEntry
 	mu                 sync.RWMutex
 }
 
-// NewEscalationService creates a new EscalationService
+// NewEscalationService creates a new EscalationService. producer may be nil,
+// in which case escalation events are logged but not published;
+// webhookNotifier may be nil when no incident webhooks are configured;
+// prefsRepo may be nil, in which case every emergency requires a single
+// acknowledgment; callBridgeService may be nil when no bridge provider is
+// configured
 func NewEscalationService(
 	emergencyRepo *repository.EmergencyRepository,
 	ackRepo *repository.AcknowledgmentRepository,
+	prefsRepo *repository.PreferencesRepository,
+	producer *kafka.Producer,
+	webhookNotifier *WebhookNotifier,
+	callBridgeService *CallBridgeService,
 	escalationTimeoutMin int,
 ) *EscalationService {
+	timeout := time.Duration(escalationTimeoutMin) * time.Minute
 	return &EscalationService{
 		emergencyRepo:     emergencyRepo,
 		ackRepo:           ackRepo,
-		escalationTimeout: time.Duration(escalationTimeoutMin) * time.Minute,
-		activeEscalations: make(map[uuid.UUID]*time.Timer),
+		prefsRepo:         prefsRepo,
+		producer:          producer,
+		webhookNotifier:   webhookNotifier,
+		callBridgeService: callBridgeService,
+		escalationTimeout: timeout,
+		levels:            defaultEscalationLevels(timeout),
+		activeEscalations: make(map[uuid.UUID]*scheduledEntry),
 	}
 }
 
+// SetEscalationLevels replaces the default ladder. Call before any
+// emergencies are being monitored; levels must be non-empty
+func (s *EscalationService) SetEscalationLevels(levels []EscalationLevel) {
+	if len(levels) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.levels = levels
+}
+
+// EvaluateQuorum reports how far an emergency is from its acknowledgment
+// quorum. The required count comes from the user's per-type preference and
+// defaults to one acknowledgment; a professional caregiver's acknowledgment
+// satisfies the quorum alone regardless of the count
+func (s *EscalationService) EvaluateQuorum(ctx context.Context, emergency *models.Emergency) (*models.QuorumStatus, error) {
+	required := 1
+	if s.prefsRepo != nil {
+		prefs, err := s.prefsRepo.GetByUserID(ctx, emergency.UserID)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergency.ID.String()).
+				Msg("Failed to get preferences for quorum check, requiring one acknowledgment")
+		} else {
+			required = prefs.QuorumFor(emergency.EmergencyType)
+		}
+	}
+
+	count, err := s.ackRepo.CountAcknowledgments(ctx, emergency.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	professional, err := s.ackRepo.HasProfessionalAcknowledgment(ctx, emergency.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.QuorumStatus{
+		Required:                 required,
+		Acknowledged:             count,
+		ProfessionalAcknowledged: professional,
+		Satisfied:                count >= required || professional,
+	}, nil
+}
+
 // StartMonitoring begins monitoring an emergency for escalation
 func (s *EscalationService) StartMonitoring(ctx context.Context, emergencyID uuid.UUID) {
+	// The caller's context is typically a request context that is cancelled
+	// as soon as the response is written; the escalation timers fire minutes
+	// later and must not inherit the cancellation
+	ctx = context.WithoutCancel(ctx)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -48,15 +141,15 @@ func (s *EscalationService) StartMonitoring(ctx context.Context, emergencyID uui
 
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
-		Dur("timeout", s.escalationTimeout).
+		Dur("timeout", s.levels[0].Timeout).
 		Msg("Starting escalation monitoring")
 
-	// Create timer for escalation check
-	timer := time.AfterFunc(s.escalationTimeout, func() {
-		s.checkEscalation(ctx, emergencyID)
+	// Create timer for the first rung of the escalation ladder
+	timer := time.AfterFunc(s.levels[0].Timeout, func() {
+		s.checkEscalation(ctx, emergencyID, 0)
 	})
 
-	s.activeEscalations[emergencyID] = timer
+	s.activeEscalations[emergencyID] = &scheduledEntry{timer: timer, firesAt: time.Now().Add(s.levels[0].Timeout)}
 }
 
 // StopMonitoring stops monitoring an emergency for escalation
@@ -64,12 +157,12 @@ func (s *EscalationService) StopMonitoring(emergencyID uuid.UUID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	timer, exists := s.activeEscalations[emergencyID]
+	entry, exists := s.activeEscalations[emergencyID]
 	if !exists {
 		return
 	}
 
-	timer.Stop()
+	entry.timer.Stop()
 	delete(s.activeEscalations, emergencyID)
 
 	log.Info().
@@ -77,17 +170,26 @@ func (s *EscalationService) StopMonitoring(emergencyID uuid.UUID) {
 		Msg("Stopped escalation monitoring")
 }
 
-// checkEscalation checks if escalation is needed for an emergency
-func (s *EscalationService) checkEscalation(ctx context.Context, emergencyID uuid.UUID) {
+// checkEscalation checks if escalation is needed for an emergency. levelIdx
+// is the rung of the ladder whose timer just fired; unless the quorum is met
+// the rung's audience is notified and the next rung's timer is armed
+func (s *EscalationService) checkEscalation(ctx context.Context, emergencyID uuid.UUID, levelIdx int) {
 	log.Info().
 		Str("emergency_id", emergencyID.String()).
+		Int("level", levelIdx+1).
 		Msg("Checking if escalation is needed")
 
-	// Remove from active escalations
+	// Remove from active escalations; snapshot the ladder for this pass
 	s.mu.Lock()
 	delete(s.activeEscalations, emergencyID)
+	levels := s.levels
 	s.mu.Unlock()
 
+	if levelIdx >= len(levels) {
+		return
+	}
+	level := levels[levelIdx]
+
 	// Check if emergency is still active
 	emergency, err := s.emergencyRepo.GetByID(ctx, emergencyID)
 	if err != nil {
@@ -107,41 +209,142 @@ func (s *EscalationService) checkEscalation(ctx context.Context, emergencyID uui
 		return
 	}
 
-	// Check if any contacts have acknowledged
-	count, err := s.ackRepo.CountAcknowledgments(ctx, emergencyID)
+	// Check the acknowledgment quorum for this user and emergency type
+	quorum, err := s.EvaluateQuorum(ctx, emergency)
 	if err != nil {
 		log.Error().
 			Err(err).
 			Str("emergency_id", emergencyID.String()).
-			Msg("Failed to count acknowledgments")
+			Msg("Failed to evaluate acknowledgment quorum")
 		return
 	}
 
-	if count > 0 {
+	if quorum.Satisfied {
 		log.Info().
 			Str("emergency_id", emergencyID.String()).
-			Int("acknowledgments", count).
-			Msg("Emergency has acknowledgments - no escalation needed")
+			Int("acknowledgments", quorum.Acknowledged).
+			Int("required", quorum.Required).
+			Msg("Acknowledgment quorum satisfied - no escalation needed")
 		return
 	}
 
-	// No acknowledgments - trigger escalation
+	// Quorum not met - trigger escalation for this rung
 	log.Warn().
 		Str("emergency_id", emergencyID.String()).
-		Msg("No acknowledgments received - escalation required")
+		Int("acknowledgments", quorum.Acknowledged).
+		Int("required", quorum.Required).
+		Int("level", level.Level).
+		Str("level_name", level.Name).
+		Msg("Acknowledgment quorum not met - escalation required")
+
+	// At the highest escalation level: pull everyone who already
+	// acknowledged (and optionally the victim) onto one audio bridge so
+	// they can coordinate instead of phoning each other in parallel. The
+	// bridge details ride along on the record and the escalation events
+	if levelIdx == len(levels)-1 && s.callBridgeService.Enabled() {
+		s.createCallBridge(ctx, emergency)
+	}
+
+	// Publish the escalation event; the notification service fans it out
+	// to this level's audience
+	if s.producer != nil {
+		if err := s.producer.PublishEmergencyEscalated(ctx, emergency, level.Level, level.Name); err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergencyID.String()).
+				Int("level", level.Level).
+				Msg("Failed to publish escalation event")
+		}
+	}
+
+	// Post escalation cards to org monitoring channels
+	if s.webhookNotifier != nil {
+		go s.webhookNotifier.NotifyEscalated(context.Background(), emergency)
+	}
+
+	// Arm the next rung until the ladder is exhausted; acknowledgment or
+	// resolution stops it via StopMonitoring or the quorum check above
+	if levelIdx+1 >= len(levels) {
+		return
+	}
+	next := levels[levelIdx+1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.activeEscalations[emergencyID]; exists {
+		return
+	}
+	timer := time.AfterFunc(next.Timeout, func() {
+		s.checkEscalation(ctx, emergencyID, levelIdx+1)
+	})
+	s.activeEscalations[emergencyID] = &scheduledEntry{timer: timer, firesAt: time.Now().Add(next.Timeout)}
+}
 
-	// TODO: Publish escalation event to Kafka
-	// This will be picked up by the Notification Service to notify secondary contacts
-	// For now, we just log it
+// createCallBridge creates a conference bridge for an escalated emergency,
+// dialing in the acknowledged contacts and, when their number is on file,
+// the victim. A provider failure never blocks the rest of the escalation
+func (s *EscalationService) createCallBridge(ctx context.Context, emergency *models.Emergency) {
+	if emergency.CallBridge != nil {
+		return
+	}
 
-	// The escalation event would trigger:
-	// 1. Notification to secondary contacts
-	// 2. More aggressive notification strategies (e.g., repeated calls)
-	// 3. Potentially notify emergency services directly
+	var participants []string
+
+	acks, err := s.ackRepo.GetByEmergencyID(ctx, emergency.ID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to list acknowledgments for call bridge")
+		return
+	}
+	for _, ack := range acks {
+		if !ack.Revoked && ack.ContactPhone != nil && *ack.ContactPhone != "" {
+			participants = append(participants, *ack.ContactPhone)
+		}
+	}
+
+	if s.prefsRepo != nil {
+		prefs, err := s.prefsRepo.GetByUserID(ctx, emergency.UserID)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("emergency_id", emergency.ID.String()).
+				Msg("Failed to get preferences for call bridge")
+		} else if prefs != nil && prefs.VictimPhone != nil && *prefs.VictimPhone != "" {
+			participants = append(participants, *prefs.VictimPhone)
+		}
+	}
+
+	if len(participants) == 0 {
+		log.Info().
+			Str("emergency_id", emergency.ID.String()).
+			Msg("No phone numbers available - skipping call bridge")
+		return
+	}
+
+	bridge, err := s.callBridgeService.CreateBridge(ctx, emergency, participants)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to create escalation call bridge")
+		return
+	}
+
+	emergency.CallBridge = bridge
+	if err := s.emergencyRepo.SetCallBridge(ctx, emergency.ID, bridge); err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to store call bridge")
+	}
 
 	log.Info().
-		Str("emergency_id", emergencyID.String()).
-		Msg("Escalation event would be published here")
+		Str("emergency_id", emergency.ID.String()).
+		Str("conference_id", bridge.ConferenceID).
+		Int("participants", len(participants)).
+		Msg("Escalation call bridge created")
 }
 
 // GetActiveMonitoring returns the count of emergencies being monitored
@@ -160,12 +363,26 @@ func (s *EscalationService) Cleanup() {
 		Int("count", len(s.activeEscalations)).
 		Msg("Cleaning up escalation monitors")
 
-	for id, timer := range s.activeEscalations {
-		timer.Stop()
+	for id, entry := range s.activeEscalations {
+		entry.timer.Stop()
 		log.Debug().
 			Str("emergency_id", id.String()).
 			Msg("Stopped escalation monitor")
 	}
 
-	s.activeEscalations = make(map[uuid.UUID]*time.Timer)
+	s.activeEscalations = make(map[uuid.UUID]*scheduledEntry)
+}
+
+// ScheduledTimers returns a snapshot of all pending escalation timers for
+// the scheduling audit
+func (s *EscalationService) ScheduledTimers() []ScheduledTimer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	timers := make([]ScheduledTimer, 0, len(s.activeEscalations))
+	for id, entry := range s.activeEscalations {
+		timers = append(timers, newScheduledTimer(id, TimerKindEscalation, entry.firesAt, now))
+	}
+	return timers
 }
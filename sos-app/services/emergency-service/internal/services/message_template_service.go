@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/kafka"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// MessageTemplateService renders organizations' branded message templates
+// into the wording carried on lifecycle events. It implements
+// kafka.MessageRenderer
+type MessageTemplateService struct {
+	templateRepo *repository.MessageTemplateRepository
+}
+
+// NewMessageTemplateService creates a new message template service
+func NewMessageTemplateService(templateRepo *repository.MessageTemplateRepository) *MessageTemplateService {
+	return &MessageTemplateService{templateRepo: templateRepo}
+}
+
+// RenderMessages renders every organization's enabled template for the event,
+// one message per (org, locale). Failures are logged and rendering is skipped
+// rather than blocking the event: branding never delays an alert
+func (s *MessageTemplateService) RenderMessages(ctx context.Context, eventType models.TemplateEventType, vars map[string]string) []kafka.RenderedMessage {
+	templates, err := s.templateRepo.ListEnabledByEvent(ctx, eventType)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("event_type", string(eventType)).
+			Msg("Failed to load message templates")
+		return nil
+	}
+
+	var messages []kafka.RenderedMessage
+	for _, template := range templates {
+		messages = append(messages, kafka.RenderedMessage{
+			OrgID:   template.OrgID,
+			Locale:  template.Locale,
+			Subject: template.RenderSubject(vars),
+			Body:    template.RenderBody(vars),
+		})
+	}
+
+	return messages
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// poiLookupTimeout bounds the provider round trip so facility enrichment
+// never delays activation noticeably
+const poiLookupTimeout = 5 * time.Second
+
+// POIService resolves the nearest emergency facilities (hospital, police,
+// fire station) around a location by querying the configured POI provider.
+// The provider exposes GET {base}/nearest?lat=..&lng=..&categories=.. and
+// returns {"facilities": [...]} ordered nearest-first per category
+type POIService struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPOIService creates a new POIService. baseURL is the POI provider
+// endpoint; an empty baseURL disables facility enrichment
+func NewPOIService(baseURL string) *POIService {
+	return &POIService{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: poiLookupTimeout},
+	}
+}
+
+// Enabled reports whether a POI provider is configured
+func (s *POIService) Enabled() bool {
+	return s != nil && s.baseURL != ""
+}
+
+// NearestFacilities returns the nearest hospital, police and fire stations
+// relative to the given location, one per category
+func (s *POIService) NearestFacilities(ctx context.Context, location models.Location) (models.NearbyFacilities, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", location.Latitude))
+	params.Set("lng", fmt.Sprintf("%f", location.Longitude))
+	params.Set("categories", fmt.Sprintf("%s,%s,%s",
+		models.FacilityHospital, models.FacilityPolice, models.FacilityFireStation))
+	params.Set("limit_per_category", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/nearest?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build POI request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query POI provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("POI provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Facilities models.NearbyFacilities `json:"facilities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode POI response: %w", err)
+	}
+
+	return result.Facilities, nil
+}
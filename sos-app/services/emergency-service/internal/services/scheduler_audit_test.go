@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSchedulerAudit_Snapshot(t *testing.T) {
+	countdown := NewCountdownService(nil, nil, nil, nil, nil, 10*time.Second)
+	escalation := NewEscalationService(nil, nil, nil, nil, nil, nil, 30)
+	defer countdown.Cleanup()
+	defer escalation.Cleanup()
+
+	countdownID := uuid.New()
+	escalationID := uuid.New()
+
+	// Timers are far enough out that none fire during the test; the
+	// auto-trigger flag also schedules a push-receipt check
+	countdown.StartCountdown(context.Background(), countdownID, 300, true)
+	escalation.StartMonitoring(context.Background(), escalationID)
+
+	audit := NewSchedulerAudit(countdown, escalation)
+	timers := audit.Snapshot()
+
+	if len(timers) != 3 {
+		t.Fatalf("expected 3 scheduled timers, got %d", len(timers))
+	}
+
+	// Soonest-firing first: push check (10s), countdown (300s), escalation (30m)
+	expectedKinds := []string{TimerKindPushCheck, TimerKindCountdown, TimerKindEscalation}
+	for i, timer := range timers {
+		if timer.Kind != expectedKinds[i] {
+			t.Errorf("timer %d: expected kind %s, got %s", i, expectedKinds[i], timer.Kind)
+		}
+		if timer.RemainingSeconds <= 0 {
+			t.Errorf("timer %d: expected positive remaining time, got %f", i, timer.RemainingSeconds)
+		}
+		if timer.FiresAt.Before(time.Now()) {
+			t.Errorf("timer %d: fire time %s is in the past", i, timer.FiresAt)
+		}
+	}
+
+	if timers[0].EmergencyID != countdownID || timers[1].EmergencyID != countdownID {
+		t.Error("countdown and push check should report the triggering emergency")
+	}
+	if timers[2].EmergencyID != escalationID {
+		t.Errorf("escalation timer reports emergency %s, expected %s", timers[2].EmergencyID, escalationID)
+	}
+}
+
+func TestSchedulerAudit_SnapshotEmpty(t *testing.T) {
+	audit := NewSchedulerAudit(
+		NewCountdownService(nil, nil, nil, nil, nil, 0),
+		NewEscalationService(nil, nil, nil, nil, nil, nil, 30),
+	)
+
+	if timers := audit.Snapshot(); len(timers) != 0 {
+		t.Errorf("expected no scheduled timers, got %d", len(timers))
+	}
+}
+
+func TestSchedulerAudit_Collector(t *testing.T) {
+	countdown := NewCountdownService(nil, nil, nil, nil, nil, 0)
+	escalation := NewEscalationService(nil, nil, nil, nil, nil, nil, 30)
+	defer countdown.Cleanup()
+	defer escalation.Cleanup()
+
+	countdown.StartCountdown(context.Background(), uuid.New(), 300, false)
+	escalation.StartMonitoring(context.Background(), uuid.New())
+
+	audit := NewSchedulerAudit(countdown, escalation)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(audit)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected gather error: %v", err)
+	}
+	if len(families) != 1 || families[0].GetName() != "sos_emergency_scheduled_timers" {
+		t.Fatalf("expected sos_emergency_scheduled_timers family, got %v", families)
+	}
+
+	counts := make(map[string]float64)
+	for _, metric := range families[0].GetMetric() {
+		counts[metric.GetLabel()[0].GetValue()] = metric.GetGauge().GetValue()
+	}
+	if counts[TimerKindCountdown] != 1 || counts[TimerKindEscalation] != 1 || counts[TimerKindPushCheck] != 0 {
+		t.Errorf("unexpected per-kind counts: %v", counts)
+	}
+}
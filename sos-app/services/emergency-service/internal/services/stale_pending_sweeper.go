@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// stalePendingSweepInterval is how often the sweep for stale PENDING
+// emergencies runs
+const stalePendingSweepInterval = 1 * time.Minute
+
+// Sweep policies for stale PENDING emergencies
+const (
+	// StalePendingActivate force-activates stale rows: missing a real
+	// emergency is worse than a false alarm
+	StalePendingActivate = "activate"
+	// StalePendingExpire cancels stale rows instead of activating them
+	StalePendingExpire = "expire"
+)
+
+// StalePendingSweeperStats counts what the sweeper has done since startup
+type StalePendingSweeperStats struct {
+	Swept     int64 `json:"swept"`
+	Activated int64 `json:"activated"`
+	Expired   int64 `json:"expired"`
+	Failures  int64 `json:"failures"`
+}
+
+// StalePendingSweeper is the last line of defense when both the countdown
+// timer and its recovery fail (bug, crash loop): emergencies stuck in
+// PENDING past their countdown plus a grace window are force-activated or
+// expired per policy, so no SOS silently rots in the database
+type StalePendingSweeper struct {
+	emergencyRepo *repository.EmergencyRepository
+	countdown     *CountdownService
+	policy        string
+	grace         time.Duration
+	stopChan      chan struct{}
+
+	swept     int64
+	activated int64
+	expired   int64
+	failures  int64
+}
+
+// NewStalePendingSweeper creates a new StalePendingSweeper. policy is
+// StalePendingActivate or StalePendingExpire; grace is how long past the
+// countdown a PENDING row is tolerated before it counts as stale
+func NewStalePendingSweeper(
+	emergencyRepo *repository.EmergencyRepository,
+	countdown *CountdownService,
+	policy string,
+	grace time.Duration,
+) *StalePendingSweeper {
+	return &StalePendingSweeper{
+		emergencyRepo: emergencyRepo,
+		countdown:     countdown,
+		policy:        policy,
+		grace:         grace,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins the periodic sweep for stale PENDING emergencies
+func (s *StalePendingSweeper) Start(ctx context.Context) {
+	log.Info().
+		Dur("interval", stalePendingSweepInterval).
+		Dur("grace", s.grace).
+		Str("policy", s.policy).
+		Msg("Stale pending sweeper started")
+
+	go func() {
+		ticker := time.NewTicker(stalePendingSweepInterval)
+		defer ticker.Stop()
+
+		// Sweep once at startup to catch rows orphaned by a crash
+		s.sweep(ctx)
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep(ctx)
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic sweep
+func (s *StalePendingSweeper) Stop() {
+	close(s.stopChan)
+}
+
+// Stats returns what the sweeper has done since startup
+func (s *StalePendingSweeper) Stats() StalePendingSweeperStats {
+	return StalePendingSweeperStats{
+		Swept:     atomic.LoadInt64(&s.swept),
+		Activated: atomic.LoadInt64(&s.activated),
+		Expired:   atomic.LoadInt64(&s.expired),
+		Failures:  atomic.LoadInt64(&s.failures),
+	}
+}
+
+// sweep resolves every PENDING emergency whose countdown should long since
+// have fired. Each action is logged at error level: a stale row means a
+// countdown timer was lost, which should never happen
+func (s *StalePendingSweeper) sweep(ctx context.Context) {
+	stale, err := s.emergencyRepo.ListStalePending(ctx, s.grace)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list stale pending emergencies")
+		return
+	}
+
+	for _, emergency := range stale {
+		// A live timer will resolve the row itself; only rows nothing is
+		// tracking anymore are swept
+		if s.countdown.IsTimerActive(emergency.ID) {
+			continue
+		}
+
+		atomic.AddInt64(&s.swept, 1)
+		log.Error().
+			Str("emergency_id", emergency.ID.String()).
+			Str("user_id", emergency.UserID.String()).
+			Time("created_at", emergency.CreatedAt).
+			Int("countdown_seconds", emergency.CountdownSeconds).
+			Str("policy", s.policy).
+			Msg("Stale PENDING emergency found - countdown timer was lost")
+
+		switch s.policy {
+		case StalePendingExpire:
+			s.expire(ctx, &emergency)
+		default:
+			s.activate(ctx, &emergency)
+		}
+	}
+}
+
+// activate pushes a stale row through the normal countdown completion path,
+// so activation hooks, enrichment and event publishing all still apply
+func (s *StalePendingSweeper) activate(ctx context.Context, emergency *models.Emergency) {
+	s.countdown.onCountdownComplete(ctx, emergency.ID)
+
+	// onCountdownComplete logs its own failures; confirm the outcome here
+	// so the counters reflect what actually happened
+	refreshed, err := s.emergencyRepo.GetByID(ctx, emergency.ID)
+	if err != nil || refreshed.Status != models.StatusActive {
+		atomic.AddInt64(&s.failures, 1)
+		return
+	}
+
+	atomic.AddInt64(&s.activated, 1)
+	log.Warn().
+		Str("emergency_id", emergency.ID.String()).
+		Msg("Stale PENDING emergency force-activated")
+}
+
+// expire cancels a stale row
+func (s *StalePendingSweeper) expire(ctx context.Context, emergency *models.Emergency) {
+	if err := s.emergencyRepo.UpdateStatus(ctx, emergency.ID, models.StatusCancelled); err != nil {
+		atomic.AddInt64(&s.failures, 1)
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to expire stale pending emergency")
+		return
+	}
+
+	atomic.AddInt64(&s.expired, 1)
+	log.Warn().
+		Str("emergency_id", emergency.ID.String()).
+		Msg("Stale PENDING emergency expired")
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+// weatherLookupTimeout bounds the provider round trip so weather enrichment
+// never delays activation noticeably
+const weatherLookupTimeout = 5 * time.Second
+
+// weatherGeohashPrecision is the cache cell size; precision 5 is roughly a
+// 5km square, well within the resolution of any weather provider
+const weatherGeohashPrecision = 5
+
+// WeatherService resolves the current weather and daylight window around a
+// location by querying the configured weather provider. The provider exposes
+// GET {base}/conditions?lat=..&lng=.. and returns current conditions plus
+// sunrise/sunset times. Responses are cached per geohash cell and hour so a
+// burst of emergencies in one area costs a single provider call
+type WeatherService struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	cache     map[string]*models.WeatherContext
+	cacheHour string
+}
+
+// NewWeatherService creates a new WeatherService. baseURL is the weather
+// provider endpoint; an empty baseURL disables weather enrichment
+func NewWeatherService(baseURL string) *WeatherService {
+	return &WeatherService{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: weatherLookupTimeout},
+		cache:      make(map[string]*models.WeatherContext),
+	}
+}
+
+// Enabled reports whether a weather provider is configured
+func (s *WeatherService) Enabled() bool {
+	return s != nil && s.baseURL != ""
+}
+
+// CurrentConditions returns the weather and daylight context for a location,
+// served from the per-geohash/hour cache when possible
+func (s *WeatherService) CurrentConditions(ctx context.Context, location models.Location) (*models.WeatherContext, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	hour := now.Format("2006-01-02T15")
+	key := geohash(location.Latitude, location.Longitude, weatherGeohashPrecision)
+
+	s.mu.Lock()
+	// A new hour invalidates the whole cache; conditions are per-hour anyway
+	if s.cacheHour != hour {
+		s.cache = make(map[string]*models.WeatherContext)
+		s.cacheHour = hour
+	}
+	if cached, ok := s.cache[key]; ok {
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	weather, err := s.fetch(ctx, location, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.cacheHour == hour {
+		s.cache[key] = weather
+	}
+	s.mu.Unlock()
+
+	return weather, nil
+}
+
+// fetch queries the weather provider for current conditions
+func (s *WeatherService) fetch(ctx context.Context, location models.Location, now time.Time) (*models.WeatherContext, error) {
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", location.Latitude))
+	params.Set("lng", fmt.Sprintf("%f", location.Longitude))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/conditions?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weather request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather provider returned status %d", resp.StatusCode)
+	}
+
+	var weather models.WeatherContext
+	if err := json.NewDecoder(resp.Body).Decode(&weather); err != nil {
+		return nil, fmt.Errorf("failed to decode weather response: %w", err)
+	}
+
+	weather.RetrievedAt = now
+	if weather.Sunrise != nil && weather.Sunset != nil {
+		daylight := now.After(*weather.Sunrise) && now.Before(*weather.Sunset)
+		weather.IsDaylight = &daylight
+	}
+
+	return &weather, nil
+}
+
+// geohashBase32 is the standard geohash alphabet
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohash encodes a coordinate into a standard geohash of the given precision
+func geohash(latitude, longitude float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var builder strings.Builder
+	var bits, bitCount int
+	even := true
+
+	for builder.Len() < precision {
+		if even {
+			mid := (lngRange[0] + lngRange[1]) / 2
+			if longitude >= mid {
+				bits = bits<<1 | 1
+				lngRange[0] = mid
+			} else {
+				bits <<= 1
+				lngRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if latitude >= mid {
+				bits = bits<<1 | 1
+				latRange[0] = mid
+			} else {
+				bits <<= 1
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		if bitCount++; bitCount == 5 {
+			builder.WriteByte(geohashBase32[bits])
+			bits, bitCount = 0, 0
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func TestGeohash(t *testing.T) {
+	// Reference values from the public geohash tables
+	cases := []struct {
+		lat, lng float64
+		expected string
+	}{
+		{57.64911, 10.40744, "u4pru"},
+		{48.85661, 2.35222, "u09tv"},
+		{-33.86882, 151.20930, "r3gx2"},
+	}
+
+	for _, c := range cases {
+		if got := geohash(c.lat, c.lng, 5); got != c.expected {
+			t.Errorf("geohash(%f, %f) = %s, expected %s", c.lat, c.lng, got, c.expected)
+		}
+	}
+}
+
+func TestWeatherService_CachesPerGeohashCell(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"temperature_c": -10.5, "conditions": "snow"}`))
+	}))
+	defer server.Close()
+
+	service := NewWeatherService(server.URL)
+	location := models.Location{Latitude: 57.64911, Longitude: 10.40744}
+
+	first, err := service.CurrentConditions(context.Background(), location)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.TemperatureC == nil || *first.TemperatureC != -10.5 || first.Conditions != "snow" {
+		t.Errorf("unexpected conditions: %+v", first)
+	}
+
+	// A second lookup in the same geohash cell is served from the cache
+	nearby := models.Location{Latitude: 57.64950, Longitude: 10.40800}
+	if _, err := service.CurrentConditions(context.Background(), nearby); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 provider call, got %d", calls)
+	}
+
+	// A distant location misses the cache
+	far := models.Location{Latitude: 48.85661, Longitude: 2.35222}
+	if _, err := service.CurrentConditions(context.Background(), far); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+}
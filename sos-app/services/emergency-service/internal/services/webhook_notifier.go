@@ -0,0 +1,284 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/repository"
+)
+
+// incidentStage identifies the lifecycle stage an incident card reports
+type incidentStage string
+
+const (
+	stageTriggered incidentStage = "triggered"
+	stageEscalated incidentStage = "escalated"
+	stageResolved  incidentStage = "resolved"
+
+	webhookPostTimeout = 10 * time.Second
+)
+
+// WebhookNotifier posts formatted incident cards to the Slack/Teams
+// connectors organizations have configured for their monitoring channels
+type WebhookNotifier struct {
+	connectorRepo *repository.WebhookConnectorRepository
+	httpClient    *http.Client
+	publicBaseURL string
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier. publicBaseURL is the
+// externally reachable base of this service, used for acknowledgment links
+func NewWebhookNotifier(connectorRepo *repository.WebhookConnectorRepository, publicBaseURL string) *WebhookNotifier {
+	return &WebhookNotifier{
+		connectorRepo: connectorRepo,
+		httpClient:    &http.Client{Timeout: webhookPostTimeout},
+		publicBaseURL: publicBaseURL,
+	}
+}
+
+// NotifyTriggered posts a trigger card for a newly activated emergency
+func (n *WebhookNotifier) NotifyTriggered(ctx context.Context, emergency *models.Emergency) {
+	n.notify(ctx, stageTriggered, emergency)
+}
+
+// NotifyEscalated posts an escalation card for an unacknowledged emergency
+func (n *WebhookNotifier) NotifyEscalated(ctx context.Context, emergency *models.Emergency) {
+	n.notify(ctx, stageEscalated, emergency)
+}
+
+// NotifyResolved posts a resolution card for a closed emergency
+func (n *WebhookNotifier) NotifyResolved(ctx context.Context, emergency *models.Emergency) {
+	n.notify(ctx, stageResolved, emergency)
+}
+
+// notify fans the incident card out to every enabled connector. Delivery
+// failures are logged per connector and never fail the emergency flow
+func (n *WebhookNotifier) notify(ctx context.Context, stage incidentStage, emergency *models.Emergency) {
+	// Drills exercise the rest of the pipeline but never reach external
+	// monitoring connectors
+	if emergency.IsTest {
+		log.Debug().
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Skipping connector delivery for test-mode emergency")
+		return
+	}
+
+	connectors, err := n.connectorRepo.ListEnabled(ctx)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to load webhook connectors")
+		return
+	}
+
+	for _, connector := range connectors {
+		if err := n.post(ctx, &connector, stage, emergency); err != nil {
+			log.Error().
+				Err(err).
+				Str("connector_id", connector.ID.String()).
+				Str("provider", string(connector.Provider)).
+				Str("emergency_id", emergency.ID.String()).
+				Msg("Failed to deliver incident card")
+		}
+	}
+}
+
+// post builds and delivers the provider-specific card to one connector
+func (n *WebhookNotifier) post(ctx context.Context, connector *models.WebhookConnector, stage incidentStage, emergency *models.Emergency) error {
+	var payload map[string]interface{}
+	switch connector.Provider {
+	case models.ConnectorProviderSlack:
+		threadRef, err := n.connectorRepo.GetThreadRef(ctx, connector.ID, emergency.ID)
+		if err != nil {
+			return err
+		}
+		payload = n.slackCard(stage, emergency, threadRef)
+	case models.ConnectorProviderTeams:
+		payload = n.teamsCard(stage, emergency)
+	default:
+		return fmt.Errorf("unknown connector provider: %s", connector.Provider)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident card: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, connector.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post incident card: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	// Slack API-style endpoints return the message ts; remember it so
+	// escalation and resolution cards thread under the trigger card
+	if stage == stageTriggered && connector.Provider == models.ConnectorProviderSlack {
+		n.saveThreadRef(ctx, connector, emergency, resp.Body)
+	}
+
+	return nil
+}
+
+// saveThreadRef extracts and stores a Slack thread reference, if present
+func (n *WebhookNotifier) saveThreadRef(ctx context.Context, connector *models.WebhookConnector, emergency *models.Emergency, body io.Reader) {
+	var response struct {
+		TS string `json:"ts"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil || response.TS == "" {
+		// Plain incoming webhooks answer "ok"; threading is unavailable there
+		return
+	}
+
+	if err := n.connectorRepo.SaveThreadRef(ctx, connector.ID, emergency.ID, response.TS); err != nil {
+		log.Error().
+			Err(err).
+			Str("connector_id", connector.ID.String()).
+			Str("emergency_id", emergency.ID.String()).
+			Msg("Failed to save webhook thread ref")
+	}
+}
+
+// slackCard builds a Slack Block Kit message for the incident
+func (n *WebhookNotifier) slackCard(stage incidentStage, emergency *models.Emergency, threadRef string) map[string]interface{} {
+	title := n.cardTitle(stage, emergency)
+
+	fields := []map[string]interface{}{
+		{"type": "mrkdwn", "text": fmt.Sprintf("*Type:*\n%s", emergency.EmergencyType)},
+		{"type": "mrkdwn", "text": fmt.Sprintf("*Status:*\n%s", emergency.Status)},
+		{"type": "mrkdwn", "text": fmt.Sprintf("*User:*\n%s", emergency.UserID)},
+		{"type": "mrkdwn", "text": fmt.Sprintf("*Emergency ID:*\n%s", emergency.ID)},
+	}
+	if emergency.InitialMessage != nil && *emergency.InitialMessage != "" {
+		fields = append(fields, map[string]interface{}{
+			"type": "mrkdwn", "text": fmt.Sprintf("*Message:*\n%s", *emergency.InitialMessage),
+		})
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": title},
+		},
+		{
+			"type":   "section",
+			"fields": fields,
+		},
+	}
+
+	// Resolved incidents need no further action
+	if stage != stageResolved {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []map[string]interface{}{
+				{
+					"type":  "button",
+					"text":  map[string]interface{}{"type": "plain_text", "text": "Acknowledge"},
+					"style": "primary",
+					"url":   n.acknowledgeURL(emergency),
+				},
+			},
+		})
+	}
+
+	payload := map[string]interface{}{
+		"text":   title,
+		"blocks": blocks,
+	}
+	if threadRef != "" {
+		payload["thread_ts"] = threadRef
+	}
+
+	return payload
+}
+
+// teamsCard builds a Teams MessageCard for the incident. Teams webhooks have
+// no threading, so each stage posts a standalone card
+func (n *WebhookNotifier) teamsCard(stage incidentStage, emergency *models.Emergency) map[string]interface{} {
+	title := n.cardTitle(stage, emergency)
+
+	facts := []map[string]string{
+		{"name": "Type", "value": string(emergency.EmergencyType)},
+		{"name": "Status", "value": string(emergency.Status)},
+		{"name": "User", "value": emergency.UserID.String()},
+		{"name": "Emergency ID", "value": emergency.ID.String()},
+	}
+	if emergency.InitialMessage != nil && *emergency.InitialMessage != "" {
+		facts = append(facts, map[string]string{"name": "Message", "value": *emergency.InitialMessage})
+	}
+
+	card := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    title,
+		"themeColor": n.themeColor(stage),
+		"sections": []map[string]interface{}{
+			{
+				"activityTitle": title,
+				"facts":         facts,
+			},
+		},
+	}
+
+	if stage != stageResolved {
+		card["potentialAction"] = []map[string]interface{}{
+			{
+				"@type": "OpenUri",
+				"name":  "Acknowledge",
+				"targets": []map[string]string{
+					{"os": "default", "uri": n.acknowledgeURL(emergency)},
+				},
+			},
+		}
+	}
+
+	return card
+}
+
+// cardTitle builds the headline for an incident card
+func (n *WebhookNotifier) cardTitle(stage incidentStage, emergency *models.Emergency) string {
+	switch stage {
+	case stageTriggered:
+		return fmt.Sprintf("🚨 Emergency triggered: %s", emergency.EmergencyType)
+	case stageEscalated:
+		return fmt.Sprintf("⚠️ Emergency escalated (unacknowledged): %s", emergency.EmergencyType)
+	case stageResolved:
+		return fmt.Sprintf("✅ Emergency resolved: %s", emergency.EmergencyType)
+	}
+	return fmt.Sprintf("Emergency update: %s", emergency.EmergencyType)
+}
+
+// themeColor maps the stage to a Teams card accent color
+func (n *WebhookNotifier) themeColor(stage incidentStage) string {
+	switch stage {
+	case stageTriggered:
+		return "D00000"
+	case stageEscalated:
+		return "E8A317"
+	case stageResolved:
+		return "2EB67D"
+	}
+	return "808080"
+}
+
+// acknowledgeURL is the link behind the card's Acknowledge button
+func (n *WebhookNotifier) acknowledgeURL(emergency *models.Emergency) string {
+	return fmt.Sprintf("%s/api/v1/emergency/%s/acknowledge", n.publicBaseURL, emergency.ID)
+}
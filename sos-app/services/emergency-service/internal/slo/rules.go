@@ -0,0 +1,110 @@
+package slo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// burnWindow is one window of the standard multi-window burn-rate policy:
+// an alert fires when the error budget burns at Factor times the sustainable
+// rate over both the long window and its short confirmation window
+type burnWindow struct {
+	Severity    string
+	Factor      float64
+	LongWindow  string
+	ShortWindow string
+}
+
+// burnWindows follows the usual two-stage policy: a fast burn pages, a slow
+// sustained burn opens a ticket
+var burnWindows = []burnWindow{
+	{Severity: "page", Factor: 14.4, LongWindow: "1h", ShortWindow: "5m"},
+	{Severity: "ticket", Factor: 6, LongWindow: "6h", ShortWindow: "30m"},
+}
+
+// AlertRulesHandler serves GET /debug/slo/rules: Prometheus alerting rules
+// in rule-file YAML, generated from the Objective declarations so the rules
+// loaded into Prometheus can never drift from the instrumented code
+func AlertRulesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(RenderAlertRules()))
+}
+
+// RenderAlertRules generates the Prometheus rule file for all Objectives
+func RenderAlertRules() string {
+	var b strings.Builder
+	b.WriteString("# Generated by emergency-service from its SLO declarations\n")
+	b.WriteString("# (internal/slo); edit the code, not this file.\n")
+	b.WriteString("groups:\n")
+	b.WriteString("  - name: emergency-service-slo\n")
+	b.WriteString("    rules:\n")
+
+	for _, objective := range Objectives {
+		for _, window := range burnWindows {
+			writeBurnRule(&b, objective, window)
+		}
+	}
+
+	return b.String()
+}
+
+// writeBurnRule emits one burn-rate alert for an objective: the fraction of
+// events slower than the threshold bucket, compared against the error budget
+// scaled by the window's burn factor
+func writeBurnRule(b *strings.Builder, objective Objective, window burnWindow) {
+	budget := (1 - objective.Target) * window.Factor
+
+	fmt.Fprintf(b, "      - alert: EmergencyService%s%sBurn\n", alertName(objective.Name), titleCase(window.Severity))
+	fmt.Fprintf(b, "        expr: >-\n")
+	fmt.Fprintf(b, "          %s\n", errorRatio(objective, window.LongWindow))
+	fmt.Fprintf(b, "          > %s\n", formatFloat(budget))
+	fmt.Fprintf(b, "          and\n")
+	fmt.Fprintf(b, "          %s\n", errorRatio(objective, window.ShortWindow))
+	fmt.Fprintf(b, "          > %s\n", formatFloat(budget))
+	fmt.Fprintf(b, "        labels:\n")
+	fmt.Fprintf(b, "          severity: %s\n", window.Severity)
+	fmt.Fprintf(b, "          slo: %s\n", objective.Name)
+	fmt.Fprintf(b, "        annotations:\n")
+	fmt.Fprintf(b, "          summary: %s\n", objective.Description)
+	fmt.Fprintf(b, "          description: Error budget is burning at more than %sx the sustainable rate over the last %s.\n",
+		formatFloat(window.Factor), window.LongWindow)
+}
+
+// errorRatio is the PromQL fraction of events that missed the objective's
+// threshold over the given window
+func errorRatio(objective Objective, window string) string {
+	le := fmt.Sprintf("le=%q", formatFloat(objective.Threshold.Seconds()))
+	var count string
+	if objective.Endpoint != "" {
+		endpoint := fmt.Sprintf("endpoint=%q", objective.Endpoint)
+		le += "," + endpoint
+		count = "{" + endpoint + "}"
+	}
+
+	return fmt.Sprintf("(1 - sum(rate(%s_bucket{%s}[%s])) / sum(rate(%s_count%s[%s])))",
+		objective.Metric, le, window, objective.Metric, count, window)
+}
+
+// alertName turns an objective name like "trigger_latency" into
+// "TriggerLatency" for use in alert identifiers
+func alertName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		parts[i] = titleCase(part)
+	}
+	return strings.Join(parts, "")
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,161 @@
+// Package slo defines the emergency-service service level objectives in
+// code. The same Objective declarations drive the Prometheus histograms,
+// the burn-rate alert rules served at /debug/slo/rules and the compliance
+// summary served at /debug/slo, so dashboards, alerts and on-call tooling
+// can never disagree about what "good" means.
+package slo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	requestDurationName = "sos_emergency_request_duration_seconds"
+	activationDelayName = "sos_emergency_activation_publish_delay_seconds"
+)
+
+// Objective is one service level objective, declared in code so the alert
+// rules can be generated from the exact same definition the service is
+// instrumented with
+type Objective struct {
+	Name        string        `json:"name"`        // stable identifier used in alert names
+	Description string        `json:"description"` // what the objective promises, for on-call
+	Metric      string        `json:"metric"`      // histogram the objective is measured on
+	Endpoint    string        `json:"endpoint,omitempty"` // route template, when the metric is per-endpoint
+	Threshold   time.Duration `json:"-"`           // an event slower than this is not "good"
+	Target      float64       `json:"target"`      // required fraction of good events
+}
+
+// Objectives are the emergency-service SLOs. Thresholds must coincide with
+// a histogram bucket boundary or the generated rules cannot measure them
+var Objectives = []Objective{
+	{
+		Name:        "trigger_latency",
+		Description: "POST /api/v1/emergency/trigger answers within 300ms (p99)",
+		Metric:      requestDurationName,
+		Endpoint:    "/api/v1/emergency/trigger",
+		Threshold:   300 * time.Millisecond,
+		Target:      0.99,
+	},
+	{
+		Name:        "activation_publish_delay",
+		Description: "EmergencyCreated is published within 1s of the countdown expiring",
+		Metric:      activationDelayName,
+		Threshold:   time.Second,
+		Target:      0.99,
+	},
+}
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    requestDurationName,
+		Help:    "HTTP request duration by route template.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.3, 0.5, 1, 2.5, 5},
+	}, []string{"endpoint"})
+
+	activationDelay = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    activationDelayName,
+		Help:    "Delay between countdown expiry and the EmergencyCreated publish.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	})
+)
+
+// tracker counts events against one objective since process start, feeding
+// the /debug/slo summary without a round-trip to Prometheus
+type tracker struct {
+	total atomic.Int64
+	good  atomic.Int64
+}
+
+var (
+	trackers     = make(map[string]*tracker, len(Objectives))
+	processStart = time.Now()
+)
+
+func init() {
+	for _, objective := range Objectives {
+		trackers[objective.Name] = &tracker{}
+	}
+}
+
+// ObserveRequest records one handled HTTP request and scores it against the
+// endpoint's latency objective, if it has one
+func ObserveRequest(endpoint string, d time.Duration) {
+	requestDuration.WithLabelValues(endpoint).Observe(d.Seconds())
+
+	for _, objective := range Objectives {
+		if objective.Metric == requestDurationName && objective.Endpoint == endpoint {
+			record(objective, d <= objective.Threshold)
+		}
+	}
+}
+
+// ObserveActivationPublish records how long after countdown expiry the
+// activation event went out. A publish that failed outright counts as a
+// missed event but is not added to the latency histogram
+func ObserveActivationPublish(d time.Duration, published bool) {
+	if published {
+		activationDelay.Observe(d.Seconds())
+	}
+
+	for _, objective := range Objectives {
+		if objective.Metric == activationDelayName {
+			record(objective, published && d <= objective.Threshold)
+		}
+	}
+}
+
+func record(objective Objective, good bool) {
+	t := trackers[objective.Name]
+	t.total.Add(1)
+	if good {
+		t.good.Add(1)
+	}
+}
+
+// objectiveStatus is one objective's entry in the /debug/slo summary
+type objectiveStatus struct {
+	Objective
+	ThresholdSeconds float64  `json:"threshold_seconds"`
+	TotalEvents      int64    `json:"total_events"`
+	GoodEvents       int64    `json:"good_events"`
+	Compliance       *float64 `json:"compliance,omitempty"` // absent until the first event
+	Met              *bool    `json:"met,omitempty"`        // absent until the first event
+}
+
+// StatusHandler serves GET /debug/slo: current compliance per objective,
+// counted in-process since service start, for on-call engineers who want a
+// quick read without querying Prometheus
+func StatusHandler(w http.ResponseWriter, r *http.Request) {
+	statuses := make([]objectiveStatus, 0, len(Objectives))
+	for _, objective := range Objectives {
+		t := trackers[objective.Name]
+		status := objectiveStatus{
+			Objective:        objective,
+			ThresholdSeconds: objective.Threshold.Seconds(),
+			TotalEvents:      t.total.Load(),
+			GoodEvents:       t.good.Load(),
+		}
+		if status.TotalEvents > 0 {
+			compliance := float64(status.GoodEvents) / float64(status.TotalEvents)
+			met := compliance >= objective.Target
+			status.Compliance = &compliance
+			status.Met = &met
+		}
+		statuses = append(statuses, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"service":    "emergency-service",
+		"since":      processStart.UTC(),
+		"objectives": statuses,
+	})
+}
@@ -0,0 +1,97 @@
+package slo
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObserveRequestScoresTriggerObjective(t *testing.T) {
+	tracker := trackers["trigger_latency"]
+	startTotal := tracker.total.Load()
+	startGood := tracker.good.Load()
+
+	ObserveRequest("/api/v1/emergency/trigger", 100*time.Millisecond)
+	ObserveRequest("/api/v1/emergency/trigger", 900*time.Millisecond)
+	// Other endpoints are measured but not scored against this objective
+	ObserveRequest("/api/v1/emergency/history", 5*time.Second)
+
+	if got := tracker.total.Load() - startTotal; got != 2 {
+		t.Errorf("expected 2 scored events, got %d", got)
+	}
+	if got := tracker.good.Load() - startGood; got != 1 {
+		t.Errorf("expected 1 good event, got %d", got)
+	}
+}
+
+func TestObserveActivationPublishCountsFailureAsMiss(t *testing.T) {
+	tracker := trackers["activation_publish_delay"]
+	startTotal := tracker.total.Load()
+	startGood := tracker.good.Load()
+
+	ObserveActivationPublish(200*time.Millisecond, true)
+	ObserveActivationPublish(100*time.Millisecond, false)
+	ObserveActivationPublish(3*time.Second, true)
+
+	if got := tracker.total.Load() - startTotal; got != 3 {
+		t.Errorf("expected 3 scored events, got %d", got)
+	}
+	if got := tracker.good.Load() - startGood; got != 1 {
+		t.Errorf("expected 1 good event, got %d", got)
+	}
+}
+
+func TestStatusHandlerReportsCompliance(t *testing.T) {
+	ObserveRequest("/api/v1/emergency/trigger", 10*time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	StatusHandler(recorder, httptest.NewRequest("GET", "/debug/slo", nil))
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+
+	var body struct {
+		Objectives []struct {
+			Name        string   `json:"name"`
+			TotalEvents int64    `json:"total_events"`
+			Compliance  *float64 `json:"compliance"`
+		} `json:"objectives"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	if len(body.Objectives) != len(Objectives) {
+		t.Fatalf("expected %d objectives, got %d", len(Objectives), len(body.Objectives))
+	}
+
+	for _, status := range body.Objectives {
+		if status.Name != "trigger_latency" {
+			continue
+		}
+		if status.TotalEvents == 0 || status.Compliance == nil {
+			t.Errorf("expected trigger_latency to have scored events and a compliance ratio")
+		}
+	}
+}
+
+func TestRenderAlertRulesCoversEveryObjective(t *testing.T) {
+	rules := RenderAlertRules()
+
+	for _, fragment := range []string{
+		"- alert: EmergencyServiceTriggerLatencyPageBurn",
+		"- alert: EmergencyServiceTriggerLatencyTicketBurn",
+		"- alert: EmergencyServiceActivationPublishDelayPageBurn",
+		"- alert: EmergencyServiceActivationPublishDelayTicketBurn",
+		`le="0.3",endpoint="/api/v1/emergency/trigger"`,
+		`sos_emergency_activation_publish_delay_seconds_bucket{le="1"}`,
+		"severity: page",
+		"severity: ticket",
+	} {
+		if !strings.Contains(rules, fragment) {
+			t.Errorf("generated rules missing %q", fragment)
+		}
+	}
+}
@@ -89,6 +89,24 @@ for migration_file in "$MIGRATIONS_DIR"/*.sql; do
     fi
 done
 
+# Optionally seed sample data for local development. The seed lives outside
+# the numbered chain, is idempotent (fixed UUIDs + ON CONFLICT DO NOTHING)
+# and is only applied when explicitly requested
+if [ "${SEED_DEV_DATA:-false}" = "true" ]; then
+    SEED_FILE="$MIGRATIONS_DIR/seed/dev_seed.sql"
+    echo -e "\n${YELLOW}Seeding dev data (SEED_DEV_DATA=true)...${NC}"
+    if [ ! -f "$SEED_FILE" ]; then
+        echo -e "${RED}✗ Seed file not found: $SEED_FILE${NC}"
+        exit 1
+    fi
+    if $PSQL_CMD -f "$SEED_FILE" 2>&1; then
+        echo -e "${GREEN}✓ Dev seed data applied${NC}"
+    else
+        echo -e "${RED}✗ Dev seed failed${NC}"
+        exit 1
+    fi
+fi
+
 echo -e "\n${GREEN}==================================${NC}"
 echo -e "${GREEN}All migrations completed successfully!${NC}"
 echo -e "${GREEN}==================================${NC}\n"
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sos-app/emergency-service/internal/models"
+	"github.com/sos-app/emergency-service/internal/services"
+)
+
+// stubHook is a scriptable activation hook for exercising the runner
+type stubHook struct {
+	name   string
+	passed bool
+	detail string
+	err    error
+	panics bool
+}
+
+func (h *stubHook) Name() string { return h.name }
+
+func (h *stubHook) Check(ctx context.Context, emergency *models.Emergency) (bool, string, error) {
+	if h.panics {
+		panic("hook exploded")
+	}
+	return h.passed, h.detail, h.err
+}
+
+func TestRunActivationHooks_RecordsEveryOutcome(t *testing.T) {
+	hooks := []services.ActivationHook{
+		&stubHook{name: "passing", passed: true, detail: "all good"},
+		&stubHook{name: "failing", passed: false, detail: "something missing"},
+		&stubHook{name: "broken", err: errors.New("policy service unreachable")},
+	}
+
+	checks := services.RunActivationHooks(context.Background(), hooks, &models.Emergency{})
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 recorded checks, got %d", len(checks))
+	}
+
+	if checks[0].Outcome != models.CheckPassed || checks[0].Detail != "all good" {
+		t.Errorf("unexpected first check: %+v", checks[0])
+	}
+	if checks[1].Outcome != models.CheckFailed {
+		t.Errorf("expected FAILED outcome, got %s", checks[1].Outcome)
+	}
+	if checks[2].Outcome != models.CheckError || checks[2].Detail != "policy service unreachable" {
+		t.Errorf("unexpected broken-hook check: %+v", checks[2])
+	}
+}
+
+func TestRunActivationHooks_PanicIsFailOpen(t *testing.T) {
+	hooks := []services.ActivationHook{
+		&stubHook{name: "panicking", panics: true},
+		&stubHook{name: "after", passed: true},
+	}
+
+	checks := services.RunActivationHooks(context.Background(), hooks, &models.Emergency{})
+	if len(checks) != 2 {
+		t.Fatalf("expected the chain to continue past a panic, got %d checks", len(checks))
+	}
+	if checks[0].Outcome != models.CheckError {
+		t.Errorf("expected ERROR outcome for panicking hook, got %s", checks[0].Outcome)
+	}
+	if checks[1].Outcome != models.CheckPassed {
+		t.Errorf("expected the following hook to still run, got %s", checks[1].Outcome)
+	}
+}
+
+func TestRunActivationHooks_NoHooksRecordsNothing(t *testing.T) {
+	if checks := services.RunActivationHooks(context.Background(), nil, &models.Emergency{}); checks != nil {
+		t.Errorf("expected nil checks for an empty chain, got %+v", checks)
+	}
+}
+
+func TestLocationFreshnessHook(t *testing.T) {
+	hook := services.NewLocationFreshnessHook(2 * time.Minute)
+
+	fresh := &models.Emergency{}
+	fresh.InitialLocation.Timestamp = time.Now().Add(-30 * time.Second)
+	if passed, _, err := hook.Check(context.Background(), fresh); err != nil || !passed {
+		t.Errorf("expected fresh location to pass, got passed=%v err=%v", passed, err)
+	}
+
+	stale := &models.Emergency{}
+	stale.InitialLocation.Timestamp = time.Now().Add(-10 * time.Minute)
+	if passed, detail, _ := hook.Check(context.Background(), stale); passed {
+		t.Errorf("expected stale location to fail, got detail %q", detail)
+	}
+
+	missing := &models.Emergency{}
+	if passed, _, _ := hook.Check(context.Background(), missing); passed {
+		t.Error("expected a fix without a timestamp to fail")
+	}
+}
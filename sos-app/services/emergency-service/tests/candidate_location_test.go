@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func candidate(provider string, accuracy float64) models.Location {
+	return models.Location{
+		Latitude:  37.7749,
+		Longitude: -122.4194,
+		Provider:  provider,
+		Accuracy:  &accuracy,
+	}
+}
+
+func TestSelectBestLocation_PrefersHigherPriorityProvider(t *testing.T) {
+	candidates := []models.Location{
+		candidate("CELLULAR", 500),
+		candidate("GPS", 15),
+		candidate("WIFI", 40),
+	}
+
+	best := models.SelectBestLocation(candidates)
+	if best.Provider != "GPS" {
+		t.Errorf("expected GPS candidate, got %s", best.Provider)
+	}
+}
+
+func TestSelectBestLocation_BreaksTiesByAccuracy(t *testing.T) {
+	candidates := []models.Location{
+		candidate("GPS", 30),
+		candidate("GPS", 8),
+	}
+
+	best := models.SelectBestLocation(candidates)
+	if best.Accuracy == nil || *best.Accuracy != 8 {
+		t.Errorf("expected the 8m GPS fix, got %+v", best.Accuracy)
+	}
+}
+
+func TestSelectBestLocation_UnknownProviderRanksLowest(t *testing.T) {
+	candidates := []models.Location{
+		candidate("FUSED_MAGIC", 1),
+		candidate("CELLULAR", 900),
+	}
+
+	best := models.SelectBestLocation(candidates)
+	if best.Provider != "CELLULAR" {
+		t.Errorf("expected known CELLULAR provider to win, got %s", best.Provider)
+	}
+}
+
+func TestAttachLocationCandidates_MergesIntoExistingMetadata(t *testing.T) {
+	metadata := rawMetadata(t, map[string]interface{}{"confidence": 0.92})
+	candidates := []models.Location{candidate("GPS", 15), candidate("WIFI", 40)}
+
+	merged, err := models.AttachLocationCandidates(metadata, candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(*merged, &doc); err != nil {
+		t.Fatalf("merged metadata is not valid JSON: %v", err)
+	}
+	if doc["confidence"] != 0.92 {
+		t.Errorf("expected existing metadata to survive, got %v", doc["confidence"])
+	}
+	stored, ok := doc["location_candidates"].([]interface{})
+	if !ok || len(stored) != 2 {
+		t.Errorf("expected 2 stored candidates, got %v", doc["location_candidates"])
+	}
+}
+
+func TestAttachLocationCandidates_NilMetadata(t *testing.T) {
+	merged, err := models.AttachLocationCandidates(nil, []models.Location{candidate("GPS", 15)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(*merged, &doc); err != nil {
+		t.Fatalf("merged metadata is not valid JSON: %v", err)
+	}
+	if _, ok := doc["location_candidates"]; !ok {
+		t.Error("expected location_candidates key")
+	}
+}
+
+func TestAttachLocationCandidates_RejectsNonObjectMetadata(t *testing.T) {
+	raw := json.RawMessage(`[1, 2, 3]`)
+	if _, err := models.AttachLocationCandidates(&raw, []models.Location{candidate("GPS", 15)}); err == nil {
+		t.Error("expected error for non-object metadata")
+	}
+}
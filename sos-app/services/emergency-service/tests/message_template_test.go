@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func TestMessageTemplate_RenderSubstitutesVariables(t *testing.T) {
+	template := models.MessageTemplate{
+		Subject: "{{emergency_type}} alert",
+		Body:    "Acme Watch: {{ emergency_type }} emergency for user {{user_id}}.",
+	}
+
+	vars := map[string]string{
+		"emergency_type": "MEDICAL",
+		"user_id":        "42",
+	}
+
+	if got := template.RenderSubject(vars); got != "MEDICAL alert" {
+		t.Errorf("expected rendered subject, got %q", got)
+	}
+	if got := template.RenderBody(vars); got != "Acme Watch: MEDICAL emergency for user 42." {
+		t.Errorf("expected rendered body, got %q", got)
+	}
+}
+
+func TestMessageTemplate_RenderUnknownVariableIsEmpty(t *testing.T) {
+	template := models.MessageTemplate{Body: "Reason: {{reason}}!"}
+
+	if got := template.RenderBody(map[string]string{}); got != "Reason: !" {
+		t.Errorf("expected unknown placeholder to render empty, got %q", got)
+	}
+}
+
+func TestUpsertTemplateRequest_Validate(t *testing.T) {
+	valid := models.UpsertTemplateRequest{
+		OrgID:     uuid.New(),
+		EventType: models.TemplateEventEmergencyCreated,
+		Locale:    "en",
+		Subject:   "Alert",
+		Body:      "Something happened",
+	}
+	if !valid.Validate() {
+		t.Error("expected valid request to pass validation")
+	}
+
+	invalid := []models.UpsertTemplateRequest{
+		{},
+		func() models.UpsertTemplateRequest { r := valid; r.OrgID = uuid.Nil; return r }(),
+		func() models.UpsertTemplateRequest { r := valid; r.EventType = "emergency.escalated"; return r }(),
+		func() models.UpsertTemplateRequest { r := valid; r.Locale = ""; return r }(),
+		func() models.UpsertTemplateRequest { r := valid; r.Subject = ""; return r }(),
+		func() models.UpsertTemplateRequest { r := valid; r.Body = ""; return r }(),
+	}
+	for i, req := range invalid {
+		if req.Validate() {
+			t.Errorf("expected request %d to fail validation", i)
+		}
+	}
+}
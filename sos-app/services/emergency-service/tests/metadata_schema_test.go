@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sos-app/emergency-service/internal/models"
+)
+
+func rawMetadata(t *testing.T, v interface{}) *json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal metadata: %v", err)
+	}
+	raw := json.RawMessage(data)
+	return &raw
+}
+
+func TestValidateMetadata_FallDetectedRequiresConfidence(t *testing.T) {
+	errs := models.ValidateMetadata(models.EmergencyTypeFallDetected, nil)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Field != "confidence" {
+		t.Errorf("expected error on confidence, got %s", errs[0].Field)
+	}
+
+	metadata := rawMetadata(t, map[string]interface{}{"confidence": 0.92})
+	if errs := models.ValidateMetadata(models.EmergencyTypeFallDetected, metadata); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateMetadata_RejectsDisallowedFields(t *testing.T) {
+	// A FIRE emergency shouldn't carry vitals
+	metadata := rawMetadata(t, map[string]interface{}{
+		"vitals": map[string]interface{}{"heart_rate": 80},
+	})
+
+	errs := models.ValidateMetadata(models.EmergencyTypeFire, metadata)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Field != "vitals" {
+		t.Errorf("expected error on vitals, got %s", errs[0].Field)
+	}
+}
+
+func TestValidateMetadata_TypeChecksFields(t *testing.T) {
+	metadata := rawMetadata(t, map[string]interface{}{
+		"confidence": "very sure",
+	})
+
+	errs := models.ValidateMetadata(models.EmergencyTypeFallDetected, metadata)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Field != "confidence" {
+		t.Errorf("expected error on confidence, got %s", errs[0].Field)
+	}
+}
+
+func TestValidateMetadata_NonObjectMetadataRejected(t *testing.T) {
+	raw := json.RawMessage(`[1, 2, 3]`)
+
+	errs := models.ValidateMetadata(models.EmergencyTypeGeneral, &raw)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Field != "metadata" {
+		t.Errorf("expected error on metadata, got %s", errs[0].Field)
+	}
+}
+
+func TestValidateMetadata_EmptyMetadataValidWhenNothingRequired(t *testing.T) {
+	if errs := models.ValidateMetadata(models.EmergencyTypeMedical, nil); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
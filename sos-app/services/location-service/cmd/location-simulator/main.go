@@ -0,0 +1,283 @@
+// Command location-simulator replays a recorded GPX or GeoJSON route as
+// location updates for a fake emergency, either through the HTTP update API
+// or straight onto the Kafka topic. It exists for sales demos, UI
+// development against live-looking data and WebSocket scale testing
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/kafka"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func main() {
+	var (
+		routePath   = flag.String("route", "", "GPX or GeoJSON route file to replay (required)")
+		mode        = flag.String("mode", "api", "delivery mode: api (HTTP update endpoint) or kafka")
+		apiURL      = flag.String("api", "http://localhost:3003", "location-service base URL for api mode")
+		brokers     = flag.String("brokers", "localhost:9092", "comma-separated Kafka brokers for kafka mode")
+		emergencyID = flag.String("emergency-id", "", "emergency ID to replay under (default: random)")
+		userID      = flag.String("user-id", "", "user ID to replay under (default: random)")
+		interval    = flag.Duration("interval", 2*time.Second, "delay between points when the route has no timestamps")
+		speed       = flag.Float64("speed", 1.0, "replay speed multiplier (2 = twice as fast)")
+		severity    = flag.String("severity", "", "emergency severity attached to updates (e.g. CRITICAL)")
+		loop        = flag.Bool("loop", false, "restart the route when it ends")
+	)
+	flag.Parse()
+
+	if *routePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *speed <= 0 {
+		log.Fatal("-speed must be positive")
+	}
+
+	route, err := loadRoute(*routePath)
+	if err != nil {
+		log.Fatalf("Failed to load route: %v", err)
+	}
+	if len(route) < 2 {
+		log.Fatalf("Route has %d points; need at least 2", len(route))
+	}
+
+	emergency := parseOrNewUUID(*emergencyID)
+	user := parseOrNewUUID(*userID)
+
+	var send func(update *models.LocationUpdate) error
+	switch *mode {
+	case "api":
+		send = apiSender(*apiURL)
+	case "kafka":
+		producer, err := kafka.NewProducer(strings.Split(*brokers, ","))
+		if err != nil {
+			log.Fatalf("Failed to create Kafka producer: %v", err)
+		}
+		defer producer.Close()
+		send = kafkaSender(producer)
+	default:
+		log.Fatalf("Unknown mode %q (want api or kafka)", *mode)
+	}
+
+	log.Printf("Replaying %d points from %s as emergency %s (mode=%s, speed=%.1fx)",
+		len(route), *routePath, emergency, *mode, *speed)
+
+	for {
+		replay(route, emergency, user, *severity, *interval, *speed, send)
+		if !*loop {
+			return
+		}
+		log.Printf("Route finished; looping")
+	}
+}
+
+// routePoint is one position along the recorded route
+type routePoint struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  *float64
+	Time      *time.Time
+}
+
+// replay walks the route once, pacing points by their recorded timestamps
+// (or the fixed interval) divided by the speed multiplier
+func replay(route []routePoint, emergencyID, userID uuid.UUID, severity string, interval time.Duration, speed float64, send func(*models.LocationUpdate) error) {
+	for i, point := range route {
+		update := &models.LocationUpdate{
+			EmergencyID: emergencyID,
+			UserID:      userID,
+			Latitude:    point.Latitude,
+			Longitude:   point.Longitude,
+			Altitude:    point.Altitude,
+			Provider:    models.ProviderGPS,
+			Severity:    severity,
+		}
+
+		if err := send(update); err != nil {
+			log.Printf("Point %d/%d failed: %v", i+1, len(route), err)
+		} else {
+			log.Printf("Point %d/%d sent (%.5f, %.5f)", i+1, len(route), point.Latitude, point.Longitude)
+		}
+
+		if i == len(route)-1 {
+			return
+		}
+
+		gap := interval
+		if point.Time != nil && route[i+1].Time != nil && route[i+1].Time.After(*point.Time) {
+			gap = route[i+1].Time.Sub(*point.Time)
+		}
+		time.Sleep(time.Duration(float64(gap) / speed))
+	}
+}
+
+// apiSender posts updates to the location-service HTTP update endpoint
+func apiSender(baseURL string) func(*models.LocationUpdate) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	endpoint := strings.TrimRight(baseURL, "/") + "/api/v1/location/update"
+
+	return func(update *models.LocationUpdate) error {
+		body, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("update endpoint returned %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// kafkaSender publishes updates straight onto the location-updated topic,
+// bypassing the API (useful for WebSocket fan-out testing)
+func kafkaSender(producer *kafka.Producer) func(*models.LocationUpdate) error {
+	return func(update *models.LocationUpdate) error {
+		return producer.PublishLocationUpdate(context.Background(), update.ToLocationPoint())
+	}
+}
+
+// loadRoute parses the route file by extension
+func loadRoute(path string) ([]routePoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpx":
+		return parseGPX(data)
+	case ".geojson", ".json":
+		return parseGeoJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported route format %q (want .gpx, .geojson or .json)", filepath.Ext(path))
+	}
+}
+
+// gpxFile is the subset of GPX needed to replay a track
+type gpxFile struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []struct {
+				Lat  float64  `xml:"lat,attr"`
+				Lon  float64  `xml:"lon,attr"`
+				Ele  *float64 `xml:"ele"`
+				Time string   `xml:"time"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// parseGPX flattens all track segments into one route
+func parseGPX(data []byte) ([]routePoint, error) {
+	var gpx gpxFile
+	if err := xml.Unmarshal(data, &gpx); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %w", err)
+	}
+
+	var route []routePoint
+	for _, track := range gpx.Tracks {
+		for _, segment := range track.Segments {
+			for _, p := range segment.Points {
+				point := routePoint{Latitude: p.Lat, Longitude: p.Lon, Altitude: p.Ele}
+				if p.Time != "" {
+					if t, err := time.Parse(time.RFC3339, p.Time); err == nil {
+						point.Time = &t
+					}
+				}
+				route = append(route, point)
+			}
+		}
+	}
+
+	return route, nil
+}
+
+// geoJSONGeometry is the subset of GeoJSON geometry needed for a route
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// parseGeoJSON accepts a bare LineString geometry, a Feature wrapping one,
+// or a FeatureCollection whose first LineString feature is the route
+func parseGeoJSON(data []byte) ([]routePoint, error) {
+	var doc struct {
+		Type     string           `json:"type"`
+		Geometry *geoJSONGeometry `json:"geometry"`
+		Features []struct {
+			Geometry geoJSONGeometry `json:"geometry"`
+		} `json:"features"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	var geometry *geoJSONGeometry
+	switch doc.Type {
+	case "LineString":
+		geometry = &geoJSONGeometry{Type: doc.Type, Coordinates: doc.Coordinates}
+	case "Feature":
+		geometry = doc.Geometry
+	case "FeatureCollection":
+		for i := range doc.Features {
+			if doc.Features[i].Geometry.Type == "LineString" {
+				geometry = &doc.Features[i].Geometry
+				break
+			}
+		}
+	}
+	if geometry == nil || geometry.Type != "LineString" {
+		return nil, fmt.Errorf("no LineString geometry found")
+	}
+
+	var coordinates [][]float64
+	if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil {
+		return nil, fmt.Errorf("invalid LineString coordinates: %w", err)
+	}
+
+	route := make([]routePoint, 0, len(coordinates))
+	for _, c := range coordinates {
+		if len(c) < 2 {
+			return nil, fmt.Errorf("coordinate with %d values; want [lng, lat, (ele)]", len(c))
+		}
+		point := routePoint{Latitude: c[1], Longitude: c[0]}
+		if len(c) >= 3 {
+			ele := c[2]
+			point.Altitude = &ele
+		}
+		route = append(route, point)
+	}
+
+	return route, nil
+}
+
+// parseOrNewUUID parses the given ID or generates a fresh one when empty
+func parseOrNewUUID(raw string) uuid.UUID {
+	if raw == "" {
+		return uuid.New()
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		log.Fatalf("Invalid UUID %q: %v", raw, err)
+	}
+	return id
+}
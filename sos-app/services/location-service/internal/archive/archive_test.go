@@ -0,0 +1,105 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func TestEncodeDecodeTrailRoundTrip(t *testing.T) {
+	accuracy := 12.5
+	battery := 80
+	points := []models.LocationPoint{
+		{
+			ID:           1,
+			EmergencyID:  uuid.New(),
+			UserID:       uuid.New(),
+			Latitude:     48.8566,
+			Longitude:    2.3522,
+			Accuracy:     &accuracy,
+			Provider:     models.ProviderGPS,
+			Timestamp:    time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC),
+			BatteryLevel: &battery,
+			TrustScore:   0.9,
+			TrustFlags:   []string{"SPEED_OK"},
+		},
+		{
+			ID:          2,
+			EmergencyID: uuid.New(),
+			UserID:      uuid.New(),
+			Latitude:    48.8570,
+			Longitude:   2.3530,
+			Provider:    models.ProviderCellular,
+			Timestamp:   time.Date(2026, 8, 1, 12, 0, 5, 0, time.UTC),
+			Redacted:    true,
+		},
+	}
+
+	body, err := EncodeTrail(points)
+	if err != nil {
+		t.Fatalf("EncodeTrail failed: %v", err)
+	}
+
+	decoded, err := DecodeTrail(body)
+	if err != nil {
+		t.Fatalf("DecodeTrail failed: %v", err)
+	}
+
+	if len(decoded) != len(points) {
+		t.Fatalf("expected %d points, got %d", len(points), len(decoded))
+	}
+	for i := range points {
+		if decoded[i].ID != points[i].ID ||
+			decoded[i].Latitude != points[i].Latitude ||
+			decoded[i].Longitude != points[i].Longitude ||
+			decoded[i].Provider != points[i].Provider ||
+			!decoded[i].Timestamp.Equal(points[i].Timestamp) ||
+			decoded[i].Redacted != points[i].Redacted {
+			t.Errorf("point %d did not round-trip: got %+v, want %+v", i, decoded[i], points[i])
+		}
+	}
+	if decoded[0].Accuracy == nil || *decoded[0].Accuracy != accuracy {
+		t.Errorf("accuracy did not round-trip")
+	}
+	if decoded[0].BatteryLevel == nil || *decoded[0].BatteryLevel != battery {
+		t.Errorf("battery level did not round-trip")
+	}
+}
+
+func TestFileStorePutGet(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	ctx := context.Background()
+	key := TrailKey(uuid.New())
+
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before Put, got %v", err)
+	}
+
+	if err := store.Put(ctx, key, []byte(`{"type":"FeatureCollection"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(body) != `{"type":"FeatureCollection"}` {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	// Overwrites replace the previous object
+	if err := store.Put(ctx, key, []byte("v2")); err != nil {
+		t.Fatalf("second Put failed: %v", err)
+	}
+	body, err = store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get after overwrite failed: %v", err)
+	}
+	if string(body) != "v2" {
+		t.Errorf("expected overwritten body, got %q", body)
+	}
+}
@@ -0,0 +1,54 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore keeps archived trails on the local filesystem. It is the
+// development and single-node option; production deployments point the
+// archiver at an S3-compatible bucket instead
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a store rooted at dir
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{root: dir}
+}
+
+// Put writes body to root/key, creating parent directories as needed. The
+// write goes through a temp file and rename so a crash never leaves a
+// half-written archive behind
+func (s *FileStore) Put(ctx context.Context, key string, body []byte) error {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write archive object: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to finalize archive object: %w", err)
+	}
+
+	return nil
+}
+
+// Get reads the object stored under key
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	body, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive object: %w", err)
+	}
+
+	return body, nil
+}
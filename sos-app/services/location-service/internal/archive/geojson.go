@@ -0,0 +1,78 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// TrailKey is the object key an emergency's archived trail is stored under
+func TrailKey(emergencyID uuid.UUID) string {
+	return "trails/" + emergencyID.String() + ".geojson"
+}
+
+// geometry is a GeoJSON Point geometry; coordinates are [longitude, latitude]
+type geometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// feature is one trail point as a GeoJSON Feature. Properties carry the full
+// original record (including coordinates) so decoding is lossless even
+// though GeoJSON tooling only reads the geometry
+type feature struct {
+	Type       string               `json:"type"`
+	Geometry   geometry             `json:"geometry"`
+	Properties models.LocationPoint `json:"properties"`
+}
+
+// featureCollection is the exported trail document
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// EncodeTrail renders a trail as a GeoJSON FeatureCollection, one Point
+// feature per location in the given order
+func EncodeTrail(points []models.LocationPoint) ([]byte, error) {
+	collection := featureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]feature, 0, len(points)),
+	}
+
+	for _, point := range points {
+		collection.Features = append(collection.Features, feature{
+			Type: "Feature",
+			Geometry: geometry{
+				Type:        "Point",
+				Coordinates: [2]float64{point.Longitude, point.Latitude},
+			},
+			Properties: point,
+		})
+	}
+
+	body, err := json.Marshal(collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode trail: %w", err)
+	}
+
+	return body, nil
+}
+
+// DecodeTrail parses a trail exported by EncodeTrail back into location
+// points, in the order they were encoded
+func DecodeTrail(body []byte) ([]models.LocationPoint, error) {
+	var collection featureCollection
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return nil, fmt.Errorf("failed to decode trail: %w", err)
+	}
+
+	points := make([]models.LocationPoint, 0, len(collection.Features))
+	for _, f := range collection.Features {
+		points = append(points, f.Properties)
+	}
+
+	return points, nil
+}
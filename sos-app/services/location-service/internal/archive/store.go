@@ -0,0 +1,22 @@
+// Package archive moves resolved-emergency trails out of the hot
+// location_points hypertable into object storage. Trails are exported as
+// GeoJSON feature collections (loadable in QGIS and every mapping toolkit,
+// with the full original record kept in feature properties) so the history
+// API can serve an archived trail back losslessly.
+package archive
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when no object exists under the requested key
+var ErrNotFound = errors.New("archived trail not found")
+
+// Store is the object storage an archived trail is written to and read from
+type Store interface {
+	// Put stores body under key, overwriting any previous object
+	Put(ctx context.Context, key string, body []byte) error
+	// Get returns the object stored under key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
@@ -76,6 +76,7 @@ func (c *GeospatialCache) SetCurrentLocation(emergencyID uuid.UUID, location *mo
 		"latitude":     location.Latitude,
 		"longitude":    location.Longitude,
 		"timestamp":    location.Timestamp.Format(time.RFC3339),
+		"receivedAt":   location.ReceivedAt.Format(time.RFC3339),
 		"provider":     string(location.Provider),
 	}
 
@@ -188,6 +189,9 @@ func (c *GeospatialCache) GetCurrentLocation(emergencyID uuid.UUID) (*models.Loc
 	if timestampStr, ok := data["timestamp"]; ok {
 		location.Timestamp, _ = time.Parse(time.RFC3339, timestampStr)
 	}
+	if receivedAtStr, ok := data["receivedAt"]; ok {
+		location.ReceivedAt, _ = time.Parse(time.RFC3339, receivedAtStr)
+	}
 
 	return location, nil
 }
@@ -239,6 +243,16 @@ func (c *GeospatialCache) DeleteLocation(emergencyID uuid.UUID) error {
 	return nil
 }
 
+// RemoveFromGeoIndex removes an emergency from the geospatial index while
+// leaving its cached location hash in place
+func (c *GeospatialCache) RemoveFromGeoIndex(emergencyID uuid.UUID) error {
+	geoKey := c.getGeoKey()
+	if err := c.client.ZRem(c.ctx, geoKey, emergencyID.String()).Err(); err != nil {
+		return fmt.Errorf("failed to remove from geospatial index: %w", err)
+	}
+	return nil
+}
+
 // SetWithTTL sets a location with a custom TTL
 func (c *GeospatialCache) SetWithTTL(emergencyID uuid.UUID, location *models.LocationPoint, ttl time.Duration) error {
 	if err := c.SetCurrentLocation(emergencyID, location); err != nil {
@@ -0,0 +1,13 @@
+-- Add geohash cell column computed at ingest time
+-- Aggregate queries group by geohash prefixes instead of running expensive
+-- ST_Within scans for heatmaps and regional load analysis
+ALTER TABLE location_points
+    ADD COLUMN IF NOT EXISTS geohash VARCHAR(12);
+
+-- Prefix queries (LEFT(geohash, n) / LIKE 'u4pru%') rely on this index
+CREATE INDEX IF NOT EXISTS idx_location_geohash
+    ON location_points (geohash text_pattern_ops);
+
+-- Cell aggregates over recent windows combine geohash and timestamp
+CREATE INDEX IF NOT EXISTS idx_location_geohash_timestamp
+    ON location_points (geohash, timestamp DESC);
@@ -0,0 +1,15 @@
+-- Create tracking_sessions table
+-- Records the lifecycle of location tracking per emergency so resolved or
+-- cancelled emergencies can be marked closed instead of lingering until TTL
+CREATE TABLE IF NOT EXISTS tracking_sessions (
+    emergency_id UUID PRIMARY KEY,
+    user_id UUID,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    close_reason VARCHAR(50)
+);
+
+-- Create index for finding sessions that are still open
+CREATE INDEX IF NOT EXISTS idx_tracking_sessions_open
+    ON tracking_sessions (started_at)
+    WHERE closed_at IS NULL;
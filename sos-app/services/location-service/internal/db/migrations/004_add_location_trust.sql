@@ -0,0 +1,16 @@
+-- Add plausibility scoring to location points
+-- trust_score is 1.0 for fully plausible points; heuristics (impossible
+-- velocity, mock providers, repeated exact coordinates) deduct from it and
+-- record the reasons in trust_flags
+
+ALTER TABLE location_points
+    ADD COLUMN IF NOT EXISTS trust_score DOUBLE PRECISION NOT NULL DEFAULT 1.0,
+    ADD COLUMN IF NOT EXISTS trust_flags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Responders filter for suspicious points when reviewing a trail
+CREATE INDEX IF NOT EXISTS idx_location_points_low_trust
+    ON location_points (emergency_id, timestamp DESC)
+    WHERE trust_score < 0.5;
+
+COMMENT ON COLUMN location_points.trust_score IS 'Plausibility score in [0,1]; 1.0 means no spoofing heuristics fired';
+COMMENT ON COLUMN location_points.trust_flags IS 'Heuristics that deducted from the trust score';
@@ -0,0 +1,4 @@
+-- Enable PostGIS for vector tile rendering
+-- Trail tiles are generated server-side with ST_AsMVT / ST_TileEnvelope;
+-- ingest and aggregate paths keep using plain lat/lng + geohash columns
+CREATE EXTENSION IF NOT EXISTS postgis;
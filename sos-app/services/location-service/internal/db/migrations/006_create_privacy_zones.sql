@@ -0,0 +1,23 @@
+-- Privacy redaction zones
+-- Users define circular zones (home, workplace) whose points are stored
+-- with a redaction flag. Redacted points are served with coarse coordinates
+-- to contacts; dispatch connectors still receive full precision
+
+CREATE TABLE IF NOT EXISTS privacy_zones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    center_latitude DOUBLE PRECISION NOT NULL,
+    center_longitude DOUBLE PRECISION NOT NULL,
+    radius_meters DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Zone lookups are always per user at ingest time
+CREATE INDEX IF NOT EXISTS idx_privacy_zones_user ON privacy_zones (user_id);
+
+ALTER TABLE location_points
+    ADD COLUMN IF NOT EXISTS redacted BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON TABLE privacy_zones IS 'Per-user circular zones whose location points are coarsened for contacts';
+COMMENT ON COLUMN location_points.redacted IS 'True when the point fell inside one of the user''s privacy zones at ingest';
@@ -0,0 +1,10 @@
+-- Track trail archival per tracking session
+-- Once the retention window passes after an emergency ends, its trail is
+-- exported to object storage and the hot rows deleted; archived_at records
+-- that the export completed so the sweep never re-archives a session
+ALTER TABLE tracking_sessions ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Index for finding closed sessions that still need archiving
+CREATE INDEX IF NOT EXISTS idx_tracking_sessions_archivable
+    ON tracking_sessions (closed_at)
+    WHERE closed_at IS NOT NULL AND archived_at IS NULL;
@@ -0,0 +1,10 @@
+-- Client capture timestamps are now preserved in the timestamp column, so
+-- the server receipt time gets its own explicitly written column. The old
+-- created_at already carried that meaning; rename it and keep the NOW()
+-- default as a fallback for rows written by older writers.
+-- The timestamp column stays the hypertable partition and retention key;
+-- the ingest-side plausibility bounds keep it within a week of receipt
+ALTER TABLE location_points RENAME COLUMN created_at TO received_at;
+
+COMMENT ON COLUMN location_points.timestamp IS 'When the client captured the fix; preserved from the update when plausible';
+COMMENT ON COLUMN location_points.received_at IS 'When this service accepted the point; differs from timestamp for offline batches';
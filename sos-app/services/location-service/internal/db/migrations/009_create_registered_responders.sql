@@ -0,0 +1,27 @@
+-- Registered community responders
+-- The Redis GEO index only tracks live emergencies and is volatile; community
+-- responders register durably here with a PostGIS geography point so the
+-- community-responder escalation can ask "who is within N meters" server-side
+-- with ST_DWithin
+
+CREATE TABLE IF NOT EXISTS registered_responders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    responder_type VARCHAR(30) NOT NULL DEFAULT 'community',
+    phone VARCHAR(30),
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    geog GEOGRAPHY(POINT, 4326) GENERATED ALWAYS AS
+        (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography) STORED,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Radius queries hit the geography column through the spatial index
+CREATE INDEX IF NOT EXISTS idx_registered_responders_geog
+    ON registered_responders USING GIST (geog) WHERE active;
+
+COMMENT ON TABLE registered_responders IS 'Durable registry of community responders queried with ST_DWithin during escalation';
+COMMENT ON COLUMN registered_responders.geog IS 'Geography point derived from latitude/longitude for spatial queries';
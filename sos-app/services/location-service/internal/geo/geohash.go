@@ -0,0 +1,65 @@
+// Package geo provides lightweight geospatial helpers for the location
+// service, currently geohash encoding used to index points into cells.
+package geo
+
+// DefaultGeohashPrecision is the precision stored at ingest time. 7
+// characters is roughly a 150m x 150m cell, fine enough for heatmaps while
+// still aggregating well at coarser prefixes.
+const DefaultGeohashPrecision = 7
+
+// MaxGeohashPrecision is the longest geohash we store or accept in queries.
+const MaxGeohashPrecision = 12
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeohash computes the geohash cell of the given coordinates at the
+// requested precision (number of characters). Precision is clamped to
+// [1, MaxGeohashPrecision].
+func EncodeGeohash(latitude, longitude float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > MaxGeohashPrecision {
+		precision = MaxGeohashPrecision
+	}
+
+	latMin, latMax := -90.0, 90.0
+	lngMin, lngMax := -180.0, 180.0
+
+	hash := make([]byte, 0, precision)
+	evenBit := true
+	idx := 0
+	bit := 0
+
+	for len(hash) < precision {
+		if evenBit {
+			mid := (lngMin + lngMax) / 2
+			if longitude >= mid {
+				idx = idx*2 + 1
+				lngMin = mid
+			} else {
+				idx = idx * 2
+				lngMax = mid
+			}
+		} else {
+			mid := (latMin + latMax) / 2
+			if latitude >= mid {
+				idx = idx*2 + 1
+				latMin = mid
+			} else {
+				idx = idx * 2
+				latMax = mid
+			}
+		}
+		evenBit = !evenBit
+
+		bit++
+		if bit == 5 {
+			hash = append(hash, geohashBase32[idx])
+			bit = 0
+			idx = 0
+		}
+	}
+
+	return string(hash)
+}
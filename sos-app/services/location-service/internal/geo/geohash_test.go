@@ -0,0 +1,37 @@
+package geo
+
+import "testing"
+
+func TestEncodeGeohash_KnownValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		precision int
+		expected  string
+	}{
+		{"Jutland reference point", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"Jutland coarse cell", 57.64911, 10.40744, 5, "u4pru"},
+		{"Equator origin", 0, 0, 7, "s000000"},
+		{"New York", 40.7128, -74.0060, 7, "dr5regw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeGeohash(tt.latitude, tt.longitude, tt.precision)
+			if got != tt.expected {
+				t.Errorf("EncodeGeohash(%v, %v, %d) = %q, want %q",
+					tt.latitude, tt.longitude, tt.precision, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeGeohash_ClampsPrecision(t *testing.T) {
+	if got := EncodeGeohash(57.64911, 10.40744, 0); len(got) != 1 {
+		t.Errorf("expected precision clamped to 1, got %q", got)
+	}
+	if got := EncodeGeohash(57.64911, 10.40744, 50); len(got) != MaxGeohashPrecision {
+		t.Errorf("expected precision clamped to %d, got %q", MaxGeohashPrecision, got)
+	}
+}
@@ -0,0 +1,37 @@
+package geo
+
+import (
+	"sync"
+	"time"
+	// Embed the IANA tz database so zone-local timestamps work in the
+	// scratch container images, which ship no /usr/share/zoneinfo
+	_ "time/tzdata"
+
+	"github.com/zsefvlol/timezonemapper"
+)
+
+// locationCache memoizes loaded *time.Location values; trail responses
+// resolve the same handful of zones over and over
+var locationCache sync.Map
+
+// ResolveTimezone returns the IANA time zone name and loaded location for a
+// coordinate. Unresolvable coordinates (open ocean) and unknown zone names
+// fall back to UTC
+func ResolveTimezone(latitude, longitude float64) (string, *time.Location) {
+	name := timezonemapper.LatLngToTimezoneString(latitude, longitude)
+	if name == "" {
+		return "UTC", time.UTC
+	}
+
+	if cached, ok := locationCache.Load(name); ok {
+		return name, cached.(*time.Location)
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return "UTC", time.UTC
+	}
+
+	locationCache.Store(name, location)
+	return name, location
+}
@@ -0,0 +1,30 @@
+package geo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveTimezone(t *testing.T) {
+	name, location := ResolveTimezone(48.8566, 2.3522)
+	if name != "Europe/Paris" {
+		t.Errorf("expected Europe/Paris, got %s", name)
+	}
+	if location == nil {
+		t.Fatal("expected a loaded location")
+	}
+
+	// Zone-local rendering should carry the zone's offset
+	utc := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	if got := utc.In(location).Hour(); got != 13 {
+		t.Errorf("expected 13h in Paris in winter, got %dh", got)
+	}
+
+	name, location = ResolveTimezone(35.6762, 139.6503)
+	if name != "Asia/Tokyo" {
+		t.Errorf("expected Asia/Tokyo, got %s", name)
+	}
+	if location == nil {
+		t.Fatal("expected a loaded location")
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// decodeStrictJSON decodes the request body as a single JSON document,
+// rejecting unknown fields and trailing data. Unlike fiber's BodyParser it
+// never falls back to form parsing, whose float handling accepts NaN/Inf
+// literals
+func decodeStrictJSON(c *fiber.Ctx, out interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("request body must be a single JSON document")
+	}
+
+	return nil
+}
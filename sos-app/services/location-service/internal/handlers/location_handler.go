@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/geo"
 	"github.com/sos-app/location-service/internal/models"
 	"github.com/sos-app/location-service/internal/services"
 )
@@ -12,12 +17,45 @@ import (
 // LocationHandler handles HTTP requests for location operations
 type LocationHandler struct {
 	locationService *services.LocationService
+	ingestQuota     *services.IngestQuota
+	trailCache      *services.TrailCache
+	ingestMetrics   *services.IngestMetrics
+	dispatchToken   string
 }
 
-// NewLocationHandler creates a new location handler
-func NewLocationHandler(locationService *services.LocationService) *LocationHandler {
+// NewLocationHandler creates a new location handler. ingestQuota may be nil
+// to disable batch ingest quotas; trailCache may be nil to disable trail
+// response caching; ingestMetrics may be nil to disable per-provider
+// quality tracking; dispatchToken may be empty to disable full-precision
+// dispatch access to redacted points
+func NewLocationHandler(locationService *services.LocationService, ingestQuota *services.IngestQuota, trailCache *services.TrailCache, ingestMetrics *services.IngestMetrics, dispatchToken string) *LocationHandler {
 	return &LocationHandler{
 		locationService: locationService,
+		ingestQuota:     ingestQuota,
+		trailCache:      trailCache,
+		ingestMetrics:   ingestMetrics,
+		dispatchToken:   dispatchToken,
+	}
+}
+
+// hasDispatchScope reports whether the request carries the dispatch
+// connector token and may see redacted points at full precision
+func (h *LocationHandler) hasDispatchScope(c *fiber.Ctx) bool {
+	if h.dispatchToken == "" {
+		return false
+	}
+	provided := c.Get("X-Dispatch-Token")
+	return provided != "" &&
+		subtle.ConstantTimeCompare([]byte(provided), []byte(h.dispatchToken)) == 1
+}
+
+// coarsenRedacted replaces points flagged by privacy zones with their
+// coarse form; callers skip it for requests with dispatch scope
+func coarsenRedacted(locations []models.LocationPoint) {
+	for i := range locations {
+		if locations[i].Redacted {
+			locations[i] = *locations[i].Coarsened()
+		}
 	}
 }
 
@@ -25,9 +63,10 @@ func NewLocationHandler(locationService *services.LocationService) *LocationHand
 func (h *LocationHandler) UpdateLocation(c *fiber.Ctx) error {
 	var update models.LocationUpdate
 
-	if err := c.BodyParser(&update); err != nil {
+	if err := decodeStrictJSON(c, &update); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
+			"error":   "Invalid request body",
+			"details": err.Error(),
 		})
 	}
 
@@ -58,9 +97,10 @@ func (h *LocationHandler) UpdateLocation(c *fiber.Ctx) error {
 func (h *LocationHandler) BatchUpdateLocation(c *fiber.Ctx) error {
 	var batch models.BatchLocationUpdate
 
-	if err := c.BodyParser(&batch); err != nil {
+	if err := decodeStrictJSON(c, &batch); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
+			"error":   "Invalid request body",
+			"details": err.Error(),
 		})
 	}
 
@@ -76,6 +116,26 @@ func (h *LocationHandler) BatchUpdateLocation(c *fiber.Ctx) error {
 		})
 	}
 
+	// Enforce the per-emergency ingest budget so a client looping over
+	// batch-update can't flood the pipeline; severity comes from the points
+	// themselves
+	if h.ingestQuota != nil {
+		ok, retryAfter := h.ingestQuota.Reserve(batch.EmergencyID, batch.Locations[0].Severity, len(batch.Locations))
+		if !ok {
+			if h.ingestMetrics != nil {
+				for _, update := range batch.Locations {
+					h.ingestMetrics.RecordRejected(update.Provider, "quota")
+				}
+			}
+			retrySeconds := int(retryAfter.Seconds()) + 1
+			c.Set("Retry-After", strconv.Itoa(retrySeconds))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":             "Ingest quota exceeded for this emergency",
+				"retryAfterSeconds": retrySeconds,
+			})
+		}
+	}
+
 	// Process the batch update
 	if err := h.locationService.BatchUpdateLocations(c.Context(), &batch); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -116,6 +176,10 @@ func (h *LocationHandler) GetCurrentLocation(c *fiber.Ctx) error {
 		})
 	}
 
+	if location.Redacted && !h.hasDispatchScope(c) {
+		location = location.Coarsened()
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success":     true,
 		"emergencyId": emergencyID,
@@ -147,6 +211,16 @@ func (h *LocationHandler) GetLocationTrail(c *fiber.Ctx) error {
 		duration = 24 * time.Hour
 	}
 
+	// Popular incidents are polled every few seconds by many viewers; serve
+	// them all from one cached response per TTL window
+	dispatchScope := h.hasDispatchScope(c)
+	cacheKey := services.TrailCacheKey(emergencyID, duration, dispatchScope)
+	if h.trailCache != nil {
+		if cached, ok := h.trailCache.Get(cacheKey); ok {
+			return sendTrailResponse(c, cached)
+		}
+	}
+
 	locations, err := h.locationService.GetLocationTrail(c.Context(), emergencyID, duration)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -154,13 +228,106 @@ func (h *LocationHandler) GetLocationTrail(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	if !dispatchScope {
+		coarsenRedacted(locations)
+	}
+
+	timezone := annotateLocalTimestamps(locations)
+
+	payload := fiber.Map{
 		"success":     true,
 		"emergencyId": emergencyID,
 		"duration":    duration.String(),
+		"timezone":    timezone,
 		"locations":   locations,
 		"count":       len(locations),
-	})
+	}
+
+	if h.trailCache == nil {
+		return c.Status(fiber.StatusOK).JSON(payload)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get location trail",
+		})
+	}
+
+	var lastModified time.Time
+	for _, location := range locations {
+		if location.Timestamp.After(lastModified) {
+			lastModified = location.Timestamp
+		}
+	}
+	if lastModified.IsZero() {
+		lastModified = time.Now().UTC()
+	}
+
+	return sendTrailResponse(c, h.trailCache.Put(cacheKey, body, lastModified))
+}
+
+// sendTrailResponse writes a cached trail response, answering 304 when the
+// client's If-None-Match already carries the current ETag
+func sendTrailResponse(c *fiber.Ctx, response services.CachedTrailResponse) error {
+	c.Set("ETag", response.ETag)
+	c.Set("Last-Modified", response.LastModified.UTC().Format(http.TimeFormat))
+	c.Set("Cache-Control", "private, max-age=5")
+
+	if c.Get("If-None-Match") == response.ETag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(response.Body)
+}
+
+// GetTrailTile handles GET /api/v1/location/trail/:emergencyId/mvt/:z/:x/:y
+// Returns the trail as a Mapbox Vector Tile so dashboards can render long
+// trails performantly at any zoom level
+func (h *LocationHandler) GetTrailTile(c *fiber.Ctx) error {
+	emergencyID, err := uuid.Parse(c.Params("emergencyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid emergency ID",
+		})
+	}
+
+	z, errZ := strconv.Atoi(c.Params("z"))
+	x, errX := strconv.Atoi(c.Params("x"))
+	y, errY := strconv.Atoi(c.Params("y"))
+	if errZ != nil || errX != nil || errY != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tile coordinates must be integers",
+		})
+	}
+	if z < 0 || z > 22 || x < 0 || y < 0 || x >= 1<<uint(z) || y >= 1<<uint(z) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tile coordinates out of range for zoom level",
+		})
+	}
+
+	// Default to the last hour; the same duration cap as the JSON trail
+	duration, err := time.ParseDuration(c.Query("duration", "1h"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid duration format (use format like '30m', '1h', '90m')",
+		})
+	}
+	if duration > 24*time.Hour {
+		duration = 24 * time.Hour
+	}
+
+	tile, err := h.locationService.GetTrailTile(c.Context(), emergencyID, z, x, y, duration, h.hasDispatchScope(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to render trail tile",
+		})
+	}
+
+	c.Set("Content-Type", "application/vnd.mapbox-vector-tile")
+	c.Set("Cache-Control", "private, max-age=15")
+	return c.Send(tile)
 }
 
 // GetLocationHistory handles GET /api/v1/location/history/:emergencyId (for reporting)
@@ -192,10 +359,27 @@ func (h *LocationHandler) GetLocationHistory(c *fiber.Ctx) error {
 		})
 	}
 
+	// Whole-trail statistics ride along so report UIs don't recompute them
+	// from the paginated raw points
+	stats, err := h.locationService.GetTrailStats(c.Context(), emergencyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get location history",
+		})
+	}
+
+	if !h.hasDispatchScope(c) {
+		coarsenRedacted(locations)
+	}
+
+	timezone := annotateLocalTimestamps(locations)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success":     true,
 		"emergencyId": emergencyID,
+		"timezone":    timezone,
 		"locations":   locations,
+		"stats":       stats,
 		"pagination": fiber.Map{
 			"limit":  limit,
 			"offset": offset,
@@ -204,3 +388,78 @@ func (h *LocationHandler) GetLocationHistory(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// annotateLocalTimestamps resolves the trail's time zone from its first
+// point and fills zone-local timestamps in next to the UTC ones, so report
+// reviewers see when things happened on the ground. Returns the IANA zone
+// name used
+func annotateLocalTimestamps(locations []models.LocationPoint) string {
+	if len(locations) == 0 {
+		return "UTC"
+	}
+
+	timezone, location := geo.ResolveTimezone(locations[0].Latitude, locations[0].Longitude)
+	for i := range locations {
+		local := locations[i].Timestamp.In(location)
+		locations[i].LocalTimestamp = &local
+	}
+
+	return timezone
+}
+
+// GetCellAggregates handles GET /api/v1/location/cells
+// Query params: precision (1-12, default 6), window (e.g. 24h), limit
+func (h *LocationHandler) GetCellAggregates(c *fiber.Ctx) error {
+	precision := c.QueryInt("precision", 6)
+	if precision < 1 || precision > geo.MaxGeohashPrecision {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "precision must be between 1 and 12",
+		})
+	}
+
+	window := 24 * time.Hour
+	if windowStr := c.Query("window"); windowStr != "" {
+		parsed, err := time.ParseDuration(windowStr)
+		if err != nil || parsed <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid window duration",
+			})
+		}
+		window = parsed
+	}
+
+	limit := c.QueryInt("limit", 100)
+	if limit < 1 || limit > 1000 {
+		limit = 100
+	}
+
+	cells, err := h.locationService.GetCellAggregates(c.Context(), precision, window, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get cell aggregates",
+		})
+	}
+
+	if cells == nil {
+		cells = []models.CellAggregate{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"precision": precision,
+		"window":    window.String(),
+		"cells":     cells,
+	})
+}
+
+// GetQualitySummary handles GET /api/v1/location/quality-summary
+// Returns the current UTC day's per-provider ingestion quality on this
+// node: accepted/rejected counts, rejection rate and average accuracy
+func (h *LocationHandler) GetQualitySummary(c *fiber.Ctx) error {
+	if h.ingestMetrics == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Ingestion quality tracking is not enabled",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(h.ingestMetrics.Summary())
+}
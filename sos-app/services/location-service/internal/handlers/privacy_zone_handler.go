@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+	"github.com/sos-app/location-service/internal/repository"
+	"github.com/sos-app/location-service/internal/services"
+)
+
+// PrivacyZoneHandler handles CRUD for user privacy redaction zones
+type PrivacyZoneHandler struct {
+	zoneRepo        *repository.PrivacyZoneRepository
+	privacyRedactor *services.PrivacyRedactor
+}
+
+// NewPrivacyZoneHandler creates a new privacy zone handler
+func NewPrivacyZoneHandler(zoneRepo *repository.PrivacyZoneRepository, privacyRedactor *services.PrivacyRedactor) *PrivacyZoneHandler {
+	return &PrivacyZoneHandler{
+		zoneRepo:        zoneRepo,
+		privacyRedactor: privacyRedactor,
+	}
+}
+
+// requestUserID extracts the gateway-injected user identity
+func requestUserID(c *fiber.Ctx) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Get("X-User-ID"))
+	return userID, err == nil
+}
+
+// CreateZone handles POST /api/v1/location/privacy-zones
+func (h *PrivacyZoneHandler) CreateZone(c *fiber.Ctx) error {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	var req models.CreatePrivacyZoneRequest
+	if err := decodeStrictJSON(c, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	zone := &models.PrivacyZone{
+		UserID:          userID,
+		Name:            req.Name,
+		CenterLatitude:  req.CenterLatitude,
+		CenterLongitude: req.CenterLongitude,
+		RadiusMeters:    req.RadiusMeters,
+	}
+
+	if err := h.zoneRepo.Create(c.Context(), zone); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create privacy zone",
+		})
+	}
+
+	h.privacyRedactor.Invalidate(userID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"zone":    zone,
+	})
+}
+
+// ListZones handles GET /api/v1/location/privacy-zones
+func (h *PrivacyZoneHandler) ListZones(c *fiber.Ctx) error {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	zones, err := h.zoneRepo.ListByUser(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list privacy zones",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"zones":   zones,
+		"count":   len(zones),
+	})
+}
+
+// DeleteZone handles DELETE /api/v1/location/privacy-zones/:id
+func (h *PrivacyZoneHandler) DeleteZone(c *fiber.Ctx) error {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	zoneID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid zone ID",
+		})
+	}
+
+	deleted, err := h.zoneRepo.Delete(c.Context(), zoneID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete privacy zone",
+		})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Privacy zone not found",
+		})
+	}
+
+	h.privacyRedactor.Invalidate(userID)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
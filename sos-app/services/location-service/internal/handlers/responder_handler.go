@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sos-app/location-service/internal/models"
+	"github.com/sos-app/location-service/internal/repository"
+	"github.com/sos-app/location-service/internal/services"
+)
+
+// ResponderHandler handles community responder registration and the
+// server-side nearby query used by the community-responder escalation
+type ResponderHandler struct {
+	responderRepo   *repository.ResponderRepository
+	travelEstimator *services.TravelEstimator
+}
+
+// NewResponderHandler creates a new responder handler
+func NewResponderHandler(responderRepo *repository.ResponderRepository, travelEstimator *services.TravelEstimator) *ResponderHandler {
+	return &ResponderHandler{
+		responderRepo:   responderRepo,
+		travelEstimator: travelEstimator,
+	}
+}
+
+// RegisterResponder handles POST /api/v1/location/responders. Registering
+// again updates the caller's existing entry and reactivates it
+func (h *ResponderHandler) RegisterResponder(c *fiber.Ctx) error {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	var req models.RegisterResponderRequest
+	if err := decodeStrictJSON(c, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	responder := &models.RegisteredResponder{
+		UserID:        userID,
+		Name:          req.Name,
+		ResponderType: req.ResponderType,
+		Phone:         req.Phone,
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+	}
+
+	if err := h.responderRepo.Upsert(c.Context(), responder); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to register responder",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":   true,
+		"responder": responder,
+	})
+}
+
+// DeregisterResponder handles DELETE /api/v1/location/responders, taking
+// the caller out of the dispatch pool
+func (h *ResponderHandler) DeregisterResponder(c *fiber.Ctx) error {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	deactivated, err := h.responderRepo.Deactivate(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to deregister responder",
+		})
+	}
+	if !deactivated {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "No active responder registration",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// GetNearbyResponders handles GET /api/v1/location/responders/nearby.
+// Query params: latitude, longitude, radius (meters, clamped to the model
+// bounds), limit. Results come back nearest first with distances computed
+// by PostGIS and travel-time estimates from the estimator
+func (h *ResponderHandler) GetNearbyResponders(c *fiber.Ctx) error {
+	if _, ok := requestUserID(c); !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing or invalid X-User-ID header",
+		})
+	}
+
+	latitude, errLat := strconv.ParseFloat(c.Query("latitude"), 64)
+	longitude, errLng := strconv.ParseFloat(c.Query("longitude"), 64)
+	if errLat != nil || errLng != nil ||
+		latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "latitude and longitude query parameters are required",
+		})
+	}
+
+	radius := float64(c.QueryInt("radius", models.DefaultResponderSearchRadiusMeters))
+	if radius < models.MinResponderSearchRadiusMeters {
+		radius = models.MinResponderSearchRadiusMeters
+	}
+	if radius > models.MaxResponderSearchRadiusMeters {
+		radius = models.MaxResponderSearchRadiusMeters
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	responders, err := h.responderRepo.FindNearby(c.Context(), latitude, longitude, radius, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to query nearby responders",
+		})
+	}
+
+	for i := range responders {
+		responders[i].TravelTimeSeconds = h.travelEstimator.EstimateSeconds(
+			c.Context(),
+			responders[i].Latitude, responders[i].Longitude,
+			latitude, longitude,
+			responders[i].DistanceMeters,
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success":      true,
+		"responders":   responders,
+		"count":        len(responders),
+		"radiusMeters": radius,
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
@@ -13,17 +15,46 @@ import (
 	ws "github.com/sos-app/location-service/internal/websocket"
 )
 
+// WebSocketOptions tunes connection behavior for operation behind
+// reverse proxies and load balancers
+type WebSocketOptions struct {
+	// WriteTimeout bounds each outbound write so one stalled client
+	// cannot wedge its writer goroutine
+	WriteTimeout time.Duration
+	// PingInterval is how often protocol-level pings are sent; keep it
+	// below the ALB/Nginx idle timeout (usually 60s)
+	PingInterval time.Duration
+	// MaxConnections caps concurrent viewers; further upgrade requests
+	// get a 503 so the load balancer can retry another instance
+	MaxConnections int
+	// EnableCompression negotiates permessage-deflate with clients
+	EnableCompression bool
+}
+
 // WebSocketHandler handles WebSocket connections
 type WebSocketHandler struct {
 	broadcastService *ws.BroadcastService
 	locationService  *services.LocationService
+	options          WebSocketOptions
+	activeConns      int64
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(broadcastService *ws.BroadcastService, locationService *services.LocationService) *WebSocketHandler {
+func NewWebSocketHandler(broadcastService *ws.BroadcastService, locationService *services.LocationService, options WebSocketOptions) *WebSocketHandler {
+	if options.WriteTimeout <= 0 {
+		options.WriteTimeout = 10 * time.Second
+	}
+	if options.PingInterval <= 0 {
+		options.PingInterval = 25 * time.Second
+	}
+	if options.MaxConnections <= 0 {
+		options.MaxConnections = 10000
+	}
+
 	return &WebSocketHandler{
 		broadcastService: broadcastService,
 		locationService:  locationService,
+		options:          options,
 	}
 }
 
@@ -31,7 +62,17 @@ func NewWebSocketHandler(broadcastService *ws.BroadcastService, locationService
 func (h *WebSocketHandler) Subscribe(c *fiber.Ctx) error {
 	// Check if request is WebSocket upgrade
 	if websocket.IsWebSocketUpgrade(c) {
-		return websocket.New(h.handleWebSocketConnection)(c)
+		// Shed load before the upgrade so the balancer sees a plain 503
+		if atomic.LoadInt64(&h.activeConns) >= int64(h.options.MaxConnections) {
+			c.Set("Retry-After", "5")
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Too many concurrent subscribers, try again shortly",
+			})
+		}
+
+		return websocket.New(h.handleWebSocketConnection, websocket.Config{
+			EnableCompression: h.options.EnableCompression,
+		})(c)
 	}
 
 	return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{
@@ -41,14 +82,26 @@ func (h *WebSocketHandler) Subscribe(c *fiber.Ctx) error {
 
 // handleWebSocketConnection manages an individual WebSocket connection
 func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
+	atomic.AddInt64(&h.activeConns, 1)
+	defer atomic.AddInt64(&h.activeConns, -1)
+
 	// Generate client ID
 	clientID := uuid.New().String()
 
+	// A missed ping plus a missed follow-up means the connection is dead
+	readTimeout := 2 * h.options.PingInterval
+	c.SetReadDeadline(time.Now().Add(readTimeout))
+	c.SetPongHandler(func(string) error {
+		return c.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
 	// Create client
 	client := &ws.Client{
 		ID:           clientID,
 		SendChan:     make(chan []byte, 256),
 		DisconnectCh: make(chan bool, 1),
+		ConnectedAt:  time.Now().UTC(),
+		CloseConn:    func() { c.Close() },
 	}
 
 	// Add client to broadcast service
@@ -71,6 +124,7 @@ func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
 	// Start goroutine to send messages from channel
 	go func() {
 		for message := range client.SendChan {
+			c.SetWriteDeadline(time.Now().Add(h.options.WriteTimeout))
 			if err := c.WriteMessage(websocket.TextMessage, message); err != nil {
 				log.Printf("Error writing to client %s: %v", clientID, err)
 				client.DisconnectCh <- true
@@ -79,6 +133,25 @@ func (h *WebSocketHandler) handleWebSocketConnection(c *websocket.Conn) {
 		}
 	}()
 
+	// Protocol-level keepalive so idle viewers survive proxy timeouts
+	pingDone := make(chan bool)
+	defer close(pingDone)
+	go func() {
+		ticker := time.NewTicker(h.options.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.options.WriteTimeout)); err != nil {
+					log.Printf("Error pinging client %s: %v", clientID, err)
+					return
+				}
+			case <-pingDone:
+				return
+			}
+		}
+	}()
+
 	// Read messages from client
 	for {
 		messageType, msg, err := c.ReadMessage()
@@ -106,7 +179,18 @@ func (h *WebSocketHandler) handleClientMessage(conn *websocket.Conn, client *ws.
 
 	switch subscription.Action {
 	case "subscribe":
-		// Subscribe client to emergency room
+		if subscription.Filters != nil {
+			if err := subscription.Filters.Validate(); err != nil {
+				h.sendError(conn, "Invalid filters: "+err.Error())
+				return
+			}
+		}
+
+		// Subscribe client to emergency room, with any per-client filters
+		// applied server-side to every update. Share-link viewers present
+		// their link token and get location payloads encrypted under it
+		client.SetFilters(subscription.Filters)
+		client.SetShareToken(subscription.ShareToken)
 		h.broadcastService.JoinRoom(subscription.EmergencyID, client.ID)
 
 		// Send current location immediately
@@ -124,9 +208,16 @@ func (h *WebSocketHandler) handleClientMessage(conn *websocket.Conn, client *ws.
 					Location:    location,
 				}
 
-				if msgBytes, err := json.Marshal(wsMsg); err == nil {
-					client.SendChan <- msgBytes
+				msgBytes, err := json.Marshal(wsMsg)
+				if err != nil {
+					return
+				}
+				msgBytes, err = client.SealLocationPayload(subscription.EmergencyID, msgBytes)
+				if err != nil {
+					log.Printf("Failed to encrypt current location for client %s: %v", client.ID, err)
+					return
 				}
+				client.SendChan <- msgBytes
 			}
 		}()
 
@@ -136,6 +227,9 @@ func (h *WebSocketHandler) handleClientMessage(conn *websocket.Conn, client *ws.
 			"emergencyId": subscription.EmergencyID.String(),
 			"message":     "Subscribed to location updates",
 		}
+		if subscription.Filters != nil {
+			response["filters"] = subscription.Filters
+		}
 
 		if msgBytes, err := json.Marshal(response); err == nil {
 			conn.WriteMessage(websocket.TextMessage, msgBytes)
@@ -143,6 +237,8 @@ func (h *WebSocketHandler) handleClientMessage(conn *websocket.Conn, client *ws.
 
 	case "unsubscribe":
 		// Unsubscribe client from emergency room
+		client.SetFilters(nil)
+		client.SetShareToken("")
 		h.broadcastService.LeaveRoom(subscription.EmergencyID, client.ID)
 
 		response := map[string]interface{}{
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	ws "github.com/sos-app/location-service/internal/websocket"
+)
+
+// WSAdminHandler exposes broadcast-service introspection for support staff
+// chasing "my contact stopped seeing updates" reports: which rooms are
+// active, who is in them, and whose send channel is dropping messages.
+// Guarded by a shared admin token; leaving the token unset disables the
+// endpoints entirely
+type WSAdminHandler struct {
+	broadcastService *ws.BroadcastService
+	adminToken       string
+}
+
+// NewWSAdminHandler creates a new WebSocket admin handler
+func NewWSAdminHandler(broadcastService *ws.BroadcastService, adminToken string) *WSAdminHandler {
+	return &WSAdminHandler{
+		broadcastService: broadcastService,
+		adminToken:       adminToken,
+	}
+}
+
+// authorize checks the X-Admin-Token header; on failure it writes the
+// response and reports false
+func (h *WSAdminHandler) authorize(c *fiber.Ctx) (bool, error) {
+	if h.adminToken == "" {
+		return false, c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Admin API is not configured",
+		})
+	}
+
+	provided := c.Get("X-Admin-Token")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(h.adminToken)) != 1 {
+		return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Access denied",
+		})
+	}
+
+	return true, nil
+}
+
+// ListRooms handles GET /api/v1/admin/ws/rooms
+// Returns every active room with its clients and delivery counters
+func (h *WSAdminHandler) ListRooms(c *fiber.Ctx) error {
+	if ok, err := h.authorize(c); !ok {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(h.broadcastService.Snapshot())
+}
+
+// DisconnectClient handles DELETE /api/v1/admin/ws/clients/:id
+// Force-closes one client's connection so a wedged viewer can reconnect
+func (h *WSAdminHandler) DisconnectClient(c *fiber.Ctx) error {
+	if ok, err := h.authorize(c); !ok {
+		return err
+	}
+
+	clientID := c.Params("id")
+	if !h.broadcastService.DisconnectClient(clientID) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Client not connected",
+		})
+	}
+
+	log.Printf("Admin force-disconnected WebSocket client %s", clientID)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Client disconnected",
+		"clientId": clientID,
+	})
+}
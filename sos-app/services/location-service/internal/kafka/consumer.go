@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	pkgkafka "github.com/sos-app/pkg/kafka"
+)
+
+// LifecycleHandler is invoked for each emergency lifecycle event received
+// from Kafka
+type LifecycleHandler func(ctx context.Context, eventType string, emergencyID uuid.UUID)
+
+// lifecycleEvent is the subset of emergency-service event payloads the
+// location service cares about
+type lifecycleEvent struct {
+	EventType   string    `json:"event_type"`
+	EmergencyID uuid.UUID `json:"emergency_id"`
+}
+
+// LifecycleConsumer consumes emergency-resolved/cancelled events so location
+// tracking can be torn down as soon as an emergency ends
+type LifecycleConsumer struct {
+	consumer *pkgkafka.Consumer
+}
+
+// NewLifecycleConsumer creates a consumer for emergency lifecycle topics.
+// Malformed events that survive retries are published to the per-topic
+// dead-letter topic through producer
+func NewLifecycleConsumer(brokers []string, groupID string, topics []string, handler LifecycleHandler, producer *Producer) *LifecycleConsumer {
+	consumer := pkgkafka.NewConsumer(pkgkafka.ConsumerConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topics:      topics,
+		DeadLetters: producer.shared(),
+		Logf:        log.Printf,
+	}, func(ctx context.Context, message pkgkafka.Message) error {
+		var event lifecycleEvent
+		if err := json.Unmarshal(message.Value, &event); err != nil {
+			return fmt.Errorf("failed to parse lifecycle event from topic %s: %w", message.Topic, err)
+		}
+
+		if event.EmergencyID == uuid.Nil {
+			return fmt.Errorf("lifecycle event from topic %s missing emergency_id", message.Topic)
+		}
+
+		// The topic is authoritative when the payload has no event type
+		eventType := event.EventType
+		if eventType == "" {
+			eventType = message.Topic
+		}
+
+		handler(ctx, eventType, event.EmergencyID)
+		return nil
+	})
+
+	return &LifecycleConsumer{consumer: consumer}
+}
+
+// Start begins consuming lifecycle events in the background
+func (c *LifecycleConsumer) Start() {
+	c.consumer.Start()
+	log.Println("Emergency lifecycle consumer started")
+}
+
+// Close stops consuming and releases the Kafka reader
+func (c *LifecycleConsumer) Close() error {
+	return c.consumer.Close()
+}
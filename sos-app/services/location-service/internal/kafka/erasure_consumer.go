@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	pkgkafka "github.com/sos-app/pkg/kafka"
+)
+
+// ErasureHandler is invoked for each data erasure event received from Kafka.
+// requestID identifies the originating erasure request so the purge can be
+// confirmed back to emergency-service; it may be uuid.Nil on legacy events
+type ErasureHandler func(ctx context.Context, requestID, userID uuid.UUID)
+
+// erasureEvent is the emergency-service data erasure payload
+type erasureEvent struct {
+	RequestID uuid.UUID `json:"request_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// ErasureConsumer consumes GDPR/CCPA data erasure events so location history
+// for erased users is purged from this service too
+type ErasureConsumer struct {
+	consumer *pkgkafka.Consumer
+}
+
+// NewErasureConsumer creates a consumer for the data erasure topic. Malformed
+// events that survive retries are published to the dead-letter topic through
+// producer
+func NewErasureConsumer(brokers []string, groupID, topic string, handler ErasureHandler, producer *Producer) *ErasureConsumer {
+	consumer := pkgkafka.NewConsumer(pkgkafka.ConsumerConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topics:      []string{topic},
+		DeadLetters: producer.shared(),
+		Logf:        log.Printf,
+	}, func(ctx context.Context, message pkgkafka.Message) error {
+		var event erasureEvent
+		if err := json.Unmarshal(message.Value, &event); err != nil {
+			return fmt.Errorf("failed to parse erasure event: %w", err)
+		}
+
+		if event.UserID == uuid.Nil {
+			return fmt.Errorf("erasure event missing user_id")
+		}
+
+		handler(ctx, event.RequestID, event.UserID)
+		return nil
+	})
+
+	return &ErasureConsumer{consumer: consumer}
+}
+
+// Start begins consuming erasure events in the background
+func (c *ErasureConsumer) Start() {
+	c.consumer.Start()
+	log.Println("Data erasure consumer started")
+}
+
+// Close stops consuming and releases the Kafka reader
+func (c *ErasureConsumer) Close() error {
+	return c.consumer.Close()
+}
@@ -2,43 +2,66 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"log"
 	"time"
 
-	"github.com/segmentio/kafka-go"
+	"github.com/google/uuid"
+	pkgkafka "github.com/sos-app/pkg/kafka"
+
 	"github.com/sos-app/location-service/internal/models"
 )
 
+// locationUpdatedTopic receives location update events for active emergencies
+const locationUpdatedTopic = "location-updated"
+
+// erasureCompletedTopic receives per-service purge confirmations for the
+// data erasure saga, consumed by emergency-service
+const erasureCompletedTopic = "erasure-completed"
+
 // Producer handles Kafka message production
 type Producer struct {
-	writer *kafka.Writer
+	producer *pkgkafka.Producer
 }
 
 // NewProducer creates a new Kafka producer
 func NewProducer(brokers []string) (*Producer, error) {
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(brokers...),
-		Topic:        "location-updated",
-		Balancer:     &kafka.Hash{},
-		MaxAttempts:  3,
-		WriteTimeout: 10 * time.Second,
-		ReadTimeout:  10 * time.Second,
-		RequiredAcks: kafka.RequireOne,
-		Compression:  kafka.Snappy,
-	}
+	producer := pkgkafka.NewProducer(pkgkafka.ProducerConfig{
+		Brokers:  brokers,
+		ClientID: "location-service",
+		Logf:     log.Printf,
+	})
 
-	return &Producer{writer: writer}, nil
+	return &Producer{producer: producer}, nil
 }
 
 // Close closes the Kafka writer
 func (p *Producer) Close() error {
-	return p.writer.Close()
+	return p.producer.Close()
+}
+
+// shared exposes the underlying producer so consumers in this package can
+// reuse it for dead-letter publishing
+func (p *Producer) shared() *pkgkafka.Producer {
+	return p.producer
+}
+
+// PublishErasureCompleted confirms to emergency-service that this service has
+// purged a user's data for an erasure request. Field names follow the
+// emergency-service ErasureCompletedEvent contract
+func (p *Producer) PublishErasureCompleted(ctx context.Context, requestID, userID uuid.UUID, recordsPurged int64) error {
+	event := map[string]interface{}{
+		"request_id":     requestID.String(),
+		"user_id":        userID.String(),
+		"service":        "location-service",
+		"records_purged": recordsPurged,
+		"timestamp":      time.Now().UTC(),
+	}
+
+	return p.producer.Publish(ctx, erasureCompletedTopic, userID.String(), event)
 }
 
 // PublishLocationUpdate publishes a location update event to Kafka
 func (p *Producer) PublishLocationUpdate(ctx context.Context, location *models.LocationPoint) error {
-	// Create event payload
 	event := map[string]interface{}{
 		"eventType":   "LocationUpdated",
 		"emergencyId": location.EmergencyID.String(),
@@ -58,24 +81,5 @@ func (p *Producer) PublishLocationUpdate(ctx context.Context, location *models.L
 		"timestamp": time.Now().UTC(),
 	}
 
-	// Serialize to JSON
-	value, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	// Create Kafka message
-	message := kafka.Message{
-		Key:   []byte(location.EmergencyID.String()),
-		Value: value,
-		Time:  time.Now(),
-	}
-
-	// Write message to Kafka
-	err = p.writer.WriteMessages(ctx, message)
-	if err != nil {
-		return fmt.Errorf("failed to publish location update: %w", err)
-	}
-
-	return nil
+	return p.producer.Publish(ctx, locationUpdatedTopic, location.EmergencyID.String(), event)
 }
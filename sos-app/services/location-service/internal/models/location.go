@@ -2,9 +2,11 @@ package models
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/geo"
 )
 
 // LocationProvider represents the source of location data
@@ -19,19 +21,25 @@ const (
 
 // LocationPoint represents a single location data point in the time-series
 type LocationPoint struct {
-	ID           int64            `json:"id" db:"id"`
-	EmergencyID  uuid.UUID        `json:"emergencyId" db:"emergency_id"`
-	UserID       uuid.UUID        `json:"userId" db:"user_id"`
-	Latitude     float64          `json:"latitude" db:"latitude"`
-	Longitude    float64          `json:"longitude" db:"longitude"`
-	Accuracy     *float64         `json:"accuracy,omitempty" db:"accuracy"`
-	Altitude     *float64         `json:"altitude,omitempty" db:"altitude"`
-	Speed        *float64         `json:"speed,omitempty" db:"speed"`
-	Heading      *float64         `json:"heading,omitempty" db:"heading"`
-	Provider     LocationProvider `json:"provider" db:"provider"`
-	Address      *string          `json:"address,omitempty" db:"address"`
-	Timestamp    time.Time        `json:"timestamp" db:"timestamp"`
-	BatteryLevel *int             `json:"batteryLevel,omitempty" db:"battery_level"`
+	ID             int64            `json:"id" db:"id"`
+	EmergencyID    uuid.UUID        `json:"emergencyId" db:"emergency_id"`
+	UserID         uuid.UUID        `json:"userId" db:"user_id"`
+	Latitude       float64          `json:"latitude" db:"latitude"`
+	Longitude      float64          `json:"longitude" db:"longitude"`
+	Accuracy       *float64         `json:"accuracy,omitempty" db:"accuracy"`
+	Altitude       *float64         `json:"altitude,omitempty" db:"altitude"`
+	Speed          *float64         `json:"speed,omitempty" db:"speed"`
+	Heading        *float64         `json:"heading,omitempty" db:"heading"`
+	Provider       LocationProvider `json:"provider" db:"provider"`
+	Address        *string          `json:"address,omitempty" db:"address"`
+	Timestamp      time.Time        `json:"timestamp" db:"timestamp"`
+	ReceivedAt     time.Time        `json:"receivedAt" db:"received_at"`     // When this service accepted the point; differs from Timestamp for offline batches
+	LocalTimestamp *time.Time       `json:"localTimestamp,omitempty" db:"-"` // Timestamp in the trail's zone, derived per response
+	BatteryLevel   *int             `json:"batteryLevel,omitempty" db:"battery_level"`
+	Geohash        string           `json:"geohash,omitempty" db:"geohash"`
+	TrustScore     float64          `json:"trustScore" db:"trust_score"`
+	TrustFlags     []string         `json:"trustFlags,omitempty" db:"trust_flags"`
+	Redacted       bool             `json:"redacted,omitempty" db:"redacted"`
 }
 
 // LocationUpdate represents an incoming location update request
@@ -45,7 +53,10 @@ type LocationUpdate struct {
 	Speed        *float64         `json:"speed"`
 	Heading      *float64         `json:"heading"`
 	Provider     LocationProvider `json:"provider" validate:"required"`
+	Timestamp    *time.Time       `json:"timestamp,omitempty"` // When the client captured the fix; nil means "now"
 	BatteryLevel *int             `json:"batteryLevel"`
+	Severity     string           `json:"severity,omitempty"`
+	MockProvider bool             `json:"mockProvider,omitempty"`
 }
 
 // BatchLocationUpdate represents multiple location updates for offline sync
@@ -61,6 +72,24 @@ type LocationTrailQuery struct {
 	Duration    time.Duration // Default: 30 minutes
 }
 
+// CellAggregate represents aggregate statistics for a single geohash cell
+type CellAggregate struct {
+	Cell        string `json:"cell"`
+	Points      int    `json:"points"`
+	Emergencies int    `json:"emergencies"`
+}
+
+// TrailStats represents aggregate statistics for an emergency's whole trail,
+// computed in SQL so report UIs don't recompute them from raw points
+type TrailStats struct {
+	PointCount          int64      `json:"pointCount"`
+	TotalDistanceMeters float64    `json:"totalDistanceMeters"`
+	AverageSpeed        *float64   `json:"averageSpeed,omitempty"`
+	MaxSpeed            *float64   `json:"maxSpeed,omitempty"`
+	FirstTimestamp      *time.Time `json:"firstTimestamp,omitempty"`
+	LastTimestamp       *time.Time `json:"lastTimestamp,omitempty"`
+}
+
 // LocationHistoryQuery represents query parameters for location history
 type LocationHistoryQuery struct {
 	EmergencyID uuid.UUID
@@ -82,16 +111,67 @@ type WebSocketMessage struct {
 	Type        string         `json:"type"`
 	EmergencyID uuid.UUID      `json:"emergencyId"`
 	Location    *LocationPoint `json:"location,omitempty"`
+	// ViewerCount is set on presence events: how many clients are watching
+	// the emergency on this node. Viewer identities are never included
+	ViewerCount int    `json:"viewerCount,omitempty"`
+	Message     string `json:"message,omitempty"`
 }
 
 // WebSocketSubscription represents a WebSocket subscription request
 type WebSocketSubscription struct {
-	Action      string    `json:"action"`
-	EmergencyID uuid.UUID `json:"emergencyId"`
+	Action      string               `json:"action"`
+	EmergencyID uuid.UUID            `json:"emergencyId"`
+	Filters     *SubscriptionFilters `json:"filters,omitempty"`
+	// ShareToken marks the subscriber as a public share-link viewer;
+	// location payloads are then encrypted under a key derived from it
+	ShareToken string `json:"shareToken,omitempty"`
+}
+
+// SubscriptionFilters are optional server-side filters applied per client,
+// reducing bandwidth for constrained viewers like SMS-to-link map pages
+type SubscriptionFilters struct {
+	// MaxAccuracy drops points whose reported accuracy is worse than this
+	// many meters; points with unknown accuracy are kept
+	MaxAccuracy *float64 `json:"maxAccuracy,omitempty"`
+	// MinIntervalSeconds delivers at most one update per interval
+	MinIntervalSeconds int `json:"minIntervalSeconds,omitempty"`
+	// OmitBattery strips the battery level from delivered points
+	OmitBattery bool `json:"omitBattery,omitempty"`
+	// OmitExtras strips speed, heading, altitude and address
+	OmitExtras bool `json:"omitExtras,omitempty"`
+}
+
+// Validate validates the SubscriptionFilters fields
+func (f *SubscriptionFilters) Validate() error {
+	if f.MaxAccuracy != nil && *f.MaxAccuracy <= 0 {
+		return errors.New("maxAccuracy must be positive")
+	}
+	if f.MinIntervalSeconds < 0 {
+		return errors.New("minIntervalSeconds must be non-negative")
+	}
+	return nil
+}
+
+// isFinite reports whether v is a real number: NaN and Inf satisfy none of
+// the range comparisons below, so they must be rejected explicitly
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// normalizeZero clamps IEEE negative zero to positive zero so stored and
+// serialized coordinates compare consistently
+func normalizeZero(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	return v
 }
 
 // Validate validates the LocationUpdate fields
 func (lu *LocationUpdate) Validate() error {
+	if !isFinite(lu.Latitude) || !isFinite(lu.Longitude) {
+		return errors.New("coordinates must be finite numbers")
+	}
 	if lu.Latitude < -90 || lu.Latitude > 90 {
 		return errors.New("latitude must be between -90 and 90")
 	}
@@ -101,13 +181,16 @@ func (lu *LocationUpdate) Validate() error {
 	if lu.Provider == "" {
 		return errors.New("provider is required")
 	}
-	if lu.Accuracy != nil && *lu.Accuracy < 0 {
+	if lu.Accuracy != nil && (!isFinite(*lu.Accuracy) || *lu.Accuracy < 0) {
 		return errors.New("accuracy must be non-negative")
 	}
-	if lu.Speed != nil && *lu.Speed < 0 {
+	if lu.Altitude != nil && !isFinite(*lu.Altitude) {
+		return errors.New("altitude must be a finite number")
+	}
+	if lu.Speed != nil && (!isFinite(*lu.Speed) || *lu.Speed < 0) {
 		return errors.New("speed must be non-negative")
 	}
-	if lu.Heading != nil && (*lu.Heading < 0 || *lu.Heading > 360) {
+	if lu.Heading != nil && (!isFinite(*lu.Heading) || *lu.Heading < 0 || *lu.Heading > 360) {
 		return errors.New("heading must be between 0 and 360")
 	}
 	if lu.BatteryLevel != nil && (*lu.BatteryLevel < 0 || *lu.BatteryLevel > 100) {
@@ -116,20 +199,43 @@ func (lu *LocationUpdate) Validate() error {
 	return nil
 }
 
-// ToLocationPoint converts LocationUpdate to LocationPoint
+// Plausibility bounds for client-supplied capture timestamps. Outside
+// them the fix is stamped with receipt time instead: older is a broken
+// clock rather than a believable offline backlog, newer is clock skew
+const (
+	maxClientTimestampAge  = 7 * 24 * time.Hour
+	maxClientTimestampSkew = time.Minute
+)
+
+// ToLocationPoint converts LocationUpdate to LocationPoint. A plausible
+// client-provided capture timestamp is preserved, so offline batches keep
+// their real ordering; receipt time is always recorded separately
 func (lu *LocationUpdate) ToLocationPoint() *LocationPoint {
+	receivedAt := time.Now().UTC()
+
+	timestamp := receivedAt
+	if lu.Timestamp != nil {
+		if t := lu.Timestamp.UTC(); t.After(receivedAt.Add(-maxClientTimestampAge)) && t.Before(receivedAt.Add(maxClientTimestampSkew)) {
+			timestamp = t
+		}
+	}
+
 	return &LocationPoint{
 		EmergencyID:  lu.EmergencyID,
 		UserID:       lu.UserID,
-		Latitude:     lu.Latitude,
-		Longitude:    lu.Longitude,
+		Latitude:     normalizeZero(lu.Latitude),
+		Longitude:    normalizeZero(lu.Longitude),
 		Accuracy:     lu.Accuracy,
 		Altitude:     lu.Altitude,
 		Speed:        lu.Speed,
 		Heading:      lu.Heading,
 		Provider:     lu.Provider,
-		Timestamp:    time.Now().UTC(),
+		Timestamp:    timestamp,
+		ReceivedAt:   receivedAt,
 		BatteryLevel: lu.BatteryLevel,
+		Geohash:      geo.EncodeGeohash(lu.Latitude, lu.Longitude, geo.DefaultGeohashPrecision),
+		TrustScore:   1.0,
+		TrustFlags:   []string{},
 	}
 }
 
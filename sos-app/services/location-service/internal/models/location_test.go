@@ -1,7 +1,9 @@
 package models
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -125,6 +127,64 @@ func TestLocationUpdateValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid latitude - NaN",
+			update: LocationUpdate{
+				EmergencyID: uuid.New(),
+				UserID:      uuid.New(),
+				Latitude:    math.NaN(),
+				Longitude:   0,
+				Provider:    ProviderGPS,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid longitude - positive infinity",
+			update: LocationUpdate{
+				EmergencyID: uuid.New(),
+				UserID:      uuid.New(),
+				Latitude:    0,
+				Longitude:   math.Inf(1),
+				Provider:    ProviderGPS,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid accuracy - NaN",
+			update: LocationUpdate{
+				EmergencyID: uuid.New(),
+				UserID:      uuid.New(),
+				Latitude:    0,
+				Longitude:   0,
+				Provider:    ProviderGPS,
+				Accuracy:    floatPtr(math.NaN()),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid altitude - negative infinity",
+			update: LocationUpdate{
+				EmergencyID: uuid.New(),
+				UserID:      uuid.New(),
+				Latitude:    0,
+				Longitude:   0,
+				Provider:    ProviderGPS,
+				Altitude:    floatPtr(math.Inf(-1)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid heading - NaN",
+			update: LocationUpdate{
+				EmergencyID: uuid.New(),
+				UserID:      uuid.New(),
+				Latitude:    0,
+				Longitude:   0,
+				Provider:    ProviderGPS,
+				Heading:     floatPtr(math.NaN()),
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid battery level - negative",
 			update: LocationUpdate{
@@ -225,6 +285,86 @@ func TestToLocationPoint(t *testing.T) {
 	}
 }
 
+func TestToLocationPointTimestamps(t *testing.T) {
+	base := LocationUpdate{
+		EmergencyID: uuid.New(),
+		UserID:      uuid.New(),
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+		Provider:    ProviderGPS,
+	}
+
+	tests := []struct {
+		name      string
+		timestamp *time.Time
+		preserved bool
+	}{
+		{
+			name:      "no client timestamp uses receipt time",
+			timestamp: nil,
+			preserved: false,
+		},
+		{
+			name:      "plausible offline timestamp preserved",
+			timestamp: timePtr(time.Now().Add(-6 * time.Hour)),
+			preserved: true,
+		},
+		{
+			name:      "older than a week falls back to receipt time",
+			timestamp: timePtr(time.Now().Add(-8 * 24 * time.Hour)),
+			preserved: false,
+		},
+		{
+			name:      "future beyond skew allowance falls back to receipt time",
+			timestamp: timePtr(time.Now().Add(10 * time.Minute)),
+			preserved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := base
+			update.Timestamp = tt.timestamp
+
+			point := update.ToLocationPoint()
+
+			if point.ReceivedAt.IsZero() {
+				t.Error("ReceivedAt must always be set")
+			}
+			if tt.preserved {
+				if !point.Timestamp.Equal(tt.timestamp.UTC()) {
+					t.Errorf("client timestamp not preserved: got %v, want %v", point.Timestamp, tt.timestamp.UTC())
+				}
+			} else if !point.Timestamp.Equal(point.ReceivedAt) {
+				t.Errorf("expected fallback to receipt time, got %v (received %v)", point.Timestamp, point.ReceivedAt)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestToLocationPointNormalizesNegativeZero(t *testing.T) {
+	update := LocationUpdate{
+		EmergencyID: uuid.New(),
+		UserID:      uuid.New(),
+		Latitude:    math.Copysign(0, -1),
+		Longitude:   math.Copysign(0, -1),
+		Provider:    ProviderGPS,
+	}
+
+	point := update.ToLocationPoint()
+
+	if math.Signbit(point.Latitude) {
+		t.Errorf("Latitude -0.0 should be normalized to +0.0, got %v", point.Latitude)
+	}
+	if math.Signbit(point.Longitude) {
+		t.Errorf("Longitude -0.0 should be normalized to +0.0, got %v", point.Longitude)
+	}
+}
+
 // Helper functions
 func floatPtr(f float64) *float64 {
 	return &f
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Privacy zone radius bounds: anything below ~50m is pointless against
+// coarsened output, anything above 10km would hide whole cities
+const (
+	MinPrivacyZoneRadiusMeters = 50
+	MaxPrivacyZoneRadiusMeters = 10000
+)
+
+// coarseCoordinateDecimals is the precision kept on redacted points: two
+// decimal places is roughly a 1.1 km cell, enough for contacts to see the
+// neighbourhood without revealing the address
+const coarseCoordinateDecimals = 2
+
+// coarseGeohashPrecision matches coarseCoordinateDecimals cell size
+const coarseGeohashPrecision = 4
+
+// PrivacyZone is a user-defined circular area (home, workplace) whose
+// location points are stored with a redaction flag and served with coarse
+// coordinates to contacts
+type PrivacyZone struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	UserID          uuid.UUID `json:"userId" db:"user_id"`
+	Name            string    `json:"name" db:"name"`
+	CenterLatitude  float64   `json:"centerLatitude" db:"center_latitude"`
+	CenterLongitude float64   `json:"centerLongitude" db:"center_longitude"`
+	RadiusMeters    float64   `json:"radiusMeters" db:"radius_meters"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at"`
+}
+
+// CreatePrivacyZoneRequest represents an incoming zone definition
+type CreatePrivacyZoneRequest struct {
+	Name            string  `json:"name"`
+	CenterLatitude  float64 `json:"centerLatitude"`
+	CenterLongitude float64 `json:"centerLongitude"`
+	RadiusMeters    float64 `json:"radiusMeters"`
+}
+
+// Validate checks the zone definition
+func (r *CreatePrivacyZoneRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.CenterLatitude < -90 || r.CenterLatitude > 90 {
+		return errors.New("centerLatitude must be between -90 and 90")
+	}
+	if r.CenterLongitude < -180 || r.CenterLongitude > 180 {
+		return errors.New("centerLongitude must be between -180 and 180")
+	}
+	if r.RadiusMeters < MinPrivacyZoneRadiusMeters || r.RadiusMeters > MaxPrivacyZoneRadiusMeters {
+		return errors.New("radiusMeters must be between 50 and 10000")
+	}
+	return nil
+}
+
+// Coarsened returns a copy of the point with coordinates rounded to a
+// ~1.1 km cell and address-revealing fields stripped. Used for redacted
+// points served to contacts; dispatch connectors get the original
+func (p *LocationPoint) Coarsened() *LocationPoint {
+	coarse := *p
+	scale := math.Pow10(coarseCoordinateDecimals)
+	coarse.Latitude = math.Round(p.Latitude*scale) / scale
+	coarse.Longitude = math.Round(p.Longitude*scale) / scale
+	coarse.Accuracy = nil
+	coarse.Altitude = nil
+	coarse.Speed = nil
+	coarse.Heading = nil
+	coarse.Address = nil
+	if len(coarse.Geohash) > coarseGeohashPrecision {
+		coarse.Geohash = coarse.Geohash[:coarseGeohashPrecision]
+	}
+	return &coarse
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Responder search radius bounds: below ~100m a responder is already on
+// scene, above 25km they are too far to arrive before emergency services
+const (
+	MinResponderSearchRadiusMeters     = 100
+	MaxResponderSearchRadiusMeters     = 25000
+	DefaultResponderSearchRadiusMeters = 2000
+)
+
+// responderTypes are the recognized responder categories. The category is
+// surfaced to the victim's contacts so they know who is coming
+var responderTypes = map[string]bool{
+	"community": true,
+	"medical":   true,
+	"security":  true,
+	"fire":      true,
+}
+
+// RegisteredResponder is a community responder who opted in to being
+// dispatched to nearby emergencies. One registration per user; the
+// coordinates are the responder's standing location (home base), not a
+// live position
+type RegisteredResponder struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	UserID        uuid.UUID `json:"userId" db:"user_id"`
+	Name          string    `json:"name" db:"name"`
+	ResponderType string    `json:"responderType" db:"responder_type"`
+	Phone         *string   `json:"phone,omitempty" db:"phone"`
+	Latitude      float64   `json:"latitude" db:"latitude"`
+	Longitude     float64   `json:"longitude" db:"longitude"`
+	Active        bool      `json:"active" db:"active"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// NearbyResponder is a registered responder returned from a radius query,
+// annotated with the server-computed distance and travel-time estimate
+type NearbyResponder struct {
+	RegisteredResponder
+	DistanceMeters    float64 `json:"distanceMeters"`
+	TravelTimeSeconds int     `json:"travelTimeSeconds"`
+}
+
+// RegisterResponderRequest represents an incoming responder registration;
+// re-registering updates the existing entry
+type RegisterResponderRequest struct {
+	Name          string  `json:"name"`
+	ResponderType string  `json:"responderType"`
+	Phone         *string `json:"phone,omitempty"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+}
+
+// Validate checks the registration and defaults the responder type
+func (r *RegisterResponderRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.ResponderType == "" {
+		r.ResponderType = "community"
+	}
+	if !responderTypes[r.ResponderType] {
+		return errors.New("responderType must be one of: community, medical, security, fire")
+	}
+	if r.Latitude < -90 || r.Latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if r.Longitude < -180 || r.Longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	return nil
+}
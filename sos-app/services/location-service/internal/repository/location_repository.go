@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"os"
 	"sync"
 	"time"
 
@@ -11,6 +14,31 @@ import (
 	"github.com/sos-app/location-service/internal/models"
 )
 
+const (
+	// Retry/backoff policy for failed batch flushes
+	maxBatchAttempts  = 5
+	retryBaseBackoff  = 1 * time.Second
+	retryMaxBackoff   = 60 * time.Second
+	maxRetryBatches   = 100
+	defaultSpillFile  = "location_points_spill.jsonl"
+)
+
+// failedBatch is a batch of points that could not be written, queued for
+// retry with exponential backoff
+type failedBatch struct {
+	points      []models.LocationPoint
+	attempts    int
+	nextAttempt time.Time
+}
+
+// FlushStats exposes counters about the batch flusher for logs and metrics
+type FlushStats struct {
+	InsertedPoints int64 `json:"insertedPoints"`
+	FailedFlushes  int64 `json:"failedFlushes"`
+	RetriedBatches int64 `json:"retriedBatches"`
+	SpilledPoints  int64 `json:"spilledPoints"`
+}
+
 // LocationRepository handles location data persistence
 type LocationRepository struct {
 	db           *Database
@@ -20,16 +48,31 @@ type LocationRepository struct {
 	flushTicker  *time.Ticker
 	stopChan     chan bool
 	wg           sync.WaitGroup
+
+	// Failed batches awaiting retry; overflow is spilled to a local file so
+	// an extended Postgres outage does not lose points
+	retryQueue    []failedBatch
+	retryMutex    sync.Mutex
+	spillFilePath string
+
+	statsMutex sync.Mutex
+	stats      FlushStats
 }
 
 // NewLocationRepository creates a new location repository with batch writing
 func NewLocationRepository(db *Database) *LocationRepository {
+	spillFile := os.Getenv("LOCATION_SPILL_FILE")
+	if spillFile == "" {
+		spillFile = defaultSpillFile
+	}
+
 	repo := &LocationRepository{
-		db:           db,
-		batchBuffer:  make([]models.LocationPoint, 0, 1000),
-		batchSize:    100,
-		flushTicker:  time.NewTicker(500 * time.Millisecond),
-		stopChan:     make(chan bool),
+		db:            db,
+		batchBuffer:   make([]models.LocationPoint, 0, 1000),
+		batchSize:     100,
+		flushTicker:   time.NewTicker(500 * time.Millisecond),
+		stopChan:      make(chan bool),
+		spillFilePath: spillFile,
 	}
 
 	// Start background batch flusher
@@ -47,13 +90,16 @@ func (r *LocationRepository) batchFlusher() {
 		select {
 		case <-r.flushTicker.C:
 			if err := r.FlushBatch(context.Background()); err != nil {
-				fmt.Printf("Error flushing batch: %v\n", err)
+				log.Printf("Error flushing batch: %v", err)
 			}
+			r.retryFailedBatches(context.Background())
 		case <-r.stopChan:
-			// Final flush on shutdown
+			// Final flush on shutdown; whatever still cannot be written is
+			// spilled to disk rather than dropped
 			if err := r.FlushBatch(context.Background()); err != nil {
-				fmt.Printf("Error in final flush: %v\n", err)
+				log.Printf("Error in final flush: %v", err)
 			}
+			r.spillRetryQueue()
 			return
 		}
 	}
@@ -100,7 +146,18 @@ func (r *LocationRepository) FlushBatch(ctx context.Context) error {
 	copy(batch, r.batchBuffer)
 	r.batchBuffer = r.batchBuffer[:0]
 
-	// Use CopyFrom for bulk insert (fastest method for TimescaleDB)
+	if err := r.insertBatch(ctx, batch); err != nil {
+		// Keep the batch for retry instead of dropping it
+		r.enqueueFailedBatch(batch, 1, err)
+		return fmt.Errorf("failed to bulk insert locations: %w", err)
+	}
+
+	return nil
+}
+
+// insertBatch bulk-inserts a batch of points using CopyFrom (fastest method
+// for TimescaleDB)
+func (r *LocationRepository) insertBatch(ctx context.Context, batch []models.LocationPoint) error {
 	rows := make([][]interface{}, len(batch))
 	for i, location := range batch {
 		rows[i] = []interface{}{
@@ -115,14 +172,20 @@ func (r *LocationRepository) FlushBatch(ctx context.Context) error {
 			location.Provider,
 			location.Address,
 			location.Timestamp,
+			location.ReceivedAt,
 			location.BatteryLevel,
+			location.Geohash,
+			location.TrustScore,
+			location.TrustFlags,
+			location.Redacted,
 		}
 	}
 
 	columns := []string{
 		"emergency_id", "user_id", "latitude", "longitude",
 		"accuracy", "altitude", "speed", "heading",
-		"provider", "address", "timestamp", "battery_level",
+		"provider", "address", "timestamp", "received_at", "battery_level",
+		"geohash", "trust_score", "trust_flags", "redacted",
 	}
 
 	copyCount, err := r.db.Pool.CopyFrom(
@@ -133,20 +196,154 @@ func (r *LocationRepository) FlushBatch(ctx context.Context) error {
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to bulk insert locations: %w", err)
+		return err
 	}
 
-	fmt.Printf("Successfully inserted %d location points\n", copyCount)
+	r.statsMutex.Lock()
+	r.stats.InsertedPoints += copyCount
+	r.statsMutex.Unlock()
+
 	return nil
 }
 
+// enqueueFailedBatch queues a failed batch for retry with exponential
+// backoff. Batches larger than one point are split so a single poison row
+// cannot hold the rest of the batch hostage. When the retry queue is full,
+// the overflow is spilled to the local spill file.
+func (r *LocationRepository) enqueueFailedBatch(batch []models.LocationPoint, attempts int, cause error) {
+	if len(batch) == 0 {
+		return
+	}
+
+	r.statsMutex.Lock()
+	r.stats.FailedFlushes++
+	r.statsMutex.Unlock()
+
+	// Exhausted batches go to the spill file, never the floor
+	if attempts > maxBatchAttempts {
+		log.Printf("ALERT: batch of %d location points exhausted %d attempts (%v), spilling to %s",
+			len(batch), maxBatchAttempts, cause, r.spillFilePath)
+		r.spillPoints(batch)
+		return
+	}
+
+	backoff := retryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff > retryMaxBackoff {
+			backoff = retryMaxBackoff
+			break
+		}
+	}
+
+	// Split so partial errors are isolated to smaller and smaller batches
+	var pending [][]models.LocationPoint
+	if len(batch) > 1 {
+		mid := len(batch) / 2
+		pending = [][]models.LocationPoint{batch[:mid], batch[mid:]}
+	} else {
+		pending = [][]models.LocationPoint{batch}
+	}
+
+	r.retryMutex.Lock()
+	defer r.retryMutex.Unlock()
+
+	for _, points := range pending {
+		if len(r.retryQueue) >= maxRetryBatches {
+			log.Printf("ALERT: retry queue full, spilling %d location points to %s", len(points), r.spillFilePath)
+			r.spillPoints(points)
+			continue
+		}
+		r.retryQueue = append(r.retryQueue, failedBatch{
+			points:      points,
+			attempts:    attempts,
+			nextAttempt: time.Now().Add(backoff),
+		})
+	}
+
+	log.Printf("Queued %d location points for retry (attempt %d, backoff %s): %v",
+		len(batch), attempts, backoff, cause)
+}
+
+// retryFailedBatches re-attempts queued batches whose backoff has elapsed
+func (r *LocationRepository) retryFailedBatches(ctx context.Context) {
+	r.retryMutex.Lock()
+	now := time.Now()
+	var due []failedBatch
+	var waiting []failedBatch
+	for _, fb := range r.retryQueue {
+		if now.After(fb.nextAttempt) {
+			due = append(due, fb)
+		} else {
+			waiting = append(waiting, fb)
+		}
+	}
+	r.retryQueue = waiting
+	r.retryMutex.Unlock()
+
+	for _, fb := range due {
+		r.statsMutex.Lock()
+		r.stats.RetriedBatches++
+		r.statsMutex.Unlock()
+
+		if err := r.insertBatch(ctx, fb.points); err != nil {
+			r.enqueueFailedBatch(fb.points, fb.attempts+1, err)
+			continue
+		}
+
+		log.Printf("Recovered %d location points after %d attempts", len(fb.points), fb.attempts)
+	}
+}
+
+// spillPoints appends points to the local spill file as JSON lines so they
+// can be replayed once Postgres is healthy again
+func (r *LocationRepository) spillPoints(points []models.LocationPoint) {
+	f, err := os.OpenFile(r.spillFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("CRITICAL: cannot open spill file %s, %d location points lost: %v",
+			r.spillFilePath, len(points), err)
+		return
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	for _, point := range points {
+		if err := encoder.Encode(point); err != nil {
+			log.Printf("CRITICAL: failed to spill location point: %v", err)
+		}
+	}
+
+	r.statsMutex.Lock()
+	r.stats.SpilledPoints += int64(len(points))
+	r.statsMutex.Unlock()
+}
+
+// spillRetryQueue persists everything still queued for retry, used on shutdown
+func (r *LocationRepository) spillRetryQueue() {
+	r.retryMutex.Lock()
+	queue := r.retryQueue
+	r.retryQueue = nil
+	r.retryMutex.Unlock()
+
+	for _, fb := range queue {
+		r.spillPoints(fb.points)
+	}
+}
+
+// GetFlushStats returns a snapshot of batch flusher counters
+func (r *LocationRepository) GetFlushStats() FlushStats {
+	r.statsMutex.Lock()
+	defer r.statsMutex.Unlock()
+	return r.stats
+}
+
 // InsertLocation inserts a single location point immediately (bypassing batch)
 func (r *LocationRepository) InsertLocation(ctx context.Context, location models.LocationPoint) (int64, error) {
 	query := `
 		INSERT INTO location_points (
 			emergency_id, user_id, latitude, longitude, accuracy,
-			altitude, speed, heading, provider, address, timestamp, battery_level
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			altitude, speed, heading, provider, address, timestamp, received_at, battery_level, geohash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id
 	`
 
@@ -163,7 +360,9 @@ func (r *LocationRepository) InsertLocation(ctx context.Context, location models
 		location.Provider,
 		location.Address,
 		location.Timestamp,
+		location.ReceivedAt,
 		location.BatteryLevel,
+		location.Geohash,
 	).Scan(&id)
 
 	if err != nil {
@@ -177,7 +376,8 @@ func (r *LocationRepository) InsertLocation(ctx context.Context, location models
 func (r *LocationRepository) GetCurrentLocation(ctx context.Context, emergencyID uuid.UUID) (*models.LocationPoint, error) {
 	query := `
 		SELECT id, emergency_id, user_id, latitude, longitude, accuracy,
-		       altitude, speed, heading, provider, address, timestamp, battery_level
+		       altitude, speed, heading, provider, address, timestamp, received_at,
+		       battery_level, trust_score, trust_flags, redacted
 		FROM location_points
 		WHERE emergency_id = $1
 		ORDER BY timestamp DESC
@@ -198,7 +398,11 @@ func (r *LocationRepository) GetCurrentLocation(ctx context.Context, emergencyID
 		&location.Provider,
 		&location.Address,
 		&location.Timestamp,
+		&location.ReceivedAt,
 		&location.BatteryLevel,
+		&location.TrustScore,
+		&location.TrustFlags,
+		&location.Redacted,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -216,7 +420,8 @@ func (r *LocationRepository) GetCurrentLocation(ctx context.Context, emergencyID
 func (r *LocationRepository) GetLocationTrail(ctx context.Context, emergencyID uuid.UUID, duration time.Duration) ([]models.LocationPoint, error) {
 	query := `
 		SELECT id, emergency_id, user_id, latitude, longitude, accuracy,
-		       altitude, speed, heading, provider, address, timestamp, battery_level
+		       altitude, speed, heading, provider, address, timestamp, received_at,
+		       battery_level, trust_score, trust_flags, redacted
 		FROM location_points
 		WHERE emergency_id = $1 AND timestamp >= NOW() - $2::interval
 		ORDER BY timestamp ASC
@@ -244,7 +449,11 @@ func (r *LocationRepository) GetLocationTrail(ctx context.Context, emergencyID u
 			&location.Provider,
 			&location.Address,
 			&location.Timestamp,
+			&location.ReceivedAt,
 			&location.BatteryLevel,
+			&location.TrustScore,
+			&location.TrustFlags,
+			&location.Redacted,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan location: %w", err)
@@ -255,6 +464,40 @@ func (r *LocationRepository) GetLocationTrail(ctx context.Context, emergencyID u
 	return locations, rows.Err()
 }
 
+// GetTrailMVT renders the trail as a Mapbox Vector Tile for one XYZ tile,
+// so dashboards can draw hour-long trails at any zoom without shipping the
+// full point set. Requires the PostGIS extension (migration 005). Tiles
+// carry exact geometry, so privacy-zone points are excluded outright unless
+// includeRedacted is set
+func (r *LocationRepository) GetTrailMVT(ctx context.Context, emergencyID uuid.UUID, z, x, y int, duration time.Duration, includeRedacted bool) ([]byte, error) {
+	query := `
+		WITH mvtgeom AS (
+			SELECT ST_AsMVTGeom(
+			           ST_Transform(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326), 3857),
+			           ST_TileEnvelope($2, $3, $4)
+			       ) AS geom,
+			       id,
+			       EXTRACT(EPOCH FROM timestamp)::bigint AS ts,
+			       accuracy, speed, battery_level, trust_score
+			FROM location_points
+			WHERE emergency_id = $1
+			  AND timestamp >= NOW() - $5::interval
+			  AND ($6 OR NOT redacted)
+			  AND ST_Transform(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326), 3857)
+			      && ST_TileEnvelope($2, $3, $4)
+		)
+		SELECT ST_AsMVT(mvtgeom.*, 'trail', 4096, 'geom') FROM mvtgeom
+	`
+
+	var tile []byte
+	err := r.db.Pool.QueryRow(ctx, query, emergencyID, z, x, y, duration, includeRedacted).Scan(&tile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render trail tile: %w", err)
+	}
+
+	return tile, nil
+}
+
 // GetLocationHistory retrieves all location points for an emergency with pagination
 func (r *LocationRepository) GetLocationHistory(ctx context.Context, emergencyID uuid.UUID, limit, offset int) ([]models.LocationPoint, int, error) {
 	// Get total count
@@ -268,7 +511,8 @@ func (r *LocationRepository) GetLocationHistory(ctx context.Context, emergencyID
 	// Get paginated results
 	query := `
 		SELECT id, emergency_id, user_id, latitude, longitude, accuracy,
-		       altitude, speed, heading, provider, address, timestamp, battery_level
+		       altitude, speed, heading, provider, address, timestamp, received_at,
+		       battery_level, trust_score, trust_flags, redacted
 		FROM location_points
 		WHERE emergency_id = $1
 		ORDER BY timestamp DESC
@@ -297,7 +541,11 @@ func (r *LocationRepository) GetLocationHistory(ctx context.Context, emergencyID
 			&location.Provider,
 			&location.Address,
 			&location.Timestamp,
+			&location.ReceivedAt,
 			&location.BatteryLevel,
+			&location.TrustScore,
+			&location.TrustFlags,
+			&location.Redacted,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan location: %w", err)
@@ -308,6 +556,76 @@ func (r *LocationRepository) GetLocationHistory(ctx context.Context, emergencyID
 	return locations, total, rows.Err()
 }
 
+// GetTrailStats computes aggregate statistics over an emergency's whole
+// trail in SQL: geodesic distance summed over consecutive points plus speed
+// and time-range aggregates. Returns zero-valued stats for an empty trail
+func (r *LocationRepository) GetTrailStats(ctx context.Context, emergencyID uuid.UUID) (*models.TrailStats, error) {
+	query := `
+		WITH ordered AS (
+			SELECT timestamp, speed,
+			       ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography AS geog,
+			       LAG(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography)
+			           OVER (ORDER BY timestamp) AS prev_geog
+			FROM location_points
+			WHERE emergency_id = $1
+		)
+		SELECT COUNT(*),
+		       COALESCE(SUM(ST_Distance(geog, prev_geog)), 0),
+		       AVG(speed),
+		       MAX(speed),
+		       MIN(timestamp),
+		       MAX(timestamp)
+		FROM ordered
+	`
+
+	var stats models.TrailStats
+	err := r.db.Pool.QueryRow(ctx, query, emergencyID).Scan(
+		&stats.PointCount,
+		&stats.TotalDistanceMeters,
+		&stats.AverageSpeed,
+		&stats.MaxSpeed,
+		&stats.FirstTimestamp,
+		&stats.LastTimestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute trail stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetCellAggregates groups recent points by geohash prefix, returning point
+// and distinct-emergency counts per cell for heatmaps and load analysis
+func (r *LocationRepository) GetCellAggregates(ctx context.Context, precision int, since time.Time, limit int) ([]models.CellAggregate, error) {
+	query := `
+		SELECT LEFT(geohash, $1) AS cell,
+		       COUNT(*) AS points,
+		       COUNT(DISTINCT emergency_id) AS emergencies
+		FROM location_points
+		WHERE geohash IS NOT NULL AND timestamp >= $2
+		GROUP BY cell
+		ORDER BY points DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, precision, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cell aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	var cells []models.CellAggregate
+	for rows.Next() {
+		var cell models.CellAggregate
+		if err := rows.Scan(&cell.Cell, &cell.Points, &cell.Emergencies); err != nil {
+			return nil, fmt.Errorf("failed to scan cell aggregate: %w", err)
+		}
+		cells = append(cells, cell)
+	}
+
+	return cells, rows.Err()
+}
+
 // BatchInsertLocations inserts multiple location points at once
 func (r *LocationRepository) BatchInsertLocations(ctx context.Context, locations []models.LocationPoint) error {
 	if len(locations) == 0 {
@@ -328,14 +646,20 @@ func (r *LocationRepository) BatchInsertLocations(ctx context.Context, locations
 			location.Provider,
 			location.Address,
 			location.Timestamp,
+			location.ReceivedAt,
 			location.BatteryLevel,
+			location.Geohash,
+			location.TrustScore,
+			location.TrustFlags,
+			location.Redacted,
 		}
 	}
 
 	columns := []string{
 		"emergency_id", "user_id", "latitude", "longitude",
 		"accuracy", "altitude", "speed", "heading",
-		"provider", "address", "timestamp", "battery_level",
+		"provider", "address", "timestamp", "received_at", "battery_level",
+		"geohash", "trust_score", "trust_flags", "redacted",
 	}
 
 	copyCount, err := r.db.Pool.CopyFrom(
@@ -362,3 +686,95 @@ func (r *LocationRepository) UpdateLocationAddress(ctx context.Context, id int64
 	}
 	return nil
 }
+
+// ListUserEmergencies returns the distinct emergencies a user has location
+// history for, so caches and rooms can be torn down before the points go
+func (r *LocationRepository) ListUserEmergencies(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT DISTINCT emergency_id FROM location_points WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user emergencies: %w", err)
+	}
+	defer rows.Close()
+
+	var emergencyIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan emergency id: %w", err)
+		}
+		emergencyIDs = append(emergencyIDs, id)
+	}
+
+	return emergencyIDs, rows.Err()
+}
+
+// GetAllLocations retrieves an emergency's entire trail in chronological
+// order, for export to the archive
+func (r *LocationRepository) GetAllLocations(ctx context.Context, emergencyID uuid.UUID) ([]models.LocationPoint, error) {
+	query := `
+		SELECT id, emergency_id, user_id, latitude, longitude, accuracy,
+		       altitude, speed, heading, provider, address, timestamp, received_at,
+		       battery_level, trust_score, trust_flags, redacted
+		FROM location_points
+		WHERE emergency_id = $1
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, emergencyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locations for export: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []models.LocationPoint
+	for rows.Next() {
+		var location models.LocationPoint
+		err := rows.Scan(
+			&location.ID,
+			&location.EmergencyID,
+			&location.UserID,
+			&location.Latitude,
+			&location.Longitude,
+			&location.Accuracy,
+			&location.Altitude,
+			&location.Speed,
+			&location.Heading,
+			&location.Provider,
+			&location.Address,
+			&location.Timestamp,
+			&location.ReceivedAt,
+			&location.BatteryLevel,
+			&location.TrustScore,
+			&location.TrustFlags,
+			&location.Redacted,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
+
+// DeleteEmergencyData removes an emergency's hot rows after its trail has
+// been exported to the archive. Returns the number of points removed
+func (r *LocationRepository) DeleteEmergencyData(ctx context.Context, emergencyID uuid.UUID) (int64, error) {
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM location_points WHERE emergency_id = $1`, emergencyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete emergency location data: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// DeleteUserData purges all location history for a user (GDPR/CCPA data
+// erasure). Returns the number of points removed
+func (r *LocationRepository) DeleteUserData(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM location_points WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user location data: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// PrivacyZoneRepository handles privacy zone persistence
+type PrivacyZoneRepository struct {
+	db *Database
+}
+
+// NewPrivacyZoneRepository creates a new privacy zone repository
+func NewPrivacyZoneRepository(db *Database) *PrivacyZoneRepository {
+	return &PrivacyZoneRepository{db: db}
+}
+
+// Create stores a new privacy zone
+func (r *PrivacyZoneRepository) Create(ctx context.Context, zone *models.PrivacyZone) error {
+	query := `
+		INSERT INTO privacy_zones (user_id, name, center_latitude, center_longitude, radius_meters)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		zone.UserID,
+		zone.Name,
+		zone.CenterLatitude,
+		zone.CenterLongitude,
+		zone.RadiusMeters,
+	).Scan(&zone.ID, &zone.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create privacy zone: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns all privacy zones defined by a user
+func (r *PrivacyZoneRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.PrivacyZone, error) {
+	query := `
+		SELECT id, user_id, name, center_latitude, center_longitude, radius_meters, created_at
+		FROM privacy_zones
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list privacy zones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []models.PrivacyZone
+	for rows.Next() {
+		var zone models.PrivacyZone
+		err := rows.Scan(
+			&zone.ID,
+			&zone.UserID,
+			&zone.Name,
+			&zone.CenterLatitude,
+			&zone.CenterLongitude,
+			&zone.RadiusMeters,
+			&zone.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan privacy zone: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, rows.Err()
+}
+
+// Delete removes a zone. The user_id guard makes it an ownership check;
+// it returns whether a row was actually deleted
+func (r *PrivacyZoneRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	query := `DELETE FROM privacy_zones WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete privacy zone: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
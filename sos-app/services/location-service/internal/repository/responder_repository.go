@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// ResponderRepository handles registered responder persistence
+type ResponderRepository struct {
+	db *Database
+}
+
+// NewResponderRepository creates a new responder repository
+func NewResponderRepository(db *Database) *ResponderRepository {
+	return &ResponderRepository{db: db}
+}
+
+// Upsert registers a responder, or updates the user's existing registration
+// and reactivates it. The geography column is generated from the coordinates
+func (r *ResponderRepository) Upsert(ctx context.Context, responder *models.RegisteredResponder) error {
+	query := `
+		INSERT INTO registered_responders (user_id, name, responder_type, phone, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			responder_type = EXCLUDED.responder_type,
+			phone = EXCLUDED.phone,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			active = TRUE,
+			updated_at = NOW()
+		RETURNING id, active, created_at, updated_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		responder.UserID,
+		responder.Name,
+		responder.ResponderType,
+		responder.Phone,
+		responder.Latitude,
+		responder.Longitude,
+	).Scan(&responder.ID, &responder.Active, &responder.CreatedAt, &responder.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert responder: %w", err)
+	}
+
+	return nil
+}
+
+// Deactivate takes a responder out of the dispatch pool without losing the
+// registration; it returns whether an active registration existed
+func (r *ResponderRepository) Deactivate(ctx context.Context, userID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE registered_responders
+		SET active = FALSE, updated_at = NOW()
+		WHERE user_id = $1 AND active
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to deactivate responder: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// FindNearby returns active responders within radiusMeters of a point,
+// nearest first. The radius filter and distances are computed server-side
+// on the geography column (ST_DWithin uses the spatial index)
+func (r *ResponderRepository) FindNearby(ctx context.Context, latitude, longitude, radiusMeters float64, limit int) ([]models.NearbyResponder, error) {
+	query := `
+		SELECT id, user_id, name, responder_type, phone, latitude, longitude,
+		       active, created_at, updated_at,
+		       ST_Distance(geog, ST_SetSRID(ST_MakePoint($2, $1), 4326)::geography) AS distance_meters
+		FROM registered_responders
+		WHERE active
+		  AND ST_DWithin(geog, ST_SetSRID(ST_MakePoint($2, $1), 4326)::geography, $3)
+		ORDER BY distance_meters ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, latitude, longitude, radiusMeters, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nearby responders: %w", err)
+	}
+	defer rows.Close()
+
+	var responders []models.NearbyResponder
+	for rows.Next() {
+		var responder models.NearbyResponder
+		err := rows.Scan(
+			&responder.ID,
+			&responder.UserID,
+			&responder.Name,
+			&responder.ResponderType,
+			&responder.Phone,
+			&responder.Latitude,
+			&responder.Longitude,
+			&responder.Active,
+			&responder.CreatedAt,
+			&responder.UpdatedAt,
+			&responder.DistanceMeters,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan nearby responder: %w", err)
+		}
+		responders = append(responders, responder)
+	}
+
+	return responders, rows.Err()
+}
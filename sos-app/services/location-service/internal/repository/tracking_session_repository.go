@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClosedSession is a tracking session whose emergency has ended, as seen by
+// the trail archiver
+type ClosedSession struct {
+	EmergencyID uuid.UUID
+	UserID      uuid.UUID
+	ClosedAt    time.Time
+	CloseReason string
+}
+
+// TrackingSessionRepository handles tracking session persistence
+type TrackingSessionRepository struct {
+	db *Database
+}
+
+// NewTrackingSessionRepository creates a new tracking session repository
+func NewTrackingSessionRepository(db *Database) *TrackingSessionRepository {
+	return &TrackingSessionRepository{db: db}
+}
+
+// OpenSession records that tracking started for an emergency. Opening an
+// already-open session is a no-op
+func (r *TrackingSessionRepository) OpenSession(ctx context.Context, emergencyID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO tracking_sessions (emergency_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (emergency_id) DO NOTHING
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query, emergencyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to open tracking session: %w", err)
+	}
+
+	return nil
+}
+
+// CloseSession marks the session closed with a reason (e.g. "resolved",
+// "cancelled"). Closing an already-closed or unknown session is a no-op
+func (r *TrackingSessionRepository) CloseSession(ctx context.Context, emergencyID uuid.UUID, reason string) error {
+	query := `
+		UPDATE tracking_sessions
+		SET closed_at = NOW(), close_reason = $2
+		WHERE emergency_id = $1 AND closed_at IS NULL
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query, emergencyID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to close tracking session: %w", err)
+	}
+
+	return nil
+}
+
+// ListArchivable returns up to limit sessions that closed before cutoff and
+// have not been archived yet, oldest first
+func (r *TrackingSessionRepository) ListArchivable(ctx context.Context, cutoff time.Time, limit int) ([]ClosedSession, error) {
+	query := `
+		SELECT emergency_id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'),
+		       closed_at, COALESCE(close_reason, '')
+		FROM tracking_sessions
+		WHERE closed_at IS NOT NULL AND closed_at < $1 AND archived_at IS NULL
+		ORDER BY closed_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archivable sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []ClosedSession
+	for rows.Next() {
+		var session ClosedSession
+		if err := rows.Scan(&session.EmergencyID, &session.UserID, &session.ClosedAt, &session.CloseReason); err != nil {
+			return nil, fmt.Errorf("failed to scan archivable session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// MarkArchived records that the session's trail was exported so the archive
+// sweep never picks it up again
+func (r *TrackingSessionRepository) MarkArchived(ctx context.Context, emergencyID uuid.UUID) error {
+	query := `
+		UPDATE tracking_sessions
+		SET archived_at = NOW()
+		WHERE emergency_id = $1 AND archived_at IS NULL
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query, emergencyID)
+	if err != nil {
+		return fmt.Errorf("failed to mark session archived: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// IngestMetrics tracks per-provider ingestion quality: how many fixes each
+// provider (GPS/WiFi/Cell/Hybrid) delivers, how accurate they are, and how
+// many get rejected. Counters are exported to Prometheus and a rolling
+// daily summary backs the quality-summary endpoint, so product teams can
+// see which client platforms deliver unusable fixes
+type IngestMetrics struct {
+	accepted *prometheus.CounterVec
+	rejected *prometheus.CounterVec
+	accuracy *prometheus.HistogramVec
+
+	mutex sync.Mutex
+	day   string
+	stats map[models.LocationProvider]*providerDayStats
+}
+
+// providerDayStats accumulates one provider's numbers for the current day
+type providerDayStats struct {
+	accepted      int64
+	rejected      int64
+	accuracySum   float64
+	accuracyCount int64
+}
+
+// ProviderQuality is one provider's row in the daily quality summary
+type ProviderQuality struct {
+	Provider          models.LocationProvider `json:"provider"`
+	Accepted          int64                   `json:"accepted"`
+	Rejected          int64                   `json:"rejected"`
+	RejectionRate     float64                 `json:"rejectionRate"`
+	AvgAccuracyMeters float64                 `json:"avgAccuracyMeters,omitempty"`
+}
+
+// QualitySummary is the daily per-provider ingestion quality report. Counts
+// are per node and reset at midnight UTC
+type QualitySummary struct {
+	Date      string            `json:"date"`
+	Providers []ProviderQuality `json:"providers"`
+}
+
+// NewIngestMetrics creates the metrics and registers the Prometheus
+// collectors with reg
+func NewIngestMetrics(reg prometheus.Registerer) *IngestMetrics {
+	m := &IngestMetrics{
+		accepted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "location_ingest_accepted_total",
+			Help: "Accepted location fixes by provider",
+		}, []string{"provider"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "location_ingest_rejected_total",
+			Help: "Rejected location fixes by provider and reason",
+		}, []string{"provider", "reason"}),
+		accuracy: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "location_ingest_accuracy_meters",
+			Help:    "Reported accuracy of accepted fixes by provider",
+			Buckets: []float64{5, 10, 25, 50, 100, 250, 500, 1000},
+		}, []string{"provider"}),
+	}
+	reg.MustRegister(m.accepted, m.rejected, m.accuracy)
+	return m
+}
+
+// RecordAccepted counts an accepted fix. accuracyMeters <= 0 means the
+// client didn't report one; the fix still counts but not toward the average
+func (m *IngestMetrics) RecordAccepted(provider models.LocationProvider, accuracyMeters float64) {
+	m.accepted.WithLabelValues(string(provider)).Inc()
+	if accuracyMeters > 0 {
+		m.accuracy.WithLabelValues(string(provider)).Observe(accuracyMeters)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stats := m.dayStats(provider)
+	stats.accepted++
+	if accuracyMeters > 0 {
+		stats.accuracySum += accuracyMeters
+		stats.accuracyCount++
+	}
+}
+
+// RecordRejected counts a rejected fix with the reason it was turned away
+func (m *IngestMetrics) RecordRejected(provider models.LocationProvider, reason string) {
+	m.rejected.WithLabelValues(string(provider), reason).Inc()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.dayStats(provider).rejected++
+}
+
+// Summary reports the current day's per-provider quality, worst rejection
+// rate first
+func (m *IngestMetrics) Summary() QualitySummary {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.rollover(time.Now().UTC())
+
+	summary := QualitySummary{
+		Date:      m.day,
+		Providers: make([]ProviderQuality, 0, len(m.stats)),
+	}
+	for provider, stats := range m.stats {
+		row := ProviderQuality{
+			Provider: provider,
+			Accepted: stats.accepted,
+			Rejected: stats.rejected,
+		}
+		if total := stats.accepted + stats.rejected; total > 0 {
+			row.RejectionRate = float64(stats.rejected) / float64(total)
+		}
+		if stats.accuracyCount > 0 {
+			row.AvgAccuracyMeters = stats.accuracySum / float64(stats.accuracyCount)
+		}
+		summary.Providers = append(summary.Providers, row)
+	}
+
+	sort.Slice(summary.Providers, func(i, j int) bool {
+		if summary.Providers[i].RejectionRate != summary.Providers[j].RejectionRate {
+			return summary.Providers[i].RejectionRate > summary.Providers[j].RejectionRate
+		}
+		return summary.Providers[i].Provider < summary.Providers[j].Provider
+	})
+
+	return summary
+}
+
+// dayStats returns the provider's stats for today, rolling the window over
+// at midnight UTC. Callers hold the mutex
+func (m *IngestMetrics) dayStats(provider models.LocationProvider) *providerDayStats {
+	m.rollover(time.Now().UTC())
+
+	stats, ok := m.stats[provider]
+	if !ok {
+		stats = &providerDayStats{}
+		m.stats[provider] = stats
+	}
+	return stats
+}
+
+// rollover resets the daily stats when the UTC date changes. Callers hold
+// the mutex
+func (m *IngestMetrics) rollover(now time.Time) {
+	day := now.Format("2006-01-02")
+	if m.day != day {
+		m.day = day
+		m.stats = make(map[models.LocationProvider]*providerDayStats)
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func TestIngestMetrics_SummaryAveragesAndRates(t *testing.T) {
+	metrics := NewIngestMetrics(prometheus.NewRegistry())
+
+	metrics.RecordAccepted(models.ProviderGPS, 10)
+	metrics.RecordAccepted(models.ProviderGPS, 30)
+	metrics.RecordAccepted(models.ProviderWiFi, 0) // no reported accuracy
+	metrics.RecordRejected(models.ProviderWiFi, "validation")
+
+	summary := metrics.Summary()
+	if len(summary.Providers) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(summary.Providers))
+	}
+
+	// Worst rejection rate sorts first
+	wifi := summary.Providers[0]
+	if wifi.Provider != models.ProviderWiFi {
+		t.Fatalf("expected WIFI first, got %s", wifi.Provider)
+	}
+	if wifi.Accepted != 1 || wifi.Rejected != 1 || wifi.RejectionRate != 0.5 {
+		t.Errorf("unexpected WIFI row: %+v", wifi)
+	}
+	if wifi.AvgAccuracyMeters != 0 {
+		t.Errorf("fixes without reported accuracy must not skew the average, got %f", wifi.AvgAccuracyMeters)
+	}
+
+	gps := summary.Providers[1]
+	if gps.Accepted != 2 || gps.Rejected != 0 || gps.AvgAccuracyMeters != 20 {
+		t.Errorf("unexpected GPS row: %+v", gps)
+	}
+}
+
+func TestIngestMetrics_RolloverResetsDailyStats(t *testing.T) {
+	metrics := NewIngestMetrics(prometheus.NewRegistry())
+
+	metrics.RecordAccepted(models.ProviderGPS, 10)
+
+	metrics.mutex.Lock()
+	metrics.day = "2000-01-01" // force a date change
+	metrics.mutex.Unlock()
+
+	summary := metrics.Summary()
+	if len(summary.Providers) != 0 {
+		t.Errorf("expected empty summary after rollover, got %d providers", len(summary.Providers))
+	}
+	if summary.Date == "2000-01-01" {
+		t.Error("summary date should roll to the current day")
+	}
+}
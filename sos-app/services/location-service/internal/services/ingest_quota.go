@@ -0,0 +1,128 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultIngestPointsPerMinute is the per-emergency batch ingest budget when
+// no severity override applies. It fits three full offline-sync batches a
+// minute, which is well above any honest client
+const DefaultIngestPointsPerMinute = 3000
+
+// IngestQuota caps how many points per minute the batch-update endpoint
+// accepts per emergency. The per-request cap bounds a single call; the quota
+// bounds a client calling it in a tight loop. Budgets are configurable per
+// severity so CRITICAL emergencies can sync larger backlogs
+type IngestQuota struct {
+	defaultLimit int
+	limits       map[string]int
+
+	mutex      sync.Mutex
+	windows    map[uuid.UUID]*ingestWindow
+	lastPruned time.Time
+}
+
+// ingestWindow tracks points consumed in the current one-minute window
+type ingestWindow struct {
+	start time.Time
+	used  int
+}
+
+// NewIngestQuota creates a quota. limits maps upper-case severity levels
+// (e.g. "CRITICAL") to their points-per-minute budget; a zero limit disables
+// the quota for that severity
+func NewIngestQuota(defaultLimit int, limits map[string]int) *IngestQuota {
+	if defaultLimit < 0 {
+		defaultLimit = DefaultIngestPointsPerMinute
+	}
+
+	return &IngestQuota{
+		defaultLimit: defaultLimit,
+		limits:       limits,
+		windows:      make(map[uuid.UUID]*ingestWindow),
+		lastPruned:   time.Now(),
+	}
+}
+
+// ParseIngestLimits parses per-severity budget overrides from a
+// comma-separated list like "CRITICAL=0,HIGH=6000"
+func ParseIngestLimits(raw string) map[string]int {
+	if raw == "" {
+		return nil
+	}
+
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil || limit < 0 {
+			continue
+		}
+		limits[strings.ToUpper(parts[0])] = limit
+	}
+
+	return limits
+}
+
+// Reserve attempts to consume points from the emergency's current one-minute
+// window. When the budget is exhausted it reports how long the caller should
+// wait before retrying
+func (q *IngestQuota) Reserve(emergencyID uuid.UUID, severity string, points int) (bool, time.Duration) {
+	limit := q.limitFor(severity)
+	if limit <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.pruneLocked(now)
+
+	window, ok := q.windows[emergencyID]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		window = &ingestWindow{start: now}
+		q.windows[emergencyID] = window
+	}
+
+	if window.used+points > limit {
+		return false, window.start.Add(time.Minute).Sub(now)
+	}
+
+	window.used += points
+	return true, 0
+}
+
+// limitFor returns the points-per-minute budget for a severity level
+func (q *IngestQuota) limitFor(severity string) int {
+	if severity != "" {
+		if limit, ok := q.limits[strings.ToUpper(severity)]; ok {
+			return limit
+		}
+	}
+	return q.defaultLimit
+}
+
+// pruneLocked drops expired windows so long-running processes don't
+// accumulate state for emergencies that stopped syncing
+func (q *IngestQuota) pruneLocked(now time.Time) {
+	if now.Sub(q.lastPruned) < time.Minute {
+		return
+	}
+
+	for id, window := range q.windows {
+		if now.Sub(window.start) >= time.Minute {
+			delete(q.windows, id)
+		}
+	}
+	q.lastPruned = now
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestIngestQuota_DeniesOverBudget(t *testing.T) {
+	quota := NewIngestQuota(1000, nil)
+	emergencyID := uuid.New()
+
+	if ok, _ := quota.Reserve(emergencyID, "", 800); !ok {
+		t.Fatal("first batch within budget should be allowed")
+	}
+
+	ok, retryAfter := quota.Reserve(emergencyID, "", 300)
+	if ok {
+		t.Fatal("batch exceeding the remaining budget should be denied")
+	}
+	if retryAfter <= 0 || retryAfter > time.Minute {
+		t.Errorf("retry hint should fall within the window, got %s", retryAfter)
+	}
+
+	// The remaining budget is still available for a smaller batch
+	if ok, _ := quota.Reserve(emergencyID, "", 200); !ok {
+		t.Error("batch within the remaining budget should be allowed")
+	}
+}
+
+func TestIngestQuota_SeverityOverride(t *testing.T) {
+	limits := ParseIngestLimits("CRITICAL=0,HIGH=500")
+	quota := NewIngestQuota(1000, limits)
+	emergencyID := uuid.New()
+
+	// A zero limit disables the quota entirely
+	for i := 0; i < 5; i++ {
+		if ok, _ := quota.Reserve(emergencyID, "CRITICAL", 1000); !ok {
+			t.Fatalf("CRITICAL batch %d should not be limited", i)
+		}
+	}
+
+	if ok, _ := quota.Reserve(uuid.New(), "HIGH", 600); ok {
+		t.Error("batch over the HIGH budget should be denied")
+	}
+}
+
+func TestIngestQuota_IndependentPerEmergency(t *testing.T) {
+	quota := NewIngestQuota(1000, nil)
+
+	if ok, _ := quota.Reserve(uuid.New(), "", 1000); !ok {
+		t.Fatal("first emergency should be allowed")
+	}
+	if ok, _ := quota.Reserve(uuid.New(), "", 1000); !ok {
+		t.Error("exhausting one emergency's budget should not affect another")
+	}
+}
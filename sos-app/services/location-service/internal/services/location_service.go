@@ -2,22 +2,40 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/archive"
 	"github.com/sos-app/location-service/internal/cache"
 	"github.com/sos-app/location-service/internal/kafka"
 	"github.com/sos-app/location-service/internal/models"
 	"github.com/sos-app/location-service/internal/repository"
 )
 
+// stoppedTrackingTTL is the short cache TTL applied once an emergency ends
+const stoppedTrackingTTL = 5 * time.Minute
+
 // LocationService handles business logic for location tracking
 type LocationService struct {
 	repo             *repository.LocationRepository
 	cache            *cache.GeospatialCache
 	kafkaProducer    *kafka.Producer
 	geocodingService *GeocodingService
+	sessionRepo      *repository.TrackingSessionRepository
+	throttler        *UpdateThrottler
+	trustScorer      *TrustScorer
+	privacyRedactor  *PrivacyRedactor
+	trailArchiver    *TrailArchiver
+	ingestMetrics    *IngestMetrics
+
+	// Tracks which sessions are already open so UpdateLocation doesn't hit
+	// the database on every point
+	openSessions     map[uuid.UUID]bool
+	openSessionsLock sync.Mutex
 }
 
 // NewLocationService creates a new location service
@@ -26,12 +44,53 @@ func NewLocationService(
 	cache *cache.GeospatialCache,
 	kafkaProducer *kafka.Producer,
 	geocodingService *GeocodingService,
+	sessionRepo *repository.TrackingSessionRepository,
+	throttler *UpdateThrottler,
+	trustScorer *TrustScorer,
+	privacyRedactor *PrivacyRedactor,
 ) *LocationService {
 	return &LocationService{
 		repo:             repo,
 		cache:            cache,
 		kafkaProducer:    kafkaProducer,
 		geocodingService: geocodingService,
+		sessionRepo:      sessionRepo,
+		throttler:        throttler,
+		trustScorer:      trustScorer,
+		privacyRedactor:  privacyRedactor,
+		openSessions:     make(map[uuid.UUID]bool),
+	}
+}
+
+// SetTrailArchiver wires the archiver that holds exported trails of old
+// emergencies. Call during startup; without it, archived trails read as
+// empty history
+func (s *LocationService) SetTrailArchiver(archiver *TrailArchiver) {
+	s.trailArchiver = archiver
+}
+
+// SetIngestMetrics wires the per-provider ingestion quality metrics. Call
+// during startup; without it, ingestion goes uncounted
+func (s *LocationService) SetIngestMetrics(metrics *IngestMetrics) {
+	s.ingestMetrics = metrics
+}
+
+// recordAccepted counts an accepted fix for the quality metrics
+func (s *LocationService) recordAccepted(update *models.LocationUpdate) {
+	if s.ingestMetrics == nil {
+		return
+	}
+	var accuracy float64
+	if update.Accuracy != nil {
+		accuracy = *update.Accuracy
+	}
+	s.ingestMetrics.RecordAccepted(update.Provider, accuracy)
+}
+
+// recordRejected counts a rejected fix for the quality metrics
+func (s *LocationService) recordRejected(update *models.LocationUpdate, reason string) {
+	if s.ingestMetrics != nil {
+		s.ingestMetrics.RecordRejected(update.Provider, reason)
 	}
 }
 
@@ -39,12 +98,32 @@ func NewLocationService(
 func (s *LocationService) UpdateLocation(ctx context.Context, update *models.LocationUpdate) error {
 	// Validate the update
 	if err := update.Validate(); err != nil {
+		s.recordRejected(update, "validation")
 		return fmt.Errorf("invalid location update: %w", err)
 	}
+	s.recordAccepted(update)
 
 	// Convert to LocationPoint
 	location := update.ToLocationPoint()
 
+	// Score the point for plausibility before it is stored or broadcast
+	if s.trustScorer != nil {
+		location.TrustScore, location.TrustFlags = s.trustScorer.Score(update, location.Timestamp)
+		if location.TrustScore < LowTrustThreshold {
+			log.Printf("Low-trust location for emergency %s (score %.2f, flags %v)",
+				update.EmergencyID, location.TrustScore, location.TrustFlags)
+		}
+	}
+
+	// Flag points inside the user's privacy zones; coarsening happens at
+	// serve time so dispatch connectors keep full precision
+	if s.privacyRedactor != nil {
+		s.privacyRedactor.Flag(ctx, location)
+	}
+
+	// Make sure a tracking session exists for this emergency
+	s.ensureSessionOpen(ctx, update.EmergencyID, update.UserID)
+
 	// Add to batch write buffer
 	if err := s.repo.AddLocationToBatch(*location); err != nil {
 		return fmt.Errorf("failed to add location to batch: %w", err)
@@ -55,10 +134,14 @@ func (s *LocationService) UpdateLocation(ctx context.Context, update *models.Loc
 		return fmt.Errorf("failed to update cache: %w", err)
 	}
 
-	// Publish to Kafka for real-time updates
-	if err := s.kafkaProducer.PublishLocationUpdate(ctx, location); err != nil {
-		// Log error but don't fail the update
-		fmt.Printf("Failed to publish location update to Kafka: %v\n", err)
+	// Publish to Kafka for real-time updates. Broadcasts are throttled per
+	// emergency so chatty clients don't flood viewers; every point is still
+	// persisted above
+	if s.throttler == nil || s.throttler.Allow(update.EmergencyID, update.Severity) {
+		if err := s.kafkaProducer.PublishLocationUpdate(ctx, location); err != nil {
+			// Log error but don't fail the update
+			fmt.Printf("Failed to publish location update to Kafka: %v\n", err)
+		}
 	}
 
 	// Async reverse geocoding if address is not provided
@@ -84,14 +167,25 @@ func (s *LocationService) BatchUpdateLocations(ctx context.Context, batch *model
 	// Validate all locations
 	for _, update := range batch.Locations {
 		if err := update.Validate(); err != nil {
+			s.recordRejected(&update, "validation")
 			return fmt.Errorf("invalid location in batch: %w", err)
 		}
 	}
+	for i := range batch.Locations {
+		s.recordAccepted(&batch.Locations[i])
+	}
 
-	// Convert to LocationPoints
+	// Convert to LocationPoints, scoring each for plausibility
 	locations := make([]models.LocationPoint, len(batch.Locations))
 	for i, update := range batch.Locations {
-		locations[i] = *update.ToLocationPoint()
+		point := update.ToLocationPoint()
+		if s.trustScorer != nil {
+			point.TrustScore, point.TrustFlags = s.trustScorer.Score(&update, point.Timestamp)
+		}
+		if s.privacyRedactor != nil {
+			s.privacyRedactor.Flag(ctx, point)
+		}
+		locations[i] = *point
 	}
 
 	// Batch insert into database
@@ -99,9 +193,15 @@ func (s *LocationService) BatchUpdateLocations(ctx context.Context, batch *model
 		return fmt.Errorf("failed to batch insert locations: %w", err)
 	}
 
-	// Update cache with most recent location
+	// Update cache with the most recent location by capture time; offline
+	// batches are not guaranteed to arrive in order
 	if len(locations) > 0 {
-		latest := &locations[len(locations)-1]
+		latest := &locations[0]
+		for i := range locations {
+			if locations[i].Timestamp.After(latest.Timestamp) {
+				latest = &locations[i]
+			}
+		}
 		if err := s.cache.SetCurrentLocation(batch.EmergencyID, latest); err != nil {
 			fmt.Printf("Failed to update cache after batch: %v\n", err)
 		}
@@ -115,6 +215,13 @@ func (s *LocationService) BatchUpdateLocations(ctx context.Context, batch *model
 	return nil
 }
 
+// GetCellAggregates returns per-geohash-cell point and emergency counts
+// over the given window
+func (s *LocationService) GetCellAggregates(ctx context.Context, precision int, window time.Duration, limit int) ([]models.CellAggregate, error) {
+	since := time.Now().Add(-window)
+	return s.repo.GetCellAggregates(ctx, precision, since, limit)
+}
+
 // GetCurrentLocation retrieves the current location for an emergency
 func (s *LocationService) GetCurrentLocation(ctx context.Context, emergencyID uuid.UUID) (*models.LocationPoint, error) {
 	// Try cache first
@@ -151,15 +258,70 @@ func (s *LocationService) GetLocationTrail(ctx context.Context, emergencyID uuid
 	return locations, nil
 }
 
-// GetLocationHistory retrieves full location history with pagination
+// GetTrailTile renders the trail as a Mapbox Vector Tile for one XYZ tile.
+// When includeRedacted is false, points inside privacy zones are left out
+// entirely (tiles carry exact geometry, so coarsening is not an option)
+func (s *LocationService) GetTrailTile(ctx context.Context, emergencyID uuid.UUID, z, x, y int, duration time.Duration, includeRedacted bool) ([]byte, error) {
+	tile, err := s.repo.GetTrailMVT(ctx, emergencyID, z, x, y, duration, includeRedacted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trail tile: %w", err)
+	}
+	return tile, nil
+}
+
+// GetLocationHistory retrieves full location history with pagination. An
+// old emergency whose hot rows were moved to the archive is served from the
+// exported trail instead, so callers never notice the archival
 func (s *LocationService) GetLocationHistory(ctx context.Context, emergencyID uuid.UUID, limit, offset int) ([]models.LocationPoint, int, error) {
 	locations, total, err := s.repo.GetLocationHistory(ctx, emergencyID, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get location history: %w", err)
 	}
+
+	if total == 0 && s.trailArchiver != nil {
+		return s.archivedHistory(ctx, emergencyID, limit, offset)
+	}
+
 	return locations, total, nil
 }
 
+// archivedHistory pages through an archived trail the same way the database
+// query does: newest first
+func (s *LocationService) archivedHistory(ctx context.Context, emergencyID uuid.UUID, limit, offset int) ([]models.LocationPoint, int, error) {
+	points, err := s.trailArchiver.GetArchivedTrail(ctx, emergencyID)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get archived history: %w", err)
+	}
+
+	// The archive stores the trail oldest-first; reverse for history order
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+
+	total := len(points)
+	if offset >= total {
+		return nil, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return points[offset:end], total, nil
+}
+
+// GetTrailStats computes aggregate statistics for an emergency's trail
+func (s *LocationService) GetTrailStats(ctx context.Context, emergencyID uuid.UUID) (*models.TrailStats, error) {
+	stats, err := s.repo.GetTrailStats(ctx, emergencyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trail stats: %w", err)
+	}
+	return stats, nil
+}
+
 // SelectBestLocation chooses the best location from multiple providers
 func (s *LocationService) SelectBestLocation(updates []models.LocationUpdate) *models.LocationUpdate {
 	if len(updates) == 0 {
@@ -204,18 +366,69 @@ func (s *LocationService) StartTracking(ctx context.Context, emergencyID uuid.UU
 
 // StopTracking ends tracking for an emergency
 func (s *LocationService) StopTracking(ctx context.Context, emergencyID uuid.UUID) error {
-	// Set cache expiration to 30 minutes from now
+	// Shorten the cached location TTL so it ages out quickly
 	location, err := s.cache.GetCurrentLocation(emergencyID)
 	if err != nil {
 		return fmt.Errorf("failed to get current location: %w", err)
 	}
 
 	if location != nil {
-		if err := s.cache.SetWithTTL(emergencyID, location, 30*time.Minute); err != nil {
+		if err := s.cache.SetWithTTL(emergencyID, location, stoppedTrackingTTL); err != nil {
 			return fmt.Errorf("failed to set expiration: %w", err)
 		}
 	}
 
+	// Remove from the geo index so the emergency no longer shows up in
+	// nearby searches
+	if err := s.cache.RemoveFromGeoIndex(emergencyID); err != nil {
+		fmt.Printf("Failed to remove emergency %s from geo index: %v\n", emergencyID, err)
+	}
+
 	fmt.Printf("Stopped tracking for emergency: %s\n", emergencyID)
 	return nil
 }
+
+// EndTracking stops tracking and marks the session closed in the database.
+// Called when an emergency is resolved or cancelled
+func (s *LocationService) EndTracking(ctx context.Context, emergencyID uuid.UUID, reason string) error {
+	if err := s.StopTracking(ctx, emergencyID); err != nil {
+		return err
+	}
+
+	s.openSessionsLock.Lock()
+	delete(s.openSessions, emergencyID)
+	s.openSessionsLock.Unlock()
+
+	if s.throttler != nil {
+		s.throttler.Forget(emergencyID)
+	}
+
+	if s.trustScorer != nil {
+		s.trustScorer.Forget(emergencyID)
+	}
+
+	if err := s.sessionRepo.CloseSession(ctx, emergencyID, reason); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureSessionOpen opens a tracking session on the first update for an
+// emergency; subsequent updates are a cheap in-memory check
+func (s *LocationService) ensureSessionOpen(ctx context.Context, emergencyID, userID uuid.UUID) {
+	s.openSessionsLock.Lock()
+	alreadyOpen := s.openSessions[emergencyID]
+	if !alreadyOpen {
+		s.openSessions[emergencyID] = true
+	}
+	s.openSessionsLock.Unlock()
+
+	if alreadyOpen {
+		return
+	}
+
+	if err := s.sessionRepo.OpenSession(ctx, emergencyID, userID); err != nil {
+		fmt.Printf("Failed to open tracking session for emergency %s: %v\n", emergencyID, err)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+	"github.com/sos-app/location-service/internal/repository"
+)
+
+// privacyZoneCacheTTL bounds how stale the per-user zone cache can get; a
+// minute keeps ingest off the database without making zone edits feel laggy
+const privacyZoneCacheTTL = time.Minute
+
+// cachedZones is one user's zone list with its fetch time
+type cachedZones struct {
+	zones     []models.PrivacyZone
+	fetchedAt time.Time
+}
+
+// PrivacyRedactor flags location points that fall inside a user's privacy
+// zones at ingest time. Flagged points are stored with full precision but
+// served coarsened to contacts; dispatch connectors keep the original
+type PrivacyRedactor struct {
+	zoneRepo *repository.PrivacyZoneRepository
+
+	mutex sync.Mutex
+	cache map[uuid.UUID]cachedZones
+}
+
+// NewPrivacyRedactor creates a new privacy redactor
+func NewPrivacyRedactor(zoneRepo *repository.PrivacyZoneRepository) *PrivacyRedactor {
+	return &PrivacyRedactor{
+		zoneRepo: zoneRepo,
+		cache:    make(map[uuid.UUID]cachedZones),
+	}
+}
+
+// Flag marks the point as redacted when it falls inside one of the user's
+// zones. Zone lookups are best effort: a failed fetch never blocks ingest,
+// the point simply stays unredacted
+func (r *PrivacyRedactor) Flag(ctx context.Context, point *models.LocationPoint) {
+	zones, err := r.zonesFor(ctx, point.UserID)
+	if err != nil {
+		log.Printf("Failed to load privacy zones for user %s: %v", point.UserID, err)
+		return
+	}
+
+	for i := range zones {
+		if pointInZone(&zones[i], point.Latitude, point.Longitude) {
+			point.Redacted = true
+			return
+		}
+	}
+}
+
+// Invalidate drops a user's cached zones after a zone is created or deleted
+func (r *PrivacyRedactor) Invalidate(userID uuid.UUID) {
+	r.mutex.Lock()
+	delete(r.cache, userID)
+	r.mutex.Unlock()
+}
+
+// zonesFor returns the user's zones, refreshing the cache entry when stale
+func (r *PrivacyRedactor) zonesFor(ctx context.Context, userID uuid.UUID) ([]models.PrivacyZone, error) {
+	r.mutex.Lock()
+	cached, ok := r.cache[userID]
+	r.mutex.Unlock()
+
+	if ok && time.Since(cached.fetchedAt) < privacyZoneCacheTTL {
+		return cached.zones, nil
+	}
+
+	zones, err := r.zoneRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	r.cache[userID] = cachedZones{zones: zones, fetchedAt: time.Now()}
+	r.mutex.Unlock()
+
+	return zones, nil
+}
+
+// pointInZone reports whether the coordinates fall inside the zone's radius
+func pointInZone(zone *models.PrivacyZone, lat, lng float64) bool {
+	return haversineMeters(zone.CenterLatitude, zone.CenterLongitude, lat, lng) <= zone.RadiusMeters
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func TestPointInZone(t *testing.T) {
+	// 200m zone around a San Francisco address
+	zone := &models.PrivacyZone{
+		CenterLatitude:  37.7749,
+		CenterLongitude: -122.4194,
+		RadiusMeters:    200,
+	}
+
+	if !pointInZone(zone, 37.7749, -122.4194) {
+		t.Error("zone center should be inside the zone")
+	}
+	// ~110m north of center
+	if !pointInZone(zone, 37.7759, -122.4194) {
+		t.Error("point 110m from center should be inside a 200m zone")
+	}
+	// ~1.1km north of center
+	if pointInZone(zone, 37.7849, -122.4194) {
+		t.Error("point 1.1km from center should be outside a 200m zone")
+	}
+}
+
+func TestCoarsened(t *testing.T) {
+	accuracy := 5.0
+	speed := 1.4
+	address := "123 Main St"
+	point := models.LocationPoint{
+		Latitude:  37.774912,
+		Longitude: -122.419456,
+		Accuracy:  &accuracy,
+		Speed:     &speed,
+		Address:   &address,
+		Geohash:   "9q8yyk8y",
+		Redacted:  true,
+	}
+
+	coarse := point.Coarsened()
+
+	if coarse.Latitude != 37.77 || coarse.Longitude != -122.42 {
+		t.Errorf("expected coordinates rounded to two decimals, got %f, %f",
+			coarse.Latitude, coarse.Longitude)
+	}
+	if coarse.Accuracy != nil || coarse.Speed != nil || coarse.Address != nil {
+		t.Error("address-revealing fields should be stripped")
+	}
+	if coarse.Geohash != "9q8y" {
+		t.Errorf("expected geohash truncated to 4 characters, got %q", coarse.Geohash)
+	}
+	if !coarse.Redacted {
+		t.Error("coarsened point should keep its redacted flag")
+	}
+
+	// The original point is untouched (dispatch connectors still need it)
+	if point.Latitude != 37.774912 || point.Accuracy == nil {
+		t.Error("coarsening must not mutate the original point")
+	}
+}
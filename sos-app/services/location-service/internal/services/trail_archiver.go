@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/archive"
+	"github.com/sos-app/location-service/internal/models"
+	"github.com/sos-app/location-service/internal/repository"
+)
+
+// archiveBatchSize caps how many sessions one sweep exports so a large
+// backlog is worked off gradually instead of in one long transaction burst
+const archiveBatchSize = 50
+
+// TrailArchiver moves the trails of long-ended emergencies out of the hot
+// location_points hypertable: once the retention window has passed after
+// tracking closed, the full trail is exported as GeoJSON to object storage
+// and the hot rows deleted, keeping the hypertable small. Archived trails
+// are served back transparently through GetArchivedTrail when history is
+// requested for an old emergency
+type TrailArchiver struct {
+	store        archive.Store
+	locationRepo *repository.LocationRepository
+	sessionRepo  *repository.TrackingSessionRepository
+	retention    time.Duration
+	interval     time.Duration
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewTrailArchiver creates an archiver that exports trails of sessions
+// closed longer than retention ago, sweeping every interval
+func NewTrailArchiver(
+	store archive.Store,
+	locationRepo *repository.LocationRepository,
+	sessionRepo *repository.TrackingSessionRepository,
+	retention time.Duration,
+	interval time.Duration,
+) *TrailArchiver {
+	return &TrailArchiver{
+		store:        store,
+		locationRepo: locationRepo,
+		sessionRepo:  sessionRepo,
+		retention:    retention,
+		interval:     interval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins the background archive sweep
+func (a *TrailArchiver) Start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				a.sweep(context.Background())
+			case <-a.stopChan:
+				return
+			}
+		}
+	}()
+
+	log.Printf("Trail archiver started (retention %s, sweep every %s)", a.retention, a.interval)
+}
+
+// Stop halts the archive sweep. An export already in flight finishes first
+func (a *TrailArchiver) Stop() {
+	close(a.stopChan)
+	a.wg.Wait()
+}
+
+// sweep exports one batch of archivable sessions
+func (a *TrailArchiver) sweep(ctx context.Context) {
+	cutoff := time.Now().Add(-a.retention)
+	sessions, err := a.sessionRepo.ListArchivable(ctx, cutoff, archiveBatchSize)
+	if err != nil {
+		log.Printf("Failed to list archivable sessions: %v", err)
+		return
+	}
+
+	for _, session := range sessions {
+		if err := a.archiveOne(ctx, session.EmergencyID); err != nil {
+			// Leave the session unarchived; the next sweep retries it
+			log.Printf("Failed to archive trail for emergency %s: %v", session.EmergencyID, err)
+		}
+	}
+}
+
+// archiveOne exports a single emergency's trail and deletes its hot rows.
+// The export is written before anything is deleted, so a failure between the
+// two at worst leaves the trail in both places until the next sweep
+func (a *TrailArchiver) archiveOne(ctx context.Context, emergencyID uuid.UUID) error {
+	points, err := a.locationRepo.GetAllLocations(ctx, emergencyID)
+	if err != nil {
+		return err
+	}
+
+	// Sessions without points (tracking opened but nothing reported) are
+	// just marked done; there is nothing to export
+	if len(points) > 0 {
+		body, err := archive.EncodeTrail(points)
+		if err != nil {
+			return err
+		}
+		if err := a.store.Put(ctx, archive.TrailKey(emergencyID), body); err != nil {
+			return err
+		}
+		if _, err := a.locationRepo.DeleteEmergencyData(ctx, emergencyID); err != nil {
+			return err
+		}
+	}
+
+	if err := a.sessionRepo.MarkArchived(ctx, emergencyID); err != nil {
+		return err
+	}
+
+	log.Printf("Archived trail for emergency %s (%d points)", emergencyID, len(points))
+	return nil
+}
+
+// GetArchivedTrail fetches an emergency's exported trail in chronological
+// order. Returns archive.ErrNotFound when the emergency was never archived
+func (a *TrailArchiver) GetArchivedTrail(ctx context.Context, emergencyID uuid.UUID) ([]models.LocationPoint, error) {
+	body, err := a.store.Get(ctx, archive.TrailKey(emergencyID))
+	if err != nil {
+		return nil, err
+	}
+
+	return archive.DecodeTrail(body)
+}
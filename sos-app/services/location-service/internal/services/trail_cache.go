@@ -0,0 +1,110 @@
+package services
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTrailCacheTTL is how long a cached trail response stays fresh. Trail
+// queries for a popular incident arrive every few seconds from many viewers;
+// a few seconds of staleness is invisible next to the broadcast interval
+const DefaultTrailCacheTTL = 5 * time.Second
+
+// CachedTrailResponse is one cached trail response body with its validators
+type CachedTrailResponse struct {
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
+// TrailCache is an in-process TTL cache for trail responses keyed by
+// (emergency, duration, dispatch scope), so concurrent viewers of the same
+// incident share one database query per TTL window. ETags let clients that
+// already hold the body revalidate with a 304 instead of a full response
+type TrailCache struct {
+	ttl time.Duration
+
+	mutex      sync.Mutex
+	entries    map[string]*trailCacheEntry
+	lastPruned time.Time
+}
+
+// trailCacheEntry is a cached response and the time it was stored
+type trailCacheEntry struct {
+	response CachedTrailResponse
+	storedAt time.Time
+}
+
+// NewTrailCache creates a trail cache; a non-positive ttl uses the default
+func NewTrailCache(ttl time.Duration) *TrailCache {
+	if ttl <= 0 {
+		ttl = DefaultTrailCacheTTL
+	}
+
+	return &TrailCache{
+		ttl:        ttl,
+		entries:    make(map[string]*trailCacheEntry),
+		lastPruned: time.Now(),
+	}
+}
+
+// TrailCacheKey builds the cache key for a trail request. Dispatch scope is
+// part of the key because redacted points render differently with it
+func TrailCacheKey(emergencyID uuid.UUID, duration time.Duration, dispatchScope bool) string {
+	return fmt.Sprintf("%s:%s:%t", emergencyID, duration, dispatchScope)
+}
+
+// Get returns the cached response for the key if it is still fresh
+func (c *TrailCache) Get(key string) (CachedTrailResponse, bool) {
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || now.Sub(entry.storedAt) >= c.ttl {
+		return CachedTrailResponse{}, false
+	}
+
+	return entry.response, true
+}
+
+// Put caches a response body, computing its ETag from the body contents so
+// identical responses revalidate across TTL windows
+func (c *TrailCache) Put(key string, body []byte, lastModified time.Time) CachedTrailResponse {
+	sum := sha256.Sum256(body)
+	response := CachedTrailResponse{
+		Body:         body,
+		ETag:         fmt.Sprintf("\"%x\"", sum[:16]),
+		LastModified: lastModified,
+	}
+
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pruneLocked(now)
+	c.entries[key] = &trailCacheEntry{response: response, storedAt: now}
+
+	return response
+}
+
+// pruneLocked drops stale entries so long-running processes don't accumulate
+// responses for emergencies nobody is watching anymore
+func (c *TrailCache) pruneLocked(now time.Time) {
+	if now.Sub(c.lastPruned) < time.Minute {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if now.Sub(entry.storedAt) >= c.ttl {
+			delete(c.entries, key)
+		}
+	}
+	c.lastPruned = now
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTrailCache_PutAndGet(t *testing.T) {
+	cache := NewTrailCache(time.Minute)
+	key := TrailCacheKey(uuid.New(), 30*time.Minute, false)
+	lastModified := time.Now().Add(-10 * time.Second)
+
+	stored := cache.Put(key, []byte(`{"count":3}`), lastModified)
+	if stored.ETag == "" {
+		t.Fatal("stored response should carry an ETag")
+	}
+
+	cached, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("fresh entry should be returned")
+	}
+	if string(cached.Body) != `{"count":3}` {
+		t.Errorf("unexpected cached body %q", cached.Body)
+	}
+	if cached.ETag != stored.ETag {
+		t.Errorf("ETag changed between Put and Get: %q vs %q", stored.ETag, cached.ETag)
+	}
+	if !cached.LastModified.Equal(lastModified) {
+		t.Errorf("unexpected Last-Modified %s", cached.LastModified)
+	}
+}
+
+func TestTrailCache_ExpiresAfterTTL(t *testing.T) {
+	cache := NewTrailCache(time.Millisecond)
+	key := TrailCacheKey(uuid.New(), 30*time.Minute, false)
+	cache.Put(key, []byte(`{}`), time.Now())
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := cache.Get(key); ok {
+		t.Error("entry past its TTL should not be returned")
+	}
+}
+
+func TestTrailCache_ETagStableForIdenticalBody(t *testing.T) {
+	cache := NewTrailCache(time.Minute)
+	key := TrailCacheKey(uuid.New(), time.Hour, false)
+
+	first := cache.Put(key, []byte(`{"count":1}`), time.Now())
+	second := cache.Put(key, []byte(`{"count":1}`), time.Now())
+	if first.ETag != second.ETag {
+		t.Errorf("identical bodies should share an ETag: %q vs %q", first.ETag, second.ETag)
+	}
+
+	changed := cache.Put(key, []byte(`{"count":2}`), time.Now())
+	if changed.ETag == first.ETag {
+		t.Error("different bodies should not share an ETag")
+	}
+}
+
+func TestTrailCacheKey_SeparatesDispatchScope(t *testing.T) {
+	emergencyID := uuid.New()
+	if TrailCacheKey(emergencyID, time.Hour, false) == TrailCacheKey(emergencyID, time.Hour, true) {
+		t.Error("dispatch scope must produce a distinct cache key")
+	}
+	if TrailCacheKey(emergencyID, time.Hour, false) == TrailCacheKey(emergencyID, 30*time.Minute, false) {
+		t.Error("duration must produce a distinct cache key")
+	}
+}
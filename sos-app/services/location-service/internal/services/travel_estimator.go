@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+)
+
+// RoutingProvider computes road travel time between two coordinates.
+// Implementations wrap an external routing API; when none is configured the
+// estimator falls back to a straight-line estimate
+type RoutingProvider interface {
+	TravelSeconds(ctx context.Context, fromLat, fromLng, toLat, toLng float64) (int, error)
+}
+
+// TravelEstimator estimates how long a responder needs to reach a point.
+// Without a routing provider it divides the great-circle distance by an
+// assumed average speed; real road routing can be plugged in later without
+// touching callers
+type TravelEstimator struct {
+	provider    RoutingProvider
+	avgSpeedMps float64
+}
+
+// NewTravelEstimator creates an estimator assuming the given average speed
+// in km/h for the straight-line fallback
+func NewTravelEstimator(avgSpeedKmh float64) *TravelEstimator {
+	if avgSpeedKmh <= 0 {
+		avgSpeedKmh = 20
+	}
+	return &TravelEstimator{
+		avgSpeedMps: avgSpeedKmh / 3.6,
+	}
+}
+
+// SetRoutingProvider plugs in a routing API; nil reverts to the
+// straight-line fallback
+func (e *TravelEstimator) SetRoutingProvider(provider RoutingProvider) {
+	e.provider = provider
+}
+
+// EstimateSeconds returns the estimated travel time from one point to
+// another. distanceMeters is the precomputed great-circle distance when the
+// caller already has it (a negative value recomputes it); a routing provider
+// failure falls back to the straight-line estimate rather than failing the
+// query
+func (e *TravelEstimator) EstimateSeconds(ctx context.Context, fromLat, fromLng, toLat, toLng, distanceMeters float64) int {
+	if e.provider != nil {
+		seconds, err := e.provider.TravelSeconds(ctx, fromLat, fromLng, toLat, toLng)
+		if err == nil {
+			return seconds
+		}
+		log.Printf("Routing provider failed, falling back to straight-line estimate: %v", err)
+	}
+
+	if distanceMeters < 0 {
+		distanceMeters = haversineMeters(fromLat, fromLng, toLat, toLng)
+	}
+
+	return int(distanceMeters/e.avgSpeedMps + 0.5)
+}
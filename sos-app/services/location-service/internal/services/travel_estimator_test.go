@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTravelEstimator_StraightLineFallback(t *testing.T) {
+	// 36 km/h = 10 m/s, so 1500m should take 150s
+	estimator := NewTravelEstimator(36)
+
+	seconds := estimator.EstimateSeconds(context.Background(), 0, 0, 0, 0, 1500)
+	if seconds != 150 {
+		t.Errorf("expected 150s for 1500m at 36 km/h, got %ds", seconds)
+	}
+}
+
+func TestTravelEstimator_RecomputesDistanceWhenNegative(t *testing.T) {
+	estimator := NewTravelEstimator(36)
+
+	// Roughly 111 km along a meridian; at 10 m/s that is ~11100s
+	seconds := estimator.EstimateSeconds(context.Background(), 0, 0, 1, 0, -1)
+	if seconds < 11000 || seconds > 11300 {
+		t.Errorf("expected ~11100s for one degree of latitude, got %ds", seconds)
+	}
+}
+
+type stubRoutingProvider struct {
+	seconds int
+	err     error
+}
+
+func (p *stubRoutingProvider) TravelSeconds(ctx context.Context, fromLat, fromLng, toLat, toLng float64) (int, error) {
+	return p.seconds, p.err
+}
+
+func TestTravelEstimator_PrefersRoutingProvider(t *testing.T) {
+	estimator := NewTravelEstimator(36)
+	estimator.SetRoutingProvider(&stubRoutingProvider{seconds: 42})
+
+	if seconds := estimator.EstimateSeconds(context.Background(), 0, 0, 0, 0, 1500); seconds != 42 {
+		t.Errorf("expected the routing provider's 42s, got %ds", seconds)
+	}
+}
+
+func TestTravelEstimator_FallsBackOnProviderError(t *testing.T) {
+	estimator := NewTravelEstimator(36)
+	estimator.SetRoutingProvider(&stubRoutingProvider{err: errors.New("routing API down")})
+
+	if seconds := estimator.EstimateSeconds(context.Background(), 0, 0, 0, 0, 1500); seconds != 150 {
+		t.Errorf("expected the 150s straight-line fallback, got %ds", seconds)
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// Trust heuristics and their score deductions
+const (
+	// TrustFlagImpossibleVelocity marks movement faster than any plausible
+	// ground or air travel between consecutive points
+	TrustFlagImpossibleVelocity = "impossible_velocity"
+	// TrustFlagMockProvider marks updates the mobile OS reported as coming
+	// from a mock/developer location provider
+	TrustFlagMockProvider = "mock_provider"
+	// TrustFlagRepeatedCoordinates marks long runs of bit-identical
+	// coordinates, typical of replayed or hardcoded positions
+	TrustFlagRepeatedCoordinates = "repeated_coordinates"
+
+	// maxPlausibleSpeedMps is generous enough for air travel (~540 km/h)
+	maxPlausibleSpeedMps = 150.0
+	// repeatedCoordinateRuns of this length start looking synthetic; real
+	// GPS fixes jitter even when stationary
+	repeatedCoordinateThreshold = 5
+
+	impossibleVelocityPenalty = 0.5
+	mockProviderPenalty       = 0.6
+	repeatedCoordinatePenalty = 0.3
+
+	// LowTrustThreshold is the score below which consumers should warn
+	LowTrustThreshold = 0.5
+
+	trustStateMaxAge        = 10 * time.Minute
+	trustStatePruneInterval = 1 * time.Minute
+)
+
+// trustState is the per-emergency history the heuristics compare against
+type trustState struct {
+	lastLatitude  float64
+	lastLongitude float64
+	lastTimestamp time.Time
+	repeatCount   int
+	lastSeen      time.Time
+}
+
+// TrustScorer scores location updates for plausibility, flagging likely
+// spoofed positions
+type TrustScorer struct {
+	mu         sync.Mutex
+	states     map[uuid.UUID]*trustState
+	lastPruned time.Time
+}
+
+// NewTrustScorer creates a new trust scorer
+func NewTrustScorer() *TrustScorer {
+	return &TrustScorer{
+		states:     make(map[uuid.UUID]*trustState),
+		lastPruned: time.Now(),
+	}
+}
+
+// Score evaluates one update against the emergency's recent history and
+// returns a trust score in [0,1] plus the heuristics that fired
+func (t *TrustScorer) Score(update *models.LocationUpdate, timestamp time.Time) (float64, []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pruneLocked(time.Now())
+
+	score := 1.0
+	var flags []string
+
+	if update.MockProvider {
+		score -= mockProviderPenalty
+		flags = append(flags, TrustFlagMockProvider)
+	}
+
+	state, seen := t.states[update.EmergencyID]
+	if !seen {
+		state = &trustState{}
+		t.states[update.EmergencyID] = state
+	} else {
+		elapsed := timestamp.Sub(state.lastTimestamp).Seconds()
+		if elapsed > 0 {
+			distance := haversineMeters(state.lastLatitude, state.lastLongitude, update.Latitude, update.Longitude)
+			if distance/elapsed > maxPlausibleSpeedMps {
+				score -= impossibleVelocityPenalty
+				flags = append(flags, TrustFlagImpossibleVelocity)
+			}
+		}
+
+		if update.Latitude == state.lastLatitude && update.Longitude == state.lastLongitude {
+			state.repeatCount++
+			if state.repeatCount >= repeatedCoordinateThreshold {
+				score -= repeatedCoordinatePenalty
+				flags = append(flags, TrustFlagRepeatedCoordinates)
+			}
+		} else {
+			state.repeatCount = 0
+		}
+	}
+
+	state.lastLatitude = update.Latitude
+	state.lastLongitude = update.Longitude
+	state.lastTimestamp = timestamp
+	state.lastSeen = time.Now()
+
+	if score < 0 {
+		score = 0
+	}
+
+	return score, flags
+}
+
+// Forget drops the tracked history for an emergency once tracking ends
+func (t *TrustScorer) Forget(emergencyID uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.states, emergencyID)
+}
+
+// pruneLocked evicts state for emergencies that stopped sending updates.
+// Caller must hold the mutex
+func (t *TrustScorer) pruneLocked(now time.Time) {
+	if now.Sub(t.lastPruned) < trustStatePruneInterval {
+		return
+	}
+	t.lastPruned = now
+
+	for id, state := range t.states {
+		if now.Sub(state.lastSeen) > trustStateMaxAge {
+			delete(t.states, id)
+		}
+	}
+}
+
+// haversineMeters returns the great-circle distance between two coordinates
+func haversineMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusMeters = 6371000.0
+
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func TestTrustScorer_PlausibleMovementKeepsFullScore(t *testing.T) {
+	scorer := NewTrustScorer()
+	emergencyID := uuid.New()
+	now := time.Now()
+
+	score, flags := scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+	}, now)
+	if score != 1.0 || len(flags) != 0 {
+		t.Fatalf("first point should score 1.0 with no flags, got %.2f %v", score, flags)
+	}
+
+	// ~100m in 10 seconds
+	score, flags = scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7758,
+		Longitude:   -122.4194,
+	}, now.Add(10*time.Second))
+	if score != 1.0 || len(flags) != 0 {
+		t.Errorf("plausible movement should score 1.0 with no flags, got %.2f %v", score, flags)
+	}
+}
+
+func TestTrustScorer_FlagsImpossibleVelocity(t *testing.T) {
+	scorer := NewTrustScorer()
+	emergencyID := uuid.New()
+	now := time.Now()
+
+	scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+	}, now)
+
+	// San Francisco to New York in one second
+	score, flags := scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    40.7128,
+		Longitude:   -74.0060,
+	}, now.Add(1*time.Second))
+
+	if score >= 1.0 {
+		t.Errorf("impossible velocity should deduct from score, got %.2f", score)
+	}
+	if len(flags) != 1 || flags[0] != TrustFlagImpossibleVelocity {
+		t.Errorf("expected [%s], got %v", TrustFlagImpossibleVelocity, flags)
+	}
+}
+
+func TestTrustScorer_FlagsMockProvider(t *testing.T) {
+	scorer := NewTrustScorer()
+
+	score, flags := scorer.Score(&models.LocationUpdate{
+		EmergencyID:  uuid.New(),
+		Latitude:     37.7749,
+		Longitude:    -122.4194,
+		MockProvider: true,
+	}, time.Now())
+
+	if score >= LowTrustThreshold {
+		t.Errorf("mock provider should drop score below %.2f, got %.2f", LowTrustThreshold, score)
+	}
+	if len(flags) != 1 || flags[0] != TrustFlagMockProvider {
+		t.Errorf("expected [%s], got %v", TrustFlagMockProvider, flags)
+	}
+}
+
+func TestTrustScorer_FlagsRepeatedCoordinates(t *testing.T) {
+	scorer := NewTrustScorer()
+	emergencyID := uuid.New()
+	now := time.Now()
+
+	update := &models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+	}
+
+	var score float64
+	var flags []string
+	for i := 0; i <= repeatedCoordinateThreshold; i++ {
+		score, flags = scorer.Score(update, now.Add(time.Duration(i)*5*time.Second))
+	}
+
+	if score >= 1.0 {
+		t.Errorf("repeated coordinates should deduct from score, got %.2f", score)
+	}
+	if len(flags) != 1 || flags[0] != TrustFlagRepeatedCoordinates {
+		t.Errorf("expected [%s], got %v", TrustFlagRepeatedCoordinates, flags)
+	}
+}
+
+func TestTrustScorer_MovementResetsRepeatCount(t *testing.T) {
+	scorer := NewTrustScorer()
+	emergencyID := uuid.New()
+	now := time.Now()
+
+	repeated := &models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+	}
+	for i := 0; i < repeatedCoordinateThreshold-1; i++ {
+		scorer.Score(repeated, now.Add(time.Duration(i)*5*time.Second))
+	}
+
+	// A genuine GPS fix jitters; the repeat run should start over
+	scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7750,
+		Longitude:   -122.4195,
+	}, now.Add(time.Duration(repeatedCoordinateThreshold)*5*time.Second))
+
+	score, flags := scorer.Score(repeated, now.Add(time.Duration(repeatedCoordinateThreshold+1)*5*time.Second))
+	if score != 1.0 || len(flags) != 0 {
+		t.Errorf("repeat count should reset after movement, got %.2f %v", score, flags)
+	}
+}
+
+func TestTrustScorer_ForgetDropsHistory(t *testing.T) {
+	scorer := NewTrustScorer()
+	emergencyID := uuid.New()
+	now := time.Now()
+
+	scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    37.7749,
+		Longitude:   -122.4194,
+	}, now)
+	scorer.Forget(emergencyID)
+
+	// With no history the teleport cannot be detected
+	score, flags := scorer.Score(&models.LocationUpdate{
+		EmergencyID: emergencyID,
+		Latitude:    40.7128,
+		Longitude:   -74.0060,
+	}, now.Add(1*time.Second))
+	if score != 1.0 || len(flags) != 0 {
+		t.Errorf("forgotten emergency should score fresh, got %.2f %v", score, flags)
+	}
+}
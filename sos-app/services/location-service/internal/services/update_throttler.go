@@ -0,0 +1,121 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBroadcastInterval is the minimum spacing between broadcast updates
+// for an emergency when no severity override applies
+const DefaultBroadcastInterval = 3 * time.Second
+
+// UpdateThrottler limits how often location updates for an emergency are
+// broadcast to Kafka and WebSocket viewers. All updates are still persisted;
+// only the fan-out is rate limited. Intervals are configurable per severity
+// so CRITICAL emergencies can stream at full rate.
+type UpdateThrottler struct {
+	defaultInterval time.Duration
+	intervals       map[string]time.Duration
+
+	mutex         sync.Mutex
+	lastBroadcast map[uuid.UUID]time.Time
+	lastPruned    time.Time
+}
+
+// NewUpdateThrottler creates a throttler. intervals maps upper-case severity
+// levels (e.g. "CRITICAL") to their minimum broadcast spacing; a zero
+// interval disables throttling for that severity
+func NewUpdateThrottler(defaultInterval time.Duration, intervals map[string]time.Duration) *UpdateThrottler {
+	if defaultInterval < 0 {
+		defaultInterval = DefaultBroadcastInterval
+	}
+
+	return &UpdateThrottler{
+		defaultInterval: defaultInterval,
+		intervals:       intervals,
+		lastBroadcast:   make(map[uuid.UUID]time.Time),
+		lastPruned:      time.Now(),
+	}
+}
+
+// ParseBroadcastIntervals parses per-severity interval overrides from a
+// comma-separated list like "CRITICAL=0s,HIGH=1s"
+func ParseBroadcastIntervals(raw string) map[string]time.Duration {
+	if raw == "" {
+		return nil
+	}
+
+	intervals := make(map[string]time.Duration)
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil || d < 0 {
+			continue
+		}
+		intervals[strings.ToUpper(parts[0])] = d
+	}
+
+	return intervals
+}
+
+// Allow reports whether an update for the emergency should be broadcast now,
+// recording the broadcast time when it is
+func (t *UpdateThrottler) Allow(emergencyID uuid.UUID, severity string) bool {
+	interval := t.intervalFor(severity)
+	if interval <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pruneLocked(now)
+
+	if last, ok := t.lastBroadcast[emergencyID]; ok && now.Sub(last) < interval {
+		return false
+	}
+
+	t.lastBroadcast[emergencyID] = now
+	return true
+}
+
+// Forget drops throttle state for an emergency, called when tracking ends
+func (t *UpdateThrottler) Forget(emergencyID uuid.UUID) {
+	t.mutex.Lock()
+	delete(t.lastBroadcast, emergencyID)
+	t.mutex.Unlock()
+}
+
+// intervalFor returns the broadcast interval for a severity level
+func (t *UpdateThrottler) intervalFor(severity string) time.Duration {
+	if severity != "" {
+		if interval, ok := t.intervals[strings.ToUpper(severity)]; ok {
+			return interval
+		}
+	}
+	return t.defaultInterval
+}
+
+// pruneLocked drops stale entries so long-running processes don't accumulate
+// state for emergencies that stopped sending updates
+func (t *UpdateThrottler) pruneLocked(now time.Time) {
+	if now.Sub(t.lastPruned) < time.Minute {
+		return
+	}
+
+	maxAge := 10 * time.Minute
+	for id, last := range t.lastBroadcast {
+		if now.Sub(last) > maxAge {
+			delete(t.lastBroadcast, id)
+		}
+	}
+	t.lastPruned = now
+}
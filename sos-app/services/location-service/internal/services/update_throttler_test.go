@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateThrottler_ThrottlesRepeatedUpdates(t *testing.T) {
+	throttler := NewUpdateThrottler(3*time.Second, nil)
+	emergencyID := uuid.New()
+
+	if !throttler.Allow(emergencyID, "") {
+		t.Fatal("first update should be allowed")
+	}
+	if throttler.Allow(emergencyID, "") {
+		t.Error("second update within interval should be throttled")
+	}
+}
+
+func TestUpdateThrottler_SeverityOverride(t *testing.T) {
+	intervals := ParseBroadcastIntervals("CRITICAL=0s,HIGH=1s")
+	throttler := NewUpdateThrottler(3*time.Second, intervals)
+	emergencyID := uuid.New()
+
+	// CRITICAL streams at full rate
+	for i := 0; i < 5; i++ {
+		if !throttler.Allow(emergencyID, "CRITICAL") {
+			t.Fatalf("CRITICAL update %d should not be throttled", i)
+		}
+	}
+}
+
+func TestUpdateThrottler_IndependentPerEmergency(t *testing.T) {
+	throttler := NewUpdateThrottler(3*time.Second, nil)
+
+	first := uuid.New()
+	second := uuid.New()
+
+	if !throttler.Allow(first, "") {
+		t.Fatal("first emergency should be allowed")
+	}
+	if !throttler.Allow(second, "") {
+		t.Error("throttling one emergency should not affect another")
+	}
+}
+
+func TestUpdateThrottler_ForgetResetsState(t *testing.T) {
+	throttler := NewUpdateThrottler(3*time.Second, nil)
+	emergencyID := uuid.New()
+
+	throttler.Allow(emergencyID, "")
+	throttler.Forget(emergencyID)
+
+	if !throttler.Allow(emergencyID, "") {
+		t.Error("update after Forget should be allowed")
+	}
+}
+
+func TestParseBroadcastIntervals(t *testing.T) {
+	intervals := ParseBroadcastIntervals("CRITICAL=0s, high=1s,bogus,BAD=nope")
+
+	if len(intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d: %v", len(intervals), intervals)
+	}
+	if intervals["CRITICAL"] != 0 {
+		t.Errorf("expected CRITICAL=0s, got %v", intervals["CRITICAL"])
+	}
+	if intervals["HIGH"] != time.Second {
+		t.Errorf("expected HIGH=1s, got %v", intervals["HIGH"])
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +21,81 @@ type Client struct {
 	EmergencyID  uuid.UUID
 	SendChan     chan []byte
 	DisconnectCh chan bool
+	// ConnectedAt is set by the connection handler when the client is built
+	ConnectedAt time.Time
+	// CloseConn closes the underlying connection; set by the handler so an
+	// admin force-disconnect tears the socket down immediately instead of
+	// waiting for a read deadline
+	CloseConn func()
+
+	filtersMutex sync.Mutex
+	filters      *models.SubscriptionFilters
+	lastSentAt   time.Time
+	// shareKey is set for public share-link viewers: location payloads to
+	// this client are AES-GCM encrypted under a key derived from the link
+	// token, so an intercepted frame is useless without the link
+	shareKey []byte
+
+	sentCount    int64
+	droppedCount int64
+}
+
+// SetFilters installs the client's subscription filters; nil clears them
+func (c *Client) SetFilters(filters *models.SubscriptionFilters) {
+	c.filtersMutex.Lock()
+	defer c.filtersMutex.Unlock()
+	c.filters = filters
+	c.lastSentAt = time.Time{}
+}
+
+// prepare shapes one location update for this client per its filters,
+// reporting whether the update should be sent at all
+func (c *Client) prepare(message models.WebSocketMessage, defaultBytes []byte) ([]byte, bool) {
+	c.filtersMutex.Lock()
+	defer c.filtersMutex.Unlock()
+
+	payload, send := applyFilters(c.filters, message, defaultBytes, c.lastSentAt, time.Now())
+	if send {
+		c.lastSentAt = time.Now()
+	}
+	return payload, send
+}
+
+// SetShareToken derives and installs the per-share encryption key from a
+// public share link token; an empty token clears it
+func (c *Client) SetShareToken(token string) {
+	c.filtersMutex.Lock()
+	defer c.filtersMutex.Unlock()
+	if token == "" {
+		c.shareKey = nil
+		return
+	}
+	c.shareKey = deriveShareKey(token)
+}
+
+// SealLocationPayload encrypts one location payload for a share-link viewer.
+// Clients without a share key get the payload back unchanged
+func (c *Client) SealLocationPayload(emergencyID uuid.UUID, payload []byte) ([]byte, error) {
+	c.filtersMutex.Lock()
+	key := c.shareKey
+	c.filtersMutex.Unlock()
+
+	if key == nil {
+		return payload, nil
+	}
+
+	sealed, err := sealSharePayload(key, payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encodeEncryptedMessage(emergencyID, sealed))
+}
+
+// hasFilters reports whether the client has subscription filters installed
+func (c *Client) hasFilters() bool {
+	c.filtersMutex.Lock()
+	defer c.filtersMutex.Unlock()
+	return c.filters != nil
 }
 
 // BroadcastService manages WebSocket connections and broadcasts
@@ -27,10 +104,16 @@ type BroadcastService struct {
 	clientsMutex sync.RWMutex
 	rooms        map[uuid.UUID]map[string]*Client
 	roomsMutex   sync.RWMutex
-	redisClient  *redis.Client
-	pubsub       *redis.PubSub
-	ctx          context.Context
-	stopChan     chan bool
+	roomTraffic  map[uuid.UUID]*trafficCounter
+	statsMutex   sync.Mutex
+	// Pending debounced presence announcements, keyed by emergency
+	presenceTimers   map[uuid.UUID]*time.Timer
+	presenceDebounce time.Duration
+	presenceMutex    sync.Mutex
+	redisClient      *redis.Client
+	pubsub           *redis.PubSub
+	ctx              context.Context
+	stopChan         chan bool
 }
 
 // NewBroadcastService creates a new broadcast service
@@ -51,12 +134,15 @@ func NewBroadcastService(redisURL string) *BroadcastService {
 	pubsub := client.Subscribe(ctx, "location-updated")
 
 	return &BroadcastService{
-		clients:     make(map[string]*Client),
-		rooms:       make(map[uuid.UUID]map[string]*Client),
-		redisClient: client,
-		pubsub:      pubsub,
-		ctx:         ctx,
-		stopChan:    make(chan bool),
+		clients:          make(map[string]*Client),
+		rooms:            make(map[uuid.UUID]map[string]*Client),
+		roomTraffic:      make(map[uuid.UUID]*trafficCounter),
+		presenceTimers:   make(map[uuid.UUID]*time.Timer),
+		presenceDebounce: defaultPresenceDebounce,
+		redisClient:      client,
+		pubsub:           pubsub,
+		ctx:              ctx,
+		stopChan:         make(chan bool),
 	}
 }
 
@@ -85,6 +171,14 @@ func (b *BroadcastService) Start() {
 // Stop stops the broadcast service
 func (b *BroadcastService) Stop() {
 	close(b.stopChan)
+
+	b.presenceMutex.Lock()
+	for emergencyID, timer := range b.presenceTimers {
+		timer.Stop()
+		delete(b.presenceTimers, emergencyID)
+	}
+	b.presenceMutex.Unlock()
+
 	b.pubsub.Close()
 	b.redisClient.Close()
 	log.Println("WebSocket broadcast service stopped")
@@ -120,20 +214,18 @@ func (b *BroadcastService) listenToRedis() {
 				continue
 			}
 
-			// Create WebSocket message
+			// Create WebSocket message, warning viewers about low-trust points
 			wsMessage := models.WebSocketMessage{
 				Type:        "location:update",
 				EmergencyID: emergencyID,
 				Location:    locationUpdate.Location,
 			}
-
-			messageBytes, err := json.Marshal(wsMessage)
-			if err != nil {
-				log.Printf("Failed to marshal WebSocket message: %v", err)
-				continue
+			if locationUpdate.Location != nil && len(locationUpdate.Location.TrustFlags) > 0 {
+				wsMessage.Message = fmt.Sprintf("Location may be spoofed (trust %.2f: %s)",
+					locationUpdate.Location.TrustScore, strings.Join(locationUpdate.Location.TrustFlags, ", "))
 			}
 
-			b.BroadcastToRoom(emergencyID, messageBytes)
+			b.BroadcastLocationToRoom(emergencyID, wsMessage)
 
 		case <-b.stopChan:
 			return
@@ -188,6 +280,8 @@ func (b *BroadcastService) JoinRoom(emergencyID uuid.UUID, clientID string) {
 	b.rooms[emergencyID][clientID] = client
 	client.EmergencyID = emergencyID
 	log.Printf("Client %s joined room for emergency %s", clientID, emergencyID)
+
+	b.schedulePresenceUpdate(emergencyID)
 }
 
 // LeaveRoom removes a client from an emergency room
@@ -202,6 +296,9 @@ func (b *BroadcastService) LeaveRoom(emergencyID uuid.UUID, clientID string) {
 		// Clean up empty rooms
 		if len(room) == 0 {
 			delete(b.rooms, emergencyID)
+			b.cancelPresenceUpdate(emergencyID)
+		} else {
+			b.schedulePresenceUpdate(emergencyID)
 		}
 	}
 }
@@ -218,15 +315,103 @@ func (b *BroadcastService) BroadcastToRoom(emergencyID uuid.UUID, message []byte
 
 	log.Printf("Broadcasting to %d clients in emergency %s", len(room), emergencyID)
 
+	var sent, dropped int
 	for _, client := range room {
 		select {
 		case client.SendChan <- message:
 			// Message sent successfully
+			atomic.AddInt64(&client.sentCount, 1)
+			sent++
 		default:
 			// Channel is full or closed, skip
+			atomic.AddInt64(&client.droppedCount, 1)
+			dropped++
 			log.Printf("Failed to send to client %s, channel full or closed", client.ID)
 		}
 	}
+	b.recordRoomTraffic(emergencyID, sent, dropped)
+}
+
+// BroadcastLocationToRoom sends a location update to all clients in a room,
+// applying each client's subscription filters server-side
+func (b *BroadcastService) BroadcastLocationToRoom(emergencyID uuid.UUID, message models.WebSocketMessage) {
+	// WebSocket viewers are contacts, never dispatch connectors: points
+	// flagged by privacy zones go out coarsened (dispatch consumes the
+	// full-precision Kafka stream)
+	if message.Location != nil && message.Location.Redacted {
+		message.Location = message.Location.Coarsened()
+	}
+
+	defaultBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal WebSocket message: %v", err)
+		return
+	}
+
+	b.roomsMutex.RLock()
+	defer b.roomsMutex.RUnlock()
+
+	room, exists := b.rooms[emergencyID]
+	if !exists || len(room) == 0 {
+		return
+	}
+
+	log.Printf("Broadcasting to %d clients in emergency %s", len(room), emergencyID)
+
+	var sent, dropped int
+	for _, client := range room {
+		payload, send := client.prepare(message, defaultBytes)
+		if !send {
+			continue
+		}
+
+		// Share-link viewers get the frame encrypted; never fall back to
+		// plaintext for them, drop the frame instead
+		payload, err := client.SealLocationPayload(emergencyID, payload)
+		if err != nil {
+			log.Printf("Failed to encrypt payload for client %s: %v", client.ID, err)
+			continue
+		}
+
+		select {
+		case client.SendChan <- payload:
+			// Message sent successfully
+			atomic.AddInt64(&client.sentCount, 1)
+			sent++
+		default:
+			// Channel is full or closed, skip
+			atomic.AddInt64(&client.droppedCount, 1)
+			dropped++
+			log.Printf("Failed to send to client %s, channel full or closed", client.ID)
+		}
+	}
+	b.recordRoomTraffic(emergencyID, sent, dropped)
+}
+
+// CloseRoom broadcasts a final "tracking ended" message to a room and then
+// removes all clients from it
+func (b *BroadcastService) CloseRoom(emergencyID uuid.UUID, reason string) {
+	message := models.WebSocketMessage{
+		Type:        "tracking:ended",
+		EmergencyID: emergencyID,
+		Message:     fmt.Sprintf("Tracking ended: emergency %s", reason),
+	}
+
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal tracking ended message: %v", err)
+	} else {
+		b.BroadcastToRoom(emergencyID, messageBytes)
+	}
+
+	b.roomsMutex.Lock()
+	defer b.roomsMutex.Unlock()
+
+	if room, exists := b.rooms[emergencyID]; exists {
+		log.Printf("Closing room for emergency %s (%d clients)", emergencyID, len(room))
+		delete(b.rooms, emergencyID)
+		b.cancelPresenceUpdate(emergencyID)
+	}
 }
 
 // PublishLocationUpdate publishes a location update to Redis Pub/Sub
@@ -0,0 +1,54 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// applyFilters shapes one location update for a client. defaultBytes is the
+// unfiltered pre-marshaled message shared by clients without filters. It
+// returns the payload to send and whether the update should be sent at all
+func applyFilters(filters *models.SubscriptionFilters, message models.WebSocketMessage, defaultBytes []byte, lastSentAt, now time.Time) ([]byte, bool) {
+	if filters == nil {
+		return defaultBytes, true
+	}
+
+	location := message.Location
+	if location != nil && filters.MaxAccuracy != nil &&
+		location.Accuracy != nil && *location.Accuracy > *filters.MaxAccuracy {
+		return nil, false
+	}
+
+	if filters.MinIntervalSeconds > 0 &&
+		now.Sub(lastSentAt) < time.Duration(filters.MinIntervalSeconds)*time.Second {
+		return nil, false
+	}
+
+	if location == nil || (!filters.OmitBattery && !filters.OmitExtras) {
+		return defaultBytes, true
+	}
+
+	// Copy the point so stripping fields doesn't affect other clients
+	trimmed := *location
+	if filters.OmitBattery {
+		trimmed.BatteryLevel = nil
+	}
+	if filters.OmitExtras {
+		trimmed.Speed = nil
+		trimmed.Heading = nil
+		trimmed.Altitude = nil
+		trimmed.Address = nil
+	}
+	message.Location = &trimmed
+
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal filtered message: %v", err)
+		return defaultBytes, true
+	}
+
+	return messageBytes, true
+}
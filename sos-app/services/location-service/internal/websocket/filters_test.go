@@ -0,0 +1,102 @@
+package websocket
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+func filterTestMessage() models.WebSocketMessage {
+	accuracy := 120.0
+	speed := 3.5
+	battery := 42
+	address := "1 Market St"
+
+	return models.WebSocketMessage{
+		Type:        "location:update",
+		EmergencyID: uuid.New(),
+		Location: &models.LocationPoint{
+			Latitude:     37.7749,
+			Longitude:    -122.4194,
+			Accuracy:     &accuracy,
+			Speed:        &speed,
+			BatteryLevel: &battery,
+			Address:      &address,
+			Provider:     models.ProviderGPS,
+		},
+	}
+}
+
+func TestApplyFiltersNoFiltersUsesDefaultPayload(t *testing.T) {
+	message := filterTestMessage()
+	defaultBytes := []byte("default")
+
+	payload, send := applyFilters(nil, message, defaultBytes, time.Time{}, time.Now())
+	if !send {
+		t.Fatal("expected update to be sent without filters")
+	}
+	if string(payload) != "default" {
+		t.Errorf("expected shared default payload, got %q", payload)
+	}
+}
+
+func TestApplyFiltersDropsInaccuratePoints(t *testing.T) {
+	message := filterTestMessage()
+	maxAccuracy := 50.0
+	filters := &models.SubscriptionFilters{MaxAccuracy: &maxAccuracy}
+
+	if _, send := applyFilters(filters, message, nil, time.Time{}, time.Now()); send {
+		t.Error("expected point with 120m accuracy to be dropped at 50m cap")
+	}
+
+	// Unknown accuracy is kept
+	message.Location.Accuracy = nil
+	if _, send := applyFilters(filters, message, []byte("default"), time.Time{}, time.Now()); !send {
+		t.Error("expected point with unknown accuracy to be kept")
+	}
+}
+
+func TestApplyFiltersThrottlesByInterval(t *testing.T) {
+	message := filterTestMessage()
+	filters := &models.SubscriptionFilters{MinIntervalSeconds: 10}
+	now := time.Now()
+
+	if _, send := applyFilters(filters, message, []byte("default"), now.Add(-3*time.Second), now); send {
+		t.Error("expected update 3s after last send to be throttled at 10s interval")
+	}
+	if _, send := applyFilters(filters, message, []byte("default"), now.Add(-15*time.Second), now); !send {
+		t.Error("expected update 15s after last send to be delivered")
+	}
+}
+
+func TestApplyFiltersOmitsFields(t *testing.T) {
+	message := filterTestMessage()
+	filters := &models.SubscriptionFilters{OmitBattery: true, OmitExtras: true}
+
+	payload, send := applyFilters(filters, message, []byte("default"), time.Time{}, time.Now())
+	if !send {
+		t.Fatal("expected update to be sent")
+	}
+	for _, field := range []string{"batteryLevel", "speed", "address"} {
+		if strings.Contains(string(payload), field) {
+			t.Errorf("expected %s to be omitted from payload", field)
+		}
+	}
+
+	// The shared message must not be mutated for other clients
+	if message.Location.BatteryLevel == nil {
+		t.Error("expected original location point to be untouched")
+	}
+
+	var delivered models.WebSocketMessage
+	if err := json.Unmarshal(payload, &delivered); err != nil {
+		t.Fatalf("delivered payload is not valid JSON: %v", err)
+	}
+	if delivered.Location == nil || delivered.Location.Latitude != message.Location.Latitude {
+		t.Error("expected latitude to survive filtering")
+	}
+}
@@ -0,0 +1,84 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sos-app/location-service/internal/models"
+)
+
+// defaultPresenceDebounce is how long the service waits after a join or
+// leave before announcing the new viewer count, so a burst of contacts
+// opening the link at once produces a single presence event
+const defaultPresenceDebounce = 2 * time.Second
+
+// presenceMessage builds the presence event broadcast to a room. It carries
+// only the viewer count, never who is watching: the service only knows
+// opaque connection IDs, and the victim's app just needs "3 people are
+// watching your location"
+func presenceMessage(emergencyID uuid.UUID, count int) models.WebSocketMessage {
+	noun := "people are"
+	if count == 1 {
+		noun = "person is"
+	}
+	return models.WebSocketMessage{
+		Type:        "presence:update",
+		EmergencyID: emergencyID,
+		ViewerCount: count,
+		Message:     fmt.Sprintf("%d %s watching this location", count, noun),
+	}
+}
+
+// schedulePresenceUpdate arranges a debounced presence broadcast for a room.
+// A timer already pending for the room is left alone so rapid join/leave
+// bursts coalesce into one announcement of the final count
+func (b *BroadcastService) schedulePresenceUpdate(emergencyID uuid.UUID) {
+	b.presenceMutex.Lock()
+	defer b.presenceMutex.Unlock()
+
+	if _, pending := b.presenceTimers[emergencyID]; pending {
+		return
+	}
+
+	b.presenceTimers[emergencyID] = time.AfterFunc(b.presenceDebounce, func() {
+		b.broadcastPresence(emergencyID)
+	})
+}
+
+// broadcastPresence announces a room's current viewer count. Counts are
+// per-node, like the rooms themselves. A room that emptied while the
+// debounce timer was pending announces nothing; there is nobody left to
+// tell, and the victim's app treats the closed room as zero viewers
+func (b *BroadcastService) broadcastPresence(emergencyID uuid.UUID) {
+	b.presenceMutex.Lock()
+	delete(b.presenceTimers, emergencyID)
+	b.presenceMutex.Unlock()
+
+	count := b.GetRoomClients(emergencyID)
+	if count == 0 {
+		return
+	}
+
+	messageBytes, err := json.Marshal(presenceMessage(emergencyID, count))
+	if err != nil {
+		log.Printf("Failed to marshal presence message: %v", err)
+		return
+	}
+
+	b.BroadcastToRoom(emergencyID, messageBytes)
+}
+
+// cancelPresenceUpdate drops any pending presence timer for a room (used
+// when the room is closed)
+func (b *BroadcastService) cancelPresenceUpdate(emergencyID uuid.UUID) {
+	b.presenceMutex.Lock()
+	defer b.presenceMutex.Unlock()
+
+	if timer, pending := b.presenceTimers[emergencyID]; pending {
+		timer.Stop()
+		delete(b.presenceTimers, emergencyID)
+	}
+}
@@ -0,0 +1,89 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newTestBroadcastService builds a service without the Redis connection so
+// the in-memory room bookkeeping can be exercised directly
+func newTestBroadcastService(debounce time.Duration) *BroadcastService {
+	return &BroadcastService{
+		clients:          make(map[string]*Client),
+		rooms:            make(map[uuid.UUID]map[string]*Client),
+		roomTraffic:      make(map[uuid.UUID]*trafficCounter),
+		presenceTimers:   make(map[uuid.UUID]*time.Timer),
+		presenceDebounce: debounce,
+	}
+}
+
+func TestPresenceMessageCountOnly(t *testing.T) {
+	emergencyID := uuid.New()
+
+	msg := presenceMessage(emergencyID, 3)
+	if msg.Type != "presence:update" {
+		t.Errorf("expected presence:update, got %s", msg.Type)
+	}
+	if msg.ViewerCount != 3 {
+		t.Errorf("expected viewer count 3, got %d", msg.ViewerCount)
+	}
+	if msg.Location != nil {
+		t.Error("presence message must not carry a location")
+	}
+	if msg.Message != "3 people are watching this location" {
+		t.Errorf("unexpected message: %q", msg.Message)
+	}
+
+	if single := presenceMessage(emergencyID, 1); single.Message != "1 person is watching this location" {
+		t.Errorf("unexpected singular message: %q", single.Message)
+	}
+}
+
+func TestPresenceDebounceCoalescesJoins(t *testing.T) {
+	service := newTestBroadcastService(20 * time.Millisecond)
+	emergencyID := uuid.New()
+
+	first := &Client{ID: "first", SendChan: make(chan []byte, 4)}
+	second := &Client{ID: "second", SendChan: make(chan []byte, 4)}
+	service.AddClient(first)
+	service.AddClient(second)
+
+	// Two joins inside the debounce window announce once, with the final count
+	service.JoinRoom(emergencyID, first.ID)
+	service.JoinRoom(emergencyID, second.ID)
+	time.Sleep(100 * time.Millisecond)
+
+	if got := len(first.SendChan); got != 1 {
+		t.Fatalf("expected 1 presence event, got %d", got)
+	}
+
+	var msg struct {
+		Type        string `json:"type"`
+		ViewerCount int    `json:"viewerCount"`
+	}
+	if err := json.Unmarshal(<-first.SendChan, &msg); err != nil {
+		t.Fatalf("failed to parse presence event: %v", err)
+	}
+	if msg.Type != "presence:update" || msg.ViewerCount != 2 {
+		t.Errorf("expected presence:update with 2 viewers, got %s with %d", msg.Type, msg.ViewerCount)
+	}
+}
+
+func TestPresenceNotAnnouncedForEmptiedRoom(t *testing.T) {
+	service := newTestBroadcastService(20 * time.Millisecond)
+	emergencyID := uuid.New()
+
+	client := &Client{ID: "only", SendChan: make(chan []byte, 4)}
+	service.AddClient(client)
+
+	service.JoinRoom(emergencyID, client.ID)
+	service.LeaveRoom(emergencyID, client.ID)
+	time.Sleep(100 * time.Millisecond)
+
+	if got := len(client.SendChan); got != 0 {
+		t.Errorf("expected no presence events for an emptied room, got %d", got)
+	}
+}
@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// shareKeyContext domain-separates share keys from any other use of the
+// link token, so rotating the derivation only needs a version bump here
+const shareKeyContext = "sos-location-share-v1:"
+
+// encryptedMessage is the frame sent to share-link viewers in place of a
+// plaintext location update. Payload is base64(nonce || AES-GCM ciphertext)
+// of the exact JSON the viewer would otherwise have received; the viewer
+// derives the key from the link token it already holds
+type encryptedMessage struct {
+	Type        string    `json:"type"`
+	EmergencyID uuid.UUID `json:"emergencyId"`
+	Payload     string    `json:"payload"`
+}
+
+// deriveShareKey turns a share link token into a 256-bit AES key. The
+// derivation is deterministic so viewer and server never exchange key
+// material: anyone without the link cannot decrypt an intercepted frame
+func deriveShareKey(token string) []byte {
+	key := sha256.Sum256([]byte(shareKeyContext + token))
+	return key[:]
+}
+
+// sealSharePayload encrypts one prepared payload under the share key,
+// returning nonce || ciphertext
+func sealSharePayload(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openSharePayload reverses sealSharePayload; it exists for the client
+// side of tests and tooling, production viewers decrypt in the browser
+func openSharePayload(key, sealed []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("sealed payload shorter than nonce")
+	}
+
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+}
+
+// encodeEncryptedMessage wraps a sealed payload in the frame sent to the
+// viewer
+func encodeEncryptedMessage(emergencyID uuid.UUID, sealed []byte) encryptedMessage {
+	return encryptedMessage{
+		Type:        "location:encrypted",
+		EmergencyID: emergencyID,
+		Payload:     base64.StdEncoding.EncodeToString(sealed),
+	}
+}
@@ -0,0 +1,92 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestShareCryptRoundTrip(t *testing.T) {
+	key := deriveShareKey("abc123-link-token")
+	plaintext := []byte(`{"type":"location:update","location":{"latitude":37.77}}`)
+
+	sealed, err := sealSharePayload(key, plaintext)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if bytes.Contains(sealed, []byte("latitude")) {
+		t.Error("sealed payload leaks plaintext")
+	}
+
+	opened, err := openSharePayload(key, sealed)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("round trip mismatch: got %q", opened)
+	}
+
+	// A key derived from a different link must not decrypt the frame
+	if _, err := openSharePayload(deriveShareKey("other-token"), sealed); err == nil {
+		t.Error("expected decryption to fail under a different share key")
+	}
+}
+
+func TestClientSealLocationPayload(t *testing.T) {
+	emergencyID := uuid.New()
+	plaintext := []byte(`{"type":"location:update"}`)
+
+	// Without a share token the payload passes through untouched
+	client := &Client{ID: "plain"}
+	payload, err := client.SealLocationPayload(emergencyID, plaintext)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if !bytes.Equal(payload, plaintext) {
+		t.Errorf("expected passthrough for non-share client, got %q", payload)
+	}
+
+	// With a share token the frame is an encrypted envelope the link
+	// holder can open
+	client.SetShareToken("link-token")
+	payload, err = client.SealLocationPayload(emergencyID, plaintext)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+
+	var envelope encryptedMessage
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		t.Fatalf("failed to parse envelope: %v", err)
+	}
+	if envelope.Type != "location:encrypted" {
+		t.Errorf("expected type location:encrypted, got %q", envelope.Type)
+	}
+	if envelope.EmergencyID != emergencyID {
+		t.Errorf("expected emergency ID %s, got %s", emergencyID, envelope.EmergencyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		t.Fatalf("payload is not base64: %v", err)
+	}
+	opened, err := openSharePayload(deriveShareKey("link-token"), sealed)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("round trip mismatch: got %q", opened)
+	}
+
+	// Clearing the token restores plaintext delivery
+	client.SetShareToken("")
+	payload, err = client.SealLocationPayload(emergencyID, plaintext)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if !bytes.Equal(payload, plaintext) {
+		t.Errorf("expected passthrough after clearing token, got %q", payload)
+	}
+}
@@ -0,0 +1,181 @@
+package websocket
+
+import (
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// trafficCounter accumulates delivery counters for one emergency room. The
+// per-minute rate uses a fixed window: windowCount fills the in-progress
+// minute and lastMinute holds the most recently completed one. Guarded by
+// the service's statsMutex
+type trafficCounter struct {
+	sent        int64
+	dropped     int64
+	windowStart time.Time
+	windowCount int64
+	lastMinute  int64
+}
+
+// record adds one broadcast's delivery outcome, rotating the rate window
+// when a minute has elapsed
+func (t *trafficCounter) record(sent, dropped int, now time.Time) {
+	if t.windowStart.IsZero() {
+		t.windowStart = now
+	}
+	if elapsed := now.Sub(t.windowStart); elapsed >= time.Minute {
+		t.lastMinute = t.windowCount
+		if elapsed >= 2*time.Minute {
+			// An idle gap means the previous full minute carried no traffic
+			t.lastMinute = 0
+		}
+		t.windowCount = 0
+		t.windowStart = now
+	}
+	t.sent += int64(sent)
+	t.dropped += int64(dropped)
+	t.windowCount += int64(sent + dropped)
+}
+
+// perMinute reports the approximate broadcast rate: the last completed
+// window when one exists, falling back to the in-progress count for rooms
+// younger than a minute
+func (t *trafficCounter) perMinute(now time.Time) int64 {
+	if t.windowStart.IsZero() || now.Sub(t.windowStart) >= 2*time.Minute {
+		return 0
+	}
+	if now.Sub(t.windowStart) >= time.Minute {
+		// A full window has elapsed but no broadcast has rotated it yet
+		return t.windowCount
+	}
+	if t.lastMinute > 0 {
+		return t.lastMinute
+	}
+	return t.windowCount
+}
+
+// ClientSnapshot describes one connected viewer for the admin API
+type ClientSnapshot struct {
+	ID              string    `json:"id"`
+	ConnectedAt     time.Time `json:"connectedAt"`
+	MessagesSent    int64     `json:"messagesSent"`
+	MessagesDropped int64     `json:"messagesDropped"`
+	HasFilters      bool      `json:"hasFilters"`
+}
+
+// RoomSnapshot describes one active emergency room and its viewers
+type RoomSnapshot struct {
+	EmergencyID       string           `json:"emergencyId"`
+	ClientCount       int              `json:"clientCount"`
+	MessagesSent      int64            `json:"messagesSent"`
+	MessagesDropped   int64            `json:"messagesDropped"`
+	MessagesPerMinute int64            `json:"messagesPerMinute"`
+	Clients           []ClientSnapshot `json:"clients"`
+}
+
+// StatsSnapshot is the admin view of the broadcast service, used to debug
+// delivery problems without attaching a debugger to a production pod
+type StatsSnapshot struct {
+	TotalClients int            `json:"totalClients"`
+	RoomCount    int            `json:"roomCount"`
+	Rooms        []RoomSnapshot `json:"rooms"`
+}
+
+// recordRoomTraffic folds one broadcast's per-room delivery counts into the
+// room's traffic counter
+func (b *BroadcastService) recordRoomTraffic(emergencyID uuid.UUID, sent, dropped int) {
+	b.statsMutex.Lock()
+	defer b.statsMutex.Unlock()
+
+	counter := b.roomTraffic[emergencyID]
+	if counter == nil {
+		counter = &trafficCounter{}
+		b.roomTraffic[emergencyID] = counter
+	}
+	counter.record(sent, dropped, time.Now())
+}
+
+// Snapshot returns the current rooms, their clients and delivery counters.
+// Dropped counts come from the full-channel path in the broadcast loops: a
+// climbing number on one client means its connection has stalled
+func (b *BroadcastService) Snapshot() StatsSnapshot {
+	now := time.Now()
+
+	b.clientsMutex.RLock()
+	totalClients := len(b.clients)
+	b.clientsMutex.RUnlock()
+
+	b.roomsMutex.RLock()
+	defer b.roomsMutex.RUnlock()
+	b.statsMutex.Lock()
+	defer b.statsMutex.Unlock()
+
+	// Drop counters for rooms that no longer exist so the map does not
+	// grow with emergency churn
+	for emergencyID := range b.roomTraffic {
+		if _, exists := b.rooms[emergencyID]; !exists {
+			delete(b.roomTraffic, emergencyID)
+		}
+	}
+
+	rooms := make([]RoomSnapshot, 0, len(b.rooms))
+	for emergencyID, room := range b.rooms {
+		snapshot := RoomSnapshot{
+			EmergencyID: emergencyID.String(),
+			ClientCount: len(room),
+			Clients:     make([]ClientSnapshot, 0, len(room)),
+		}
+		if counter, exists := b.roomTraffic[emergencyID]; exists {
+			snapshot.MessagesSent = counter.sent
+			snapshot.MessagesDropped = counter.dropped
+			snapshot.MessagesPerMinute = counter.perMinute(now)
+		}
+
+		for _, client := range room {
+			snapshot.Clients = append(snapshot.Clients, ClientSnapshot{
+				ID:              client.ID,
+				ConnectedAt:     client.ConnectedAt,
+				MessagesSent:    atomic.LoadInt64(&client.sentCount),
+				MessagesDropped: atomic.LoadInt64(&client.droppedCount),
+				HasFilters:      client.hasFilters(),
+			})
+		}
+		sort.Slice(snapshot.Clients, func(i, j int) bool {
+			return snapshot.Clients[i].ID < snapshot.Clients[j].ID
+		})
+
+		rooms = append(rooms, snapshot)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].EmergencyID < rooms[j].EmergencyID
+	})
+
+	return StatsSnapshot{
+		TotalClients: totalClients,
+		RoomCount:    len(rooms),
+		Rooms:        rooms,
+	}
+}
+
+// DisconnectClient force-closes one client's connection and removes it from
+// the service, reporting whether the client was connected at all
+func (b *BroadcastService) DisconnectClient(clientID string) bool {
+	b.clientsMutex.RLock()
+	client, exists := b.clients[clientID]
+	b.clientsMutex.RUnlock()
+
+	if !exists {
+		return false
+	}
+
+	log.Printf("Force-disconnecting client %s", clientID)
+	b.RemoveClient(clientID)
+	if client.CloseConn != nil {
+		client.CloseConn()
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrafficCounterAccumulatesTotals(t *testing.T) {
+	counter := &trafficCounter{}
+	now := time.Now()
+
+	counter.record(3, 1, now)
+	counter.record(2, 0, now.Add(10*time.Second))
+
+	if counter.sent != 5 {
+		t.Errorf("expected 5 sent, got %d", counter.sent)
+	}
+	if counter.dropped != 1 {
+		t.Errorf("expected 1 dropped, got %d", counter.dropped)
+	}
+}
+
+func TestTrafficCounterRotatesWindow(t *testing.T) {
+	counter := &trafficCounter{}
+	start := time.Now()
+
+	counter.record(10, 2, start)
+	counter.record(8, 0, start.Add(30*time.Second))
+
+	// Young window falls back to the in-progress count
+	if rate := counter.perMinute(start.Add(30 * time.Second)); rate != 20 {
+		t.Errorf("expected in-progress rate 20, got %d", rate)
+	}
+
+	// A record after the minute boundary rotates the window
+	counter.record(5, 0, start.Add(70*time.Second))
+	if rate := counter.perMinute(start.Add(75 * time.Second)); rate != 20 {
+		t.Errorf("expected completed-window rate 20, got %d", rate)
+	}
+}
+
+func TestTrafficCounterIdleGapResetsRate(t *testing.T) {
+	counter := &trafficCounter{}
+	start := time.Now()
+
+	counter.record(10, 0, start)
+
+	// A record landing after a multi-minute gap must not report the stale
+	// window as the previous minute's rate
+	counter.record(1, 0, start.Add(5*time.Minute))
+	if rate := counter.perMinute(start.Add(5*time.Minute + time.Second)); rate != 1 {
+		t.Errorf("expected rate 1 after idle gap, got %d", rate)
+	}
+
+	// With no records at all in the last two minutes the rate is zero
+	if rate := counter.perMinute(start.Add(10 * time.Minute)); rate != 0 {
+		t.Errorf("expected rate 0 for a stale counter, got %d", rate)
+	}
+}
@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
-	"os"
-	"os/signal"
-	"syscall"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sos-app/location-service/internal/archive"
 	"github.com/sos-app/location-service/internal/cache"
 	"github.com/sos-app/location-service/internal/config"
 	"github.com/sos-app/location-service/internal/handlers"
@@ -21,16 +25,33 @@ import (
 	"github.com/sos-app/location-service/internal/repository"
 	"github.com/sos-app/location-service/internal/services"
 	"github.com/sos-app/location-service/internal/websocket"
+	"github.com/sos-app/pkg/httpserver"
 )
 
 func main() {
+	// The same binary runs as a combined node (the default), a write-side
+	// ingest worker or a read-side query API, so the write-heavy and
+	// read-heavy paths can scale independently
+	role := flag.String("role", "all", "service role: all, ingest (write APIs, consumers, archiver) or query (read APIs, WebSocket)")
+	flag.Parse()
+
+	runIngest := *role == "all" || *role == "ingest"
+	runQuery := *role == "all" || *role == "query"
+	if !runIngest && !runQuery {
+		log.Fatalf("Unknown --role %q: must be all, ingest or query", *role)
+	}
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Load configuration
-	cfg := config.Load()
+	// Load configuration (CONFIG_FILE + env overrides, SIGHUP reload)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	defer cfg.Close()
 
 	// Initialize database connection
 	db, err := repository.NewDatabase(cfg.DatabaseURL)
@@ -53,21 +74,203 @@ func main() {
 	// Initialize location repository
 	locationRepo := repository.NewLocationRepository(db)
 
+	// Initialize tracking session repository
+	sessionRepo := repository.NewTrackingSessionRepository(db)
+
+	// Initialize privacy zone repository
+	privacyZoneRepo := repository.NewPrivacyZoneRepository(db)
+
+	// Initialize registered responder repository
+	responderRepo := repository.NewResponderRepository(db)
+
 	// Initialize geocoding service
 	geocodingService := services.NewGeocodingService(cfg.GeocodingAPIKey, redisCache)
 
+	// Throttle per-emergency broadcasts; CRITICAL emergencies stream at
+	// full rate by default
+	updateThrottler := services.NewUpdateThrottler(
+		cfg.BroadcastInterval,
+		services.ParseBroadcastIntervals(cfg.BroadcastSeverityIntervals),
+	)
+
+	// Score incoming points for plausibility so spoofed locations are flagged
+	trustScorer := services.NewTrustScorer()
+
+	// Cap per-emergency batch ingest so a looping client can't flood the
+	// pipeline; CRITICAL emergencies get a larger budget by default
+	ingestQuota := services.NewIngestQuota(
+		cfg.IngestPointsPerMinute,
+		services.ParseIngestLimits(cfg.IngestSeverityLimits),
+	)
+
+	// Per-provider ingestion quality: counts, accuracy and rejection rates,
+	// exported to Prometheus and summarized daily for product teams
+	ingestMetrics := services.NewIngestMetrics(prometheus.DefaultRegisterer)
+
+	// Flag points inside user privacy zones so contacts only see coarse
+	// coordinates near home or work
+	privacyRedactor := services.NewPrivacyRedactor(privacyZoneRepo)
+
+	// Travel-time estimates for nearby responders; straight-line fallback
+	// until a routing provider is plugged in
+	travelEstimator := services.NewTravelEstimator(cfg.ResponderAvgSpeedKmh)
+
+	// Share one trail response per TTL window across concurrent viewers of
+	// the same incident; a zero TTL disables caching
+	var trailCache *services.TrailCache
+	if cfg.TrailCacheTTL > 0 {
+		trailCache = services.NewTrailCache(cfg.TrailCacheTTL)
+	}
+
 	// Initialize location service
 	locationService := services.NewLocationService(
 		locationRepo,
 		redisCache,
 		kafkaProducer,
 		geocodingService,
+		sessionRepo,
+		updateThrottler,
+		trustScorer,
+		privacyRedactor,
 	)
+	locationService.SetIngestMetrics(ingestMetrics)
+
+	// Archive trails of long-ended emergencies to object storage, keeping
+	// the hypertable small; the history endpoint serves archived trails back
+	// transparently. Disabled unless a store is configured
+	var archiveStore archive.Store
+	switch {
+	case cfg.ArchiveS3Endpoint != "":
+		archiveStore = archive.NewS3Store(
+			cfg.ArchiveS3Endpoint,
+			cfg.ArchiveS3Bucket,
+			cfg.ArchiveS3Region,
+			cfg.ArchiveS3AccessKey,
+			cfg.ArchiveS3SecretKey,
+		)
+	case cfg.ArchiveDir != "":
+		archiveStore = archive.NewFileStore(cfg.ArchiveDir)
+	}
+	if archiveStore != nil {
+		trailArchiver := services.NewTrailArchiver(
+			archiveStore,
+			locationRepo,
+			sessionRepo,
+			cfg.ArchiveAfter,
+			cfg.ArchiveSweepInterval,
+		)
+		// Only the ingest side runs the archive sweep; query nodes still
+		// need the archiver to serve archived trails back
+		if runIngest {
+			trailArchiver.Start()
+			defer trailArchiver.Stop()
+		}
+		locationService.SetTrailArchiver(trailArchiver)
+	}
 
-	// Initialize WebSocket broadcast service
-	broadcastService := websocket.NewBroadcastService(cfg.RedisURL)
-	go broadcastService.Start()
-	defer broadcastService.Stop()
+	// WebSocket fan-out only runs on query nodes. Location updates reach
+	// them through the Redis pub/sub channel regardless of which node
+	// ingested the point
+	var broadcastService *websocket.BroadcastService
+	if runQuery {
+		broadcastService = websocket.NewBroadcastService(cfg.RedisURL)
+		go broadcastService.Start()
+		defer broadcastService.Stop()
+	}
+
+	// Dedicated query nodes join a separate consumer group so both halves
+	// of a split deployment see every event: ingest nodes do the database
+	// work, query nodes tear down their local WebSocket rooms
+	consumerGroup := cfg.KafkaConsumerGroup
+	if !runIngest {
+		consumerGroup += "-query"
+	}
+
+	// Consume emergency lifecycle events so tracking is torn down as soon as
+	// an emergency resolves or is cancelled
+	lifecycleConsumer := kafka.NewLifecycleConsumer(
+		cfg.KafkaBrokers,
+		consumerGroup,
+		[]string{cfg.EmergencyResolvedTopic, cfg.EmergencyCancelledTopic},
+		func(ctx context.Context, eventType string, emergencyID uuid.UUID) {
+			reason := "resolved"
+			if strings.Contains(strings.ToLower(eventType), "cancel") {
+				reason = "cancelled"
+			}
+
+			log.Printf("Emergency %s %s, ending tracking", emergencyID, reason)
+
+			if runIngest {
+				if err := locationService.EndTracking(ctx, emergencyID, reason); err != nil {
+					log.Printf("Failed to end tracking for emergency %s: %v", emergencyID, err)
+				}
+			}
+
+			if broadcastService != nil {
+				broadcastService.CloseRoom(emergencyID, reason)
+			}
+		},
+		kafkaProducer,
+	)
+	lifecycleConsumer.Start()
+	defer lifecycleConsumer.Close()
+
+	// Consume data erasure events so erased users' location history is
+	// purged here as well: points, cached locations and any live rooms.
+	// The purge is confirmed back to emergency-service so the erasure saga
+	// can track which services are done
+	erasureConsumer := kafka.NewErasureConsumer(
+		cfg.KafkaBrokers,
+		consumerGroup,
+		cfg.DataErasureTopic,
+		func(ctx context.Context, requestID, userID uuid.UUID) {
+			// Capture the user's emergencies before the points are gone so
+			// their cache entries and rooms can be torn down too
+			emergencyIDs, err := locationRepo.ListUserEmergencies(ctx, userID)
+			if err != nil {
+				log.Printf("Failed to list emergencies for erased user %s: %v", userID, err)
+			}
+
+			// Query nodes only close their local rooms; the purge and the
+			// saga confirmation belong to the ingest side
+			if !runIngest {
+				for _, emergencyID := range emergencyIDs {
+					broadcastService.CloseRoom(emergencyID, "erased")
+				}
+				return
+			}
+
+			deleted, err := locationRepo.DeleteUserData(ctx, userID)
+			if err != nil {
+				log.Printf("Failed to erase location data for user %s: %v", userID, err)
+				return
+			}
+
+			for _, emergencyID := range emergencyIDs {
+				if err := redisCache.DeleteLocation(emergencyID); err != nil {
+					log.Printf("Failed to evict cached location for emergency %s: %v", emergencyID, err)
+				}
+				if err := redisCache.RemoveFromGeoIndex(emergencyID); err != nil {
+					log.Printf("Failed to remove emergency %s from geo index: %v", emergencyID, err)
+				}
+				if broadcastService != nil {
+					broadcastService.CloseRoom(emergencyID, "erased")
+				}
+			}
+
+			log.Printf("Erased %d location points for user %s", deleted, userID)
+
+			if requestID == uuid.Nil {
+				return
+			}
+			if err := kafkaProducer.PublishErasureCompleted(ctx, requestID, userID, deleted); err != nil {
+				log.Printf("Failed to confirm erasure %s: %v", requestID, err)
+			}
+		},
+		kafkaProducer,
+	)
+	erasureConsumer.Start()
+	defer erasureConsumer.Close()
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -75,6 +278,9 @@ func main() {
 		AppName:      "SOS App Location Service v1.0.0",
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
+		// A 1000-point batch-update is well under 1MB; anything bigger is
+		// a misbehaving client
+		BodyLimit: cfg.MaxBodyBytes,
 	})
 
 	// Middleware
@@ -99,48 +305,80 @@ func main() {
 		})
 	})
 
+	app.Get("/openapi.json", handlers.OpenAPIHandler)
+
+	// Prometheus scrape target
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
 	// Initialize handlers
-	locationHandler := handlers.NewLocationHandler(locationService)
-	websocketHandler := handlers.NewWebSocketHandler(broadcastService, locationService)
+	locationHandler := handlers.NewLocationHandler(locationService, ingestQuota, trailCache, ingestMetrics, cfg.DispatchToken)
 
 	// API routes
 	api := app.Group("/api/v1")
 
-	// Location endpoints
-	api.Post("/location/update", locationHandler.UpdateLocation)
-	api.Post("/location/batch-update", locationHandler.BatchUpdateLocation)
-	api.Get("/location/current/:emergencyId", locationHandler.GetCurrentLocation)
-	api.Get("/location/trail/:emergencyId", locationHandler.GetLocationTrail)
-	api.Get("/location/history/:emergencyId", locationHandler.GetLocationHistory)
+	// Write path: high-volume location ingestion
+	if runIngest {
+		api.Post("/location/update", locationHandler.UpdateLocation)
+		api.Post("/location/batch-update", locationHandler.BatchUpdateLocation)
+
+		// Daily per-provider ingestion quality (stats accumulate on the
+		// nodes doing the ingesting)
+		api.Get("/location/quality-summary", locationHandler.GetQualitySummary)
+	}
+
+	// Read path: queries, privacy zone management and WebSocket fan-out
+	if runQuery {
+		privacyZoneHandler := handlers.NewPrivacyZoneHandler(privacyZoneRepo, privacyRedactor)
+		responderHandler := handlers.NewResponderHandler(responderRepo, travelEstimator)
+		wsAdminHandler := handlers.NewWSAdminHandler(broadcastService, cfg.AdminToken)
+		websocketHandler := handlers.NewWebSocketHandler(broadcastService, locationService, handlers.WebSocketOptions{
+			WriteTimeout:      cfg.WSWriteTimeout,
+			PingInterval:      cfg.WSPingInterval,
+			MaxConnections:    cfg.WSMaxConnections,
+			EnableCompression: cfg.WSCompression,
+		})
 
-	// WebSocket endpoint
-	api.Get("/location/subscribe", websocketHandler.Subscribe)
+		// Location endpoints
+		api.Get("/location/current/:emergencyId", locationHandler.GetCurrentLocation)
+		api.Get("/location/trail/:emergencyId", locationHandler.GetLocationTrail)
+		api.Get("/location/trail/:emergencyId/mvt/:z/:x/:y", locationHandler.GetTrailTile)
+		api.Get("/location/history/:emergencyId", locationHandler.GetLocationHistory)
+		api.Get("/location/cells", locationHandler.GetCellAggregates)
 
-	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		// Privacy redaction zones
+		api.Post("/location/privacy-zones", privacyZoneHandler.CreateZone)
+		api.Get("/location/privacy-zones", privacyZoneHandler.ListZones)
+		api.Delete("/location/privacy-zones/:id", privacyZoneHandler.DeleteZone)
 
-	go func() {
-		<-quit
-		log.Println("Shutting down gracefully...")
+		// Community responders: registration and the ST_DWithin-backed
+		// nearby query used during escalation
+		api.Post("/location/responders", responderHandler.RegisterResponder)
+		api.Delete("/location/responders", responderHandler.DeregisterResponder)
+		api.Get("/location/responders/nearby", responderHandler.GetNearbyResponders)
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		// WebSocket endpoint
+		api.Get("/location/subscribe", websocketHandler.Subscribe)
 
-		if err := app.ShutdownWithContext(ctx); err != nil {
-			log.Printf("Server forced to shutdown: %v", err)
-		}
-	}()
+		// Broadcast introspection for support staff debugging delivery problems
+		api.Get("/admin/ws/rooms", wsAdminHandler.ListRooms)
+		api.Delete("/admin/ws/clients/:id", wsAdminHandler.DisconnectClient)
+	}
 
-	// Start server
+	// Start server with shared signal handling and graceful shutdown
 	port := cfg.Port
 	if port == "" {
 		port = "3003"
 	}
 
-	log.Printf("Location Service starting on port %s...", port)
-	if err := app.Listen(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	log.Printf("Location Service starting on port %s (role: %s)...", port, *role)
+	err = httpserver.RunUntilSignal(
+		func() error { return app.Listen(fmt.Sprintf(":%s", port)) },
+		func(ctx context.Context) error { return app.ShutdownWithContext(ctx) },
+		10*time.Second,
+		log.Printf,
+	)
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
 	}
 
 	log.Println("Location Service stopped")